@@ -1,89 +1,319 @@
 package main
 
 import (
+	"cake-store/internal/apikeys"
+	"cake-store/internal/auth"
 	"cake-store/internal/cakes"
+	"cake-store/internal/carts"
+	"cake-store/internal/categories"
+	"cake-store/internal/idempotency"
+	"cake-store/internal/ingredients"
+	"cake-store/internal/inventory"
 	"cake-store/internal/middlewares"
-	"database/sql"
-	"github.com/go-sql-driver/mysql"
+	"cake-store/internal/options"
+	"cake-store/internal/orders"
+	"cake-store/internal/ratelimit"
+	"cake-store/internal/reviews"
+	"cake-store/internal/scheduling"
+	"cake-store/internal/storage"
+	"cake-store/internal/stores"
+	"cake-store/internal/users"
+	"cake-store/internal/variants"
+	"context"
 	"github.com/joho/godotenv"
 	echoSwagger "github.com/swaggo/echo-swagger"
 	"os"
-	"time"
 
 	_ "cake-store/docs"
-	_ "github.com/go-sql-driver/mysql"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	// The shop time zone loads without a time zone database on the host.
+	_ "time/tzdata"
 )
 
-var dbInstance *sql.DB
-
-func initDB() (*sql.DB, error) {
-	conf := mysql.Config{
-		User:                 os.Getenv("DB_USER"),
-		Passwd:               os.Getenv("DB_PASSWORD"),
-		Net:                  os.Getenv("DB_NET"),
-		Addr:                 os.Getenv("DB_ADDRESS"),
-		DBName:               os.Getenv("DB_NAME"),
-		AllowNativePasswords: true,
-		ParseTime:            true,
-	}
-	if dbInstance == nil {
-		newInstance, err := sql.Open("mysql", conf.FormatDSN())
-		if err != nil {
-			return nil, err
-		}
-		if err = newInstance.Ping(); err != nil {
-			return nil, err
-		}
-		dbInstance = newInstance
-		dbInstance.SetConnMaxIdleTime(3)
-		dbInstance.SetMaxOpenConns(10)
-		dbInstance.SetConnMaxLifetime(time.Hour)
-		return dbInstance, nil
-	}
-	return dbInstance, nil
-}
-
 // @title Cake Store API
 // @version 1.0
 // @description Cake store API for testing purposes.
 
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Access token from /auth/login, sent as "Bearer <token>".
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description Partner API key from /api-keys, limited to its scopes.
+
 func main() {
 	godotenv.Load(".env")
 	e := echo.New()
+	// Clients are told apart by IP address, taken from X-Forwarded-For only
+	// when sent by a proxy on a private network.
+	e.IPExtractor = echo.ExtractIPFromXFFHeader()
 
 	e.Use(middleware.Recover())
+	e.Use(middleware.RequestID())
 
-	db, err := initDB()
+	driver := storage.Driver()
+	db, err := storage.Open(driver)
 	if err != nil {
 		panic(err)
 	}
-	defer func() {
-		if err = db.Close(); err != nil {
-			panic(err)
-		}
-	}()
+	if db != nil {
+		defer func() {
+			if err = db.Close(); err != nil {
+				panic(err)
+			}
+		}()
+	}
 
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowHeaders:  []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
-		ExposeHeaders: []string{echo.HeaderContentLength, echo.HeaderContentType, "Pagination-Rows", "Pagination-Page", "Pagination-Limit"},
+		AllowHeaders:  []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, apikeys.Header, idempotency.Header, cakes.HeaderIfMatch, cakes.HeaderIfNoneMatch},
+		ExposeHeaders: []string{echo.HeaderContentLength, echo.HeaderContentType, echo.HeaderXRequestID, "Pagination-Rows", "Pagination-Page", "Pagination-Limit", "Pagination-Next-Cursor", "Facets-Rating", "Facets-Has-Image", ratelimit.HeaderLimit, ratelimit.HeaderRemaining, ratelimit.HeaderReset, ratelimit.HeaderRetryAfter, idempotency.HeaderReplayed, cakes.HeaderETag},
 	}))
 	middlewares.UseCustomValidatorHandler(e)
 	e.Use(middleware.Logger())
 
 	// Init Repo
-	cakesRepo := cakes.NewRepository(db)
+	var (
+		cakesRepo       cakes.RepoInterface
+		categoriesRepo  categories.RepoInterface
+		ingredientsRepo ingredients.RepoInterface
+		variantsRepo    variants.RepoInterface
+		inventoryRepo   inventory.RepoInterface
+		ordersRepo      orders.RepoInterface
+		cartsRepo       carts.RepoInterface
+		reviewsRepo     reviews.RepoInterface
+		optionsRepo     options.RepoInterface
+		scheduleRepo    scheduling.RepoInterface
+		storesRepo      stores.RepoInterface
+		usersRepo       users.RepoInterface
+		apiKeysRepo     apikeys.RepoInterface
+		idempotencyRepo idempotency.RepoInterface
+	)
+	switch driver {
+	case storage.DriverMemory:
+		cakesRepo = cakes.NewMemoryRepository()
+		categoriesRepo = categories.NewMemoryRepository()
+		ingredientsRepo = ingredients.NewMemoryRepository()
+		variantsRepo = variants.NewMemoryRepository()
+		inventoryRepo = inventory.NewMemoryRepository()
+		ordersRepo = orders.NewMemoryRepository()
+		cartsRepo = carts.NewMemoryRepository()
+		reviewsRepo = reviews.NewMemoryRepository()
+		optionsRepo = options.NewMemoryRepository()
+		scheduleRepo = scheduling.NewMemoryRepository()
+		storesRepo = stores.NewMemoryRepository(variantsRepo)
+		usersRepo = users.NewMemoryRepository()
+		apiKeysRepo = apikeys.NewMemoryRepository()
+		idempotencyRepo = idempotency.NewMemoryRepository()
+	case storage.DriverSQLite:
+		if cakesRepo, err = cakes.NewSQLiteRepository(db); err != nil {
+			panic(err)
+		}
+		categoriesRepo = categories.NewRepository(db)
+		ingredientsRepo = ingredients.NewRepository(db)
+		variantsRepo = variants.NewRepository(db)
+		inventoryRepo = inventory.NewRepository(db)
+		ordersRepo = orders.NewRepository(db)
+		cartsRepo = carts.NewRepository(db)
+		reviewsRepo = reviews.NewRepository(db)
+		optionsRepo = options.NewRepository(db)
+		scheduleRepo = scheduling.NewRepository(db)
+		storesRepo = stores.NewRepository(db)
+		usersRepo = users.NewRepository(db)
+		apiKeysRepo = apikeys.NewRepository(db)
+		idempotencyRepo = idempotency.NewRepository(db)
+	default:
+		cakesRepo = cakes.NewRepository(db)
+		categoriesRepo = categories.NewRepository(db)
+		ingredientsRepo = ingredients.NewRepository(db)
+		variantsRepo = variants.NewRepository(db)
+		inventoryRepo = inventory.NewRepository(db)
+		ordersRepo = orders.NewRepository(db)
+		cartsRepo = carts.NewRepository(db)
+		reviewsRepo = reviews.NewRepository(db)
+		optionsRepo = options.NewRepository(db)
+		scheduleRepo = scheduling.NewRepository(db)
+		storesRepo = stores.NewRepository(db)
+		usersRepo = users.NewRepository(db)
+		apiKeysRepo = apikeys.NewRepository(db)
+		idempotencyRepo = idempotency.NewRepository(db)
+	}
+
+	// Init Auth
+	authConfig, err := auth.ConfigFromEnv()
+	if err != nil {
+		panic(err)
+	}
+	tokens, err := auth.NewTokens(authConfig)
+	if err != nil {
+		panic(err)
+	}
+	authenticator := users.NewAuthenticator(usersRepo, tokens)
+	if err := authenticator.Bootstrap(context.Background(), os.Getenv("AUTH_ADMIN_EMAIL"), os.Getenv("AUTH_ADMIN_PASSWORD")); err != nil {
+		panic(err)
+	}
+
+	// Init Rate Limits
+	rateConfig, err := ratelimit.ConfigFromEnv()
+	if err != nil {
+		panic(err)
+	}
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore())
+	// Addresses are throttled before their credentials are looked up,
+	// clients once they are known.
+	e.Use(limiter.LimitIP("ip", rateConfig.IP))
+	e.Use(auth.Authenticate(tokens))
+	e.Use(apikeys.Authenticate(apiKeysRepo))
+	e.Use(limiter.Limit("default", rateConfig.Default))
+	authLimit := limiter.Limit("auth", rateConfig.Auth)
+
+	// Init Idempotency Keys
+	idempotencyConfig, err := idempotency.ConfigFromEnv()
+	if err != nil {
+		panic(err)
+	}
+	e.Use(idempotency.Middleware(idempotencyRepo, idempotencyConfig))
+
+	// Init Schedule
+	shopLocation, err := scheduling.LocationFromEnv()
+	if err != nil {
+		panic(err)
+	}
+
+	signedIn := auth.Require(auth.RoleAdmin, auth.RoleStaff, auth.RoleCustomer)
+	staff := auth.Require(auth.RoleAdmin, auth.RoleStaff)
+	admin := auth.Require(auth.RoleAdmin)
+	// API keys only get as far as their scopes allow.
+	cakesRead := auth.LimitKeys(auth.ScopeCakesRead)
+	cakesWrite := auth.RequireScope(auth.ScopeCakesWrite, auth.RoleAdmin, auth.RoleStaff)
+	ordersRead := auth.RequireScope(auth.ScopeOrdersRead, auth.RoleAdmin, auth.RoleStaff)
+	ordersWrite := auth.RequireScope(auth.ScopeOrdersWrite, auth.RoleAdmin, auth.RoleStaff)
+	ordersPlace := auth.RequireScope(auth.ScopeOrdersWrite, auth.RoleAdmin, auth.RoleStaff, auth.RoleCustomer)
 
 	// Init Handler
-	cakesHandler := cakes.NewHandler(cakesRepo)
+	menu := stores.NewMenu(storesRepo, variantsRepo)
+	cakesHandler := cakes.NewHandler(cakesRepo,
+		cakes.WithCategories(categoriesRepo),
+		cakes.WithIngredients(ingredientsRepo),
+		cakes.WithVariants(variantsRepo),
+		cakes.WithStock(inventoryRepo),
+		cakes.WithReviews(reviewsRepo),
+		cakes.WithOptions(optionsRepo),
+		cakes.WithSchedule(scheduleRepo),
+		cakes.WithStores(menu),
+		cakes.WithTransactions(storage.NewTransactor(db)),
+	)
+	categoriesHandler := categories.NewHandler(categoriesRepo)
+	ingredientsHandler := ingredients.NewHandler(ingredientsRepo)
+	variantsHandler := variants.NewHandler(variantsRepo, cakesRepo)
+	inventoryHandler := inventory.NewHandler(inventoryRepo, variantsRepo)
+	catalog := orders.NewCatalog(cakesRepo, variantsRepo, orders.WithMenu(menu))
+	scheduler := scheduling.NewScheduler(scheduleRepo, scheduling.WithLocation(shopLocation))
+	placer := orders.NewPlacer(ordersRepo, catalog,
+		orders.WithInventory(inventoryRepo),
+		orders.WithScheduling(scheduler),
+		orders.WithTransactions(storage.NewTransactor(db)),
+		orders.WithLogger(e.Logger),
+	)
+	ordersHandler := orders.NewHandler(ordersRepo, placer)
+	cartsHandler := carts.NewHandler(cartsRepo, catalog, placer)
+	reviewsHandler := reviews.NewHandler(reviewsRepo, cakesRepo, storage.NewTransactor(db))
+	optionsHandler := options.NewHandler(optionsRepo, cakesRepo, variantsRepo)
+	schedulingHandler := scheduling.NewHandler(scheduleRepo, scheduler, cakesRepo)
+	storesHandler := stores.NewHandler(storesRepo, menu, cakesRepo)
+	usersHandler := users.NewHandler(usersRepo, authenticator)
+	apiKeysHandler := apikeys.NewHandler(apiKeysRepo)
 
 	// Routes
-	e.GET("/cakes", cakesHandler.List)
-	e.GET("/cakes/:id", cakesHandler.Get)
-	e.POST("/cakes", cakesHandler.Create)
-	e.PATCH("/cakes/:id", cakesHandler.Update)
-	e.DELETE("/cakes/:id", cakesHandler.Delete)
+	e.GET("/cakes", cakesHandler.List, cakesRead)
+	e.GET("/cakes/suggest", cakesHandler.Suggest, cakesRead)
+	e.GET("/cakes/:id", cakesHandler.Get, cakesRead)
+	e.POST("/cakes", cakesHandler.Create, cakesWrite)
+	e.PATCH("/cakes/:id", cakesHandler.Update, cakesWrite)
+	e.DELETE("/cakes/:id", cakesHandler.Delete, cakesWrite)
+	e.GET("/cakes/:id/variants", variantsHandler.List, cakesRead)
+	e.GET("/cakes/:id/variants/:variant_id", variantsHandler.Get, cakesRead)
+	e.POST("/cakes/:id/variants", variantsHandler.Create, cakesWrite)
+	e.PATCH("/cakes/:id/variants/:variant_id", variantsHandler.Update, cakesWrite)
+	e.DELETE("/cakes/:id/variants/:variant_id", variantsHandler.Delete, cakesWrite)
+	e.GET("/cakes/:id/variants/:variant_id/stock/:day", inventoryHandler.Get, cakesRead)
+	e.PUT("/cakes/:id/variants/:variant_id/stock/:day", inventoryHandler.Set, cakesWrite)
+	e.GET("/cakes/:id/options", optionsHandler.Get, cakesRead)
+	e.PUT("/cakes/:id/options", optionsHandler.Set, cakesWrite)
+	e.POST("/cakes/:id/quote", optionsHandler.Quote, cakesRead)
+	e.GET("/cakes/:id/lead-time", schedulingHandler.LeadTime, cakesRead)
+	e.PUT("/cakes/:id/lead-time", schedulingHandler.SetLeadTime, cakesWrite)
+	e.GET("/cakes/:id/reviews", reviewsHandler.List, cakesRead)
+	e.POST("/cakes/:id/reviews", reviewsHandler.Create, signedIn)
+	e.GET("/categories", categoriesHandler.List, cakesRead)
+	e.GET("/categories/:id", categoriesHandler.Get, cakesRead)
+	e.POST("/categories", categoriesHandler.Create, cakesWrite)
+	e.PATCH("/categories/:id", categoriesHandler.Update, cakesWrite)
+	e.DELETE("/categories/:id", categoriesHandler.Delete, cakesWrite)
+	e.GET("/ingredients", ingredientsHandler.List, cakesRead)
+	e.GET("/ingredients/:id", ingredientsHandler.Get, cakesRead)
+	e.POST("/ingredients", ingredientsHandler.Create, cakesWrite)
+	e.PATCH("/ingredients/:id", ingredientsHandler.Update, cakesWrite)
+	e.DELETE("/ingredients/:id", ingredientsHandler.Delete, cakesWrite)
+	e.GET("/stock", inventoryHandler.List, cakesRead)
+	e.POST("/reservations", inventoryHandler.Reserve, staff)
+	e.GET("/reservations/:id", inventoryHandler.GetReservation, staff)
+	e.POST("/reservations/:id/release", inventoryHandler.Release, staff)
+	e.POST("/reservations/:id/commit", inventoryHandler.Commit, staff)
+	e.GET("/orders", ordersHandler.List, ordersRead)
+	e.GET("/orders/:id", ordersHandler.Get, ordersRead)
+	e.POST("/orders", ordersHandler.Create, ordersPlace)
+	e.POST("/orders/:id/confirm", ordersHandler.Transition(orders.StatusConfirmed), ordersWrite)
+	e.POST("/orders/:id/bake", ordersHandler.Transition(orders.StatusBaking), ordersWrite)
+	e.POST("/orders/:id/ready", ordersHandler.Transition(orders.StatusReady), ordersWrite)
+	e.POST("/orders/:id/pick-up", ordersHandler.Transition(orders.StatusPickedUp), ordersWrite)
+	e.POST("/orders/:id/deliver", ordersHandler.Transition(orders.StatusDelivered), ordersWrite)
+	e.POST("/orders/:id/cancel", ordersHandler.Transition(orders.StatusCancelled), ordersWrite)
+	e.GET("/customers/:customer_id/orders", ordersHandler.List, ordersRead)
+	e.POST("/carts", cartsHandler.Create)
+	e.GET("/carts/:id", cartsHandler.Get)
+	e.DELETE("/carts/:id", cartsHandler.Delete)
+	e.PUT("/carts/:id/items", cartsHandler.SetItem)
+	e.DELETE("/carts/:id/items/:variant_id", cartsHandler.RemoveItem)
+	e.POST("/carts/:id/checkout", cartsHandler.Checkout, signedIn)
+	e.GET("/reviews", reviewsHandler.List, staff)
+	e.GET("/reviews/:id", reviewsHandler.Get, staff)
+	e.DELETE("/reviews/:id", reviewsHandler.Delete, staff)
+	e.POST("/reviews/:id/approve", reviewsHandler.Transition(reviews.StatusApproved), staff)
+	e.POST("/reviews/:id/reject", reviewsHandler.Transition(reviews.StatusRejected), staff)
+	e.GET("/schedule/hours", schedulingHandler.Hours)
+	e.PUT("/schedule/hours", schedulingHandler.SetHours, admin)
+	e.GET("/holidays", schedulingHandler.Holidays)
+	e.PUT("/holidays/:day", schedulingHandler.SetHoliday, admin)
+	e.DELETE("/holidays/:day", schedulingHandler.DeleteHoliday, admin)
+	e.GET("/slots", schedulingHandler.Slots)
+	e.POST("/bookings", schedulingHandler.Book, staff)
+	e.GET("/bookings/:id", schedulingHandler.GetBooking, staff)
+	e.POST("/bookings/:id/release", schedulingHandler.Release, staff)
+	e.GET("/stores", storesHandler.List, cakesRead)
+	e.GET("/stores/:id", storesHandler.Get, cakesRead)
+	e.POST("/stores", storesHandler.Create, admin)
+	e.PATCH("/stores/:id", storesHandler.Update, admin)
+	e.DELETE("/stores/:id", storesHandler.Delete, admin)
+	e.GET("/stores/:id/cakes/:cake_id", storesHandler.GetCake, cakesRead)
+	e.PUT("/stores/:id/cakes/:cake_id", storesHandler.SetCake, admin)
+	e.DELETE("/stores/:id/cakes/:cake_id", storesHandler.DeleteCake, admin)
+	e.POST("/auth/register", usersHandler.Register, authLimit, idempotency.NoStore)
+	e.POST("/auth/login", usersHandler.Login, authLimit, idempotency.NoStore)
+	e.POST("/auth/refresh", usersHandler.Refresh, authLimit, idempotency.NoStore)
+	e.POST("/auth/logout", usersHandler.Logout)
+	e.GET("/users/me", usersHandler.Me, signedIn)
+	e.GET("/users/me/orders", ordersHandler.Mine, signedIn)
+	e.GET("/users/:id", usersHandler.Get, admin)
+	e.PUT("/users/:id/role", usersHandler.SetRole, admin)
+	e.GET("/api-keys", apiKeysHandler.List, admin)
+	e.GET("/api-keys/:id", apiKeysHandler.Get, admin)
+	e.POST("/api-keys", apiKeysHandler.Create, admin, idempotency.NoStore)
+	e.POST("/api-keys/:id/rotate", apiKeysHandler.Rotate, admin, idempotency.NoStore)
+	e.POST("/api-keys/:id/revoke", apiKeysHandler.Revoke, admin)
 
 	e.GET("/", func(ctx echo.Context) error {
 		return ctx.JSON(200, map[string]interface{}{"message": "API OK"})
@@ -0,0 +1,19 @@
+package apikeys
+
+import (
+	"cake-store/internal/auth"
+	"cake-store/internal/helpers"
+	"fmt"
+)
+
+var (
+	ErrNotFound   = fmt.Errorf("API key %w", helpers.ErrNotFound)
+	ErrConflict   = fmt.Errorf("API key %w", helpers.ErrConflict)
+	ErrValidation = fmt.Errorf("API key %w", helpers.ErrValidation)
+
+	// ErrInvalidKey is returned for an X-API-Key header that matches no
+	// active key.
+	ErrInvalidKey = fmt.Errorf("%w: the API key is invalid or revoked", auth.ErrUnauthorized)
+	// ErrRevoked is returned when rotating a revoked key.
+	ErrRevoked = fmt.Errorf("%w: the key is revoked", ErrConflict)
+)
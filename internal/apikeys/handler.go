@@ -0,0 +1,178 @@
+package apikeys
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+)
+
+type SvcInterface interface {
+	List(ctx echo.Context) error
+	Get(ctx echo.Context) error
+	Create(ctx echo.Context) error
+	Rotate(ctx echo.Context) error
+	Revoke(ctx echo.Context) error
+}
+
+type svcImplementation struct {
+	repo RepoInterface
+}
+
+func NewHandler(repo RepoInterface) SvcInterface {
+	return svcImplementation{repo}
+}
+
+func pathID(ctx echo.Context) (int, error) {
+	ID, errConv := strconv.Atoi(ctx.Param("id"))
+	if errConv != nil {
+		return 0, echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid id")
+	}
+	return ID, nil
+}
+
+// List godoc
+// @Summary List all API keys
+// @Description This endpoint for get list of API keys, revoked ones included, newest first; the keys themselves are never returned
+// @Tags API Keys
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Success 200 {array} Key
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /api-keys [get]
+func (s svcImplementation) List(ctx echo.Context) error {
+	res, err := s.repo.List(context.TODO())
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// Get godoc
+// @Summary Get detail of API key
+// @Description This endpoint for get detail of API key
+// @Tags API Keys
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path string true "API key id"
+// @Success 200 {object} Key
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /api-keys/{id} [get]
+func (s svcImplementation) Get(ctx echo.Context) error {
+	ID, err := pathID(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := s.repo.Get(context.TODO(), ID)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, data)
+}
+
+// Create godoc
+// @Summary Issue API key
+// @Description This endpoint for issuing an API key with scopes to a partner; the key is only returned in this response
+// @Tags API Keys
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param Request body RequestDto true "Issue API key"
+// @Success 201 {object} IssuedKey
+// @Header 201 {string} Location "/api-keys/{id}"
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /api-keys [post]
+func (s svcImplementation) Create(ctx echo.Context) error {
+	request := RequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	secret, prefix, hash, err := newSecret()
+	if err != nil {
+		return err
+	}
+	created, err := s.repo.Create(context.TODO(), Key{Name: request.Name, Prefix: prefix, Hash: hash, Scopes: request.Scopes})
+	if err != nil {
+		return err
+	}
+	ctx.Response().Header().Set(echo.HeaderLocation, "/api-keys/"+strconv.Itoa(created.ID))
+	return ctx.JSON(http.StatusCreated, IssuedKey{*created, secret})
+}
+
+// Rotate godoc
+// @Summary Rotate API key
+// @Description This endpoint for replacing the key of an active API key, keeping its name and scopes; the old key stops working at once and the new one is only returned in this response
+// @Tags API Keys
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path string true "API key id"
+// @Success 200 {object} IssuedKey
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 404 {object} helpers.Problem
+// @Failure 409 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /api-keys/{id}/rotate [post]
+func (s svcImplementation) Rotate(ctx echo.Context) error {
+	ID, err := pathID(ctx)
+	if err != nil {
+		return err
+	}
+
+	secret, prefix, hash, err := newSecret()
+	if err != nil {
+		return err
+	}
+	updated, err := s.repo.Rotate(context.TODO(), ID, prefix, hash)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, IssuedKey{*updated, secret})
+}
+
+// Revoke godoc
+// @Summary Revoke API key
+// @Description This endpoint for revoking an API key for good; revoking it again changes nothing
+// @Tags API Keys
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path string true "API key id"
+// @Success 200 {object} Key
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /api-keys/{id}/revoke [post]
+func (s svcImplementation) Revoke(ctx echo.Context) error {
+	ID, err := pathID(ctx)
+	if err != nil {
+		return err
+	}
+
+	revoked, err := s.repo.Revoke(context.TODO(), ID)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, revoked)
+}
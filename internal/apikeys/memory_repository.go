@@ -0,0 +1,148 @@
+package apikeys
+
+import (
+	"cake-store/internal/storage"
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+type memoryRepoImplementation struct {
+	mu     sync.RWMutex
+	keys   map[int]Key
+	nextID int
+}
+
+// NewMemoryRepository returns a RepoInterface that keeps API keys by ID,
+// looked up by hash with a scan of them all.
+func NewMemoryRepository() RepoInterface {
+	return &memoryRepoImplementation{
+		keys:   map[int]Key{},
+		nextID: 1,
+	}
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	value := *t
+	return &value
+}
+
+func copyKey(key Key) Key {
+	key.Scopes = append([]string{}, key.Scopes...)
+	key.UpdatedAt = copyTime(key.UpdatedAt)
+	key.LastUsedAt = copyTime(key.LastUsedAt)
+	key.RevokedAt = copyTime(key.RevokedAt)
+	return key
+}
+
+func (m *memoryRepoImplementation) List(ctx context.Context) ([]Key, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := []Key{}
+	for _, key := range m.keys {
+		result = append(result, copyKey(key))
+	}
+	sort.Slice(result, func(a, b int) bool { return result[a].ID > result[b].ID })
+	return result, nil
+}
+func (m *memoryRepoImplementation) Get(ctx context.Context, id int) (*Key, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	key, ok := m.keys[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	result := copyKey(key)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) GetByHash(ctx context.Context, hash string) (*Key, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, key := range m.keys {
+		if key.Hash == hash {
+			result := copyKey(key)
+			return &result, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+func (m *memoryRepoImplementation) Create(ctx context.Context, key Key) (*Key, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, existing := range m.keys {
+		if existing.Hash == key.Hash {
+			return nil, ErrConflict
+		}
+	}
+	key = copyKey(key)
+	key.ID = m.nextID
+	key.Scopes = normalizeScopes(key.Scopes)
+	key.CreatedAt = storage.TruncateTime(time.Now())
+	key.UpdatedAt, key.LastUsedAt, key.RevokedAt = nil, nil, nil
+	m.keys[key.ID] = key
+	m.nextID++
+	result := copyKey(key)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Rotate(ctx context.Context, id int, prefix, hash string) (*Key, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key, ok := m.keys[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if !key.Active() {
+		return nil, ErrRevoked
+	}
+	for _, existing := range m.keys {
+		if existing.ID != id && existing.Hash == hash {
+			return nil, ErrConflict
+		}
+	}
+	key.Prefix, key.Hash = prefix, hash
+	updatedAt := storage.TruncateTime(time.Now())
+	key.UpdatedAt = &updatedAt
+	m.keys[id] = key
+	result := copyKey(key)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Revoke(ctx context.Context, id int) (*Key, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key, ok := m.keys[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if key.Active() {
+		now := storage.TruncateTime(time.Now())
+		key.RevokedAt, key.UpdatedAt = &now, copyTime(&now)
+		m.keys[id] = key
+	}
+	result := copyKey(key)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Touch(ctx context.Context, id int, now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key, ok := m.keys[id]
+	if !ok {
+		return nil
+	}
+	now = storage.TruncateTime(now)
+	if key.LastUsedAt == nil || key.LastUsedAt.Before(now.Add(-TouchInterval)) {
+		key.LastUsedAt = &now
+		m.keys[id] = key
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package apikeys
+
+import (
+	"cake-store/internal/auth"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Authenticate reads the X-API-Key header of every request, after
+// auth.Authenticate. Requests without one go on as they are, requests with
+// an unknown or revoked key, or with a bearer token as well, are refused
+// with 401.
+func Authenticate(repo RepoInterface) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			secret := ctx.Request().Header.Get(Header)
+			if secret == "" {
+				return next(ctx)
+			}
+			if _, ok := auth.PrincipalFrom(ctx); ok {
+				return fmt.Errorf("%w: send either a bearer token or an API key", auth.ErrUnauthorized)
+			}
+			key, err := repo.GetByHash(context.TODO(), HashKey(secret))
+			if errors.Is(err, ErrNotFound) || (err == nil && !key.Active()) {
+				return ErrInvalidKey
+			}
+			if err != nil {
+				return err
+			}
+			if err := repo.Touch(context.TODO(), key.ID, time.Now()); err != nil {
+				ctx.Logger().Warnf("API key %d: recording its use: %v", key.ID, err)
+			}
+			auth.SetPrincipal(ctx, key.principal())
+			return next(ctx)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package apikeys
+
+//go:generate mockgen -destination=../../mocks/apikeys/mock_repository.go -package=mock_apikeys -source=repository.go
+
+import (
+	"cake-store/internal/query"
+	"cake-store/internal/storage"
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+)
+
+const TableName = "api_keys"
+
+// Columns lists the api_keys columns in the order scanned by scanKey.
+var Columns = []string{"id", "name", "prefix", "key_hash", "scopes", "created_at", "updated_at", "last_used_at", "revoked_at"}
+
+// TouchInterval is how often the last use of a key is recorded at most, so
+// busy keys do not write on every request.
+const TouchInterval = time.Minute
+
+type repoImplementation struct {
+	db *sql.DB
+}
+
+type RepoInterface interface {
+	// List returns every key, revoked ones included, newest first.
+	List(ctx context.Context) ([]Key, error)
+	Get(ctx context.Context, id int) (*Key, error)
+	// GetByHash looks a key up by the hash of its secret.
+	GetByHash(ctx context.Context, hash string) (*Key, error)
+	Create(ctx context.Context, key Key) (*Key, error)
+	// Rotate replaces the prefix and hash of an active key, so the old
+	// secret stops working at once. It returns ErrRevoked for revoked keys.
+	Rotate(ctx context.Context, id int, prefix, hash string) (*Key, error)
+	// Revoke revokes a key; revoking it again changes nothing.
+	Revoke(ctx context.Context, id int) (*Key, error)
+	// Touch records that a key was used at now, unless that was already
+	// recorded within TouchInterval.
+	Touch(ctx context.Context, id int, now time.Time) error
+}
+
+// NewRepository returns a RepoInterface over the api_keys table, which
+// holds the hashes of the keys, never the keys themselves.
+func NewRepository(db *sql.DB) RepoInterface {
+	return repoImplementation{db}
+}
+
+func scanKey(scan func(dest ...interface{}) error) (key Key, err error) {
+	var scopes string
+	err = scan(&key.ID, &key.Name, &key.Prefix, &key.Hash, &scopes, &key.CreatedAt, &key.UpdatedAt, &key.LastUsedAt, &key.RevokedAt)
+	key.Scopes = splitScopes(scopes)
+	return
+}
+
+func (i repoImplementation) getWhere(ctx context.Context, condition query.Condition) (*Key, error) {
+	q, args := query.Select(TableName, Columns...).Where(condition).Build()
+	result, err := scanKey(i.db.QueryRowContext(ctx, q, args...).Scan)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (i repoImplementation) List(ctx context.Context) ([]Key, error) {
+	q, args := query.Select(TableName, Columns...).OrderBy("id DESC").Build()
+	rows, err := i.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []Key{}
+	for rows.Next() {
+		key, err := scanKey(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, key)
+	}
+	return result, rows.Err()
+}
+func (i repoImplementation) Get(ctx context.Context, id int) (*Key, error) {
+	return i.getWhere(ctx, query.Eq("id", id))
+}
+func (i repoImplementation) GetByHash(ctx context.Context, hash string) (*Key, error) {
+	return i.getWhere(ctx, query.Eq("key_hash", hash))
+}
+func (i repoImplementation) Create(ctx context.Context, key Key) (*Key, error) {
+	q, args := query.Insert(TableName).
+		Set("name", key.Name).
+		Set("prefix", key.Prefix).
+		Set("key_hash", key.Hash).
+		Set("scopes", strings.Join(normalizeScopes(key.Scopes), ",")).
+		Set("created_at", storage.TruncateTime(time.Now())).
+		Build()
+	res, err := i.db.ExecContext(ctx, q, args...)
+	if storage.IsDuplicate(err) {
+		return nil, ErrConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return i.Get(ctx, int(id))
+}
+func (i repoImplementation) Rotate(ctx context.Context, id int, prefix, hash string) (*Key, error) {
+	q, args := query.Update(TableName).
+		Set("prefix", prefix).
+		Set("key_hash", hash).
+		Set("updated_at", storage.TruncateTime(time.Now())).
+		Where(query.Eq("id", id), query.Expr("revoked_at IS NULL")).
+		Build()
+	res, err := i.db.ExecContext(ctx, q, args...)
+	if storage.IsDuplicate(err) {
+		return nil, ErrConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	key, err := i.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, ErrRevoked
+	}
+	return key, nil
+}
+func (i repoImplementation) Revoke(ctx context.Context, id int) (*Key, error) {
+	now := storage.TruncateTime(time.Now())
+	q, args := query.Update(TableName).
+		Set("revoked_at", now).
+		Set("updated_at", now).
+		Where(query.Eq("id", id), query.Expr("revoked_at IS NULL")).
+		Build()
+	if _, err := i.db.ExecContext(ctx, q, args...); err != nil {
+		return nil, err
+	}
+	return i.Get(ctx, id)
+}
+func (i repoImplementation) Touch(ctx context.Context, id int, now time.Time) error {
+	now = storage.TruncateTime(now)
+	q, args := query.Update(TableName).
+		Set("last_used_at", now).
+		Where(query.Eq("id", id), query.Expr("last_used_at IS NULL OR last_used_at < ?", now.Add(-TouchInterval))).
+		Build()
+	_, err := i.db.ExecContext(ctx, q, args...)
+	return err
+}
@@ -0,0 +1,76 @@
+// Package auth issues and checks the JWTs that authenticate API requests,
+// and guards routes by role.
+package auth
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+)
+
+const (
+	defaultAccessTTL  = 15 * time.Minute
+	defaultRefreshTTL = 30 * 24 * time.Hour
+	defaultIssuer     = "cake-store"
+)
+
+// placeholderPrefix starts the example secrets of the docs.
+const placeholderPrefix = "change-me"
+
+// Placeholder reports whether secret is missing or an example value, which
+// anyone could use to sign tokens or log in.
+func Placeholder(secret string) bool {
+	return secret == "" || strings.HasPrefix(strings.ToLower(secret), placeholderPrefix)
+}
+
+// Config selects how tokens are signed and how long they last.
+type Config struct {
+	// Algorithm is HS256, signing with Secret, or RS256, signing with the
+	// PEM private key in PrivateKeyFile. PublicKeyFile is optional for
+	// RS256; the public half of the private key is used without it.
+	Algorithm      string
+	Secret         string
+	PrivateKeyFile string
+	PublicKeyFile  string
+	Issuer         string
+	AccessTTL      time.Duration
+	RefreshTTL     time.Duration
+}
+
+// ConfigFromEnv reads the AUTH_* environment variables, defaulting to
+// HS256 tokens valid for 15 minutes and refresh tokens valid for 30 days.
+func ConfigFromEnv() (Config, error) {
+	config := Config{
+		Algorithm:      os.Getenv("AUTH_JWT_ALGORITHM"),
+		Secret:         os.Getenv("AUTH_JWT_SECRET"),
+		PrivateKeyFile: os.Getenv("AUTH_JWT_PRIVATE_KEY"),
+		PublicKeyFile:  os.Getenv("AUTH_JWT_PUBLIC_KEY"),
+		Issuer:         os.Getenv("AUTH_JWT_ISSUER"),
+		AccessTTL:      defaultAccessTTL,
+		RefreshTTL:     defaultRefreshTTL,
+	}
+	if config.Algorithm == "" {
+		config.Algorithm = AlgorithmHS256
+	}
+	if config.Issuer == "" {
+		config.Issuer = defaultIssuer
+	}
+	for name, ttl := range map[string]*time.Duration{"AUTH_ACCESS_TTL": &config.AccessTTL, "AUTH_REFRESH_TTL": &config.RefreshTTL} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			return config, fmt.Errorf("%w: %s must be a positive duration such as 15m", ErrConfig, name)
+		}
+		*ttl = parsed
+	}
+	return config, nil
+}
@@ -0,0 +1,16 @@
+package auth
+
+import (
+	"cake-store/internal/helpers"
+	"fmt"
+)
+
+var (
+	ErrUnauthorized = fmt.Errorf("request %w", helpers.ErrUnauthorized)
+	ErrForbidden    = fmt.Errorf("request %w", helpers.ErrForbidden)
+	ErrConfig       = fmt.Errorf("auth configuration %w", helpers.ErrValidation)
+
+	// ErrInvalidToken is returned for a bearer token that is malformed,
+	// expired or not signed by us.
+	ErrInvalidToken = fmt.Errorf("%w: the token is invalid or expired", ErrUnauthorized)
+)
@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	RoleAdmin    = "admin"
+	RoleStaff    = "staff"
+	RoleCustomer = "customer"
+)
+
+// Roles lists the roles from the most to the least privileged.
+var Roles = []string{RoleAdmin, RoleStaff, RoleCustomer}
+
+// Scopes of partner API keys.
+const (
+	ScopeCakesRead   = "cakes:read"
+	ScopeCakesWrite  = "cakes:write"
+	ScopeOrdersRead  = "orders:read"
+	ScopeOrdersWrite = "orders:write"
+)
+
+// Scopes lists every scope an API key can be granted.
+var Scopes = []string{ScopeCakesRead, ScopeCakesWrite, ScopeOrdersRead, ScopeOrdersWrite}
+
+// principalKey is the echo.Context key Authenticate stores the principal
+// under.
+const principalKey = "auth.principal"
+
+// ValidRole reports whether role is one of Roles.
+func ValidRole(role string) bool {
+	for _, name := range Roles {
+		if name == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticate reads the bearer token of every request. Requests without
+// one go on anonymously, requests with an invalid one are refused with 401.
+func Authenticate(tokens Tokens) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			header := ctx.Request().Header.Get(echo.HeaderAuthorization)
+			if header == "" {
+				return next(ctx)
+			}
+			scheme, token, found := strings.Cut(header, " ")
+			if !found || !strings.EqualFold(scheme, "Bearer") {
+				return challenge(ctx, ErrInvalidToken)
+			}
+			principal, err := tokens.Parse(strings.TrimSpace(token))
+			if err != nil {
+				return challenge(ctx, err)
+			}
+			SetPrincipal(ctx, principal)
+			return next(ctx)
+		}
+	}
+}
+
+// Require lets through requests by users with one of roles, refusing
+// anonymous ones with 401 and others, API keys included, with 403.
+func Require(roles ...string) echo.MiddlewareFunc {
+	return RequireScope("", roles...)
+}
+
+// RequireScope is Require that also lets through API keys granted scope.
+func RequireScope(scope string, roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			principal, ok := PrincipalFrom(ctx)
+			if !ok {
+				return challenge(ctx, fmt.Errorf("%w: a bearer token or an API key is required", ErrUnauthorized))
+			}
+			if principal.KeyID != 0 {
+				if scope != "" && principal.HasScope(scope) {
+					return next(ctx)
+				}
+				return keyForbidden(scope)
+			}
+			for _, role := range roles {
+				if principal.Role == role {
+					return next(ctx)
+				}
+			}
+			return fmt.Errorf("%w: the %s role may not do this", ErrForbidden, principal.Role)
+		}
+	}
+}
+
+// LimitKeys refuses requests by API keys not granted scope with 403, and
+// lets every other request through.
+func LimitKeys(scope string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			principal, ok := PrincipalFrom(ctx)
+			if ok && principal.KeyID != 0 && !principal.HasScope(scope) {
+				return keyForbidden(scope)
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// SetPrincipal records who made the request, for authenticators other
+// than Authenticate.
+func SetPrincipal(ctx echo.Context, principal *Principal) {
+	ctx.Set(principalKey, principal)
+}
+
+// PrincipalFrom returns who made the request, when it was authenticated.
+func PrincipalFrom(ctx echo.Context) (*Principal, bool) {
+	principal, ok := ctx.Get(principalKey).(*Principal)
+	return principal, ok
+}
+
+// HasRole reports whether the request was made by a user with one of
+// roles.
+func HasRole(ctx echo.Context, roles ...string) bool {
+	principal, ok := PrincipalFrom(ctx)
+	if !ok || principal.KeyID != 0 {
+		return false
+	}
+	for _, role := range roles {
+		if principal.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Customer returns the principal of a request made by a customer. Orders,
+// carts and reviews of customers are always their own, whatever the
+// request body says.
+func Customer(ctx echo.Context) (*Principal, bool) {
+	if !HasRole(ctx, RoleCustomer) {
+		return nil, false
+	}
+	return PrincipalFrom(ctx)
+}
+
+// ClientID tells clients apart, by API key, then by user, then by IP
+// address.
+func ClientID(ctx echo.Context) string {
+	if principal, ok := PrincipalFrom(ctx); ok {
+		if principal.KeyID != 0 {
+			return "key:" + strconv.Itoa(principal.KeyID)
+		}
+		return "user:" + strconv.Itoa(principal.UserID)
+	}
+	return "ip:" + ctx.RealIP()
+}
+
+// keyForbidden is returned to an API key missing scope.
+func keyForbidden(scope string) error {
+	if scope == "" {
+		return fmt.Errorf("%w: API keys may not do this", ErrForbidden)
+	}
+	return fmt.Errorf("%w: the API key lacks the %s scope", ErrForbidden, scope)
+}
+
+// challenge asks the client to authenticate with a bearer token.
+func challenge(ctx echo.Context, err error) error {
+	ctx.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer realm="cake-store"`)
+	return err
+}
@@ -0,0 +1,165 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// minSecretLength is the shortest HS256 secret accepted, 256 bits.
+const minSecretLength = 32
+
+// Principal is who a request is made by: a user, with a role, or a
+// partner API key, with scopes.
+type Principal struct {
+	UserID int      `json:"user_id,omitempty"`
+	Email  string   `json:"email,omitempty"`
+	Name   string   `json:"name,omitempty"`
+	Role   string   `json:"role,omitempty"`
+	KeyID  int      `json:"key_id,omitempty"`
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// CustomerID is the customer_id of the orders and carts of the user.
+func (p Principal) CustomerID() string {
+	return "user-" + strconv.Itoa(p.UserID)
+}
+
+// DisplayName is the name the user signs reviews with: their name, or the
+// part of their email before the @ when they gave none.
+func (p Principal) DisplayName() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	name, _, _ := strings.Cut(p.Email, "@")
+	return name
+}
+
+// HasScope reports whether the principal is an API key granted scope.
+func (p Principal) HasScope(scope string) bool {
+	for _, name := range p.Scopes {
+		if name == scope {
+			return p.KeyID != 0
+		}
+	}
+	return false
+}
+
+// Claims are the claims of an access token; the subject is the user ID.
+type Claims struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+	Role  string `json:"role"`
+	jwt.StandardClaims
+}
+
+// Tokens signs and verifies access tokens.
+type Tokens struct {
+	method     jwt.SigningMethod
+	signKey    interface{}
+	verifyKey  interface{}
+	issuer     string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+// NewTokens loads the keys config names, or returns ErrConfig when they
+// are missing or unusable.
+func NewTokens(config Config) (Tokens, error) {
+	tokens := Tokens{issuer: config.Issuer, accessTTL: config.AccessTTL, refreshTTL: config.RefreshTTL}
+	if tokens.accessTTL <= 0 || tokens.refreshTTL <= 0 {
+		return tokens, fmt.Errorf("%w: token lifetimes must be positive", ErrConfig)
+	}
+
+	switch config.Algorithm {
+	case AlgorithmHS256:
+		if Placeholder(config.Secret) {
+			return tokens, fmt.Errorf("%w: AUTH_JWT_SECRET must be set to a random secret", ErrConfig)
+		}
+		if len(config.Secret) < minSecretLength {
+			return tokens, fmt.Errorf("%w: AUTH_JWT_SECRET must be at least %d bytes", ErrConfig, minSecretLength)
+		}
+		tokens.method = jwt.SigningMethodHS256
+		tokens.signKey, tokens.verifyKey = []byte(config.Secret), []byte(config.Secret)
+	case AlgorithmRS256:
+		pem, err := os.ReadFile(config.PrivateKeyFile)
+		if err != nil {
+			return tokens, fmt.Errorf("%w: AUTH_JWT_PRIVATE_KEY: %v", ErrConfig, err)
+		}
+		private, err := jwt.ParseRSAPrivateKeyFromPEM(pem)
+		if err != nil {
+			return tokens, fmt.Errorf("%w: AUTH_JWT_PRIVATE_KEY: %v", ErrConfig, err)
+		}
+		tokens.method = jwt.SigningMethodRS256
+		tokens.signKey, tokens.verifyKey = private, &private.PublicKey
+		if config.PublicKeyFile != "" {
+			pem, err := os.ReadFile(config.PublicKeyFile)
+			if err != nil {
+				return tokens, fmt.Errorf("%w: AUTH_JWT_PUBLIC_KEY: %v", ErrConfig, err)
+			}
+			public, err := jwt.ParseRSAPublicKeyFromPEM(pem)
+			if err != nil {
+				return tokens, fmt.Errorf("%w: AUTH_JWT_PUBLIC_KEY: %v", ErrConfig, err)
+			}
+			if public.N.Cmp(private.N) != 0 || public.E != private.E {
+				return tokens, fmt.Errorf("%w: AUTH_JWT_PUBLIC_KEY does not match the private key", ErrConfig)
+			}
+			tokens.verifyKey = public
+		}
+	default:
+		return tokens, fmt.Errorf("%w: AUTH_JWT_ALGORITHM must be %s or %s", ErrConfig, AlgorithmHS256, AlgorithmRS256)
+	}
+	return tokens, nil
+}
+
+// AccessTTL is how long issued access tokens are valid.
+func (t Tokens) AccessTTL() time.Duration {
+	return t.accessTTL
+}
+
+// RefreshTTL is how long refresh tokens issued alongside them are valid.
+func (t Tokens) RefreshTTL() time.Duration {
+	return t.refreshTTL
+}
+
+// Issue returns a signed access token for principal.
+func (t Tokens) Issue(principal Principal) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		Email: principal.Email,
+		Name:  principal.Name,
+		Role:  principal.Role,
+		StandardClaims: jwt.StandardClaims{
+			Issuer:    t.issuer,
+			Subject:   strconv.Itoa(principal.UserID),
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(t.accessTTL).Unix(),
+		},
+	}
+	return jwt.NewWithClaims(t.method, claims).SignedString(t.signKey)
+}
+
+// Parse verifies an access token and returns who it was issued to, or
+// ErrInvalidToken. Only the configured algorithm is accepted.
+func (t Tokens) Parse(token string) (*Principal, error) {
+	parser := jwt.Parser{ValidMethods: []string{t.method.Alg()}}
+	claims := Claims{}
+	_, err := parser.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return t.verifyKey, nil
+	})
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	if claims.ExpiresAt == 0 || claims.Issuer != t.issuer || !ValidRole(claims.Role) {
+		return nil, ErrInvalidToken
+	}
+	userID, err := strconv.Atoi(claims.Subject)
+	if err != nil || userID <= 0 {
+		return nil, ErrInvalidToken
+	}
+	return &Principal{UserID: userID, Email: claims.Email, Name: claims.Name, Role: claims.Role}, nil
+}
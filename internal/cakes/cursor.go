@@ -0,0 +1,56 @@
+package cakes
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Cursor is the keyset position of a cake in a listing order. It carries the
+// values of every sortable field of the last cake on a page plus the sort it
+// was produced for. Clients only see it as an opaque string.
+type Cursor struct {
+	Sort      string    `json:"s"`
+	Rating    float64   `json:"r"`
+	Title     string    `json:"t"`
+	CreatedAt time.Time `json:"c"`
+	Score     float64   `json:"sc,omitempty"`
+	ID        int       `json:"i"`
+}
+
+// EncodeCursor returns the opaque cursor pointing after cake in the order
+// given by keys.
+func EncodeCursor(keys []SortKey, cake Cake) string {
+	raw, _ := json.Marshal(Cursor{
+		Sort:      sortString(keys),
+		Rating:    cake.Rating,
+		Title:     cake.Title,
+		CreatedAt: cake.CreatedAt,
+		Score:     cake.score(),
+		ID:        cake.ID,
+	})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor parses a cursor produced by EncodeCursor for the same keys.
+func DecodeCursor(keys []SortKey, s string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", ErrValidation)
+	}
+	var cursor Cursor
+	if err = json.Unmarshal(raw, &cursor); err != nil || cursor.ID <= 0 {
+		return nil, fmt.Errorf("%w: invalid cursor", ErrValidation)
+	}
+	if cursor.Sort != sortString(keys) {
+		return nil, fmt.Errorf("%w: cursor was issued for a different sort", ErrValidation)
+	}
+	return &cursor, nil
+}
+
+// cake returns the boundary cake the cursor points after.
+func (c Cursor) cake() Cake {
+	score := c.Score
+	return Cake{Rating: c.Rating, Title: c.Title, CreatedAt: c.CreatedAt, Score: &score, ID: c.ID}
+}
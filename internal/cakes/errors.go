@@ -0,0 +1,18 @@
+package cakes
+
+import (
+	"cake-store/internal/helpers"
+	"fmt"
+)
+
+var (
+	ErrNotFound   = fmt.Errorf("cake %w", helpers.ErrNotFound)
+	ErrConflict   = fmt.Errorf("cake %w", helpers.ErrConflict)
+	ErrValidation = fmt.Errorf("cake %w", helpers.ErrValidation)
+
+	// ErrStale is returned when a cake changed since the version a write
+	// was conditioned on.
+	ErrStale = fmt.Errorf("cake %w: it was changed since it was read", helpers.ErrPreconditionFailed)
+	// ErrIfMatchRequired is returned for writes without If-Match.
+	ErrIfMatchRequired = fmt.Errorf("cake %w: send the ETag it was read with in If-Match", helpers.ErrPreconditionRequired)
+)
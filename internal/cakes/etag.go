@@ -0,0 +1,116 @@
+package cakes
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Conditional request headers.
+const (
+	HeaderETag        = "ETag"
+	HeaderIfMatch     = "If-Match"
+	HeaderIfNoneMatch = "If-None-Match"
+)
+
+// ETag formats the entity tag of a cake response: the version of the
+// cake, which If-Match is checked against, and a hash of body, which also
+// changes with what is embedded in the cake, such as its stock.
+func ETag(version int, body []byte) string {
+	return `"` + strconv.Itoa(version) + "-" + bodyHash(body) + `"`
+}
+
+// listETag is the entity tag of a page of cakes.
+func listETag(body []byte) string {
+	return `"` + bodyHash(body) + `"`
+}
+
+func bodyHash(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:8])
+}
+
+// entityTags splits an If-Match or If-None-Match header. With weak, for
+// the weak comparison of If-None-Match, weak tags count as their strong
+// ones; otherwise, for the strong comparison of If-Match, they are left
+// out, as a weak tag never matches.
+func entityTags(header string, weak bool) []string {
+	tags := []string{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if strings.HasPrefix(tag, "W/") {
+			if !weak {
+				continue
+			}
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// writeJSON sends data with the ETag tag computes from it, or 304 to a
+// read whose If-None-Match holds that ETag.
+func writeJSON(ctx echo.Context, status int, data interface{}, tag func(body []byte) string) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	etag := tag(body)
+	ctx.Response().Header().Set(HeaderETag, etag)
+
+	if method := ctx.Request().Method; method == http.MethodGet || method == http.MethodHead {
+		for _, match := range entityTags(ctx.Request().Header.Get(HeaderIfNoneMatch), true) {
+			if match == "*" || match == etag {
+				return ctx.NoContent(http.StatusNotModified)
+			}
+		}
+	}
+	return ctx.JSONBlob(status, body)
+}
+
+// matchedVersion returns the version of the cake with id that the
+// If-Match header of a write holds, for the repository to apply the write
+// to only that version; 0 for "*", which matches any.
+func (s svcImplementation) matchedVersion(ctx echo.Context, id int) (int, error) {
+	header := ctx.Request().Header.Get(HeaderIfMatch)
+	if header == "" {
+		return 0, ErrIfMatchRequired
+	}
+	versions := []int{}
+	for _, tag := range entityTags(header, false) {
+		if tag == "*" {
+			return 0, nil
+		}
+		version, _, found := strings.Cut(strings.Trim(tag, `"`), "-")
+		if parsed, err := strconv.Atoi(version); found && err == nil && parsed > 0 {
+			versions = append(versions, parsed)
+		}
+	}
+	switch len(versions) {
+	case 0:
+		return 0, ErrStale
+	case 1:
+		return versions[0], nil
+	}
+
+	// Of several tags only the current version can match.
+	current, err := s.repo.Get(context.TODO(), id)
+	if err != nil {
+		return 0, err
+	}
+	for _, version := range versions {
+		if version == current.Version {
+			return version, nil
+		}
+	}
+	return 0, ErrStale
+}
@@ -0,0 +1,103 @@
+package cakes
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// FacetBucket is the number of matching cakes with one facet value.
+type FacetBucket struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// Facets maps facet names to their buckets, in bucket order.
+type Facets map[string][]FacetBucket
+
+type facetDef struct {
+	// values lists every bucket, so empty buckets are reported too.
+	values []string
+	// expr is the SQL expression evaluating to the bucket value.
+	expr string
+	// value is the in-memory equivalent of expr.
+	value func(Cake) string
+}
+
+var facetDefs = map[string]facetDef{
+	"rating": {
+		values: []string{"unrated", "1-2", "2-3", "3-4", "4-5"},
+		expr:   "CASE WHEN rating_count = 0 THEN 'unrated' WHEN rating < 2 THEN '1-2' WHEN rating < 3 THEN '2-3' WHEN rating < 4 THEN '3-4' ELSE '4-5' END",
+		value: func(c Cake) string {
+			switch {
+			case c.RatingCount == 0:
+				return "unrated"
+			case c.Rating < 2:
+				return "1-2"
+			case c.Rating < 3:
+				return "2-3"
+			case c.Rating < 4:
+				return "3-4"
+			}
+			return "4-5"
+		},
+	},
+	"has_image": {
+		values: []string{"true", "false"},
+		expr:   "CASE WHEN image IS NOT NULL AND image <> '' THEN 'true' ELSE 'false' END",
+		value: func(c Cake) string {
+			return fmt.Sprint(c.Image != nil && *c.Image != "")
+		},
+	},
+}
+
+// ParseFacets parses a comma separated list of facet names.
+func ParseFacets(s string) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if _, ok := facetDefs[name]; !ok {
+			return nil, fmt.Errorf("%w: unknown facet %q", ErrValidation, name)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// FacetHeader returns the response header carrying a facet, such as
+// Facets-Has-Image for has_image.
+func FacetHeader(name string) string {
+	return http.CanonicalHeaderKey("Facets-" + strings.ReplaceAll(name, "_", "-"))
+}
+
+// FormatFacet formats buckets as a header value such as "true=4, false=2".
+func FormatFacet(buckets []FacetBucket) string {
+	terms := make([]string, len(buckets))
+	for i, bucket := range buckets {
+		terms[i] = bucket.Value + "=" + strconv.FormatInt(bucket.Count, 10)
+	}
+	return strings.Join(terms, ", ")
+}
+
+// newFacets returns the named facets with every bucket at zero.
+func newFacets(names []string) Facets {
+	facets := Facets{}
+	for _, name := range names {
+		buckets := make([]FacetBucket, len(facetDefs[name].values))
+		for i, value := range facetDefs[name].values {
+			buckets[i] = FacetBucket{Value: value}
+		}
+		facets[name] = buckets
+	}
+	return facets
+}
+
+// add counts n cakes with value into the facet's bucket.
+func (f Facets) add(name, value string, n int64) {
+	for i := range f[name] {
+		if f[name][i].Value == value {
+			f[name][i].Count += n
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package cakes
+
+import (
+	"cake-store/internal/query"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseIDs parses a comma separated list of cake IDs such as "1,2,3".
+func ParseIDs(s string) ([]int, error) {
+	var ids []int
+	for _, term := range strings.Split(s, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(term))
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("%w: invalid id %q", ErrValidation, term)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// splitList splits a comma separated list, trimming every term.
+func splitList(s string) []string {
+	terms := strings.Split(s, ",")
+	for i, term := range terms {
+		terms[i] = strings.TrimSpace(term)
+	}
+	return terms
+}
+
+// listPlan is a ListRequestDto with its sort and id list parsed, shared by
+// the repository implementations.
+type listPlan struct {
+	dto  ListRequestDto
+	keys []SortKey
+	ids  []int
+	// facets are the names of the requested facets.
+	facets []string
+	// rel is the SQL relevance of a search, set by the SQL repository.
+	rel *relevance
+	// scores are the search results used by matches.
+	scores map[int]float64
+}
+
+func planList(dto ListRequestDto) (plan listPlan, err error) {
+	plan.dto = dto
+	plan.keys = dto.SortKeys
+	if plan.keys == nil {
+		if plan.keys, err = ParseSort(dto.sortExpression()); err != nil {
+			return
+		}
+	}
+	if dto.Q == "" {
+		for _, key := range plan.keys {
+			if key.Field == "score" {
+				err = fmt.Errorf("%w: sorting by score requires q", ErrValidation)
+				return
+			}
+		}
+	}
+	if dto.RatingMin != nil && dto.RatingMax != nil && *dto.RatingMin > *dto.RatingMax {
+		err = fmt.Errorf("%w: rating_min must not exceed rating_max", ErrValidation)
+		return
+	}
+	if dto.CreatedAfter != nil && dto.CreatedBefore != nil && !dto.CreatedAfter.Before(*dto.CreatedBefore) {
+		err = fmt.Errorf("%w: created_after must be before created_before", ErrValidation)
+		return
+	}
+	if dto.IDs != "" {
+		if plan.ids, err = ParseIDs(dto.IDs); err != nil {
+			return
+		}
+	}
+	if dto.Facets != "" {
+		plan.facets, err = ParseFacets(dto.Facets)
+	}
+	return
+}
+
+// conditions returns the WHERE conditions of the plan's filters, excluding
+// the cursor position.
+func (p listPlan) conditions() []query.Condition {
+	var conditions []query.Condition
+	if p.dto.Title != "" {
+		conditions = append(conditions, query.Like("title", p.dto.Title))
+	}
+	if p.dto.Description != "" {
+		conditions = append(conditions, query.Like("description", p.dto.Description))
+	}
+	if p.dto.RatingMin != nil {
+		conditions = append(conditions, query.Expr("rating >= ?", *p.dto.RatingMin))
+	}
+	if p.dto.RatingMax != nil {
+		conditions = append(conditions, query.Expr("rating <= ?", *p.dto.RatingMax))
+	}
+	if p.dto.CreatedAfter != nil {
+		conditions = append(conditions, query.Expr("created_at > ?", p.dto.CreatedAfter.UTC()))
+	}
+	if p.dto.CreatedBefore != nil {
+		conditions = append(conditions, query.Expr("created_at < ?", p.dto.CreatedBefore.UTC()))
+	}
+	if p.dto.HasImage != nil {
+		if *p.dto.HasImage {
+			conditions = append(conditions, query.Expr("image IS NOT NULL AND image <> ''"))
+		} else {
+			conditions = append(conditions, query.Expr("image IS NULL OR image = ''"))
+		}
+	}
+	if p.dto.IDs != "" {
+		conditions = append(conditions, query.In("id", query.Ints(p.ids)...))
+	}
+	for _, ids := range p.dto.Within {
+		conditions = append(conditions, query.InIDs("id", ids))
+	}
+	for _, ids := range p.dto.Without {
+		conditions = append(conditions, query.NotInIDs("id", ids))
+	}
+	if p.rel != nil {
+		conditions = append(conditions, p.rel.condition)
+	}
+	return conditions
+}
+
+// column returns the SQL expression of a sortable field.
+func (p listPlan) column(field string) (string, []interface{}) {
+	if field == "score" {
+		return p.rel.expr, p.rel.args
+	}
+	return sortFields[field].column, nil
+}
+
+// orderBy adds the ORDER BY terms of the plan's sort to builder.
+func (p listPlan) orderBy(builder *query.SelectBuilder) {
+	for _, key := range p.keys {
+		column, args := p.column(key.Field)
+		direction := " ASC"
+		if key.Desc {
+			direction = " DESC"
+		}
+		builder.OrderByExpr(column+direction, args...)
+	}
+}
+
+// keysetCondition matches the rows sorting strictly after the cake at the
+// cursor position. The cursor values are compared for equality, so sort
+// columns must hold them exactly: rating is a DOUBLE for that reason, as a
+// FLOAT does not round-trip values such as 4.3.
+func (p listPlan) keysetCondition(after Cake) query.Condition {
+	var (
+		alternatives []string
+		args         []interface{}
+	)
+	for i, key := range p.keys {
+		var terms []string
+		for _, prev := range p.keys[:i] {
+			column, columnArgs := p.column(prev.Field)
+			terms = append(terms, column+" = ?")
+			args = append(append(args, columnArgs...), sortFields[prev.Field].value(after))
+		}
+		op := " > ?"
+		if key.Desc {
+			op = " < ?"
+		}
+		column, columnArgs := p.column(key.Field)
+		terms = append(terms, column+op)
+		args = append(append(args, columnArgs...), sortFields[key.Field].value(after))
+		alternatives = append(alternatives, "("+strings.Join(terms, " AND ")+")")
+	}
+	return query.Expr(strings.Join(alternatives, " OR "), args...)
+}
+
+// matches is the in-memory equivalent of conditions. The IDs in Within and
+// Without must be lists, as the memory repositories return them.
+func (p listPlan) matches(cake Cake) bool {
+	if p.dto.Title != "" && !containsFold(cake.Title, p.dto.Title) {
+		return false
+	}
+	if p.dto.Description != "" && !containsFold(cake.Description, p.dto.Description) {
+		return false
+	}
+	if p.dto.RatingMin != nil && cake.Rating < *p.dto.RatingMin {
+		return false
+	}
+	if p.dto.RatingMax != nil && cake.Rating > *p.dto.RatingMax {
+		return false
+	}
+	if p.dto.CreatedAfter != nil && !cake.CreatedAt.After(*p.dto.CreatedAfter) {
+		return false
+	}
+	if p.dto.CreatedBefore != nil && !cake.CreatedAt.Before(*p.dto.CreatedBefore) {
+		return false
+	}
+	if p.dto.HasImage != nil && *p.dto.HasImage != (cake.Image != nil && *cake.Image != "") {
+		return false
+	}
+	if p.dto.Q != "" {
+		if _, ok := p.scores[cake.ID]; !ok {
+			return false
+		}
+	}
+	if p.dto.IDs != "" && !containsID(p.ids, cake.ID) {
+		return false
+	}
+	for _, ids := range p.dto.Within {
+		if !ids.Contains(cake.ID) {
+			return false
+		}
+	}
+	for _, ids := range p.dto.Without {
+		if ids.Contains(cake.ID) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsID(ids []int, id int) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
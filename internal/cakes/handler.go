@@ -1,7 +1,9 @@
 package cakes
 
 import (
-	"cake-store/internal/helpers"
+	"cake-store/internal/categories"
+	"cake-store/internal/ingredients"
+	"cake-store/internal/storage"
 	"context"
 	"github.com/labstack/echo/v4"
 	"math"
@@ -15,14 +17,28 @@ type SvcInterface interface {
 	Create(ctx echo.Context) error
 	Update(ctx echo.Context) error
 	Delete(ctx echo.Context) error
+	Suggest(ctx echo.Context) error
 }
 
 type svcImplementation struct {
-	repo RepoInterface
+	repo        RepoInterface
+	categories  categories.RepoInterface
+	ingredients ingredients.RepoInterface
+	variants    VariantIndex
+	stock       StockIndex
+	reviews     ReviewIndex
+	options     OptionIndex
+	schedule    ScheduleIndex
+	stores      StoreIndex
+	tx          storage.Transactor
 }
 
-func NewHandler(repo RepoInterface) SvcInterface {
-	return svcImplementation{repo}
+func NewHandler(repo RepoInterface, options ...Option) SvcInterface {
+	s := svcImplementation{repo: repo, tx: storage.NewTransactor(nil)}
+	for _, option := range options {
+		option(&s)
+	}
+	return s
 }
 
 // List godoc
@@ -32,9 +48,15 @@ func NewHandler(repo RepoInterface) SvcInterface {
 // @Accept  json
 // @Produce  json
 // @Param services query ListRequestDto true "Find query"
+// @Param If-None-Match header string false "ETag of a page read before; 304 when it is unchanged"
 // @Success 200 {array} Cake
-// @Failure 422 {object} helpers.JSONResponse
-// @Failure 500 {object} helpers.JSONResponse
+// @Header 200 {string} ETag "tag of the page"
+// @Header 200 {string} Pagination-Next-Cursor "cursor of the next page, absent on the last page"
+// @Header 200 {string} Facets-Rating "rating bucket counts when requested, e.g. unrated=1, 1-2=0, 2-3=1, 3-4=3, 4-5=2"
+// @Header 200 {string} Facets-Has-Image "image presence counts when requested, e.g. true=4, false=2"
+// @Success 304
+// @Failure 422 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
 // @Router /cakes [get]
 func (s svcImplementation) List(ctx echo.Context) error {
 	request := ListRequestDto{}
@@ -50,16 +72,52 @@ func (s svcImplementation) List(ctx echo.Context) error {
 		request.Limit = 10
 	}
 
+	keys, err := ParseSort(request.sortExpression())
+	if err != nil {
+		return err
+	}
+	request.SortKeys = keys
+
+	if request.Cursor != "" {
+		after, err := DecodeCursor(keys, request.Cursor)
+		if err != nil {
+			return err
+		}
+		request.After = after
+	}
+
+	if err := s.restrict(context.TODO(), &request); err != nil {
+		return err
+	}
+
 	res, total, err := s.repo.List(context.TODO(), request)
 	if err != nil {
 		return err
 	}
+	if err := s.attach(context.TODO(), res, false, request.stockDay()); err != nil {
+		return err
+	}
 
-	page := math.Ceil(float64(total) / float64(request.Limit))
-	ctx.Response().Header().Add("Pagination-Rows", strconv.Itoa(int(total)))
-	ctx.Response().Header().Add("Pagination-Page", strconv.Itoa(int(page)))
+	if total >= 0 {
+		page := math.Ceil(float64(total) / float64(request.Limit))
+		ctx.Response().Header().Add("Pagination-Rows", strconv.Itoa(int(total)))
+		ctx.Response().Header().Add("Pagination-Page", strconv.Itoa(int(page)))
+	}
 	ctx.Response().Header().Add("Pagination-Limit", strconv.Itoa(request.Limit))
-	return ctx.JSON(http.StatusOK, res)
+	if len(res) > 0 && len(res) == request.Limit {
+		ctx.Response().Header().Add("Pagination-Next-Cursor", EncodeCursor(keys, res[len(res)-1]))
+	}
+
+	if request.Facets != "" {
+		facets, err := s.repo.Facets(context.TODO(), request)
+		if err != nil {
+			return err
+		}
+		for name, buckets := range facets {
+			ctx.Response().Header().Add(FacetHeader(name), FormatFacet(buckets))
+		}
+	}
+	return writeJSON(ctx, http.StatusOK, res, listETag)
 }
 
 // Get godoc
@@ -69,10 +127,14 @@ func (s svcImplementation) List(ctx echo.Context) error {
 // @Accept  json
 // @Produce  json
 // @Param id path string true "cake id"
+// @Param store_id query int false "only when on the menu of this store, with its prices"
+// @Param If-None-Match header string false "ETag of the cake read before; 304 when it is unchanged"
 // @Success 200 {object} Cake
-// @Failure 422 {object} helpers.JSONResponse
-// @Failure 204 {object} helpers.JSONResponse
-// @Failure 500 {object} helpers.JSONResponse
+// @Header 200 {string} ETag "tag of the cake, to send in If-Match on writes"
+// @Success 304
+// @Failure 422 {object} helpers.Problem
+// @Failure 404 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
 // @Router /cakes/{id} [get]
 func (s svcImplementation) Get(ctx echo.Context) error {
 	ID, errConv := strconv.Atoi(ctx.Param("id"))
@@ -81,14 +143,33 @@ func (s svcImplementation) Get(ctx echo.Context) error {
 	}
 
 	data, errGet := s.repo.Get(context.TODO(), ID)
-	if data == nil {
-		return echo.NewHTTPError(http.StatusNoContent, "Data Not Found")
-	}
 	if errGet != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, errGet.Error())
+		return errGet
+	}
+	storeID := 0
+	if value := ctx.QueryParam("store_id"); value != "" {
+		var errConv error
+		storeID, errConv = strconv.Atoi(value)
+		if errConv != nil || storeID <= 0 {
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid store_id")
+		}
+		if err := s.onMenu(context.TODO(), storeID, ID); err != nil {
+			return err
+		}
+	}
+	if err := s.attachOne(context.TODO(), data); err != nil {
+		return err
+	}
+	// The prices are those carts and orders at the store charge.
+	if storeID != 0 {
+		prices, err := s.stores.Prices(context.TODO(), storeID, ID)
+		if err != nil {
+			return err
+		}
+		data.Prices = prices
 	}
 
-	return ctx.JSON(http.StatusOK, data)
+	return writeJSON(ctx, http.StatusOK, data, func(body []byte) string { return ETag(data.Version, body) })
 }
 
 // Create godoc
@@ -97,10 +178,18 @@ func (s svcImplementation) Get(ctx echo.Context) error {
 // @Tags Cakes
 // @Accept  json
 // @Produce  json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param Request body RequestDto true "Create cakes"
-// @Success 200 {object} Cake
-// @Failure 422 {object} helpers.JSONResponse
-// @Failure 500 {object} helpers.JSONResponse
+// @Param Idempotency-Key header string false "retries with the same key and body get the first response"
+// @Success 201 {object} Cake
+// @Header 201 {string} Location "/cakes/{id}"
+// @Header 201 {string} ETag "tag of the cake, to send in If-Match on writes"
+// @Failure 409 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
 // @Router /cakes [post]
 func (s svcImplementation) Create(ctx echo.Context) error {
 	request := RequestDto{}
@@ -112,11 +201,26 @@ func (s svcImplementation) Create(ctx echo.Context) error {
 		return err
 	}
 
-	errCreate := s.repo.Create(context.TODO(), request)
-	if errCreate != nil {
-		return errCreate
+	if err := s.checkLinks(context.TODO(), request.links()); err != nil {
+		return err
 	}
-	return ctx.JSON(http.StatusCreated, helpers.JSONResponse{Message: "Cake Created"})
+
+	var created *Cake
+	err := s.tx.InTx(context.TODO(), func(ctx context.Context) error {
+		var err error
+		if created, err = s.repo.Create(ctx, request); err != nil {
+			return err
+		}
+		return s.link(ctx, created.ID, request.links())
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.attachOne(context.TODO(), created); err != nil {
+		return err
+	}
+	ctx.Response().Header().Set(echo.HeaderLocation, "/cakes/"+strconv.Itoa(created.ID))
+	return writeJSON(ctx, http.StatusCreated, created, func(body []byte) string { return ETag(created.Version, body) })
 }
 
 // Update godoc
@@ -125,12 +229,22 @@ func (s svcImplementation) Create(ctx echo.Context) error {
 // @Tags Cakes
 // @Accept  json
 // @Produce  json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path string true "cake id"
 // @Param Request body UpdateRequestDto true "Update cakes"
+// @Param If-Match header string true "ETag the cake was read with, or * to update any version"
+// @Param Idempotency-Key header string false "retries with the same key and body get the first response"
 // @Success 200 {object} Cake
-// @Failure 422 {object} helpers.JSONResponse
-// @Failure 204 {object} helpers.JSONResponse
-// @Failure 500 {object} helpers.JSONResponse
+// @Header 200 {string} ETag "tag of the updated cake"
+// @Failure 422 {object} helpers.Problem
+// @Failure 404 {object} helpers.Problem
+// @Failure 409 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 412 {object} helpers.Problem
+// @Failure 428 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
 // @Router /cakes/{id} [patch]
 func (s svcImplementation) Update(ctx echo.Context) error {
 	request := UpdateRequestDto{}
@@ -142,19 +256,31 @@ func (s svcImplementation) Update(ctx echo.Context) error {
 		return err
 	}
 
-	exist, errGet := s.repo.Get(context.TODO(), request.ID)
-	if exist == nil {
-		return echo.NewHTTPError(http.StatusNoContent, "Data Not Found")
+	if err := s.checkLinks(context.TODO(), request.links()); err != nil {
+		return err
 	}
-	if errGet != nil {
-		return errGet
+
+	version, err := s.matchedVersion(ctx, request.ID)
+	if err != nil {
+		return err
 	}
+	request.Version = version
 
-	errCreate := s.repo.Update(context.TODO(), request)
-	if errCreate != nil {
-		return errCreate
+	var updated *Cake
+	err = s.tx.InTx(context.TODO(), func(ctx context.Context) error {
+		var err error
+		if updated, err = s.repo.Update(ctx, request); err != nil {
+			return err
+		}
+		return s.link(ctx, updated.ID, request.links())
+	})
+	if err != nil {
+		return err
 	}
-	return ctx.JSON(http.StatusOK, helpers.JSONResponse{Message: "Cake Updated"})
+	if err := s.attachOne(context.TODO(), updated); err != nil {
+		return err
+	}
+	return writeJSON(ctx, http.StatusOK, updated, func(body []byte) string { return ETag(updated.Version, body) })
 }
 
 // Delete godoc
@@ -163,29 +289,69 @@ func (s svcImplementation) Update(ctx echo.Context) error {
 // @Tags Cakes
 // @Accept  json
 // @Produce  json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path string true "cake id"
+// @Param If-Match header string true "ETag the cake was read with, or * to delete any version"
 // @Success 200 {object} Cake
-// @Failure 422 {object} helpers.JSONResponse
-// @Failure 204 {object} helpers.JSONResponse
-// @Failure 500 {object} helpers.JSONResponse
+// @Failure 422 {object} helpers.Problem
+// @Failure 404 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 412 {object} helpers.Problem
+// @Failure 428 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
 // @Router /cakes/{id} [delete]
 func (s svcImplementation) Delete(ctx echo.Context) error {
 	ID, errConv := strconv.Atoi(ctx.Param("id"))
 	if errConv != nil {
 		return echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid id")
 	}
-	exist, errGet := s.repo.Get(context.TODO(), ID)
-	if exist == nil {
-		return echo.NewHTTPError(http.StatusNoContent, "Data Not Found")
-	}
-	if errGet != nil {
-		return errGet
+	version, err := s.matchedVersion(ctx, ID)
+	if err != nil {
+		return err
 	}
-
-	err := s.repo.Delete(context.TODO(), ID)
+	err = s.tx.InTx(context.TODO(), func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, ID, version); err != nil {
+			return err
+		}
+		return s.unlink(ctx, ID)
+	})
 	if err != nil {
 		return err
 	}
 
 	return ctx.JSON(http.StatusOK, "Success")
 }
+
+// Suggest godoc
+// @Summary Suggest cake titles
+// @Description This endpoint for typeahead suggestions of cake titles
+// @Tags Cakes
+// @Accept  json
+// @Produce  json
+// @Param services query SuggestRequestDto true "Suggest query"
+// @Success 200 {array} Suggestion
+// @Failure 422 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /cakes/suggest [get]
+func (s svcImplementation) Suggest(ctx echo.Context) error {
+	request := SuggestRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	if request.Limit == 0 {
+		request.Limit = 5
+	}
+
+	res, err := s.repo.Suggest(context.TODO(), request.Prefix, request.Limit)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
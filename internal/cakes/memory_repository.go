@@ -0,0 +1,225 @@
+package cakes
+
+import (
+	"cake-store/internal/storage"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type memoryRepoImplementation struct {
+	mu     sync.RWMutex
+	cakes  map[int]Cake
+	nextID int
+	// stars holds the star totals the cake ratings are averaged from.
+	stars   map[int]int
+	search  *searchIndex
+	suggest *prefixIndex
+}
+
+// NewMemoryRepository returns a RepoInterface that keeps cakes in a map,
+// searched through the same in-process indexes as the SQLite repository.
+func NewMemoryRepository() RepoInterface {
+	return &memoryRepoImplementation{
+		cakes:   map[int]Cake{},
+		nextID:  1,
+		stars:   map[int]int{},
+		search:  newSearchIndex(),
+		suggest: newPrefixIndex(),
+	}
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+func copyCake(cake Cake) Cake {
+	if cake.Image != nil {
+		image := *cake.Image
+		cake.Image = &image
+	}
+	if cake.UpdatedAt != nil {
+		updatedAt := *cake.UpdatedAt
+		cake.UpdatedAt = &updatedAt
+	}
+	return cake
+}
+
+func (m *memoryRepoImplementation) List(ctx context.Context, dto ListRequestDto) (result []Cake, total int64, err error) {
+	result = []Cake{}
+	plan, err := planList(dto)
+	if err != nil {
+		return
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if dto.Q != "" {
+		plan.scores = m.search.search(dto.Q)
+	}
+
+	matched := []Cake{}
+	for _, cake := range m.cakes {
+		if plan.matches(cake) {
+			cake = copyCake(cake)
+			if dto.Q != "" {
+				score := plan.scores[cake.ID]
+				cake.Score = &score
+			}
+			matched = append(matched, cake)
+		}
+	}
+	sort.Slice(matched, func(a, b int) bool {
+		return lessBy(plan.keys, matched[a], matched[b])
+	})
+
+	total = int64(len(matched))
+	if dto.SkipCount {
+		total = -1
+	}
+
+	start := dto.Offset
+	if dto.After != nil {
+		after := dto.After.cake()
+		start = sort.Search(len(matched), func(i int) bool {
+			return lessBy(plan.keys, after, matched[i])
+		})
+	}
+
+	if start < len(matched) {
+		end := start + dto.Limit
+		if end > len(matched) {
+			end = len(matched)
+		}
+		result = matched[start:end]
+	}
+	return
+}
+func (m *memoryRepoImplementation) Get(ctx context.Context, id int) (*Cake, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cake, ok := m.cakes[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	result := copyCake(cake)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Create(ctx context.Context, dto RequestDto) (*Cake, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cake := Cake{
+		ID:          m.nextID,
+		Title:       dto.Title,
+		Description: dto.Description,
+		CreatedAt:   storage.TruncateTime(time.Now()),
+		Version:     1,
+	}
+	if dto.Image != "" {
+		image := dto.Image
+		cake.Image = &image
+	}
+	m.cakes[cake.ID] = cake
+	m.search.put(cake)
+	m.suggest.put(cake)
+	m.nextID++
+	result := copyCake(cake)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Update(ctx context.Context, dto UpdateRequestDto) (*Cake, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cake, ok := m.cakes[dto.ID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if dto.Version != 0 && dto.Version != cake.Version {
+		return nil, ErrStale
+	}
+	if dto.Title != "" {
+		cake.Title = dto.Title
+	}
+	if dto.Description != "" {
+		cake.Description = dto.Description
+	}
+	if dto.Image != "" {
+		image := dto.Image
+		cake.Image = &image
+	}
+	updatedAt := storage.TruncateTime(time.Now())
+	cake.UpdatedAt = &updatedAt
+	cake.Version++
+	m.cakes[dto.ID] = cake
+	m.search.put(cake)
+	m.suggest.put(cake)
+	result := copyCake(cake)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Delete(ctx context.Context, id, version int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cake, ok := m.cakes[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if version != 0 && version != cake.Version {
+		return ErrStale
+	}
+	delete(m.cakes, id)
+	delete(m.stars, id)
+	m.search.remove(id)
+	m.suggest.remove(id)
+	return nil
+}
+func (m *memoryRepoImplementation) AddRating(ctx context.Context, id, stars, count int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cake, ok := m.cakes[id]
+	if !ok {
+		return ErrNotFound
+	}
+	m.stars[id] += stars
+	cake.RatingCount += count
+	cake.Rating = 0
+	if cake.RatingCount > 0 {
+		cake.Rating = float64(m.stars[id]) / float64(cake.RatingCount)
+	}
+	m.cakes[id] = cake
+	m.search.put(cake)
+	m.suggest.put(cake)
+	return nil
+}
+func (m *memoryRepoImplementation) Suggest(ctx context.Context, prefix string, limit int) ([]Suggestion, error) {
+	return m.suggest.suggest(prefix, limit), nil
+}
+func (m *memoryRepoImplementation) Facets(ctx context.Context, dto ListRequestDto) (Facets, error) {
+	plan, err := planList(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if dto.Q != "" {
+		plan.scores = m.search.search(dto.Q)
+	}
+
+	facets := newFacets(plan.facets)
+	for _, cake := range m.cakes {
+		if plan.matches(cake) {
+			for _, name := range plan.facets {
+				facets.add(name, facetDefs[name].value(cake), 1)
+			}
+		}
+	}
+	return facets, nil
+}
@@ -1,36 +1,160 @@
 package cakes
 
 import (
+	"cake-store/internal/categories"
+	"cake-store/internal/ingredients"
+	"cake-store/internal/money"
+	"cake-store/internal/query"
+	"fmt"
 	"time"
 )
 
 type (
 	Cake struct {
-		ID          int        `json:"id"`
-		Title       string     `json:"title"`
-		Description string     `json:"description"`
+		ID          int    `json:"id"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		// Rating is the average stars of the approved reviews, 0 without any.
 		Rating      float64    `json:"rating"`
+		RatingCount int        `json:"rating_count"`
 		Image       *string    `json:"image"`
 		CreatedAt   time.Time  `json:"created_at"`
 		UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+		// Version counts the writes to the cake; its ETag carries it.
+		Version    int                   `json:"version"`
+		Score      *float64              `json:"score,omitempty"`
+		Categories []categories.Category `json:"categories"`
+		Tags       []string              `json:"tags"`
+		// Ingredients and Dietary are only embedded in single cakes.
+		Ingredients []ingredients.CakeIngredient `json:"ingredients,omitempty"`
+		Dietary     *ingredients.Dietary         `json:"dietary,omitempty"`
+		// InStock is only set when stock is tracked.
+		InStock *bool `json:"in_stock,omitempty"`
+		// Prices are only embedded in single cakes read for a store, at the
+		// prices of that store.
+		Prices []StorePrice `json:"prices,omitempty"`
+	}
+	// StorePrice is what a store charges for an active variant of a cake.
+	StorePrice struct {
+		VariantID int         `json:"variant_id"`
+		Size      string      `json:"size" example:"8 inch"`
+		Price     money.Money `json:"price"`
 	}
 	ListRequestDto struct {
-		Title       string `query:"title"`
-		Description string `query:"description"`
-		Offset      int    `query:"offset" validate:"omitempty,gte=0"`
-		Limit       int    `query:"limit" validate:"omitempty,gte=0"`
+		Q                string     `query:"q" json:"q"`
+		Title            string     `query:"title"`
+		Description      string     `query:"description"`
+		Offset           int        `query:"offset" validate:"omitempty,gte=0,excluded_with=Cursor"`
+		Limit            int        `query:"limit" validate:"omitempty,gte=0"`
+		Cursor           string     `query:"cursor"`
+		SkipCount        bool       `query:"skip_count" json:"skip_count"`
+		Sort             string     `query:"sort" json:"sort" validate:"omitempty,sortby=rating title created_at id score" example:"-rating,title"`
+		RatingMin        *float64   `query:"rating_min" json:"rating_min" validate:"omitempty,gte=0,lte=5"`
+		RatingMax        *float64   `query:"rating_max" json:"rating_max" validate:"omitempty,gte=0,lte=5"`
+		CreatedAfter     *time.Time `query:"created_after" json:"created_after" format:"date-time"`
+		CreatedBefore    *time.Time `query:"created_before" json:"created_before" format:"date-time"`
+		HasImage         *bool      `query:"has_image" json:"has_image"`
+		IDs              string     `query:"ids" json:"ids" validate:"omitempty,intlist" example:"1,2,3"`
+		Facets           string     `query:"facets" json:"facets" validate:"omitempty,csvoneof=rating has_image" example:"rating,has_image"`
+		Category         string     `query:"category" json:"category" example:"cheesecakes"`
+		Tag              string     `query:"tag" json:"tag" example:"vegan"`
+		ExcludeAllergens string     `query:"exclude_allergens" json:"exclude_allergens" validate:"omitempty,csvoneof=celery crustaceans dairy egg fish gluten lupin molluscs mustard nuts peanuts sesame soy sulphites" example:"nuts,gluten"`
+		PriceMin         string     `query:"price_min" json:"price_min" example:"12.50"`
+		PriceMax         string     `query:"price_max" json:"price_max" example:"30"`
+		Currency         string     `query:"currency" json:"currency" validate:"omitempty,iso4217" example:"USD"`
+		InStock          *bool      `query:"in_stock" json:"in_stock"`
+		AvailableOn      string     `query:"available_on" json:"available_on" validate:"omitempty,datetime=2006-01-02" example:"2026-10-18"`
+		StoreID          int        `query:"store_id" json:"store_id" validate:"omitempty,gt=0" example:"1"`
+		// Within limits the listing to the cakes in every one of these.
+		Within []query.IDs `query:"-" json:"-" swaggerignore:"true"`
+		// Without excludes the cakes in any of these from the listing.
+		Without  []query.IDs `query:"-" json:"-" swaggerignore:"true"`
+		SortKeys []SortKey   `query:"-" json:"-" swaggerignore:"true"`
+		After    *Cursor     `query:"-" json:"-" swaggerignore:"true"`
+	}
+	SuggestRequestDto struct {
+		Prefix string `query:"prefix" json:"prefix" validate:"required"`
+		Limit  int    `query:"limit" json:"limit" validate:"omitempty,gte=1,lte=20"`
+	}
+	Suggestion struct {
+		ID    int    `json:"id"`
+		Title string `json:"title"`
 	}
 	RequestDto struct {
-		Title       string  `json:"title" validate:"required"`
-		Description string  `json:"description"`
-		Rating      float64 `json:"rating" validate:"omitempty,numeric"`
-		Image       string  `json:"image" validate:"omitempty,url"`
+		Title       string                    `json:"title" validate:"required"`
+		Description string                    `json:"description"`
+		Image       string                    `json:"image" validate:"omitempty,url"`
+		CategoryIDs []int                     `json:"category_ids" validate:"omitempty,dive,gt=0"`
+		Tags        []string                  `json:"tags" validate:"omitempty,max=20,dive,required,max=50"`
+		Ingredients []ingredients.QuantityDto `json:"ingredients" validate:"omitempty,max=50,dive"`
 	}
 	UpdateRequestDto struct {
-		ID          int      `param:"id"`
-		Title       string   `json:"title"`
-		Description string   `json:"description"`
-		Rating      *float64 `json:"rating" validate:"omitempty,numeric"`
-		Image       string   `json:"image" validate:"omitempty,url"`
+		ID          int    `param:"id"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		Image       string `json:"image" validate:"omitempty,url"`
+		// CategoryIDs, Tags and Ingredients replace the cake's when not nil; an empty
+		// list clears them.
+		CategoryIDs []int                     `json:"category_ids" validate:"omitempty,dive,gt=0"`
+		Tags        []string                  `json:"tags" validate:"omitempty,max=20,dive,required,max=50"`
+		Ingredients []ingredients.QuantityDto `json:"ingredients" validate:"omitempty,max=50,dive"`
+		// Version, when not 0, is the version the update applies to.
+		Version int `query:"-" json:"-" swaggerignore:"true"`
 	}
 )
+
+func (c Cake) score() float64 {
+	if c.Score == nil {
+		return 0
+	}
+	return *c.Score
+}
+
+// stockDay returns the day stock is checked for, today unless requested.
+func (dto ListRequestDto) stockDay() string {
+	if dto.AvailableOn != "" {
+		return dto.AvailableOn
+	}
+	return today()
+}
+
+// today returns the current baking day.
+func today() string {
+	return time.Now().UTC().Format("2006-01-02")
+}
+
+// priceRange parses the price filters into minor units of the requested
+// currency.
+func (dto ListRequestDto) priceRange() (r money.Range, err error) {
+	r.Currency = dto.Currency
+	if r.Currency == "" {
+		r.Currency = money.DefaultCurrency
+	}
+	if dto.PriceMin != "" {
+		min, err := money.Parse(dto.PriceMin, r.Currency)
+		if err != nil {
+			return r, err
+		}
+		r.Min = &min
+	}
+	if dto.PriceMax != "" {
+		max, err := money.Parse(dto.PriceMax, r.Currency)
+		if err != nil {
+			return r, err
+		}
+		r.Max = &max
+	}
+	if r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+		return r, fmt.Errorf("%w: price_min must not exceed price_max", ErrValidation)
+	}
+	return r, nil
+}
+
+// sortExpression returns the requested sort, defaulting to relevance for
+// searches.
+func (dto ListRequestDto) sortExpression() string {
+	if dto.Sort == "" && dto.Q != "" {
+		return RelevanceSort
+	}
+	return dto.Sort
+}
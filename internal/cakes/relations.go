@@ -0,0 +1,378 @@
+package cakes
+
+import (
+	"cake-store/internal/categories"
+	"cake-store/internal/ingredients"
+	"cake-store/internal/money"
+	"cake-store/internal/query"
+	"cake-store/internal/storage"
+	"context"
+	"fmt"
+)
+
+// Option configures the packages a cake handler links cakes to.
+type Option func(*svcImplementation)
+
+// WithCategories embeds categories and tags in cakes, accepts them on
+// writes and enables the category and tag filters.
+func WithCategories(repo categories.RepoInterface) Option {
+	return func(s *svcImplementation) {
+		s.categories = repo
+	}
+}
+
+// WithIngredients embeds ingredients and the derived dietary information in
+// single cakes, accepts them on writes and enables the allergen filter.
+func WithIngredients(repo ingredients.RepoInterface) Option {
+	return func(s *svcImplementation) {
+		s.ingredients = repo
+	}
+}
+
+// VariantIndex is the part of variants.RepoInterface the cake handler
+// uses. The variants package depends on cakes, so cakes cannot import it.
+type VariantIndex interface {
+	CakesInPriceRange(ctx context.Context, r money.Range) (query.IDs, error)
+	DeleteForCake(ctx context.Context, cakeID int) error
+}
+
+// WithVariants enables the price filters and deletes the variants of
+// deleted cakes.
+func WithVariants(index VariantIndex) Option {
+	return func(s *svcImplementation) {
+		s.variants = index
+	}
+}
+
+// StockIndex is the part of inventory.RepoInterface the cake handler uses.
+type StockIndex interface {
+	CakesInStock(ctx context.Context, day string) (query.IDs, error)
+	InStock(ctx context.Context, day string, cakeIDs []int) ([]int, error)
+}
+
+// WithStock flags cakes as in stock and enables the in_stock filter.
+func WithStock(index StockIndex) Option {
+	return func(s *svcImplementation) {
+		s.stock = index
+	}
+}
+
+// ReviewIndex is the part of reviews.RepoInterface the cake handler uses.
+type ReviewIndex interface {
+	DeleteForCake(ctx context.Context, cakeID int) error
+}
+
+// WithReviews deletes the reviews of deleted cakes.
+func WithReviews(index ReviewIndex) Option {
+	return func(s *svcImplementation) {
+		s.reviews = index
+	}
+}
+
+// OptionIndex is the part of options.RepoInterface the cake handler uses.
+type OptionIndex interface {
+	DeleteForCake(ctx context.Context, cakeID int) error
+}
+
+// WithOptions deletes the options of deleted cakes.
+func WithOptions(index OptionIndex) Option {
+	return func(s *svcImplementation) {
+		s.options = index
+	}
+}
+
+// ScheduleIndex is the part of scheduling.RepoInterface the cake handler
+// uses.
+type ScheduleIndex interface {
+	DeleteForCake(ctx context.Context, cakeID int) error
+}
+
+// WithSchedule deletes the lead times of deleted cakes.
+func WithSchedule(index ScheduleIndex) Option {
+	return func(s *svcImplementation) {
+		s.schedule = index
+	}
+}
+
+// StoreIndex is the part of stores.Menu the cake handler uses.
+type StoreIndex interface {
+	OffMenu(ctx context.Context, storeID int) (query.IDs, error)
+	CakesInPriceRange(ctx context.Context, storeID int, r money.Range) (query.IDs, error)
+	Sells(ctx context.Context, storeID, cakeID int) (bool, error)
+	// Prices returns the active variants of a cake at the prices of a
+	// store.
+	Prices(ctx context.Context, storeID, cakeID int) ([]StorePrice, error)
+	DeleteForCake(ctx context.Context, cakeID int) error
+}
+
+// WithStores enables the store_id scope, prices the price filters and
+// single cakes at the store and deletes the store overrides of deleted cakes.
+func WithStores(index StoreIndex) Option {
+	return func(s *svcImplementation) {
+		s.stores = index
+	}
+}
+
+// WithTransactions writes a cake and everything linked to it in one
+// transaction, so a failed write leaves neither half behind. The
+// repositories linked must join it through storage.Using.
+func WithTransactions(tx storage.Transactor) Option {
+	return func(s *svcImplementation) {
+		s.tx = tx
+	}
+}
+
+// notEnabled is returned for input on a package no repository was
+// configured for.
+func notEnabled(what string) error {
+	return fmt.Errorf("%w: %s are not enabled", ErrValidation, what)
+}
+
+// links are what a cake write links to in other packages. Nil lists are
+// left as they are and empty lists clear them.
+type links struct {
+	categoryIDs []int
+	tags        []string
+	ingredients []ingredients.QuantityDto
+}
+
+func (dto RequestDto) links() links {
+	return links{dto.CategoryIDs, dto.Tags, dto.Ingredients}
+}
+
+func (dto UpdateRequestDto) links() links {
+	return links{dto.CategoryIDs, dto.Tags, dto.Ingredients}
+}
+
+// restrict turns the filters on other packages into dto.Within and
+// dto.Without, which the repository applies in the listing query.
+func (s svcImplementation) restrict(ctx context.Context, dto *ListRequestDto) error {
+	if dto.Category != "" || dto.Tag != "" {
+		if s.categories == nil {
+			return notEnabled("categories")
+		}
+	}
+	if dto.Category != "" {
+		ids, err := s.categories.CakesInCategory(ctx, dto.Category)
+		if err != nil {
+			return err
+		}
+		dto.Within = append(dto.Within, ids)
+	}
+	if dto.Tag != "" {
+		ids, err := s.categories.CakesTagged(ctx, dto.Tag)
+		if err != nil {
+			return err
+		}
+		dto.Within = append(dto.Within, ids)
+	}
+	if dto.PriceMin != "" || dto.PriceMax != "" {
+		if s.variants == nil {
+			return notEnabled("variants")
+		}
+		r, err := dto.priceRange()
+		if err != nil {
+			return err
+		}
+		var ids query.IDs
+		if dto.StoreID != 0 && s.stores != nil {
+			ids, err = s.stores.CakesInPriceRange(ctx, dto.StoreID, r)
+		} else {
+			ids, err = s.variants.CakesInPriceRange(ctx, r)
+		}
+		if err != nil {
+			return err
+		}
+		dto.Within = append(dto.Within, ids)
+	}
+	if dto.StoreID != 0 {
+		if s.stores == nil {
+			return notEnabled("stores")
+		}
+		ids, err := s.stores.OffMenu(ctx, dto.StoreID)
+		if err != nil {
+			return err
+		}
+		dto.Without = append(dto.Without, ids)
+	}
+	if dto.InStock != nil || dto.AvailableOn != "" {
+		if s.stock == nil {
+			return notEnabled("stock")
+		}
+	}
+	if dto.InStock != nil {
+		ids, err := s.stock.CakesInStock(ctx, dto.stockDay())
+		if err != nil {
+			return err
+		}
+		if *dto.InStock {
+			dto.Within = append(dto.Within, ids)
+		} else {
+			dto.Without = append(dto.Without, ids)
+		}
+	}
+	if dto.ExcludeAllergens != "" {
+		if s.ingredients == nil {
+			return notEnabled("ingredients")
+		}
+		ids, err := s.ingredients.CakesWithAllergens(ctx, splitList(dto.ExcludeAllergens))
+		if err != nil {
+			return err
+		}
+		dto.Without = append(dto.Without, ids)
+	}
+	return nil
+}
+
+// onMenu returns ErrNotFound when a store does not sell a cake.
+func (s svcImplementation) onMenu(ctx context.Context, storeID, cakeID int) error {
+	if s.stores == nil {
+		return notEnabled("stores")
+	}
+	sells, err := s.stores.Sells(ctx, storeID, cakeID)
+	if err != nil {
+		return err
+	}
+	if !sells {
+		return fmt.Errorf("%w: it is not on the menu of store %d", ErrNotFound, storeID)
+	}
+	return nil
+}
+
+// checkLinks validates the links of a write before the cake is stored.
+func (s svcImplementation) checkLinks(ctx context.Context, l links) error {
+	if l.categoryIDs != nil || l.tags != nil {
+		if s.categories == nil {
+			return notEnabled("categories")
+		}
+		if err := s.categories.Check(ctx, l.categoryIDs); err != nil {
+			return err
+		}
+	}
+	if l.ingredients != nil {
+		if s.ingredients == nil {
+			return notEnabled("ingredients")
+		}
+		if err := s.ingredients.Check(ctx, l.ingredients); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// link replaces the links of a cake.
+func (s svcImplementation) link(ctx context.Context, id int, l links) error {
+	if l.categoryIDs != nil {
+		if err := s.categories.Assign(ctx, id, l.categoryIDs); err != nil {
+			return err
+		}
+	}
+	if l.tags != nil {
+		if err := s.categories.Tag(ctx, id, l.tags); err != nil {
+			return err
+		}
+	}
+	if l.ingredients != nil {
+		if err := s.ingredients.Assign(ctx, id, l.ingredients); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unlink drops everything linked to a deleted cake.
+func (s svcImplementation) unlink(ctx context.Context, id int) error {
+	var l links
+	if s.categories != nil {
+		l.categoryIDs, l.tags = []int{}, []string{}
+	}
+	if s.ingredients != nil {
+		l.ingredients = []ingredients.QuantityDto{}
+	}
+	if s.variants != nil {
+		if err := s.variants.DeleteForCake(ctx, id); err != nil {
+			return err
+		}
+	}
+	if s.reviews != nil {
+		if err := s.reviews.DeleteForCake(ctx, id); err != nil {
+			return err
+		}
+	}
+	if s.options != nil {
+		if err := s.options.DeleteForCake(ctx, id); err != nil {
+			return err
+		}
+	}
+	if s.schedule != nil {
+		if err := s.schedule.DeleteForCake(ctx, id); err != nil {
+			return err
+		}
+	}
+	if s.stores != nil {
+		if err := s.stores.DeleteForCake(ctx, id); err != nil {
+			return err
+		}
+	}
+	return s.link(ctx, id, l)
+}
+
+// attach embeds the linked categories and tags into cakes, and the
+// ingredients too when detailed, and flags whether they are in stock on day.
+func (s svcImplementation) attach(ctx context.Context, cakes []Cake, detailed bool, day string) error {
+	if len(cakes) == 0 {
+		return nil
+	}
+	ids := make([]int, len(cakes))
+	for i, cake := range cakes {
+		ids[i] = cake.ID
+	}
+
+	if s.categories != nil {
+		linked, err := s.categories.ForCakes(ctx, ids)
+		if err != nil {
+			return err
+		}
+		tags, err := s.categories.TagsForCakes(ctx, ids)
+		if err != nil {
+			return err
+		}
+		for i := range cakes {
+			cakes[i].Categories = append([]categories.Category{}, linked[cakes[i].ID]...)
+			cakes[i].Tags = append([]string{}, tags[cakes[i].ID]...)
+		}
+	}
+
+	if s.ingredients != nil && detailed {
+		used, err := s.ingredients.ForCakes(ctx, ids)
+		if err != nil {
+			return err
+		}
+		for i := range cakes {
+			cakes[i].Ingredients = used[cakes[i].ID]
+			cakes[i].Dietary = ingredients.Summarize(used[cakes[i].ID])
+		}
+	}
+
+	if s.stock != nil {
+		inStock, err := s.stock.InStock(ctx, day, ids)
+		if err != nil {
+			return err
+		}
+		for i := range cakes {
+			flag := containsID(inStock, cakes[i].ID)
+			cakes[i].InStock = &flag
+		}
+	}
+	return nil
+}
+
+// attachOne is attach for a single, detailed cake, in stock today.
+func (s svcImplementation) attachOne(ctx context.Context, cake *Cake) error {
+	cakes := []Cake{*cake}
+	if err := s.attach(ctx, cakes, true, today()); err != nil {
+		return err
+	}
+	*cake = cakes[0]
+	return nil
+}
@@ -3,112 +3,379 @@ package cakes
 //go:generate mockgen -destination=../../mocks/repository/mock_repository.go -package=mock_repository -source=repository.go
 
 import (
+	"cake-store/internal/query"
+	"cake-store/internal/storage"
 	"context"
 	"database/sql"
-	"fmt"
-	"strings"
+	"time"
 )
 
 const TableName = "cakes"
 
-var (
-	QuerySelect = fmt.Sprintf(`SELECT * FROM %s `, TableName)
-	QueryInsert = `INSERT INTO ` + TableName + ` 
-		(title, description, rating, image) 
-		VALUES 
-		('%s', '%s', %v, '%s')`
-	QueryDelete = fmt.Sprintf(`DELETE FROM %s WHERE ID = ?`, TableName)
-)
+// Columns lists the cakes columns in the order scanned by scanCake.
+var Columns = []string{"id", "title", "description", "rating", "rating_count", "image", "created_at", "updated_at", "version"}
 
 type repoImplementation struct {
 	db *sql.DB
+	// search is the fallback full-text index for databases without
+	// FULLTEXT support; nil on MySQL.
+	search *searchIndex
+	// suggest is the typeahead index, loaded on first use.
+	suggest *prefixIndex
 }
 
 type RepoInterface interface {
+	// List returns a page of cakes and the total number of matches, or -1
+	// as the total when dto.SkipCount is set.
 	List(ctx context.Context, dto ListRequestDto) ([]Cake, int64, error)
 	Get(ctx context.Context, id int) (*Cake, error)
-	Create(ctx context.Context, dto RequestDto) error
-	Update(ctx context.Context, dto UpdateRequestDto) error
-	Delete(ctx context.Context, id int) error
+	// Create stores a cake. Like Update and Delete, it joins the
+	// transaction of ctx and only updates the search indexes once that
+	// commits.
+	Create(ctx context.Context, dto RequestDto) (*Cake, error)
+	// Update updates a cake and counts up its version. When dto.Version is
+	// not 0 it only applies to that version, returning ErrStale otherwise.
+	Update(ctx context.Context, dto UpdateRequestDto) (*Cake, error)
+	// Delete deletes a cake; when version is not 0, only that version of
+	// it, returning ErrStale otherwise.
+	Delete(ctx context.Context, id, version int) error
+	// Suggest returns typeahead suggestions for cake titles starting with
+	// prefix, or with a word starting with it.
+	Suggest(ctx context.Context, prefix string, limit int) ([]Suggestion, error)
+	// AddRating adds count reviews totalling stars to the rating of a cake;
+	// negative values take approved reviews away again. It joins the
+	// transaction of ctx, as do the Gets in it, and only updates the search
+	// indexes once that commits.
+	AddRating(ctx context.Context, id, stars, count int) error
+	// Facets counts the cakes matching the filters of dto per value of
+	// each facet named in dto.Facets.
+	Facets(ctx context.Context, dto ListRequestDto) (Facets, error)
 }
 
+// NewRepository returns the MySQL repository, searching through the
+// FULLTEXT index created by the migrations.
 func NewRepository(db *sql.DB) RepoInterface {
 	return repoImplementation{
-		db,
+		db:      db,
+		suggest: newPrefixIndex(),
 	}
 }
 
-func (i repoImplementation) List(ctx context.Context, dto ListRequestDto) (result []Cake, total int64, err error) {
-	result = []Cake{}
-	qWhere := "WHERE true "
+// NewSQLiteRepository returns the SQLite repository. Searches go through an
+// in-process index loaded from the table, so only this process may write to
+// the database.
+func NewSQLiteRepository(db *sql.DB) (RepoInterface, error) {
+	repo := repoImplementation{
+		db:      db,
+		search:  newSearchIndex(),
+		suggest: newPrefixIndex(),
+	}
 
-	if dto.Title != "" {
-		qWhere += fmt.Sprintf(`AND title LIKE '%%%s%%' `, dto.Title)
+	cakes, err := repo.all(context.Background())
+	if err != nil {
+		return nil, err
 	}
+	for _, cake := range cakes {
+		repo.search.put(cake)
+	}
+	err = repo.suggest.load(func() ([]Cake, error) {
+		return cakes, nil
+	})
+	return repo, err
+}
 
-	if dto.Description != "" {
-		qWhere += fmt.Sprintf(`AND description LIKE '%%%s%%' `, dto.Description)
+// all returns every stored cake, for building the in-process indexes.
+func (i repoImplementation) all(ctx context.Context) ([]Cake, error) {
+	q, args := query.Select(TableName, Columns...).Build()
+	rows, err := storage.Using(ctx, i.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Cake
+	for rows.Next() {
+		cake, err := scanCake(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, cake)
+	}
+	return result, rows.Err()
+}
+
+// indexed keeps the in-process indexes in sync with a written cake.
+func (i repoImplementation) indexed(cake *Cake) {
+	if i.search != nil {
+		i.search.put(*cake)
+	}
+	i.suggest.put(*cake)
+}
+
+func scanCake(scan func(dest ...interface{}) error, extra ...interface{}) (cake Cake, err error) {
+	err = scan(append([]interface{}{&cake.ID, &cake.Title, &cake.Description, &cake.Rating, &cake.RatingCount, &cake.Image, &cake.CreatedAt, &cake.UpdatedAt, &cake.Version}, extra...)...)
+	return
+}
+
+func nullableString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// planList plans dto, scoring searches with FULLTEXT or the in-process index.
+func (i repoImplementation) planList(dto ListRequestDto) (listPlan, error) {
+	plan, err := planList(dto)
+	if err != nil || dto.Q == "" {
+		return plan, err
 	}
+	rel := mysqlRelevance(dto.Q)
+	if i.search != nil {
+		rel = indexRelevance(i.search.search(dto.Q))
+	}
+	plan.rel = &rel
+	return plan, nil
+}
 
-	err = i.db.QueryRowContext(ctx, fmt.Sprintf("SELECT COUNT(*) FROM %s %s", TableName, qWhere)).Scan(&total)
+func (i repoImplementation) List(ctx context.Context, dto ListRequestDto) (result []Cake, total int64, err error) {
+	result = []Cake{}
+	plan, err := i.planList(dto)
 	if err != nil {
 		return
 	}
+	builder := query.Select(TableName, Columns...).Where(plan.conditions()...)
+
+	total = -1
+	if !dto.SkipCount {
+		countQuery, countArgs := builder.Count()
+		err = storage.Using(ctx, i.db).QueryRowContext(ctx, countQuery, countArgs...).Scan(&total)
+		if err != nil {
+			return
+		}
+	}
+
+	if plan.rel != nil {
+		builder.Column(plan.rel.expr+" AS score", plan.rel.args...)
+	}
+	plan.orderBy(builder)
+	builder.Limit(dto.Limit)
+	if dto.After != nil {
+		builder.Where(plan.keysetCondition(dto.After.cake()))
+	} else {
+		builder.Offset(dto.Offset)
+	}
 
-	rows, err := i.db.QueryContext(ctx, QuerySelect+qWhere+"ORDER BY rating DESC, title ASC LIMIT ? OFFSET ?", dto.Limit, dto.Offset)
+	listQuery, listArgs := builder.Build()
+	rows, err := storage.Using(ctx, i.db).QueryContext(ctx, listQuery, listArgs...)
 	if err != nil {
 		return
 	}
+	defer rows.Close()
 
 	for rows.Next() {
-		var cake Cake
-		err = rows.Scan(&cake.ID, &cake.Title, &cake.Description, &cake.Rating, &cake.Image, &cake.CreatedAt, &cake.UpdatedAt)
+		var (
+			cake  Cake
+			extra []interface{}
+			score float64
+		)
+		if plan.rel != nil {
+			extra = append(extra, &score)
+		}
+		cake, err = scanCake(rows.Scan, extra...)
 		if err != nil {
 			return
 		}
+		if plan.rel != nil {
+			cake.Score = &score
+		}
 		result = append(result, cake)
 	}
+	err = rows.Err()
 	return
 }
+
+// versionConditions selects the cake with id, at version when it is not 0.
+func versionConditions(id, version int) []query.Condition {
+	conditions := []query.Condition{query.Eq("id", id)}
+	if version != 0 {
+		conditions = append(conditions, query.Eq("version", version))
+	}
+	return conditions
+}
+
+// affected tells apart why a write conditioned on versionConditions changed
+// nothing: the cake is missing or at another version.
+func (i repoImplementation) affected(ctx context.Context, res sql.Result, id int) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+	if _, err := i.Get(ctx, id); err != nil {
+		return err
+	}
+	return ErrStale
+}
+
 func (i repoImplementation) Get(ctx context.Context, id int) (*Cake, error) {
-	var result Cake
-	err := i.db.QueryRowContext(ctx, fmt.Sprintf(QuerySelect+" WHERE id = ?"), id).
-		Scan(&result.ID, &result.Title, &result.Description, &result.Rating, &result.Image, &result.CreatedAt, &result.UpdatedAt)
+	q, args := query.Select(TableName, Columns...).Where(query.Eq("id", id)).Build()
+	result, err := scanCake(storage.Using(ctx, i.db).QueryRowContext(ctx, q, args...).Scan)
 	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
 		return nil, err
 	}
-	return &result, err
+	return &result, nil
 }
-func (i repoImplementation) Create(ctx context.Context, dto RequestDto) (err error) {
-	_, err = i.db.ExecContext(ctx, fmt.Sprintf(QueryInsert, dto.Title, dto.Description, dto.Rating, dto.Image))
-	return
+func (i repoImplementation) Create(ctx context.Context, dto RequestDto) (*Cake, error) {
+	q, args := query.Insert(TableName).
+		Set("title", dto.Title).
+		Set("description", dto.Description).
+		Set("image", nullableString(dto.Image)).
+		Set("created_at", storage.TruncateTime(time.Now())).
+		Build()
+	res, err := storage.Using(ctx, i.db).ExecContext(ctx, q, args...)
+	if storage.IsDuplicate(err) {
+		return nil, ErrConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	created, err := i.Get(ctx, int(id))
+	if err != nil {
+		return nil, err
+	}
+	storage.AfterCommit(ctx, func() {
+		i.indexed(created)
+	})
+	return created, nil
 }
-func (i repoImplementation) Update(ctx context.Context, dto UpdateRequestDto) (err error) {
-	updateQuery := "UPDATE cakes "
-	var updated []string
+func (i repoImplementation) Update(ctx context.Context, dto UpdateRequestDto) (*Cake, error) {
+	builder := query.Update(TableName).Set("updated_at", storage.TruncateTime(time.Now()))
 	if dto.Title != "" {
-		updated = append(updated, fmt.Sprintf(`title = '%s'`, dto.Title))
+		builder.Set("title", dto.Title)
 	}
 	if dto.Description != "" {
-		updated = append(updated, fmt.Sprintf(`description = '%s'`, dto.Description))
-	}
-	if dto.Rating != nil {
-		updated = append(updated, fmt.Sprintf(`rating = %v`, *dto.Rating))
+		builder.Set("description", dto.Description)
 	}
 	if dto.Image != "" {
-		updated = append(updated, fmt.Sprintf(`image = '%s'`, dto.Image))
-	}
-	if len(updated) > 0 {
-		updateQuery += "set updated_at = now(), " + strings.Join(updated, ", ")
+		builder.Set("image", dto.Image)
 	}
 
-	_, err = i.db.ExecContext(ctx, updateQuery+"where id = ?", dto.ID)
-	return
+	builder.SetExpr("version", "version + 1")
+
+	q, args := builder.Where(versionConditions(dto.ID, dto.Version)...).Build()
+	res, err := storage.Using(ctx, i.db).ExecContext(ctx, q, args...)
+	if storage.IsDuplicate(err) {
+		return nil, ErrConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := i.affected(ctx, res, dto.ID); err != nil {
+		return nil, err
+	}
+	updated, err := i.Get(ctx, dto.ID)
+	if err != nil {
+		return nil, err
+	}
+	storage.AfterCommit(ctx, func() {
+		i.indexed(updated)
+	})
+	return updated, nil
+}
+func (i repoImplementation) Delete(ctx context.Context, id, version int) error {
+	q, args := query.Delete(TableName).Where(versionConditions(id, version)...).Build()
+	res, err := storage.Using(ctx, i.db).ExecContext(ctx, q, args...)
+	if err != nil {
+		return err
+	}
+	if err := i.affected(ctx, res, id); err != nil {
+		return err
+	}
+	storage.AfterCommit(ctx, func() {
+		if i.search != nil {
+			i.search.remove(id)
+		}
+		i.suggest.remove(id)
+	})
+	return nil
 }
-func (i repoImplementation) Delete(ctx context.Context, id int) error {
-	_, err := i.db.ExecContext(ctx, QueryDelete, id)
+func (i repoImplementation) AddRating(ctx context.Context, id, stars, count int) error {
+	// MySQL assigns left to right with the new values, SQLite with the old
+	// ones, so the average is computed before the sums change.
+	q, args := query.Update(TableName).
+		SetExpr("rating", "CASE WHEN rating_count + ? > 0 THEN (rating_sum + ?) * 1.0 / (rating_count + ?) ELSE 0 END", count, stars, count).
+		SetExpr("rating_sum", "rating_sum + ?", stars).
+		SetExpr("rating_count", "rating_count + ?", count).
+		Where(query.Eq("id", id)).
+		Build()
+	if _, err := storage.Using(ctx, i.db).ExecContext(ctx, q, args...); err != nil {
+		return err
+	}
+	rated, err := i.Get(ctx, id)
 	if err != nil {
 		return err
 	}
+	storage.AfterCommit(ctx, func() {
+		i.indexed(rated)
+	})
 	return nil
 }
+func (i repoImplementation) Suggest(ctx context.Context, prefix string, limit int) ([]Suggestion, error) {
+	err := i.suggest.load(func() ([]Cake, error) {
+		return i.all(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return i.suggest.suggest(prefix, limit), nil
+}
+func (i repoImplementation) Facets(ctx context.Context, dto ListRequestDto) (Facets, error) {
+	plan, err := i.planList(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	facets := newFacets(plan.facets)
+	for _, name := range plan.facets {
+		q, args := query.Select(TableName).
+			Column(facetDefs[name].expr + " AS bucket").
+			Column("COUNT(*)").
+			Where(plan.conditions()...).
+			GroupBy("bucket").
+			Build()
+		if err := i.countFacet(ctx, facets, name, q, args); err != nil {
+			return nil, err
+		}
+	}
+	return facets, nil
+}
+
+func (i repoImplementation) countFacet(ctx context.Context, facets Facets, name, q string, args []interface{}) error {
+	rows, err := storage.Using(ctx, i.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			value string
+			count int64
+		)
+		if err := rows.Scan(&value, &count); err != nil {
+			return err
+		}
+		facets.add(name, value, count)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,160 @@
+package cakes
+
+import (
+	"cake-store/internal/query"
+	"math"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// RelevanceSort is the listing order used for searches without a sort.
+const RelevanceSort = "-score"
+
+// mysqlMatch is the relevance expression backed by the FULLTEXT index over
+// title and description.
+const mysqlMatch = "MATCH(title, description) AGAINST (? IN NATURAL LANGUAGE MODE)"
+
+// minTokenLength and stopwords mirror the InnoDB FULLTEXT defaults so the
+// fallback index matches the same words MySQL does.
+const minTokenLength = 3
+
+var stopwords = map[string]bool{
+	"a": true, "about": true, "an": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "com": true, "de": true, "en": true, "for": true,
+	"from": true, "how": true, "i": true, "in": true, "is": true, "it": true,
+	"la": true, "of": true, "on": true, "or": true, "that": true, "the": true,
+	"this": true, "to": true, "was": true, "what": true, "when": true, "where": true,
+	"who": true, "will": true, "with": true, "und": true, "www": true,
+}
+
+// tokenize splits text into lower-cased searchable words.
+func tokenize(text string) []string {
+	var tokens []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len([]rune(word)) >= minTokenLength && !stopwords[word] {
+			tokens = append(tokens, word)
+		}
+	}
+	return tokens
+}
+
+// searchIndex is an in-process inverted index over cake titles and
+// descriptions, used by the backends without FULLTEXT support. Scores follow
+// the InnoDB natural language formula: the sum over query words of
+// tf * idf^2 with idf = log10(documents / documents containing the word).
+type searchIndex struct {
+	mu       sync.RWMutex
+	postings map[string]map[int]int
+	terms    map[int][]string
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		postings: map[string]map[int]int{},
+		terms:    map[int][]string{},
+	}
+}
+
+// put indexes cake, replacing any previous version of it.
+func (s *searchIndex) put(cake Cake) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeLocked(cake.ID)
+	tokens := tokenize(cake.Title + " " + cake.Description)
+	for _, token := range tokens {
+		if s.postings[token] == nil {
+			s.postings[token] = map[int]int{}
+		}
+		s.postings[token][cake.ID]++
+	}
+	s.terms[cake.ID] = tokens
+}
+
+func (s *searchIndex) remove(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeLocked(id)
+}
+
+func (s *searchIndex) removeLocked(id int) {
+	for _, token := range s.terms[id] {
+		delete(s.postings[token], id)
+		if len(s.postings[token]) == 0 {
+			delete(s.postings, token)
+		}
+	}
+	delete(s.terms, id)
+}
+
+// search returns the relevance of every cake matching q. Cakes with a zero
+// relevance, e.g. because a word appears in every cake, do not match.
+func (s *searchIndex) search(q string) map[int]float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	scores := map[int]float64{}
+	seen := map[string]bool{}
+	for _, token := range tokenize(q) {
+		if seen[token] {
+			continue
+		}
+		seen[token] = true
+
+		docs := s.postings[token]
+		if len(docs) == 0 {
+			continue
+		}
+		idf := math.Log10(float64(len(s.terms)) / float64(len(docs)))
+		for id, tf := range docs {
+			scores[id] += float64(tf) * idf * idf
+		}
+	}
+	for id, score := range scores {
+		if score <= 0 {
+			delete(scores, id)
+		}
+	}
+	return scores
+}
+
+// relevance is the SQL form of a search: a score expression and the
+// condition selecting the matching rows.
+type relevance struct {
+	expr      string
+	args      []interface{}
+	condition query.Condition
+}
+
+func mysqlRelevance(q string) relevance {
+	return relevance{
+		expr:      mysqlMatch,
+		args:      []interface{}{q},
+		condition: query.Expr(mysqlMatch, q),
+	}
+}
+
+// indexRelevance inlines scores computed by a searchIndex into SQL.
+func indexRelevance(scores map[int]float64) relevance {
+	if len(scores) == 0 {
+		return relevance{expr: "CAST(0 AS REAL)", condition: query.In("id")}
+	}
+
+	var (
+		sb   strings.Builder
+		args []interface{}
+		ids  []interface{}
+	)
+	sb.WriteString("CASE id")
+	for id, score := range scores {
+		sb.WriteString(" WHEN ? THEN ?")
+		args = append(args, id, score)
+		ids = append(ids, id)
+	}
+	sb.WriteString(" ELSE 0 END")
+	return relevance{expr: sb.String(), args: args, condition: query.In("id", ids...)}
+}
@@ -0,0 +1,126 @@
+package cakes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultSort is the listing order used when no sort is requested.
+const DefaultSort = "-rating,title"
+
+// SortKey is one term of a sort expression; "-rating" is {rating, true}.
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// sortField describes a sortable field. Fields without a column, such as the
+// search score, are resolved by the listPlan.
+type sortField struct {
+	column  string
+	value   func(Cake) interface{}
+	compare func(a, b Cake) int
+}
+
+var sortFields = map[string]sortField{
+	"rating": {
+		column: "rating",
+		value:  func(c Cake) interface{} { return c.Rating },
+		compare: func(a, b Cake) int {
+			return compareOrdered(a.Rating < b.Rating, a.Rating > b.Rating)
+		},
+	},
+	"title": {
+		column: "title",
+		value:  func(c Cake) interface{} { return c.Title },
+		compare: func(a, b Cake) int {
+			return strings.Compare(a.Title, b.Title)
+		},
+	},
+	"created_at": {
+		column: "created_at",
+		value:  func(c Cake) interface{} { return c.CreatedAt },
+		compare: func(a, b Cake) int {
+			return compareOrdered(a.CreatedAt.Before(b.CreatedAt), a.CreatedAt.After(b.CreatedAt))
+		},
+	},
+	"score": {
+		value: func(c Cake) interface{} { return c.score() },
+		compare: func(a, b Cake) int {
+			return compareOrdered(a.score() < b.score(), a.score() > b.score())
+		},
+	},
+	"id": {
+		column: "id",
+		value:  func(c Cake) interface{} { return c.ID },
+		compare: func(a, b Cake) int {
+			return compareOrdered(a.ID < b.ID, a.ID > b.ID)
+		},
+	},
+}
+
+func compareOrdered(less, greater bool) int {
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	}
+	return 0
+}
+
+// ParseSort parses a comma separated sort expression such as
+// "-rating,created_at". The result always ends with id so that the order is
+// total, which keyset pagination relies on.
+func ParseSort(s string) ([]SortKey, error) {
+	if s == "" {
+		s = DefaultSort
+	}
+
+	var keys []SortKey
+	seen := map[string]bool{}
+	for _, term := range strings.Split(s, ",") {
+		key := SortKey{Field: strings.TrimSpace(term)}
+		if strings.HasPrefix(key.Field, "-") {
+			key.Field, key.Desc = key.Field[1:], true
+		}
+		if _, ok := sortFields[key.Field]; !ok {
+			return nil, fmt.Errorf("%w: cannot sort by %q", ErrValidation, key.Field)
+		}
+		if seen[key.Field] {
+			return nil, fmt.Errorf("%w: duplicate sort field %q", ErrValidation, key.Field)
+		}
+		seen[key.Field] = true
+		keys = append(keys, key)
+	}
+	if !seen["id"] {
+		keys = append(keys, SortKey{Field: "id"})
+	}
+	return keys, nil
+}
+
+// sortString renders keys back into the sort expression form.
+func sortString(keys []SortKey) string {
+	terms := make([]string, len(keys))
+	for i, key := range keys {
+		terms[i] = key.Field
+		if key.Desc {
+			terms[i] = "-" + key.Field
+		}
+	}
+	return strings.Join(terms, ",")
+}
+
+// lessBy reports whether a sorts before b under keys.
+func lessBy(keys []SortKey, a, b Cake) bool {
+	for _, key := range keys {
+		c := sortFields[key.Field].compare(a, b)
+		if key.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c < 0
+		}
+	}
+	return false
+}
@@ -0,0 +1,185 @@
+package cakes
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	rankTitlePrefix = iota
+	rankWordPrefix
+)
+
+type trieNode struct {
+	children map[rune]*trieNode
+	// ranks holds the best rank of every cake with a key through this node.
+	ranks map[int]int
+}
+
+func newTrieNode() *trieNode {
+	return &trieNode{children: map[rune]*trieNode{}, ranks: map[int]int{}}
+}
+
+type suggestEntry struct {
+	title  string
+	rating float64
+	keys   []string
+}
+
+// prefixIndex is a trie over cake titles answering typeahead queries. Every
+// title is indexed from its start and from the start of each later word, so
+// "che" finds "Lemon cheesecake" too, ranked below titles starting with it.
+type prefixIndex struct {
+	mu      sync.RWMutex
+	root    *trieNode
+	entries map[int]suggestEntry
+	// loaded is set once the index holds every stored cake.
+	loaded bool
+}
+
+func newPrefixIndex() *prefixIndex {
+	return &prefixIndex{root: newTrieNode(), entries: map[int]suggestEntry{}}
+}
+
+// titleKeys returns the lower-cased suffixes of title starting at each word.
+func titleKeys(title string) []string {
+	title = strings.ToLower(strings.TrimSpace(title))
+	var keys []string
+	inWord := false
+	for i, r := range title {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if isWord && !inWord {
+			keys = append(keys, title[i:])
+		}
+		inWord = isWord
+	}
+	return keys
+}
+
+// put indexes cake, replacing any previous version of it.
+func (p *prefixIndex) put(cake Cake) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.putLocked(cake)
+}
+
+func (p *prefixIndex) putLocked(cake Cake) {
+	p.removeLocked(cake.ID)
+	entry := suggestEntry{title: cake.Title, rating: cake.Rating, keys: titleKeys(cake.Title)}
+	for i, key := range entry.keys {
+		rank := rankWordPrefix
+		if i == 0 {
+			rank = rankTitlePrefix
+		}
+		node := p.root
+		for _, r := range key {
+			child, ok := node.children[r]
+			if !ok {
+				child = newTrieNode()
+				node.children[r] = child
+			}
+			node = child
+			if current, ok := node.ranks[cake.ID]; !ok || rank < current {
+				node.ranks[cake.ID] = rank
+			}
+		}
+	}
+	p.entries[cake.ID] = entry
+}
+
+func (p *prefixIndex) remove(id int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.removeLocked(id)
+}
+
+func (p *prefixIndex) removeLocked(id int) {
+	entry, ok := p.entries[id]
+	if !ok {
+		return
+	}
+	for _, key := range entry.keys {
+		path := []*trieNode{p.root}
+		runes := []rune(key)
+		for _, r := range runes {
+			node := path[len(path)-1].children[r]
+			if node == nil {
+				break
+			}
+			delete(node.ranks, id)
+			path = append(path, node)
+		}
+		for i := len(path) - 1; i > 0; i-- {
+			if len(path[i].ranks) > 0 || len(path[i].children) > 0 {
+				break
+			}
+			delete(path[i-1].children, runes[i-1])
+		}
+	}
+	delete(p.entries, id)
+}
+
+// suggest returns up to limit cakes with a title or title word starting
+// with prefix, best rank first, then by rating.
+func (p *prefixIndex) suggest(prefix string, limit int) []Suggestion {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	result := []Suggestion{}
+	node := p.root
+	for _, r := range strings.ToLower(strings.TrimSpace(prefix)) {
+		if node = node.children[r]; node == nil {
+			return result
+		}
+	}
+
+	ids := make([]int, 0, len(node.ranks))
+	for id := range node.ranks {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(a, b int) bool {
+		ea, eb := p.entries[ids[a]], p.entries[ids[b]]
+		switch {
+		case node.ranks[ids[a]] != node.ranks[ids[b]]:
+			return node.ranks[ids[a]] < node.ranks[ids[b]]
+		case ea.rating != eb.rating:
+			return ea.rating > eb.rating
+		case ea.title != eb.title:
+			return ea.title < eb.title
+		}
+		return ids[a] < ids[b]
+	})
+
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	for _, id := range ids {
+		result = append(result, Suggestion{ID: id, Title: p.entries[id].title})
+	}
+	return result
+}
+
+// load fills the index with the cakes returned by fetch unless it is already
+// loaded. The index stays locked while fetching, so writes that race with the
+// load are applied after it and win.
+func (p *prefixIndex) load(fetch func() ([]Cake, error)) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.loaded {
+		return nil
+	}
+	cakes, err := fetch()
+	if err != nil {
+		return err
+	}
+	for _, cake := range cakes {
+		p.putLocked(cake)
+	}
+	p.loaded = true
+	return nil
+}
@@ -0,0 +1,12 @@
+package carts
+
+import (
+	"cake-store/internal/helpers"
+	"fmt"
+)
+
+var (
+	ErrNotFound   = fmt.Errorf("cart %w", helpers.ErrNotFound)
+	ErrConflict   = fmt.Errorf("cart %w", helpers.ErrConflict)
+	ErrValidation = fmt.Errorf("cart %w", helpers.ErrValidation)
+)
@@ -0,0 +1,345 @@
+package carts
+
+import (
+	"cake-store/internal/auth"
+	"cake-store/internal/orders"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type SvcInterface interface {
+	Get(ctx echo.Context) error
+	Create(ctx echo.Context) error
+	Delete(ctx echo.Context) error
+	SetItem(ctx echo.Context) error
+	RemoveItem(ctx echo.Context) error
+	Checkout(ctx echo.Context) error
+}
+
+type svcImplementation struct {
+	repo    RepoInterface
+	catalog orders.Catalog
+	placer  orders.Placer
+}
+
+// NewHandler returns the cart handler. Carts are priced from catalog and
+// checked out through placer, exactly like orders placed directly.
+func NewHandler(repo RepoInterface, catalog orders.Catalog, placer orders.Placer) SvcInterface {
+	return svcImplementation{repo, catalog, placer}
+}
+
+// refresh re-prices the lines of cart from the catalog, or the menu of its
+// store. Unavailable lines are dropped and changed prices taken over, each
+// with a warning; once the refreshed lines are saved the warnings are not
+// repeated.
+func (s svcImplementation) refresh(ctx context.Context, cart *Cart) error {
+	cart.Items = []orders.Item{}
+	cart.Warnings = []Warning{}
+	lines := []Line{}
+	for _, line := range cart.Lines {
+		item, err := s.catalog.Item(ctx, cart.StoreID, orders.ItemDto{CakeID: line.CakeID, VariantID: line.VariantID, Quantity: line.Quantity})
+		if errors.Is(err, orders.ErrUnavailable) {
+			cart.Warnings = append(cart.Warnings, Warning{line.CakeID, line.VariantID, WarningUnavailable,
+				fmt.Sprintf("%s (%s) is no longer available", line.Title, line.Size)})
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if item.UnitPrice != line.UnitPrice {
+			cart.Warnings = append(cart.Warnings, Warning{line.CakeID, line.VariantID, WarningPriceChanged,
+				fmt.Sprintf("%s (%s) now costs %s %s instead of %s %s", item.Title, item.Size,
+					item.UnitPrice.Decimal, item.UnitPrice.Currency, line.UnitPrice.Decimal, line.UnitPrice.Currency)})
+		}
+		cart.Items = append(cart.Items, item)
+		lines = append(lines, lineOf(item))
+	}
+	cart.Lines = lines
+	cart.retotal()
+	return nil
+}
+
+// save stores the refreshed cart, extending its expiry, and returns it with
+// the prices and warnings of the refresh.
+func (s svcImplementation) save(ctx context.Context, cart *Cart) (*Cart, error) {
+	cart.ExpiresAt = time.Now().Add(TTL)
+	saved, err := s.repo.Save(ctx, *cart)
+	if err != nil {
+		return nil, err
+	}
+	saved.Items, saved.Total, saved.Warnings = cart.Items, cart.Total, cart.Warnings
+	return saved, nil
+}
+
+// load returns the refreshed cart with the given id.
+func (s svcImplementation) load(ctx context.Context, id string) (*Cart, error) {
+	cart, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.refresh(ctx, cart); err != nil {
+		return nil, err
+	}
+	return cart, nil
+}
+
+// Get godoc
+// @Summary Get cart
+// @Description This endpoint for get a cart re-priced from the current cakes; warnings report dropped items and changed prices once
+// @Tags Carts
+// @Accept  json
+// @Produce  json
+// @Param id path string true "cart id"
+// @Success 200 {object} Cart
+// @Failure 404 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /carts/{id} [get]
+func (s svcImplementation) Get(ctx echo.Context) error {
+	cart, err := s.load(context.TODO(), ctx.Param("id"))
+	if err != nil {
+		return err
+	}
+	data, err := s.save(context.TODO(), cart)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, data)
+}
+
+// Create godoc
+// @Summary Create cart
+// @Description This endpoint for creating an empty cart, optionally for a customer; carts created by a signed-in customer are theirs. Carts expire a week after they were last used.
+// @Tags Carts
+// @Accept  json
+// @Produce  json
+// @Param Request body RequestDto false "Create cart"
+// @Success 201 {object} Cart
+// @Header 201 {string} Location "/carts/{id}"
+// @Failure 422 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /carts [post]
+func (s svcImplementation) Create(ctx echo.Context) error {
+	request := RequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+	if principal, ok := auth.Customer(ctx); ok {
+		request.CustomerID = principal.CustomerID()
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := s.repo.DeleteExpired(context.TODO(), now); err != nil {
+		return err
+	}
+	id, err := newID()
+	if err != nil {
+		return err
+	}
+	if err := s.catalog.Store(context.TODO(), request.StoreID); err != nil {
+		return err
+	}
+	created, err := s.repo.Create(context.TODO(), Cart{ID: id, CustomerID: request.CustomerID, StoreID: request.StoreID, ExpiresAt: now.Add(TTL)})
+	if err != nil {
+		return err
+	}
+	if err := s.refresh(context.TODO(), created); err != nil {
+		return err
+	}
+	ctx.Response().Header().Set(echo.HeaderLocation, "/carts/"+created.ID)
+	return ctx.JSON(http.StatusCreated, created)
+}
+
+// Delete godoc
+// @Summary Delete cart
+// @Description This endpoint for deleting a cart
+// @Tags Carts
+// @Accept  json
+// @Produce  json
+// @Param id path string true "cart id"
+// @Success 200 {string} string
+// @Failure 404 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /carts/{id} [delete]
+func (s svcImplementation) Delete(ctx echo.Context) error {
+	if err := s.repo.Delete(context.TODO(), ctx.Param("id")); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, "Success")
+}
+
+// SetItem godoc
+// @Summary Set cart item
+// @Description This endpoint for setting the quantity of a cake variant in a cart; zero removes it
+// @Tags Carts
+// @Accept  json
+// @Produce  json
+// @Param id path string true "cart id"
+// @Param Request body ItemRequestDto true "Set item"
+// @Success 200 {object} Cart
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /carts/{id}/items [put]
+func (s svcImplementation) SetItem(ctx echo.Context) error {
+	request := ItemRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	cart, err := s.load(context.TODO(), request.CartID)
+	if err != nil {
+		return err
+	}
+	if request.Quantity == 0 {
+		cart.remove(request.VariantID)
+	} else {
+		item, err := s.catalog.Item(context.TODO(), cart.StoreID, orders.ItemDto{CakeID: request.CakeID, VariantID: request.VariantID, Quantity: request.Quantity})
+		if err != nil {
+			return err
+		}
+		if len(cart.Items) > 0 && cart.Total.Currency != item.UnitPrice.Currency {
+			return fmt.Errorf("%w: the cart is priced in %s, not %s", ErrValidation, cart.Total.Currency, item.UnitPrice.Currency)
+		}
+		cart.put(item)
+	}
+	cart.retotal()
+
+	data, err := s.save(context.TODO(), cart)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, data)
+}
+
+// RemoveItem godoc
+// @Summary Remove cart item
+// @Description This endpoint for removing a cake variant from a cart
+// @Tags Carts
+// @Accept  json
+// @Produce  json
+// @Param id path string true "cart id"
+// @Param variant_id path string true "variant id"
+// @Success 200 {object} Cart
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /carts/{id}/items/{variant_id} [delete]
+func (s svcImplementation) RemoveItem(ctx echo.Context) error {
+	variantID, err := strconv.Atoi(ctx.Param("variant_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid id")
+	}
+
+	cart, err := s.load(context.TODO(), ctx.Param("id"))
+	if err != nil {
+		return err
+	}
+	cart.remove(variantID)
+	cart.retotal()
+
+	data, err := s.save(context.TODO(), cart)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, data)
+}
+
+// Checkout godoc
+// @Summary Check out cart
+// @Description This endpoint for turning a cart into a pending order and deleting the cart. While the order is placed the cart is checked out and reads as not found; it opens again when the order cannot be placed. When the cart changed since it was last read, nothing is ordered and 409 is returned; read the cart to see the warnings and check out again. A cart is only ever ordered once: concurrent checkouts of it get 404. Customers check out for themselves, whatever customer_id is sent, and only their own carts or carts of no customer.
+// @Tags Carts
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path string true "cart id"
+// @Param Request body CheckoutRequestDto true "Check out"
+// @Param Idempotency-Key header string false "retries with the same key and body get the first response"
+// @Success 201 {object} orders.Order
+// @Header 201 {string} Location "/orders/{id}"
+// @Failure 404 {object} helpers.Problem
+// @Failure 409 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /carts/{id}/checkout [post]
+func (s svcImplementation) Checkout(ctx echo.Context) error {
+	request := CheckoutRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	cart, err := s.load(context.TODO(), request.CartID)
+	if err != nil {
+		return err
+	}
+	// The refresh is not saved, so reading the cart shows its warnings.
+	if len(cart.Warnings) > 0 {
+		return fmt.Errorf("%w: %s", ErrConflict, cart.Warnings[0].Message)
+	}
+	if len(cart.Lines) == 0 {
+		return fmt.Errorf("%w: the cart is empty", ErrValidation)
+	}
+	customerID := request.CustomerID
+	if principal, ok := auth.Customer(ctx); ok {
+		customerID = principal.CustomerID()
+		if cart.CustomerID != "" && cart.CustomerID != customerID {
+			return fmt.Errorf("%w: the cart belongs to another customer", ErrConflict)
+		}
+	}
+	if customerID == "" {
+		customerID = cart.CustomerID
+	}
+	if customerID == "" {
+		return fmt.Errorf("%w: customer_id is required", ErrValidation)
+	}
+
+	items := make([]orders.ItemDto, len(cart.Lines))
+	for n, line := range cart.Lines {
+		items[n] = orders.ItemDto{CakeID: line.CakeID, VariantID: line.VariantID, Quantity: line.Quantity}
+	}
+	// Claiming the cart first checks it out: of concurrent checkouts only
+	// the one that claimed it places an order, the others get 404.
+	if err := s.repo.Claim(context.TODO(), cart.ID); err != nil {
+		return err
+	}
+	created, err := s.placer.Place(context.TODO(), orders.RequestDto{
+		CustomerID:      customerID,
+		StoreID:         cart.StoreID,
+		Fulfilment:      request.Fulfilment,
+		DeliveryAddress: request.DeliveryAddress,
+		Day:             request.Day,
+		Slot:            request.Slot,
+		Items:           items,
+	})
+	if err != nil {
+		// The shopper can fix the cart and check out again; the error of
+		// the checkout is what they need to see.
+		if errReopen := s.repo.Reopen(context.TODO(), cart.ID); errReopen != nil {
+			ctx.Logger().Warnf("carts: reopening a cart whose checkout failed: %v", errReopen)
+		}
+		return err
+	}
+	// A checked out cart left behind is hidden, and purged once it expires.
+	if errDelete := s.repo.Delete(context.TODO(), cart.ID); errDelete != nil {
+		ctx.Logger().Warnf("carts: deleting a checked out cart: %v", errDelete)
+	}
+	ctx.Response().Header().Set(echo.HeaderLocation, "/orders/"+strconv.Itoa(created.ID))
+	return ctx.JSON(http.StatusCreated, created)
+}
@@ -0,0 +1,136 @@
+package carts
+
+import (
+	"cake-store/internal/storage"
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryRepoImplementation struct {
+	mu    sync.RWMutex
+	carts map[string]Cart
+}
+
+// NewMemoryRepository returns a RepoInterface that keeps carts by their
+// random ID, with their lines.
+func NewMemoryRepository() RepoInterface {
+	return &memoryRepoImplementation{carts: map[string]Cart{}}
+}
+
+// copyCart returns the stored fields of cart.
+func copyCart(cart Cart) Cart {
+	if cart.UpdatedAt != nil {
+		updatedAt := *cart.UpdatedAt
+		cart.UpdatedAt = &updatedAt
+	}
+	return Cart{
+		ID:         cart.ID,
+		CustomerID: cart.CustomerID,
+		StoreID:    cart.StoreID,
+		Status:     cart.Status,
+		ExpiresAt:  cart.ExpiresAt,
+		CreatedAt:  cart.CreatedAt,
+		UpdatedAt:  cart.UpdatedAt,
+		Lines:      append([]Line{}, cart.Lines...),
+	}
+}
+
+func (m *memoryRepoImplementation) Get(ctx context.Context, id string) (*Cart, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cart, ok := m.carts[id]
+	if !ok || cart.Status != StatusOpen || !cart.ExpiresAt.After(time.Now()) {
+		return nil, ErrNotFound
+	}
+	result := copyCart(cart)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Create(ctx context.Context, cart Cart) (*Cart, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cart = copyCart(cart)
+	cart.Status = StatusOpen
+	cart.ExpiresAt = storage.TruncateTime(cart.ExpiresAt)
+	cart.CreatedAt = storage.TruncateTime(time.Now())
+	cart.UpdatedAt = nil
+	m.carts[cart.ID] = cart
+	if !cart.ExpiresAt.After(time.Now()) {
+		return nil, ErrNotFound
+	}
+	result := copyCart(cart)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Save(ctx context.Context, cart Cart) (*Cart, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.carts[cart.ID]
+	if !ok || stored.Status != StatusOpen {
+		return nil, ErrNotFound
+	}
+	updatedAt := storage.TruncateTime(time.Now())
+	stored.CustomerID = cart.CustomerID
+	stored.ExpiresAt = storage.TruncateTime(cart.ExpiresAt)
+	stored.UpdatedAt = &updatedAt
+	stored.Lines = append([]Line{}, cart.Lines...)
+	m.carts[cart.ID] = stored
+	if !stored.ExpiresAt.After(time.Now()) {
+		return nil, ErrNotFound
+	}
+	result := copyCart(stored)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Delete(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.carts[id]; !ok {
+		return ErrNotFound
+	}
+	delete(m.carts, id)
+	return nil
+}
+func (m *memoryRepoImplementation) Claim(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cart, ok := m.carts[id]
+	if !ok || cart.Status != StatusOpen || !cart.ExpiresAt.After(time.Now()) {
+		return ErrNotFound
+	}
+	m.move(cart, StatusCheckedOut)
+	return nil
+}
+func (m *memoryRepoImplementation) Reopen(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cart, ok := m.carts[id]
+	if !ok || cart.Status != StatusCheckedOut {
+		return ErrNotFound
+	}
+	m.move(cart, StatusOpen)
+	return nil
+}
+
+// move stores cart with status; the caller holds the lock.
+func (m *memoryRepoImplementation) move(cart Cart, status string) {
+	updatedAt := storage.TruncateTime(time.Now())
+	cart.Status = status
+	cart.UpdatedAt = &updatedAt
+	m.carts[cart.ID] = cart
+}
+func (m *memoryRepoImplementation) DeleteExpired(ctx context.Context, now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, cart := range m.carts {
+		if !cart.ExpiresAt.After(now) {
+			delete(m.carts, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package carts
+
+import (
+	"cake-store/internal/money"
+	"cake-store/internal/orders"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// TTL is how long a cart is kept after it was last used.
+const TTL = 7 * 24 * time.Hour
+
+// Cart statuses.
+const (
+	// StatusOpen carts can be read, changed and checked out.
+	StatusOpen = "open"
+	// StatusCheckedOut carts are claimed by a checkout placing their order,
+	// and read as not found.
+	StatusCheckedOut = "checked_out"
+)
+
+// Warning codes.
+const (
+	// WarningUnavailable reports a line dropped because its cake was
+	// deleted or its variant is gone or no longer for sale.
+	WarningUnavailable = "unavailable"
+	// WarningPriceChanged reports a line whose price changed since the
+	// shopper last saw the cart.
+	WarningPriceChanged = "price_changed"
+	// WarningMixedCurrencies reports lines priced in different currencies,
+	// which cannot be totalled or ordered together.
+	WarningMixedCurrencies = "mixed_currencies"
+)
+
+type (
+	// Cart holds the items of an anonymous or logged-in shopper. Items and
+	// Total are re-priced from the cakes on every read.
+	Cart struct {
+		ID         string `json:"id" example:"0f8fad5bd9cb469fa16570867728950e"`
+		CustomerID string `json:"customer_id,omitempty" example:"customer-42"`
+		// StoreID is the store the cart is priced at and checked out with;
+		// the catalog prices apply without one.
+		StoreID   int           `json:"store_id,omitempty" example:"2"`
+		Items     []orders.Item `json:"items"`
+		Total     money.Money   `json:"total"`
+		Warnings  []Warning     `json:"warnings"`
+		ExpiresAt time.Time     `json:"expires_at"`
+		CreatedAt time.Time     `json:"created_at"`
+		UpdatedAt *time.Time    `json:"updated_at,omitempty"`
+		// Lines are the items as last shown to the shopper, which the next
+		// read is compared against.
+		Lines  []Line `json:"-" swaggerignore:"true"`
+		Status string `json:"-" swaggerignore:"true"`
+	}
+	Line struct {
+		CakeID    int
+		VariantID int
+		Title     string
+		Size      string
+		Quantity  int
+		UnitPrice money.Money
+	}
+	Warning struct {
+		CakeID    int    `json:"cake_id"`
+		VariantID int    `json:"variant_id"`
+		Code      string `json:"code" example:"price_changed"`
+		Message   string `json:"message" example:"Lemon cake (8 inch) now costs 35.00 USD instead of 32.50 USD"`
+	}
+	RequestDto struct {
+		CustomerID string `json:"customer_id" validate:"omitempty,max=64" example:"customer-42"`
+		StoreID    int    `json:"store_id" validate:"omitempty,gt=0" example:"2"`
+	}
+	// ItemRequestDto sets the quantity of a variant in a cart; zero removes
+	// it.
+	ItemRequestDto struct {
+		CartID    string `param:"id" json:"-" swaggerignore:"true"`
+		CakeID    int    `json:"cake_id" validate:"required,gt=0"`
+		VariantID int    `json:"variant_id" validate:"required,gt=0"`
+		Quantity  int    `json:"quantity" validate:"gte=0,lte=100"`
+	}
+	CheckoutRequestDto struct {
+		CartID          string `param:"id" json:"-" swaggerignore:"true"`
+		CustomerID      string `json:"customer_id" validate:"omitempty,max=64" example:"customer-42"`
+		Fulfilment      string `json:"fulfilment" validate:"omitempty,oneof=pickup delivery" example:"pickup"`
+		DeliveryAddress string `json:"delivery_address" validate:"required_if=Fulfilment delivery,max=255"`
+		Day             string `json:"day" validate:"required,datetime=2006-01-02" example:"2026-10-18"`
+		Slot            string `json:"slot" validate:"omitempty,datetime=15:04" example:"09:00"`
+	}
+)
+
+// lineOf returns the line storing a priced item.
+func lineOf(item orders.Item) Line {
+	return Line{item.CakeID, item.VariantID, item.Title, item.Size, item.Quantity, item.UnitPrice}
+}
+
+// put sets the line of the item's variant, adding it when new.
+func (c *Cart) put(item orders.Item) {
+	for n := range c.Lines {
+		if c.Lines[n].VariantID == item.VariantID {
+			c.Lines[n], c.Items[n] = lineOf(item), item
+			return
+		}
+	}
+	c.Lines = append(c.Lines, lineOf(item))
+	c.Items = append(c.Items, item)
+}
+
+// remove drops the line of a variant.
+func (c *Cart) remove(variantID int) {
+	for n := range c.Lines {
+		if c.Lines[n].VariantID == variantID {
+			c.Lines = append(c.Lines[:n], c.Lines[n+1:]...)
+			c.Items = append(c.Items[:n], c.Items[n+1:]...)
+			return
+		}
+	}
+}
+
+// retotal sums the items, warning when they cannot be totalled.
+func (c *Cart) retotal() {
+	total, err := orders.Total(c.Items)
+	if err != nil {
+		c.Warnings = append(c.Warnings, Warning{Code: WarningMixedCurrencies, Message: "items priced in different currencies cannot be ordered together"})
+		total = money.New(0, c.Items[0].UnitPrice.Currency)
+	}
+	c.Total = total
+}
+
+// newID returns a random cart ID. Anonymous carts are only protected by
+// their ID, so it must not be guessable.
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
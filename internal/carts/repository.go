@@ -0,0 +1,229 @@
+package carts
+
+//go:generate mockgen -destination=../../mocks/carts/mock_repository.go -package=mock_carts -source=repository.go
+
+import (
+	"cake-store/internal/money"
+	"cake-store/internal/query"
+	"cake-store/internal/storage"
+	"context"
+	"database/sql"
+	"time"
+)
+
+const (
+	TableName = "carts"
+	ItemsName = "cart_items"
+)
+
+// Columns lists the carts columns in the order scanned by scanCart.
+var Columns = []string{"id", "customer_id", "store_id", "status", "expires_at", "created_at", "updated_at"}
+
+// LineColumns lists the cart_items columns in the order scanned by Get.
+var LineColumns = []string{"cake_id", "variant_id", "title", "size", "quantity", "unit_price_minor", "currency"}
+
+type repoImplementation struct {
+	db *sql.DB
+}
+
+type RepoInterface interface {
+	// Get returns an open cart with its lines, or ErrNotFound once it
+	// expired or was checked out.
+	Get(ctx context.Context, id string) (*Cart, error)
+	Create(ctx context.Context, cart Cart) (*Cart, error)
+	// Save stores the customer, expiry and lines of an open cart.
+	Save(ctx context.Context, cart Cart) (*Cart, error)
+	Delete(ctx context.Context, id string) error
+	// Claim checks out an open cart, or returns ErrNotFound when it is not
+	// open; of concurrent claims of a cart only one succeeds.
+	Claim(ctx context.Context, id string) error
+	// Reopen opens a checked out cart again, for a checkout that failed.
+	Reopen(ctx context.Context, id string) error
+	// DeleteExpired removes the carts that expired before now.
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
+
+// NewRepository returns a RepoInterface storing carts with their lines in
+// cart_items.
+func NewRepository(db *sql.DB) RepoInterface {
+	return repoImplementation{db}
+}
+
+func scanCart(scan func(dest ...interface{}) error) (cart Cart, err error) {
+	err = scan(&cart.ID, &cart.CustomerID, &cart.StoreID, &cart.Status, &cart.ExpiresAt, &cart.CreatedAt, &cart.UpdatedAt)
+	return
+}
+
+func (i repoImplementation) Get(ctx context.Context, id string) (*Cart, error) {
+	q, args := query.Select(TableName, Columns...).
+		Where(query.Eq("id", id), query.Eq("status", StatusOpen), query.Expr("expires_at > ?", storage.TruncateTime(time.Now()))).
+		Build()
+	cart, err := scanCart(i.db.QueryRowContext(ctx, q, args...).Scan)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	q, args = query.Select(ItemsName, LineColumns...).Where(query.Eq("cart_id", id)).OrderBy("position ASC").Build()
+	rows, err := i.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cart.Lines = []Line{}
+	for rows.Next() {
+		var (
+			line     Line
+			price    int64
+			currency string
+		)
+		if err := rows.Scan(&line.CakeID, &line.VariantID, &line.Title, &line.Size, &line.Quantity, &price, &currency); err != nil {
+			return nil, err
+		}
+		line.UnitPrice = money.New(price, currency)
+		cart.Lines = append(cart.Lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &cart, nil
+}
+func (i repoImplementation) Create(ctx context.Context, cart Cart) (*Cart, error) {
+	q, args := query.Insert(TableName).
+		Set("id", cart.ID).
+		Set("customer_id", cart.CustomerID).
+		Set("store_id", cart.StoreID).
+		Set("status", StatusOpen).
+		Set("expires_at", storage.TruncateTime(cart.ExpiresAt)).
+		Set("created_at", storage.TruncateTime(time.Now())).
+		Build()
+	if _, err := i.db.ExecContext(ctx, q, args...); err != nil {
+		return nil, err
+	}
+	return i.Get(ctx, cart.ID)
+}
+func (i repoImplementation) Save(ctx context.Context, cart Cart) (*Cart, error) {
+	tx, err := i.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var exists int
+	q, args := query.Select(TableName).Where(query.Eq("id", cart.ID), query.Eq("status", StatusOpen)).Count()
+	if err := tx.QueryRowContext(ctx, q, args...).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if exists == 0 {
+		return nil, ErrNotFound
+	}
+
+	q, args = query.Update(TableName).
+		Set("customer_id", cart.CustomerID).
+		Set("expires_at", storage.TruncateTime(cart.ExpiresAt)).
+		Set("updated_at", storage.TruncateTime(time.Now())).
+		Where(query.Eq("id", cart.ID)).
+		Build()
+	if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+		return nil, err
+	}
+	q, args = query.Delete(ItemsName).Where(query.Eq("cart_id", cart.ID)).Build()
+	if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+		return nil, err
+	}
+	for position, line := range cart.Lines {
+		q, args := query.Insert(ItemsName).
+			Set("cart_id", cart.ID).
+			Set("cake_id", line.CakeID).
+			Set("variant_id", line.VariantID).
+			Set("title", line.Title).
+			Set("size", line.Size).
+			Set("quantity", line.Quantity).
+			Set("unit_price_minor", line.UnitPrice.Amount).
+			Set("currency", line.UnitPrice.Currency).
+			Set("position", position).
+			Build()
+		if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+			return nil, err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return i.Get(ctx, cart.ID)
+}
+func (i repoImplementation) Delete(ctx context.Context, id string) error {
+	tx, err := i.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	q, args := query.Delete(ItemsName).Where(query.Eq("cart_id", id)).Build()
+	if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+		return err
+	}
+	q, args = query.Delete(TableName).Where(query.Eq("id", id)).Build()
+	res, err := tx.ExecContext(ctx, q, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return tx.Commit()
+}
+func (i repoImplementation) Claim(ctx context.Context, id string) error {
+	return i.move(ctx, id, StatusOpen, StatusCheckedOut, query.Expr("expires_at > ?", storage.TruncateTime(time.Now())))
+}
+func (i repoImplementation) Reopen(ctx context.Context, id string) error {
+	return i.move(ctx, id, StatusCheckedOut, StatusOpen)
+}
+
+// move sets the status of a cart from one to another in a single update
+// conditioned on the status, so only one of concurrent moves succeeds.
+func (i repoImplementation) move(ctx context.Context, id, from, to string, conditions ...query.Condition) error {
+	q, args := query.Update(TableName).
+		Set("status", to).
+		Set("updated_at", storage.TruncateTime(time.Now())).
+		Where(append([]query.Condition{query.Eq("id", id), query.Eq("status", from)}, conditions...)...).
+		Build()
+	res, err := i.db.ExecContext(ctx, q, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+func (i repoImplementation) DeleteExpired(ctx context.Context, now time.Time) error {
+	tx, err := i.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	expired := query.Expr("expires_at <= ?", storage.TruncateTime(now))
+	sub, subArgs := query.Select(TableName, "id").Where(expired).Build()
+	q, args := query.Delete(ItemsName).Where(query.Expr("cart_id IN ("+sub+")", subArgs...)).Build()
+	if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+		return err
+	}
+	q, args = query.Delete(TableName).Where(expired).Build()
+	if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,12 @@
+package categories
+
+import (
+	"cake-store/internal/helpers"
+	"fmt"
+)
+
+var (
+	ErrNotFound   = fmt.Errorf("category %w", helpers.ErrNotFound)
+	ErrConflict   = fmt.Errorf("category %w", helpers.ErrConflict)
+	ErrValidation = fmt.Errorf("category %w", helpers.ErrValidation)
+)
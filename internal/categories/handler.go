@@ -0,0 +1,163 @@
+package categories
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+)
+
+type SvcInterface interface {
+	List(ctx echo.Context) error
+	Get(ctx echo.Context) error
+	Create(ctx echo.Context) error
+	Update(ctx echo.Context) error
+	Delete(ctx echo.Context) error
+}
+
+type svcImplementation struct {
+	repo RepoInterface
+}
+
+func NewHandler(repo RepoInterface) SvcInterface {
+	return svcImplementation{repo}
+}
+
+// List godoc
+// @Summary List all categories
+// @Description This endpoint for get list of categories ordered by name
+// @Tags Categories
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} Category
+// @Failure 500 {object} helpers.Problem
+// @Router /categories [get]
+func (s svcImplementation) List(ctx echo.Context) error {
+	res, err := s.repo.List(context.TODO())
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// Get godoc
+// @Summary Get detail of category
+// @Description This endpoint for get detail of category
+// @Tags Categories
+// @Accept  json
+// @Produce  json
+// @Param id path string true "category id"
+// @Success 200 {object} Category
+// @Failure 422 {object} helpers.Problem
+// @Failure 404 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /categories/{id} [get]
+func (s svcImplementation) Get(ctx echo.Context) error {
+	ID, errConv := strconv.Atoi(ctx.Param("id"))
+	if errConv != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid id")
+	}
+
+	data, errGet := s.repo.Get(context.TODO(), ID)
+	if errGet != nil {
+		return errGet
+	}
+	return ctx.JSON(http.StatusOK, data)
+}
+
+// Create godoc
+// @Summary Create category
+// @Description This endpoint for creating category, the slug defaults to the slugified name
+// @Tags Categories
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param Request body RequestDto true "Create category"
+// @Success 201 {object} Category
+// @Header 201 {string} Location "/categories/{id}"
+// @Failure 409 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /categories [post]
+func (s svcImplementation) Create(ctx echo.Context) error {
+	request := RequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	created, errCreate := s.repo.Create(context.TODO(), request)
+	if errCreate != nil {
+		return errCreate
+	}
+	ctx.Response().Header().Set(echo.HeaderLocation, "/categories/"+strconv.Itoa(created.ID))
+	return ctx.JSON(http.StatusCreated, created)
+}
+
+// Update godoc
+// @Summary Update category
+// @Description This endpoint for updating category
+// @Tags Categories
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path string true "category id"
+// @Param Request body UpdateRequestDto true "Update category"
+// @Success 200 {object} Category
+// @Failure 422 {object} helpers.Problem
+// @Failure 404 {object} helpers.Problem
+// @Failure 409 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /categories/{id} [patch]
+func (s svcImplementation) Update(ctx echo.Context) error {
+	request := UpdateRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	updated, errUpdate := s.repo.Update(context.TODO(), request)
+	if errUpdate != nil {
+		return errUpdate
+	}
+	return ctx.JSON(http.StatusOK, updated)
+}
+
+// Delete godoc
+// @Summary Delete category
+// @Description This endpoint for deleting category, its cakes are kept
+// @Tags Categories
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path string true "category id"
+// @Success 200 {string} string
+// @Failure 422 {object} helpers.Problem
+// @Failure 404 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /categories/{id} [delete]
+func (s svcImplementation) Delete(ctx echo.Context) error {
+	ID, errConv := strconv.Atoi(ctx.Param("id"))
+	if errConv != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid id")
+	}
+	if err := s.repo.Delete(context.TODO(), ID); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, "Success")
+}
@@ -0,0 +1,252 @@
+package categories
+
+import (
+	"cake-store/internal/query"
+	"cake-store/internal/storage"
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+type memoryRepoImplementation struct {
+	mu         sync.RWMutex
+	categories map[int]Category
+	nextID     int
+	// assigned and tags map cake IDs to their category IDs and tags.
+	assigned map[int][]int
+	tags     map[int][]string
+}
+
+// NewMemoryRepository returns a RepoInterface that keeps categories by ID
+// and the categories and tags of each cake.
+func NewMemoryRepository() RepoInterface {
+	return &memoryRepoImplementation{
+		categories: map[int]Category{},
+		nextID:     1,
+		assigned:   map[int][]int{},
+		tags:       map[int][]string{},
+	}
+}
+
+func copyCategory(category Category) Category {
+	if category.UpdatedAt != nil {
+		updatedAt := *category.UpdatedAt
+		category.UpdatedAt = &updatedAt
+	}
+	return category
+}
+
+// sortCategories orders categories by name, then id.
+func sortCategories(categories []Category) {
+	sort.Slice(categories, func(a, b int) bool {
+		if categories[a].Name != categories[b].Name {
+			return categories[a].Name < categories[b].Name
+		}
+		return categories[a].ID < categories[b].ID
+	})
+}
+
+// slugTaken reports whether another category than id uses slug.
+func (m *memoryRepoImplementation) slugTaken(slug string, id int) bool {
+	for _, category := range m.categories {
+		if category.Slug == slug && category.ID != id {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *memoryRepoImplementation) List(ctx context.Context) ([]Category, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := []Category{}
+	for _, category := range m.categories {
+		result = append(result, copyCategory(category))
+	}
+	sortCategories(result)
+	return result, nil
+}
+func (m *memoryRepoImplementation) Get(ctx context.Context, id int) (*Category, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	category, ok := m.categories[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	result := copyCategory(category)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Create(ctx context.Context, dto RequestDto) (*Category, error) {
+	slug, err := slugFor(dto.Name, dto.Slug)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.slugTaken(slug, 0) {
+		return nil, ErrConflict
+	}
+	category := Category{
+		ID:          m.nextID,
+		Name:        dto.Name,
+		Slug:        slug,
+		Description: dto.Description,
+		CreatedAt:   storage.TruncateTime(time.Now()),
+	}
+	m.categories[category.ID] = category
+	m.nextID++
+	result := copyCategory(category)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Update(ctx context.Context, dto UpdateRequestDto) (*Category, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	category, ok := m.categories[dto.ID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if dto.Name != "" {
+		category.Name = dto.Name
+	}
+	if dto.Slug != "" {
+		slug, err := slugFor("", dto.Slug)
+		if err != nil {
+			return nil, err
+		}
+		if m.slugTaken(slug, dto.ID) {
+			return nil, ErrConflict
+		}
+		category.Slug = slug
+	}
+	if dto.Description != "" {
+		category.Description = dto.Description
+	}
+	updatedAt := storage.TruncateTime(time.Now())
+	category.UpdatedAt = &updatedAt
+	m.categories[dto.ID] = category
+	result := copyCategory(category)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Delete(ctx context.Context, id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.categories[id]; !ok {
+		return ErrNotFound
+	}
+	delete(m.categories, id)
+	for cakeID, ids := range m.assigned {
+		kept := []int{}
+		for _, assigned := range ids {
+			if assigned != id {
+				kept = append(kept, assigned)
+			}
+		}
+		m.assigned[cakeID] = kept
+	}
+	return nil
+}
+
+func (m *memoryRepoImplementation) Check(ctx context.Context, ids []int) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.check(ids)
+}
+
+func (m *memoryRepoImplementation) check(ids []int) error {
+	var found []int
+	for _, id := range ids {
+		if _, ok := m.categories[id]; ok {
+			found = append(found, id)
+		}
+	}
+	return checkFound(ids, found)
+}
+
+func (m *memoryRepoImplementation) Assign(ctx context.Context, cakeID int, ids []int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.check(ids); err != nil {
+		return err
+	}
+	assigned := []int{}
+	seen := map[int]bool{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			assigned = append(assigned, id)
+		}
+	}
+	m.assigned[cakeID] = assigned
+	return nil
+}
+func (m *memoryRepoImplementation) Tag(ctx context.Context, cakeID int, tags []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.tags[cakeID] = NormalizeTags(tags)
+	return nil
+}
+func (m *memoryRepoImplementation) ForCakes(ctx context.Context, cakeIDs []int) (map[int][]Category, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := map[int][]Category{}
+	for _, cakeID := range cakeIDs {
+		for _, id := range m.assigned[cakeID] {
+			result[cakeID] = append(result[cakeID], copyCategory(m.categories[id]))
+		}
+		sortCategories(result[cakeID])
+	}
+	return result, nil
+}
+func (m *memoryRepoImplementation) TagsForCakes(ctx context.Context, cakeIDs []int) (map[int][]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := map[int][]string{}
+	for _, cakeID := range cakeIDs {
+		if tags := m.tags[cakeID]; len(tags) > 0 {
+			result[cakeID] = append([]string{}, tags...)
+		}
+	}
+	return result, nil
+}
+func (m *memoryRepoImplementation) CakesInCategory(ctx context.Context, slug string) (query.IDs, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	slug = Slugify(slug)
+	result := []int{}
+	for cakeID, ids := range m.assigned {
+		for _, id := range ids {
+			if m.categories[id].Slug == slug {
+				result = append(result, cakeID)
+			}
+		}
+	}
+	return query.IDs{List: result}, nil
+}
+func (m *memoryRepoImplementation) CakesTagged(ctx context.Context, tag string) (query.IDs, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	tags := NormalizeTags([]string{tag})
+	result := []int{}
+	for cakeID, cakeTags := range m.tags {
+		for _, cakeTag := range cakeTags {
+			if len(tags) > 0 && cakeTag == tags[0] {
+				result = append(result, cakeID)
+			}
+		}
+	}
+	return query.IDs{List: result}, nil
+}
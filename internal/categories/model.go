@@ -0,0 +1,68 @@
+package categories
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type (
+	Category struct {
+		ID          int        `json:"id"`
+		Name        string     `json:"name"`
+		Slug        string     `json:"slug"`
+		Description string     `json:"description"`
+		CreatedAt   time.Time  `json:"created_at"`
+		UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+	}
+	RequestDto struct {
+		Name        string `json:"name" validate:"required,max=100"`
+		Slug        string `json:"slug" validate:"omitempty,max=100" example:"birthday-cakes"`
+		Description string `json:"description" validate:"omitempty,max=255"`
+	}
+	UpdateRequestDto struct {
+		ID          int    `param:"id"`
+		Name        string `json:"name" validate:"omitempty,max=100"`
+		Slug        string `json:"slug" validate:"omitempty,max=100"`
+		Description string `json:"description" validate:"omitempty,max=255"`
+	}
+)
+
+// Slugify lowercases s and joins its letters and digits with dashes, so
+// "Birthday Cakes!" becomes "birthday-cakes".
+func Slugify(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, "-")
+}
+
+// slugFor returns the slug of a category, derived from its name unless one
+// is given.
+func slugFor(name, slug string) (string, error) {
+	if slug == "" {
+		slug = name
+	}
+	if slug = Slugify(slug); slug == "" {
+		return "", fmt.Errorf("%w: slug must contain a letter or digit", ErrValidation)
+	}
+	return slug, nil
+}
+
+// NormalizeTags trims and lowercases tags, dropping blanks and duplicates,
+// and returns them sorted.
+func NormalizeTags(tags []string) []string {
+	seen := map[string]bool{}
+	result := []string{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			result = append(result, tag)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,323 @@
+package categories
+
+//go:generate mockgen -destination=../../mocks/categories/mock_repository.go -package=mock_categories -source=repository.go
+
+import (
+	"cake-store/internal/query"
+	"cake-store/internal/storage"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const (
+	TableName          = "categories"
+	CakeCategoriesName = "cake_categories"
+	CakeTagsName       = "cake_tags"
+)
+
+// assignedCategories joins the categories to the cakes they are assigned to.
+const assignedCategories = CakeCategoriesName + " JOIN " + TableName + " ON " + TableName + ".id = " + CakeCategoriesName + ".category_id"
+
+// Columns lists the categories columns in the order scanned by scanCategory.
+var Columns = []string{"id", "name", "slug", "description", "created_at", "updated_at"}
+
+type repoImplementation struct {
+	db *sql.DB
+	tx storage.Transactor
+}
+
+type RepoInterface interface {
+	// List returns every category ordered by name.
+	List(ctx context.Context) ([]Category, error)
+	Get(ctx context.Context, id int) (*Category, error)
+	Create(ctx context.Context, dto RequestDto) (*Category, error)
+	Update(ctx context.Context, dto UpdateRequestDto) (*Category, error)
+	// Delete removes a category and unassigns it from its cakes.
+	Delete(ctx context.Context, id int) error
+
+	// Check returns ErrValidation naming the first of ids that is not a
+	// category.
+	Check(ctx context.Context, ids []int) error
+	// Assign replaces the categories of a cake.
+	Assign(ctx context.Context, cakeID int, ids []int) error
+	// Tag replaces the tags of a cake.
+	Tag(ctx context.Context, cakeID int, tags []string) error
+	// ForCakes returns the categories of each of the cakes, ordered by name.
+	ForCakes(ctx context.Context, cakeIDs []int) (map[int][]Category, error)
+	// TagsForCakes returns the sorted tags of each of the cakes.
+	TagsForCakes(ctx context.Context, cakeIDs []int) (map[int][]string, error)
+	// CakesInCategory selects the IDs of the cakes in the category with slug.
+	CakesInCategory(ctx context.Context, slug string) (query.IDs, error)
+	// CakesTagged selects the IDs of the cakes tagged with tag.
+	CakesTagged(ctx context.Context, tag string) (query.IDs, error)
+}
+
+// NewRepository returns a RepoInterface over the categories and the
+// cake_categories and cake_tags assigning them to cakes.
+func NewRepository(db *sql.DB) RepoInterface {
+	return repoImplementation{db, storage.NewTransactor(db)}
+}
+
+func scanCategory(scan func(dest ...interface{}) error, extra ...interface{}) (category Category, err error) {
+	err = scan(append(extra, &category.ID, &category.Name, &category.Slug, &category.Description, &category.CreatedAt, &category.UpdatedAt)...)
+	return
+}
+
+func (i repoImplementation) List(ctx context.Context) ([]Category, error) {
+	q, args := query.Select(TableName, Columns...).OrderBy("name ASC", "id ASC").Build()
+	rows, err := storage.Using(ctx, i.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []Category{}
+	for rows.Next() {
+		category, err := scanCategory(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, category)
+	}
+	return result, rows.Err()
+}
+func (i repoImplementation) Get(ctx context.Context, id int) (*Category, error) {
+	q, args := query.Select(TableName, Columns...).Where(query.Eq("id", id)).Build()
+	result, err := scanCategory(storage.Using(ctx, i.db).QueryRowContext(ctx, q, args...).Scan)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+func (i repoImplementation) Create(ctx context.Context, dto RequestDto) (*Category, error) {
+	slug, err := slugFor(dto.Name, dto.Slug)
+	if err != nil {
+		return nil, err
+	}
+	q, args := query.Insert(TableName).
+		Set("name", dto.Name).
+		Set("slug", slug).
+		Set("description", dto.Description).
+		Set("created_at", storage.TruncateTime(time.Now())).
+		Build()
+	res, err := storage.Using(ctx, i.db).ExecContext(ctx, q, args...)
+	if storage.IsDuplicate(err) {
+		return nil, ErrConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return i.Get(ctx, int(id))
+}
+func (i repoImplementation) Update(ctx context.Context, dto UpdateRequestDto) (*Category, error) {
+	builder := query.Update(TableName).Set("updated_at", storage.TruncateTime(time.Now()))
+	if dto.Name != "" {
+		builder.Set("name", dto.Name)
+	}
+	if dto.Slug != "" {
+		slug, err := slugFor("", dto.Slug)
+		if err != nil {
+			return nil, err
+		}
+		builder.Set("slug", slug)
+	}
+	if dto.Description != "" {
+		builder.Set("description", dto.Description)
+	}
+
+	q, args := builder.Where(query.Eq("id", dto.ID)).Build()
+	_, err := storage.Using(ctx, i.db).ExecContext(ctx, q, args...)
+	if storage.IsDuplicate(err) {
+		return nil, ErrConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+	return i.Get(ctx, dto.ID)
+}
+func (i repoImplementation) Delete(ctx context.Context, id int) error {
+	return i.tx.InTx(ctx, func(ctx context.Context) error {
+		tx := storage.Using(ctx, i.db)
+		q, args := query.Delete(CakeCategoriesName).Where(query.Eq("category_id", id)).Build()
+		if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+			return err
+		}
+		q, args = query.Delete(TableName).Where(query.Eq("id", id)).Build()
+		res, err := tx.ExecContext(ctx, q, args...)
+		if err != nil {
+			return err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+}
+
+func (i repoImplementation) Check(ctx context.Context, ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return check(ctx, storage.Using(ctx, i.db), ids)
+}
+
+func check(ctx context.Context, db storage.Conn, ids []int) error {
+	q, args := query.Select(TableName, "id").Where(query.In("id", query.Ints(ids)...)).Build()
+	found, err := ints(ctx, db, q, args)
+	if err != nil {
+		return err
+	}
+	return checkFound(ids, found)
+}
+
+// checkFound returns ErrValidation naming the first of ids missing from found.
+func checkFound(ids, found []int) error {
+	exists := map[int]bool{}
+	for _, id := range found {
+		exists[id] = true
+	}
+	for _, id := range ids {
+		if !exists[id] {
+			return fmt.Errorf("%w: unknown category %d", ErrValidation, id)
+		}
+	}
+	return nil
+}
+
+func (i repoImplementation) Assign(ctx context.Context, cakeID int, ids []int) error {
+	return i.tx.InTx(ctx, func(ctx context.Context) error {
+		tx := storage.Using(ctx, i.db)
+		if len(ids) > 0 {
+			if err := check(ctx, tx, ids); err != nil {
+				return err
+			}
+		}
+		q, args := query.Delete(CakeCategoriesName).Where(query.Eq("cake_id", cakeID)).Build()
+		if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+			return err
+		}
+		assigned := map[int]bool{}
+		for _, id := range ids {
+			if assigned[id] {
+				continue
+			}
+			assigned[id] = true
+			q, args = query.Insert(CakeCategoriesName).Set("cake_id", cakeID).Set("category_id", id).Build()
+			if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+func (i repoImplementation) Tag(ctx context.Context, cakeID int, tags []string) error {
+	return i.tx.InTx(ctx, func(ctx context.Context) error {
+		tx := storage.Using(ctx, i.db)
+		q, args := query.Delete(CakeTagsName).Where(query.Eq("cake_id", cakeID)).Build()
+		if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+			return err
+		}
+		for _, tag := range NormalizeTags(tags) {
+			q, args = query.Insert(CakeTagsName).Set("cake_id", cakeID).Set("tag", tag).Build()
+			if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+func (i repoImplementation) ForCakes(ctx context.Context, cakeIDs []int) (map[int][]Category, error) {
+	result := map[int][]Category{}
+	if len(cakeIDs) == 0 {
+		return result, nil
+	}
+	q, args := query.Select(assignedCategories, append([]string{"cake_id"}, Columns...)...).
+		Where(query.In("cake_id", query.Ints(cakeIDs)...)).
+		OrderBy("name ASC", "id ASC").
+		Build()
+	rows, err := storage.Using(ctx, i.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cakeID int
+		category, err := scanCategory(rows.Scan, &cakeID)
+		if err != nil {
+			return nil, err
+		}
+		result[cakeID] = append(result[cakeID], category)
+	}
+	return result, rows.Err()
+}
+func (i repoImplementation) TagsForCakes(ctx context.Context, cakeIDs []int) (map[int][]string, error) {
+	result := map[int][]string{}
+	if len(cakeIDs) == 0 {
+		return result, nil
+	}
+	q, args := query.Select(CakeTagsName, "cake_id", "tag").
+		Where(query.In("cake_id", query.Ints(cakeIDs)...)).
+		OrderBy("tag ASC").
+		Build()
+	rows, err := storage.Using(ctx, i.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cakeID int
+			tag    string
+		)
+		if err := rows.Scan(&cakeID, &tag); err != nil {
+			return nil, err
+		}
+		result[cakeID] = append(result[cakeID], tag)
+	}
+	return result, rows.Err()
+}
+func (i repoImplementation) CakesInCategory(ctx context.Context, slug string) (query.IDs, error) {
+	return query.Subquery(query.Select(assignedCategories, CakeCategoriesName+".cake_id").
+		Where(query.Eq(TableName+".slug", Slugify(slug)))), nil
+}
+func (i repoImplementation) CakesTagged(ctx context.Context, tag string) (query.IDs, error) {
+	tags := NormalizeTags([]string{tag})
+	if len(tags) == 0 {
+		return query.IDs{List: []int{}}, nil
+	}
+	return query.Subquery(query.Select(CakeTagsName, "cake_id").Where(query.Eq("tag", tags[0]))), nil
+}
+
+// ints runs a query selecting a single integer column.
+func ints(ctx context.Context, db storage.Conn, q string, args []interface{}) ([]int, error) {
+	rows, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		result = append(result, id)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,19 @@
+package helpers
+
+import "errors"
+
+// Error kinds shared by the domain packages. Packages wrap them into their
+// own errors, e.g. fmt.Errorf("cake %w", helpers.ErrNotFound), and the HTTP
+// error handler maps each kind to a status code with errors.Is.
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrConflict   = errors.New("conflict")
+	ErrValidation = errors.New("validation failed")
+
+	ErrUnauthorized = errors.New("not authenticated")
+	ErrForbidden    = errors.New("forbidden")
+	ErrRateLimited  = errors.New("rate limited")
+
+	ErrPreconditionFailed   = errors.New("precondition failed")
+	ErrPreconditionRequired = errors.New("precondition required")
+)
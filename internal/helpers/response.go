@@ -1,11 +1,34 @@
 package helpers
 
-type JSONResponse struct {
-	Message string      `json:"message"`
-	Errors  interface{} `json:"errors,omitempty"`
+import "net/http"
+
+// MIMEApplicationProblemJSON is the media type of RFC 7807 problem details.
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// Problem is an RFC 7807 problem details document.
+type Problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	RequestID     string         `json:"request_id,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// InvalidParam describes a single request field that failed validation.
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
 }
 
-type ErrorObject struct {
-	Name    string `json:"name"`
-	Message string `json:"message"`
+// NewProblem returns a problem with the generic about:blank type, titled
+// after the status code.
+func NewProblem(status int, detail string) Problem {
+	return Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
 }
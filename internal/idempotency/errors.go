@@ -0,0 +1,19 @@
+package idempotency
+
+import (
+	"cake-store/internal/helpers"
+	"fmt"
+)
+
+var (
+	ErrConflict   = fmt.Errorf("idempotency key %w", helpers.ErrConflict)
+	ErrValidation = fmt.Errorf("idempotency key %w", helpers.ErrValidation)
+	ErrConfig     = fmt.Errorf("idempotency configuration %w", helpers.ErrValidation)
+
+	// ErrInFlight is returned for a retry of a request still being handled.
+	ErrInFlight = fmt.Errorf("%w: a request with this key is still in progress", ErrConflict)
+	// ErrMismatch is returned when a key is reused for a different request.
+	ErrMismatch = fmt.Errorf("%w: the key was already used with a different request body", ErrValidation)
+	// ErrInvalidKey is returned for an unusable Idempotency-Key header.
+	ErrInvalidKey = fmt.Errorf("%w: keys are 1 to 255 printable ASCII characters", ErrValidation)
+)
@@ -0,0 +1,75 @@
+package idempotency
+
+import (
+	"cake-store/internal/storage"
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryRepoImplementation struct {
+	mu      sync.Mutex
+	records map[string]Record
+}
+
+// NewMemoryRepository returns a RepoInterface that keeps records by key.
+// Reserve claims a key under a lock, so of two requests with the same key
+// only one runs.
+func NewMemoryRepository() RepoInterface {
+	return &memoryRepoImplementation{records: map[string]Record{}}
+}
+
+func copyRecord(record Record) Record {
+	if record.Body != nil {
+		record.Body = append([]byte{}, record.Body...)
+	}
+	return record
+}
+
+func (m *memoryRepoImplementation) Reserve(ctx context.Context, record Record, staleBefore time.Time) (*Record, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	record.CreatedAt, record.ExpiresAt, staleBefore = storage.TruncateTime(record.CreatedAt), storage.TruncateTime(record.ExpiresAt), storage.TruncateTime(staleBefore)
+	existing, ok := m.records[record.Key]
+	if ok && existing.ExpiresAt.After(record.CreatedAt) && !(existing.InFlight() && existing.CreatedAt.Before(staleBefore)) {
+		result := copyRecord(existing)
+		return &result, nil
+	}
+	record.Response = Response{}
+	m.records[record.Key] = record
+	return nil, nil
+}
+func (m *memoryRepoImplementation) Complete(ctx context.Context, key, owner string, response Response) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	record, ok := m.records[key]
+	if !ok || record.Owner != owner || !record.InFlight() {
+		return nil
+	}
+	record.Response = response
+	m.records[key] = copyRecord(record)
+	return nil
+}
+func (m *memoryRepoImplementation) Release(ctx context.Context, key, owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if record, ok := m.records[key]; ok && record.Owner == owner && record.InFlight() {
+		delete(m.records, key)
+	}
+	return nil
+}
+func (m *memoryRepoImplementation) Purge(ctx context.Context, now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now = storage.TruncateTime(now)
+	for key, record := range m.records {
+		if !record.ExpiresAt.After(now) {
+			delete(m.records, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package idempotency
+
+import (
+	"bytes"
+	"cake-store/internal/auth"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// purgeInterval is how often the middleware removes expired records.
+const purgeInterval = time.Hour
+
+// headerETag is replayed with the stored response, for conditional writes
+// that follow it.
+const headerETag = "ETag"
+
+// noStoreKey is the echo.Context key NoStore marks its routes with.
+const noStoreKey = "idempotency.noStore"
+
+// NoStore keeps the responses of a route out of the store, for routes
+// whose responses carry credentials such as tokens or API keys, which must
+// not be kept at rest. Retries of such a route run it again.
+func NoStore(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		ctx.Set(noStoreKey, true)
+		return next(ctx)
+	}
+}
+
+// Middleware makes POST and PATCH requests sent with an Idempotency-Key
+// header safe to retry. It must run after the authentication middlewares,
+// as keys are scoped to the client and the route. A response the handler
+// wrote itself with a status below 500 is stored for config.TTL and
+// replayed, marked with Idempotent-Replayed, to retries with the same
+// body; retries with another body get 422 and retries while the first
+// request is in progress 409. Requests that return an error, as the API's
+// 4xx responses are, or end in a 5xx release their key, so retries run the
+// handler again; so do the routes marked with NoStore.
+func Middleware(repo RepoInterface, config Config) echo.MiddlewareFunc {
+	var (
+		mu     sync.Mutex
+		purged time.Time
+	)
+	purge := func(ctx echo.Context, now time.Time) {
+		mu.Lock()
+		due := now.Sub(purged) >= purgeInterval
+		if due {
+			purged = now
+		}
+		mu.Unlock()
+		if !due {
+			return
+		}
+		if err := repo.Purge(context.TODO(), now); err != nil {
+			ctx.Logger().Warnf("idempotency keys: purging: %v", err)
+		}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			request := ctx.Request()
+			key := request.Header.Get(Header)
+			if key == "" || (request.Method != http.MethodPost && request.Method != http.MethodPatch) {
+				return next(ctx)
+			}
+			if !validKey(key) {
+				return ErrInvalidKey
+			}
+
+			body, err := io.ReadAll(request.Body)
+			if err != nil {
+				return err
+			}
+			request.Body = io.NopCloser(bytes.NewReader(body))
+
+			owner, err := newOwner()
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			purge(ctx, now)
+			record := Record{
+				Key:         hash(auth.ClientID(ctx), request.Method, request.URL.Path, key),
+				Fingerprint: hash(request.Header.Get(echo.HeaderContentType), string(body)),
+				Owner:       owner,
+				CreatedAt:   now,
+				ExpiresAt:   now.Add(config.TTL),
+			}
+			existing, err := repo.Reserve(context.TODO(), record, now.Add(-config.LockTimeout))
+			if err != nil {
+				return err
+			}
+			if existing != nil {
+				return replay(ctx, record, *existing)
+			}
+
+			recorder := &recorder{ResponseWriter: ctx.Response().Writer}
+			ctx.Response().Writer = recorder
+			err = next(ctx)
+			ctx.Response().Writer = recorder.ResponseWriter
+
+			status := ctx.Response().Status
+			noStore, _ := ctx.Get(noStoreKey).(bool)
+			if err != nil || noStore || !ctx.Response().Committed || status >= http.StatusInternalServerError {
+				if errRelease := repo.Release(context.TODO(), record.Key, record.Owner); errRelease != nil {
+					ctx.Logger().Warnf("idempotency keys: releasing: %v", errRelease)
+				}
+				return err
+			}
+			header := ctx.Response().Header()
+			response := Response{
+				StatusCode:  status,
+				ContentType: header.Get(echo.HeaderContentType),
+				Location:    header.Get(echo.HeaderLocation),
+				ETag:        header.Get(headerETag),
+				Body:        recorder.body.Bytes(),
+			}
+			if err := repo.Complete(context.TODO(), record.Key, record.Owner, response); err != nil {
+				ctx.Logger().Warnf("idempotency keys: storing the response: %v", err)
+			}
+			return nil
+		}
+	}
+}
+
+// replay answers a retry of the request existing was stored for.
+func replay(ctx echo.Context, record, existing Record) error {
+	if existing.Fingerprint != record.Fingerprint {
+		return ErrMismatch
+	}
+	if existing.InFlight() {
+		ctx.Response().Header().Set("Retry-After", "1")
+		return ErrInFlight
+	}
+	header := ctx.Response().Header()
+	header.Set(HeaderReplayed, strconv.FormatBool(true))
+	if existing.Location != "" {
+		header.Set(echo.HeaderLocation, existing.Location)
+	}
+	if existing.ETag != "" {
+		header.Set(headerETag, existing.ETag)
+	}
+	return ctx.Blob(existing.StatusCode, existing.ContentType, existing.Body)
+}
+
+// recorder keeps a copy of the response body.
+type recorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,121 @@
+// Package idempotency lets clients retry POST and PATCH requests safely
+// by sending an Idempotency-Key header: the first successful response for
+// a key is stored and replayed to the retries.
+package idempotency
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	// Header is the request header carrying the key.
+	Header = "Idempotency-Key"
+	// HeaderReplayed marks responses replayed from a stored one.
+	HeaderReplayed = "Idempotent-Replayed"
+	// maxKeyLength bounds the keys clients may send.
+	maxKeyLength = 255
+)
+
+// Record is a request made with a key and, once handled, its response.
+type Record struct {
+	// Key is the hash of the key, the client and the route.
+	Key string
+	// Fingerprint is the hash of the request body.
+	Fingerprint string
+	// Owner is a random token of the request that reserved the key; only
+	// it can complete or release the record, so a request whose key was
+	// taken over cannot overwrite the record of the one that took it.
+	Owner string
+	Response
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// Response is what is replayed. A StatusCode of 0 means the request is
+// still being handled.
+type Response struct {
+	StatusCode  int
+	ContentType string
+	Location    string
+	ETag        string
+	Body        []byte
+}
+
+// InFlight reports whether the request of the record is still being
+// handled.
+func (r Record) InFlight() bool {
+	return r.StatusCode == 0
+}
+
+var (
+	defaultTTL         = 24 * time.Hour
+	defaultLockTimeout = time.Minute
+)
+
+// Config sets how long keys are kept.
+type Config struct {
+	// TTL is how long a response is replayed for.
+	TTL time.Duration
+	// LockTimeout is how long a request may be in progress before a retry
+	// may take its key over, for requests cut off by a crash. It must be
+	// longer than the slowest handler takes, or retries run requests still
+	// in progress a second time.
+	LockTimeout time.Duration
+}
+
+// ConfigFromEnv reads IDEMPOTENCY_TTL, defaulting to 24 hours, and
+// IDEMPOTENCY_LOCK_TIMEOUT, defaulting to a minute.
+func ConfigFromEnv() (Config, error) {
+	config := Config{TTL: defaultTTL, LockTimeout: defaultLockTimeout}
+	if value := os.Getenv("IDEMPOTENCY_TTL"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			return config, fmt.Errorf("%w: IDEMPOTENCY_TTL must be a positive duration such as 24h", ErrConfig)
+		}
+		config.TTL = parsed
+	}
+	if value := os.Getenv("IDEMPOTENCY_LOCK_TIMEOUT"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			return config, fmt.Errorf("%w: IDEMPOTENCY_LOCK_TIMEOUT must be a positive duration such as 1m", ErrConfig)
+		}
+		config.LockTimeout = parsed
+	}
+	return config, nil
+}
+
+// newOwner returns a random owner token.
+func newOwner() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// validKey reports whether key is printable ASCII of a usable length.
+func validKey(key string) bool {
+	if key == "" || len(key) > maxKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < ' ' || key[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func hash(parts ...string) string {
+	sum := sha256.New()
+	for _, part := range parts {
+		// Length prefixes keep parts from running into each other.
+		fmt.Fprintf(sum, "%d:%s", len(part), part)
+	}
+	return hex.EncodeToString(sum.Sum(nil))
+}
@@ -0,0 +1,143 @@
+package idempotency
+
+//go:generate mockgen -destination=../../mocks/idempotency/mock_repository.go -package=mock_idempotency -source=repository.go
+
+import (
+	"cake-store/internal/query"
+	"cake-store/internal/storage"
+	"context"
+	"database/sql"
+	"time"
+)
+
+const TableName = "idempotency_keys"
+
+// Columns lists the idempotency_keys columns in the order scanned by
+// scanRecord.
+var Columns = []string{"key_hash", "fingerprint", "owner", "status_code", "content_type", "location", "etag", "body", "created_at", "expires_at"}
+
+// reserveAttempts bounds how often Reserve retries when the record it
+// found is released or taken over meanwhile.
+const reserveAttempts = 3
+
+type repoImplementation struct {
+	db *sql.DB
+}
+
+type RepoInterface interface {
+	// Reserve stores record as in flight and returns nil, unless another
+	// record has its key; then it returns that one. Expired records, and
+	// records in flight since before staleBefore, are taken over. Of
+	// concurrent reservations of a key only one succeeds.
+	Reserve(ctx context.Context, record Record, staleBefore time.Time) (*Record, error)
+	// Complete stores the response of the request in flight with key,
+	// unless another owner took the key over.
+	Complete(ctx context.Context, key, owner string, response Response) error
+	// Release removes the record of the request in flight with key, so it
+	// can be retried, unless another owner took the key over.
+	Release(ctx context.Context, key, owner string) error
+	// Purge removes the records expired at now.
+	Purge(ctx context.Context, now time.Time) error
+}
+
+// NewRepository returns a RepoInterface over the idempotency_keys table.
+// Reserve claims a key by inserting its row, so of two requests with the
+// same key only one runs.
+func NewRepository(db *sql.DB) RepoInterface {
+	return repoImplementation{db}
+}
+
+func scanRecord(scan func(dest ...interface{}) error) (record Record, err error) {
+	err = scan(&record.Key, &record.Fingerprint, &record.Owner, &record.StatusCode, &record.ContentType, &record.Location, &record.ETag, &record.Body, &record.CreatedAt, &record.ExpiresAt)
+	return
+}
+
+func (i repoImplementation) get(ctx context.Context, key string) (*Record, error) {
+	q, args := query.Select(TableName, Columns...).Where(query.Eq("key_hash", key)).Build()
+	result, err := scanRecord(i.db.QueryRowContext(ctx, q, args...).Scan)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (i repoImplementation) Reserve(ctx context.Context, record Record, staleBefore time.Time) (*Record, error) {
+	createdAt, expiresAt, staleBefore := storage.TruncateTime(record.CreatedAt), storage.TruncateTime(record.ExpiresAt), storage.TruncateTime(staleBefore)
+	for attempt := 0; attempt < reserveAttempts; attempt++ {
+		q, args := query.Insert(TableName).
+			Set("key_hash", record.Key).
+			Set("fingerprint", record.Fingerprint).
+			Set("owner", record.Owner).
+			Set("created_at", createdAt).
+			Set("expires_at", expiresAt).
+			Build()
+		_, err := i.db.ExecContext(ctx, q, args...)
+		if err == nil {
+			return nil, nil
+		}
+		if !storage.IsDuplicate(err) {
+			return nil, err
+		}
+
+		q, args = query.Update(TableName).
+			Set("fingerprint", record.Fingerprint).
+			Set("owner", record.Owner).
+			Set("status_code", 0).
+			Set("content_type", "").
+			Set("location", "").
+			Set("etag", "").
+			Set("body", nil).
+			Set("created_at", createdAt).
+			Set("expires_at", expiresAt).
+			Where(
+				query.Eq("key_hash", record.Key),
+				query.Expr("expires_at <= ? OR (status_code = 0 AND created_at < ?)", createdAt, staleBefore),
+			).
+			Build()
+		res, err := i.db.ExecContext(ctx, q, args...)
+		if err != nil {
+			return nil, err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if affected > 0 {
+			return nil, nil
+		}
+
+		existing, err := i.get(ctx, record.Key)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return existing, nil
+		}
+	}
+	return nil, ErrInFlight
+}
+func (i repoImplementation) Complete(ctx context.Context, key, owner string, response Response) error {
+	q, args := query.Update(TableName).
+		Set("status_code", response.StatusCode).
+		Set("content_type", response.ContentType).
+		Set("location", response.Location).
+		Set("etag", response.ETag).
+		Set("body", response.Body).
+		Where(query.Eq("key_hash", key), query.Eq("owner", owner), query.Eq("status_code", 0)).
+		Build()
+	_, err := i.db.ExecContext(ctx, q, args...)
+	return err
+}
+func (i repoImplementation) Release(ctx context.Context, key, owner string) error {
+	q, args := query.Delete(TableName).Where(query.Eq("key_hash", key), query.Eq("owner", owner), query.Eq("status_code", 0)).Build()
+	_, err := i.db.ExecContext(ctx, q, args...)
+	return err
+}
+func (i repoImplementation) Purge(ctx context.Context, now time.Time) error {
+	q, args := query.Delete(TableName).Where(query.Expr("expires_at <= ?", storage.TruncateTime(now))).Build()
+	_, err := i.db.ExecContext(ctx, q, args...)
+	return err
+}
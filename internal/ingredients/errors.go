@@ -0,0 +1,12 @@
+package ingredients
+
+import (
+	"cake-store/internal/helpers"
+	"fmt"
+)
+
+var (
+	ErrNotFound   = fmt.Errorf("ingredient %w", helpers.ErrNotFound)
+	ErrConflict   = fmt.Errorf("ingredient %w", helpers.ErrConflict)
+	ErrValidation = fmt.Errorf("ingredient %w", helpers.ErrValidation)
+)
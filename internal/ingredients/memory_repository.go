@@ -0,0 +1,231 @@
+package ingredients
+
+import (
+	"cake-store/internal/query"
+	"cake-store/internal/storage"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type memoryRepoImplementation struct {
+	mu          sync.RWMutex
+	ingredients map[int]Ingredient
+	nextID      int
+	// used maps cake IDs to the quantities of their ingredients.
+	used map[int][]QuantityDto
+}
+
+// NewMemoryRepository returns a RepoInterface that keeps ingredients by ID
+// and the quantities of them each cake uses.
+func NewMemoryRepository() RepoInterface {
+	return &memoryRepoImplementation{
+		ingredients: map[int]Ingredient{},
+		nextID:      1,
+		used:        map[int][]QuantityDto{},
+	}
+}
+
+func copyIngredient(ingredient Ingredient) Ingredient {
+	ingredient.Allergens = append([]string{}, ingredient.Allergens...)
+	if ingredient.UpdatedAt != nil {
+		updatedAt := *ingredient.UpdatedAt
+		ingredient.UpdatedAt = &updatedAt
+	}
+	return ingredient
+}
+
+// nameTaken reports whether another ingredient than id uses name. Names
+// compare case-insensitively, like the MySQL collation.
+func (m *memoryRepoImplementation) nameTaken(name string, id int) bool {
+	for _, ingredient := range m.ingredients {
+		if strings.EqualFold(ingredient.Name, name) && ingredient.ID != id {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *memoryRepoImplementation) List(ctx context.Context) ([]Ingredient, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := []Ingredient{}
+	for _, ingredient := range m.ingredients {
+		result = append(result, copyIngredient(ingredient))
+	}
+	sort.Slice(result, func(a, b int) bool {
+		if result[a].Name != result[b].Name {
+			return result[a].Name < result[b].Name
+		}
+		return result[a].ID < result[b].ID
+	})
+	return result, nil
+}
+func (m *memoryRepoImplementation) Get(ctx context.Context, id int) (*Ingredient, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ingredient, ok := m.ingredients[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	result := copyIngredient(ingredient)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Create(ctx context.Context, dto RequestDto) (*Ingredient, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.nameTaken(dto.Name, 0) {
+		return nil, ErrConflict
+	}
+	ingredient := Ingredient{
+		ID:        m.nextID,
+		Name:      dto.Name,
+		Allergens: normalizeAllergens(dto.Allergens),
+		Vegan:     dto.Vegan,
+		Halal:     dto.Halal,
+		CreatedAt: storage.TruncateTime(time.Now()),
+	}
+	m.ingredients[ingredient.ID] = ingredient
+	m.nextID++
+	result := copyIngredient(ingredient)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Update(ctx context.Context, dto UpdateRequestDto) (*Ingredient, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ingredient, ok := m.ingredients[dto.ID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if dto.Name != "" {
+		if m.nameTaken(dto.Name, dto.ID) {
+			return nil, ErrConflict
+		}
+		ingredient.Name = dto.Name
+	}
+	if dto.Allergens != nil {
+		ingredient.Allergens = normalizeAllergens(dto.Allergens)
+	}
+	if dto.Vegan != nil {
+		ingredient.Vegan = *dto.Vegan
+	}
+	if dto.Halal != nil {
+		ingredient.Halal = *dto.Halal
+	}
+	updatedAt := storage.TruncateTime(time.Now())
+	ingredient.UpdatedAt = &updatedAt
+	m.ingredients[dto.ID] = ingredient
+	result := copyIngredient(ingredient)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Delete(ctx context.Context, id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.ingredients[id]; !ok {
+		return ErrNotFound
+	}
+	used := 0
+	for _, quantities := range m.used {
+		for _, quantity := range quantities {
+			if quantity.IngredientID == id {
+				used++
+			}
+		}
+	}
+	if used > 0 {
+		return fmt.Errorf("%w: used by %d cakes", ErrConflict, used)
+	}
+	delete(m.ingredients, id)
+	return nil
+}
+
+func (m *memoryRepoImplementation) Check(ctx context.Context, quantities []QuantityDto) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.check(quantities)
+}
+
+func (m *memoryRepoImplementation) check(quantities []QuantityDto) error {
+	found := map[int]bool{}
+	for id := range m.ingredients {
+		found[id] = true
+	}
+	return checkFound(quantities, found)
+}
+
+func (m *memoryRepoImplementation) Assign(ctx context.Context, cakeID int, quantities []QuantityDto) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.check(quantities); err != nil {
+		return err
+	}
+	if len(quantities) == 0 {
+		delete(m.used, cakeID)
+		return nil
+	}
+	m.used[cakeID] = append([]QuantityDto{}, quantities...)
+	return nil
+}
+func (m *memoryRepoImplementation) ForCakes(ctx context.Context, cakeIDs []int) (map[int][]CakeIngredient, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := map[int][]CakeIngredient{}
+	for _, cakeID := range cakeIDs {
+		for _, quantity := range m.used[cakeID] {
+			ingredient := m.ingredients[quantity.IngredientID]
+			result[cakeID] = append(result[cakeID], CakeIngredient{
+				ID:        ingredient.ID,
+				Name:      ingredient.Name,
+				Quantity:  quantity.Quantity,
+				Unit:      quantity.Unit,
+				Allergens: append([]string{}, ingredient.Allergens...),
+				Vegan:     ingredient.Vegan,
+				Halal:     ingredient.Halal,
+			})
+		}
+		ingredients := result[cakeID]
+		sort.Slice(ingredients, func(a, b int) bool {
+			if ingredients[a].Name != ingredients[b].Name {
+				return ingredients[a].Name < ingredients[b].Name
+			}
+			return ingredients[a].ID < ingredients[b].ID
+		})
+	}
+	return result, nil
+}
+func (m *memoryRepoImplementation) CakesWithAllergens(ctx context.Context, allergens []string) (query.IDs, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := []int{}
+	for cakeID, quantities := range m.used {
+		if m.carriesAny(quantities, allergens) {
+			result = append(result, cakeID)
+		}
+	}
+	return query.IDs{List: result}, nil
+}
+
+func (m *memoryRepoImplementation) carriesAny(quantities []QuantityDto, allergens []string) bool {
+	for _, quantity := range quantities {
+		for _, carried := range m.ingredients[quantity.IngredientID].Allergens {
+			for _, allergen := range allergens {
+				if carried == allergen {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package ingredients
+
+import (
+	"sort"
+	"time"
+)
+
+// Allergens are the allergen flags an ingredient may carry, after the 14
+// allergens that must be declared on food sold in the EU and UK.
+var Allergens = []string{"celery", "crustaceans", "dairy", "egg", "fish", "gluten", "lupin", "molluscs", "mustard", "nuts", "peanuts", "sesame", "soy", "sulphites"}
+
+type (
+	Ingredient struct {
+		ID        int        `json:"id"`
+		Name      string     `json:"name"`
+		Allergens []string   `json:"allergens"`
+		Vegan     bool       `json:"vegan"`
+		Halal     bool       `json:"halal"`
+		CreatedAt time.Time  `json:"created_at"`
+		UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	}
+	// CakeIngredient is an ingredient in the amount used by a cake.
+	CakeIngredient struct {
+		ID        int      `json:"id"`
+		Name      string   `json:"name"`
+		Quantity  float64  `json:"quantity"`
+		Unit      string   `json:"unit"`
+		Allergens []string `json:"allergens"`
+		Vegan     bool     `json:"vegan"`
+		Halal     bool     `json:"halal"`
+	}
+	// Dietary is derived from the ingredients of a cake.
+	Dietary struct {
+		Allergens []string `json:"allergens"`
+		Vegan     bool     `json:"vegan"`
+		Halal     bool     `json:"halal"`
+	}
+	RequestDto struct {
+		Name      string   `json:"name" validate:"required,max=100"`
+		Allergens []string `json:"allergens" validate:"omitempty,dive,oneof=celery crustaceans dairy egg fish gluten lupin molluscs mustard nuts peanuts sesame soy sulphites" example:"gluten,dairy"`
+		Vegan     bool     `json:"vegan"`
+		Halal     bool     `json:"halal"`
+	}
+	UpdateRequestDto struct {
+		ID   int    `param:"id"`
+		Name string `json:"name" validate:"omitempty,max=100"`
+		// Allergens replaces the ingredient's when not nil.
+		Allergens []string `json:"allergens" validate:"omitempty,dive,oneof=celery crustaceans dairy egg fish gluten lupin molluscs mustard nuts peanuts sesame soy sulphites"`
+		Vegan     *bool    `json:"vegan"`
+		Halal     *bool    `json:"halal"`
+	}
+	// QuantityDto is the amount of an ingredient used by a cake.
+	QuantityDto struct {
+		IngredientID int     `json:"ingredient_id" validate:"required,gt=0"`
+		Quantity     float64 `json:"quantity" validate:"gt=0"`
+		Unit         string  `json:"unit" validate:"required,oneof=g kg ml l pcs tsp tbsp"`
+	}
+)
+
+// Summarize derives the dietary information of a cake from its
+// ingredients, or returns nil when none are recorded.
+func Summarize(ingredients []CakeIngredient) *Dietary {
+	if len(ingredients) == 0 {
+		return nil
+	}
+	dietary := Dietary{Vegan: true, Halal: true}
+	var allergens []string
+	for _, ingredient := range ingredients {
+		allergens = append(allergens, ingredient.Allergens...)
+		dietary.Vegan = dietary.Vegan && ingredient.Vegan
+		dietary.Halal = dietary.Halal && ingredient.Halal
+	}
+	dietary.Allergens = normalizeAllergens(allergens)
+	return &dietary
+}
+
+// normalizeAllergens drops duplicates and returns the allergens sorted.
+func normalizeAllergens(allergens []string) []string {
+	seen := map[string]bool{}
+	result := []string{}
+	for _, allergen := range allergens {
+		if !seen[allergen] {
+			seen[allergen] = true
+			result = append(result, allergen)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,381 @@
+package ingredients
+
+//go:generate mockgen -destination=../../mocks/ingredients/mock_repository.go -package=mock_ingredients -source=repository.go
+
+import (
+	"cake-store/internal/query"
+	"cake-store/internal/storage"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const (
+	TableName           = "ingredients"
+	AllergensName       = "ingredient_allergens"
+	CakeIngredientsName = "cake_ingredients"
+)
+
+// usedIngredients joins the ingredients to the cakes using them.
+const usedIngredients = CakeIngredientsName + " JOIN " + TableName + " ON " + TableName + ".id = " + CakeIngredientsName + ".ingredient_id"
+
+// Columns lists the ingredients columns in the order scanned by
+// scanIngredient.
+var Columns = []string{"id", "name", "vegan", "halal", "created_at", "updated_at"}
+
+type repoImplementation struct {
+	db *sql.DB
+	tx storage.Transactor
+}
+
+type RepoInterface interface {
+	// List returns every ingredient ordered by name.
+	List(ctx context.Context) ([]Ingredient, error)
+	Get(ctx context.Context, id int) (*Ingredient, error)
+	Create(ctx context.Context, dto RequestDto) (*Ingredient, error)
+	Update(ctx context.Context, dto UpdateRequestDto) (*Ingredient, error)
+	// Delete removes an ingredient, or returns ErrConflict while cakes use
+	// it so their allergens cannot silently disappear.
+	Delete(ctx context.Context, id int) error
+
+	// Check returns ErrValidation naming the first ingredient of quantities
+	// that does not exist.
+	Check(ctx context.Context, quantities []QuantityDto) error
+	// Assign replaces the ingredients of a cake.
+	Assign(ctx context.Context, cakeID int, quantities []QuantityDto) error
+	// ForCakes returns the ingredients of each of the cakes, ordered by name.
+	ForCakes(ctx context.Context, cakeIDs []int) (map[int][]CakeIngredient, error)
+	// CakesWithAllergens selects the IDs of the cakes with an ingredient
+	// carrying any of the allergens.
+	CakesWithAllergens(ctx context.Context, allergens []string) (query.IDs, error)
+}
+
+// NewRepository returns a RepoInterface over the ingredients, their
+// allergens and the cake_ingredients using them.
+func NewRepository(db *sql.DB) RepoInterface {
+	return repoImplementation{db, storage.NewTransactor(db)}
+}
+
+func scanIngredient(scan func(dest ...interface{}) error) (ingredient Ingredient, err error) {
+	err = scan(&ingredient.ID, &ingredient.Name, &ingredient.Vegan, &ingredient.Halal, &ingredient.CreatedAt, &ingredient.UpdatedAt)
+	return
+}
+
+// allergens returns the sorted allergens of each of the ingredients.
+func allergens(ctx context.Context, db storage.Conn, ids []int) (map[int][]string, error) {
+	result := map[int][]string{}
+	if len(ids) == 0 {
+		return result, nil
+	}
+	q, args := query.Select(AllergensName, "ingredient_id", "allergen").
+		Where(query.In("ingredient_id", query.Ints(ids)...)).
+		OrderBy("allergen ASC").
+		Build()
+	rows, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id       int
+			allergen string
+		)
+		if err := rows.Scan(&id, &allergen); err != nil {
+			return nil, err
+		}
+		result[id] = append(result[id], allergen)
+	}
+	return result, rows.Err()
+}
+
+// withAllergens fills in the allergens of ingredients.
+func (i repoImplementation) withAllergens(ctx context.Context, ingredients []Ingredient) error {
+	ids := make([]int, len(ingredients))
+	for n, ingredient := range ingredients {
+		ids[n] = ingredient.ID
+	}
+	byID, err := allergens(ctx, storage.Using(ctx, i.db), ids)
+	if err != nil {
+		return err
+	}
+	for n := range ingredients {
+		ingredients[n].Allergens = append([]string{}, byID[ingredients[n].ID]...)
+	}
+	return nil
+}
+
+// setAllergens replaces the allergens of an ingredient.
+func setAllergens(ctx context.Context, tx storage.Conn, id int, list []string) error {
+	q, args := query.Delete(AllergensName).Where(query.Eq("ingredient_id", id)).Build()
+	if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+		return err
+	}
+	for _, allergen := range normalizeAllergens(list) {
+		q, args = query.Insert(AllergensName).Set("ingredient_id", id).Set("allergen", allergen).Build()
+		if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i repoImplementation) List(ctx context.Context) ([]Ingredient, error) {
+	q, args := query.Select(TableName, Columns...).OrderBy("name ASC", "id ASC").Build()
+	rows, err := storage.Using(ctx, i.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []Ingredient{}
+	for rows.Next() {
+		ingredient, err := scanIngredient(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, ingredient)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+	return result, i.withAllergens(ctx, result)
+}
+func (i repoImplementation) Get(ctx context.Context, id int) (*Ingredient, error) {
+	q, args := query.Select(TableName, Columns...).Where(query.Eq("id", id)).Build()
+	ingredient, err := scanIngredient(storage.Using(ctx, i.db).QueryRowContext(ctx, q, args...).Scan)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := []Ingredient{ingredient}
+	if err = i.withAllergens(ctx, result); err != nil {
+		return nil, err
+	}
+	return &result[0], nil
+}
+func (i repoImplementation) Create(ctx context.Context, dto RequestDto) (*Ingredient, error) {
+	var id int64
+	err := i.tx.InTx(ctx, func(ctx context.Context) error {
+		tx := storage.Using(ctx, i.db)
+		q, args := query.Insert(TableName).
+			Set("name", dto.Name).
+			Set("vegan", dto.Vegan).
+			Set("halal", dto.Halal).
+			Set("created_at", storage.TruncateTime(time.Now())).
+			Build()
+		res, err := tx.ExecContext(ctx, q, args...)
+		if storage.IsDuplicate(err) {
+			return ErrConflict
+		}
+		if err != nil {
+			return err
+		}
+		if id, err = res.LastInsertId(); err != nil {
+			return err
+		}
+		return setAllergens(ctx, tx, int(id), dto.Allergens)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return i.Get(ctx, int(id))
+}
+func (i repoImplementation) Update(ctx context.Context, dto UpdateRequestDto) (*Ingredient, error) {
+	err := i.tx.InTx(ctx, func(ctx context.Context) error {
+		tx := storage.Using(ctx, i.db)
+		var exists int
+		q, args := query.Select(TableName).Where(query.Eq("id", dto.ID)).Count()
+		if err := tx.QueryRowContext(ctx, q, args...).Scan(&exists); err != nil {
+			return err
+		}
+		if exists == 0 {
+			return ErrNotFound
+		}
+
+		builder := query.Update(TableName).Set("updated_at", storage.TruncateTime(time.Now()))
+		if dto.Name != "" {
+			builder.Set("name", dto.Name)
+		}
+		if dto.Vegan != nil {
+			builder.Set("vegan", *dto.Vegan)
+		}
+		if dto.Halal != nil {
+			builder.Set("halal", *dto.Halal)
+		}
+
+		q, args = builder.Where(query.Eq("id", dto.ID)).Build()
+		_, err := tx.ExecContext(ctx, q, args...)
+		if storage.IsDuplicate(err) {
+			return ErrConflict
+		}
+		if err != nil || dto.Allergens == nil {
+			return err
+		}
+		return setAllergens(ctx, tx, dto.ID, dto.Allergens)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return i.Get(ctx, dto.ID)
+}
+func (i repoImplementation) Delete(ctx context.Context, id int) error {
+	return i.tx.InTx(ctx, func(ctx context.Context) error {
+		tx := storage.Using(ctx, i.db)
+		var used int
+		q, args := query.Select(CakeIngredientsName).Where(query.Eq("ingredient_id", id)).Count()
+		if err := tx.QueryRowContext(ctx, q, args...).Scan(&used); err != nil {
+			return err
+		}
+		if used > 0 {
+			return fmt.Errorf("%w: used by %d cakes", ErrConflict, used)
+		}
+		q, args = query.Delete(AllergensName).Where(query.Eq("ingredient_id", id)).Build()
+		if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+			return err
+		}
+		q, args = query.Delete(TableName).Where(query.Eq("id", id)).Build()
+		res, err := tx.ExecContext(ctx, q, args...)
+		if err != nil {
+			return err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+}
+
+func (i repoImplementation) Check(ctx context.Context, quantities []QuantityDto) error {
+	return check(ctx, storage.Using(ctx, i.db), quantities)
+}
+
+func check(ctx context.Context, db storage.Conn, quantities []QuantityDto) error {
+	if len(quantities) == 0 {
+		return nil
+	}
+	ids := make([]int, len(quantities))
+	for n, quantity := range quantities {
+		ids[n] = quantity.IngredientID
+	}
+	q, args := query.Select(TableName, "id").Where(query.In("id", query.Ints(ids)...)).Build()
+	rows, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	found := map[int]bool{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+		found[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return checkFound(quantities, found)
+}
+
+// checkFound returns ErrValidation naming the first ingredient of
+// quantities missing from found, or listed twice.
+func checkFound(quantities []QuantityDto, found map[int]bool) error {
+	seen := map[int]bool{}
+	for _, quantity := range quantities {
+		if !found[quantity.IngredientID] {
+			return fmt.Errorf("%w: unknown ingredient %d", ErrValidation, quantity.IngredientID)
+		}
+		if seen[quantity.IngredientID] {
+			return fmt.Errorf("%w: ingredient %d listed twice", ErrValidation, quantity.IngredientID)
+		}
+		seen[quantity.IngredientID] = true
+	}
+	return nil
+}
+
+func (i repoImplementation) Assign(ctx context.Context, cakeID int, quantities []QuantityDto) error {
+	return i.tx.InTx(ctx, func(ctx context.Context) error {
+		tx := storage.Using(ctx, i.db)
+		if err := check(ctx, tx, quantities); err != nil {
+			return err
+		}
+		q, args := query.Delete(CakeIngredientsName).Where(query.Eq("cake_id", cakeID)).Build()
+		if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+			return err
+		}
+		for _, quantity := range quantities {
+			q, args = query.Insert(CakeIngredientsName).
+				Set("cake_id", cakeID).
+				Set("ingredient_id", quantity.IngredientID).
+				Set("quantity", quantity.Quantity).
+				Set("unit", quantity.Unit).
+				Build()
+			if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+func (i repoImplementation) ForCakes(ctx context.Context, cakeIDs []int) (map[int][]CakeIngredient, error) {
+	result := map[int][]CakeIngredient{}
+	if len(cakeIDs) == 0 {
+		return result, nil
+	}
+	q, args := query.Select(usedIngredients, "cake_id", "id", "name", "quantity", "unit", "vegan", "halal").
+		Where(query.In("cake_id", query.Ints(cakeIDs)...)).
+		OrderBy("name ASC", "id ASC").
+		Build()
+	rows, err := storage.Using(ctx, i.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var (
+			cakeID     int
+			ingredient CakeIngredient
+		)
+		if err := rows.Scan(&cakeID, &ingredient.ID, &ingredient.Name, &ingredient.Quantity, &ingredient.Unit, &ingredient.Vegan, &ingredient.Halal); err != nil {
+			return nil, err
+		}
+		result[cakeID] = append(result[cakeID], ingredient)
+		ids = append(ids, ingredient.ID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	byID, err := allergens(ctx, storage.Using(ctx, i.db), ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, ingredients := range result {
+		for n := range ingredients {
+			ingredients[n].Allergens = append([]string{}, byID[ingredients[n].ID]...)
+		}
+	}
+	return result, nil
+}
+func (i repoImplementation) CakesWithAllergens(ctx context.Context, list []string) (query.IDs, error) {
+	values := make([]interface{}, len(list))
+	for n, allergen := range list {
+		values[n] = allergen
+	}
+	return query.Subquery(query.Select(CakeIngredientsName+" JOIN "+AllergensName+" ON "+AllergensName+".ingredient_id = "+CakeIngredientsName+".ingredient_id", CakeIngredientsName+".cake_id").
+		Where(query.In(AllergensName+".allergen", values...))), nil
+}
@@ -0,0 +1,17 @@
+package inventory
+
+import (
+	"cake-store/internal/helpers"
+	"fmt"
+)
+
+var (
+	ErrNotFound   = fmt.Errorf("stock %w", helpers.ErrNotFound)
+	ErrConflict   = fmt.Errorf("stock %w", helpers.ErrConflict)
+	ErrValidation = fmt.Errorf("stock %w", helpers.ErrValidation)
+
+	ErrReservationNotFound = fmt.Errorf("reservation %w", helpers.ErrNotFound)
+	// ErrInsufficient is returned when a reservation asks for more than is
+	// available.
+	ErrInsufficient = fmt.Errorf("%w: not enough stock", ErrConflict)
+)
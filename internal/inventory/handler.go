@@ -0,0 +1,261 @@
+package inventory
+
+import (
+	"cake-store/internal/variants"
+	"context"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+)
+
+type SvcInterface interface {
+	List(ctx echo.Context) error
+	Get(ctx echo.Context) error
+	Set(ctx echo.Context) error
+	Reserve(ctx echo.Context) error
+	GetReservation(ctx echo.Context) error
+	Release(ctx echo.Context) error
+	Commit(ctx echo.Context) error
+}
+
+type svcImplementation struct {
+	repo     RepoInterface
+	variants variants.RepoInterface
+}
+
+// NewHandler returns the stock and reservation handler, checking variants
+// belong to their cake through variantsRepo.
+func NewHandler(repo RepoInterface, variantsRepo variants.RepoInterface) SvcInterface {
+	return svcImplementation{repo, variantsRepo}
+}
+
+// reservationID parses the reservation id from the path.
+func reservationID(ctx echo.Context) (int, error) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return 0, echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid id")
+	}
+	return id, nil
+}
+
+// List godoc
+// @Summary List stock levels
+// @Description This endpoint for get the stock of the variants, optionally of a day or only the low ones
+// @Tags Inventory
+// @Accept  json
+// @Produce  json
+// @Param services query ListRequestDto true "Find query"
+// @Success 200 {array} Stock
+// @Failure 422 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /stock [get]
+func (s svcImplementation) List(ctx echo.Context) error {
+	request := ListRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	res, err := s.repo.List(context.TODO(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// Get godoc
+// @Summary Get stock of variant
+// @Description This endpoint for get the stock of a cake variant for a day
+// @Tags Inventory
+// @Accept  json
+// @Produce  json
+// @Param id path string true "cake id"
+// @Param variant_id path string true "variant id"
+// @Param day path string true "day" example(2026-10-18)
+// @Success 200 {object} Stock
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /cakes/{id}/variants/{variant_id}/stock/{day} [get]
+func (s svcImplementation) Get(ctx echo.Context) error {
+	cakeID, errCake := strconv.Atoi(ctx.Param("id"))
+	variantID, errVariant := strconv.Atoi(ctx.Param("variant_id"))
+	if errCake != nil || errVariant != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid id")
+	}
+
+	if _, err := s.variants.Get(context.TODO(), cakeID, variantID); err != nil {
+		return err
+	}
+
+	data, err := s.repo.Get(context.TODO(), variantID, ctx.Param("day"))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, data)
+}
+
+// Set godoc
+// @Summary Set stock of variant
+// @Description This endpoint for setting how many units of a cake variant were baked for a day
+// @Tags Inventory
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path string true "cake id"
+// @Param variant_id path string true "variant id"
+// @Param day path string true "day" example(2026-10-18)
+// @Param Request body SetRequestDto true "Set stock"
+// @Success 200 {object} Stock
+// @Failure 404 {object} helpers.Problem
+// @Failure 409 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /cakes/{id}/variants/{variant_id}/stock/{day} [put]
+func (s svcImplementation) Set(ctx echo.Context) error {
+	request := SetRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	if _, err := s.variants.Get(context.TODO(), request.CakeID, request.VariantID); err != nil {
+		return err
+	}
+
+	data, err := s.repo.Set(context.TODO(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, data)
+}
+
+// Reserve godoc
+// @Summary Reserve stock
+// @Description This endpoint for holding units of a day's stock until they are committed or released; a variant with no stock set for the day is refused with 404
+// @Tags Inventory
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param Request body ReserveRequestDto true "Reserve stock"
+// @Success 201 {object} Reservation
+// @Header 201 {string} Location "/reservations/{id}"
+// @Failure 404 {object} helpers.Problem
+// @Failure 409 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /reservations [post]
+func (s svcImplementation) Reserve(ctx echo.Context) error {
+	request := ReserveRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	created, err := s.repo.Reserve(context.TODO(), request)
+	if err != nil {
+		return err
+	}
+	ctx.Response().Header().Set(echo.HeaderLocation, "/reservations/"+strconv.Itoa(created.ID))
+	return ctx.JSON(http.StatusCreated, created)
+}
+
+// GetReservation godoc
+// @Summary Get detail of reservation
+// @Description This endpoint for get detail of a stock reservation
+// @Tags Inventory
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path string true "reservation id"
+// @Success 200 {object} Reservation
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /reservations/{id} [get]
+func (s svcImplementation) GetReservation(ctx echo.Context) error {
+	id, err := reservationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := s.repo.GetReservation(context.TODO(), id)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, data)
+}
+
+// Release godoc
+// @Summary Release reservation
+// @Description This endpoint for returning the units of a held reservation to the stock
+// @Tags Inventory
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path string true "reservation id"
+// @Success 200 {object} Reservation
+// @Failure 404 {object} helpers.Problem
+// @Failure 409 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /reservations/{id}/release [post]
+func (s svcImplementation) Release(ctx echo.Context) error {
+	id, err := reservationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := s.repo.Release(context.TODO(), id)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, data)
+}
+
+// Commit godoc
+// @Summary Commit reservation
+// @Description This endpoint for taking the units of a held reservation off the stock once sold
+// @Tags Inventory
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path string true "reservation id"
+// @Success 200 {object} Reservation
+// @Failure 404 {object} helpers.Problem
+// @Failure 409 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /reservations/{id}/commit [post]
+func (s svcImplementation) Commit(ctx echo.Context) error {
+	id, err := reservationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := s.repo.Commit(context.TODO(), id)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, data)
+}
@@ -0,0 +1,231 @@
+package inventory
+
+import (
+	"cake-store/internal/query"
+	"cake-store/internal/storage"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// stockKey identifies the stock of a variant for a day.
+type stockKey struct {
+	variantID int
+	day       string
+}
+
+type memoryRepoImplementation struct {
+	mu              sync.RWMutex
+	stock           map[stockKey]Stock
+	reservations    map[int]Reservation
+	nextID          int
+	nextReservation int
+}
+
+// NewMemoryRepository returns a RepoInterface that keeps stock by variant
+// and day, and reservations by ID. Reservations are checked against the
+// units available under the same lock that takes them.
+func NewMemoryRepository() RepoInterface {
+	return &memoryRepoImplementation{
+		stock:           map[stockKey]Stock{},
+		reservations:    map[int]Reservation{},
+		nextID:          1,
+		nextReservation: 1,
+	}
+}
+
+func copyStock(stock Stock) Stock {
+	if stock.UpdatedAt != nil {
+		updatedAt := *stock.UpdatedAt
+		stock.UpdatedAt = &updatedAt
+	}
+	stock.derive()
+	return stock
+}
+
+func copyReservation(reservation Reservation) Reservation {
+	if reservation.UpdatedAt != nil {
+		updatedAt := *reservation.UpdatedAt
+		reservation.UpdatedAt = &updatedAt
+	}
+	return reservation
+}
+
+// save stores stock as its next version.
+func (m *memoryRepoImplementation) save(stock Stock) {
+	updatedAt := storage.TruncateTime(time.Now())
+	stock.UpdatedAt = &updatedAt
+	stock.Version++
+	m.stock[stockKey{stock.VariantID, stock.Day}] = stock
+}
+
+func (m *memoryRepoImplementation) List(ctx context.Context, dto ListRequestDto) ([]Stock, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := []Stock{}
+	for _, stock := range m.stock {
+		stock = copyStock(stock)
+		if dto.Day != "" && stock.Day != dto.Day {
+			continue
+		}
+		if dto.VariantID != 0 && stock.VariantID != dto.VariantID {
+			continue
+		}
+		if dto.LowStock != nil && stock.LowStock != *dto.LowStock {
+			continue
+		}
+		result = append(result, stock)
+	}
+	sort.Slice(result, func(a, b int) bool {
+		if result[a].Day != result[b].Day {
+			return result[a].Day < result[b].Day
+		}
+		return result[a].VariantID < result[b].VariantID
+	})
+	return result, nil
+}
+func (m *memoryRepoImplementation) Get(ctx context.Context, variantID int, day string) (*Stock, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stock, ok := m.stock[stockKey{variantID, day}]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	result := copyStock(stock)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Set(ctx context.Context, dto SetRequestDto) (*Stock, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := stockKey{dto.VariantID, dto.Day}
+	stock, ok := m.stock[key]
+	if !ok {
+		stock = Stock{
+			ID:        m.nextID,
+			VariantID: dto.VariantID,
+			CakeID:    dto.CakeID,
+			Day:       dto.Day,
+			CreatedAt: storage.TruncateTime(time.Now()),
+		}
+		m.nextID++
+	}
+	if dto.OnHand < stock.Reserved {
+		return nil, fmt.Errorf("%w: %d units are reserved", ErrConflict, stock.Reserved)
+	}
+	stock.OnHand = dto.OnHand
+	if dto.LowStockThreshold != nil {
+		stock.LowStockThreshold = *dto.LowStockThreshold
+	}
+	if ok {
+		m.save(stock)
+	} else {
+		stock.Version = 1
+		m.stock[key] = stock
+	}
+	result := copyStock(m.stock[key])
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Reserve(ctx context.Context, dto ReserveRequestDto) (*Reservation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stock, ok := m.stock[stockKey{dto.VariantID, dto.Day}]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if stock.OnHand-stock.Reserved < dto.Quantity {
+		return nil, ErrInsufficient
+	}
+	stock.Reserved += dto.Quantity
+	m.save(stock)
+
+	reservation := Reservation{
+		ID:        m.nextReservation,
+		VariantID: dto.VariantID,
+		Day:       dto.Day,
+		Quantity:  dto.Quantity,
+		Status:    StatusHeld,
+		CreatedAt: storage.TruncateTime(time.Now()),
+	}
+	m.reservations[reservation.ID] = reservation
+	m.nextReservation++
+	result := copyReservation(reservation)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) GetReservation(ctx context.Context, id int) (*Reservation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	reservation, ok := m.reservations[id]
+	if !ok {
+		return nil, ErrReservationNotFound
+	}
+	result := copyReservation(reservation)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Release(ctx context.Context, id int) (*Reservation, error) {
+	return m.settle(id, StatusReleased)
+}
+func (m *memoryRepoImplementation) Commit(ctx context.Context, id int) (*Reservation, error) {
+	return m.settle(id, StatusCommitted)
+}
+func (m *memoryRepoImplementation) settle(id int, status string) (*Reservation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	reservation, ok := m.reservations[id]
+	if !ok {
+		return nil, ErrReservationNotFound
+	}
+	if reservation.Status != StatusHeld {
+		return nil, fmt.Errorf("%w: reservation is already %s", ErrConflict, reservation.Status)
+	}
+	stock, ok := m.stock[stockKey{reservation.VariantID, reservation.Day}]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	stock.Reserved -= reservation.Quantity
+	if status == StatusCommitted {
+		stock.OnHand -= reservation.Quantity
+	}
+	m.save(stock)
+
+	updatedAt := storage.TruncateTime(time.Now())
+	reservation.Status = status
+	reservation.UpdatedAt = &updatedAt
+	m.reservations[id] = reservation
+	result := copyReservation(reservation)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) CakesInStock(ctx context.Context, day string) (query.IDs, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	seen := map[int]bool{}
+	result := []int{}
+	for _, stock := range m.stock {
+		if stock.Day == day && stock.OnHand-stock.Reserved > 0 && !seen[stock.CakeID] {
+			seen[stock.CakeID] = true
+			result = append(result, stock.CakeID)
+		}
+	}
+	return query.IDs{List: result}, nil
+}
+func (m *memoryRepoImplementation) InStock(ctx context.Context, day string, cakeIDs []int) ([]int, error) {
+	inStock, err := m.CakesInStock(ctx, day)
+	if err != nil {
+		return nil, err
+	}
+	result := []int{}
+	for _, id := range cakeIDs {
+		if inStock.Contains(id) {
+			result = append(result, id)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,72 @@
+package inventory
+
+import "time"
+
+// DayLayout is the format of the baking days stock is tracked for.
+const DayLayout = "2006-01-02"
+
+// Reservation statuses. Only held reservations can be released or
+// committed.
+const (
+	StatusHeld      = "held"
+	StatusReleased  = "released"
+	StatusCommitted = "committed"
+)
+
+type (
+	// Stock is what was baked of a cake variant for a day. Reserved units
+	// are held for unfinished orders and are not available.
+	Stock struct {
+		ID                int        `json:"id"`
+		VariantID         int        `json:"variant_id"`
+		CakeID            int        `json:"cake_id"`
+		Day               string     `json:"day" example:"2026-10-18"`
+		OnHand            int        `json:"on_hand"`
+		Reserved          int        `json:"reserved"`
+		Available         int        `json:"available"`
+		LowStockThreshold int        `json:"low_stock_threshold"`
+		LowStock          bool       `json:"low_stock"`
+		Version           int        `json:"version"`
+		CreatedAt         time.Time  `json:"created_at"`
+		UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+	}
+	Reservation struct {
+		ID        int        `json:"id"`
+		VariantID int        `json:"variant_id"`
+		Day       string     `json:"day" example:"2026-10-18"`
+		Quantity  int        `json:"quantity"`
+		Status    string     `json:"status" example:"held"`
+		CreatedAt time.Time  `json:"created_at"`
+		UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	}
+	ListRequestDto struct {
+		Day       string `query:"day" json:"day" validate:"omitempty,datetime=2006-01-02" example:"2026-10-18"`
+		VariantID int    `query:"variant_id" json:"variant_id" validate:"omitempty,gt=0"`
+		LowStock  *bool  `query:"low_stock" json:"low_stock"`
+	}
+	// SetRequestDto sets the stock of a variant for a day, creating it when
+	// the day has none yet.
+	SetRequestDto struct {
+		CakeID            int    `param:"id" json:"-" swaggerignore:"true"`
+		VariantID         int    `param:"variant_id" json:"-" swaggerignore:"true"`
+		Day               string `param:"day" json:"-" swaggerignore:"true" validate:"datetime=2006-01-02"`
+		OnHand            int    `json:"on_hand" validate:"gte=0"`
+		LowStockThreshold *int   `json:"low_stock_threshold" validate:"omitempty,gte=0"`
+	}
+	ReserveRequestDto struct {
+		VariantID int    `json:"variant_id" validate:"required,gt=0"`
+		Day       string `json:"day" validate:"required,datetime=2006-01-02" example:"2026-10-18"`
+		Quantity  int    `json:"quantity" validate:"required,gt=0"`
+	}
+)
+
+// derive fills the fields computed from the stored counts.
+func (s *Stock) derive() {
+	s.Available = s.OnHand - s.Reserved
+	s.LowStock = s.Available <= s.LowStockThreshold
+}
+
+// Today returns the current baking day.
+func Today() string {
+	return time.Now().UTC().Format(DayLayout)
+}
@@ -0,0 +1,311 @@
+package inventory
+
+//go:generate mockgen -destination=../../mocks/inventory/mock_repository.go -package=mock_inventory -source=repository.go
+
+import (
+	"cake-store/internal/query"
+	"cake-store/internal/storage"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const (
+	TableName        = "stock_levels"
+	ReservationsName = "stock_reservations"
+)
+
+// Columns lists the stock_levels columns in the order scanned by scanStock.
+var Columns = []string{"id", "variant_id", "cake_id", "day", "on_hand", "reserved", "low_stock_threshold", "version", "created_at", "updated_at"}
+
+// ReservationColumns lists the stock_reservations columns in the order
+// scanned by scanReservation.
+var ReservationColumns = []string{"id", "variant_id", "day", "quantity", "status", "created_at", "updated_at"}
+
+type repoImplementation struct {
+	db *sql.DB
+	tx storage.Transactor
+}
+
+type RepoInterface interface {
+	// List returns stock levels ordered by day and variant.
+	List(ctx context.Context, dto ListRequestDto) ([]Stock, error)
+	Get(ctx context.Context, variantID int, day string) (*Stock, error)
+	// Set sets the units on hand of a variant for a day, or returns
+	// ErrConflict when fewer than the reserved units would be left.
+	Set(ctx context.Context, dto SetRequestDto) (*Stock, error)
+
+	// Reserve holds units of a day's stock, or returns ErrNotFound when
+	// the variant has no stock for the day and ErrInsufficient when fewer
+	// units are available.
+	Reserve(ctx context.Context, dto ReserveRequestDto) (*Reservation, error)
+	GetReservation(ctx context.Context, id int) (*Reservation, error)
+	// Release returns the units of a held reservation to the stock.
+	Release(ctx context.Context, id int) (*Reservation, error)
+	// Commit takes the units of a held reservation off the stock once they
+	// are sold.
+	Commit(ctx context.Context, id int) (*Reservation, error)
+
+	// CakesInStock selects the IDs of the cakes with units available on day.
+	CakesInStock(ctx context.Context, day string) (query.IDs, error)
+	// InStock returns the IDs of those of the cakes with units available on
+	// day.
+	InStock(ctx context.Context, day string, cakeIDs []int) ([]int, error)
+}
+
+// NewRepository returns a RepoInterface over stock_levels and the
+// stock_reservations holding units of them. Writes change the counts of a
+// stock level in a single update conditioned on the units available, so
+// concurrent reservations never overbook and never fail while units are
+// left.
+func NewRepository(db *sql.DB) RepoInterface {
+	return repoImplementation{db, storage.NewTransactor(db)}
+}
+
+func scanStock(scan func(dest ...interface{}) error) (stock Stock, err error) {
+	err = scan(&stock.ID, &stock.VariantID, &stock.CakeID, &stock.Day, &stock.OnHand, &stock.Reserved, &stock.LowStockThreshold, &stock.Version, &stock.CreatedAt, &stock.UpdatedAt)
+	stock.derive()
+	return
+}
+
+func scanReservation(scan func(dest ...interface{}) error) (reservation Reservation, err error) {
+	err = scan(&reservation.ID, &reservation.VariantID, &reservation.Day, &reservation.Quantity, &reservation.Status, &reservation.CreatedAt, &reservation.UpdatedAt)
+	return
+}
+
+func getStock(ctx context.Context, db storage.Conn, variantID int, day string) (*Stock, error) {
+	q, args := query.Select(TableName, Columns...).Where(query.Eq("variant_id", variantID), query.Eq("day", day)).Build()
+	result, err := scanStock(db.QueryRowContext(ctx, q, args...).Scan)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func getReservation(ctx context.Context, db storage.Conn, id int) (*Reservation, error) {
+	q, args := query.Select(ReservationsName, ReservationColumns...).Where(query.Eq("id", id)).Build()
+	result, err := scanReservation(db.QueryRowContext(ctx, q, args...).Scan)
+	if err == sql.ErrNoRows {
+		return nil, ErrReservationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// affected returns errNone when res changed no rows.
+func affected(res sql.Result, errNone error) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errNone
+	}
+	return nil
+}
+
+func (i repoImplementation) List(ctx context.Context, dto ListRequestDto) ([]Stock, error) {
+	builder := query.Select(TableName, Columns...)
+	if dto.Day != "" {
+		builder.Where(query.Eq("day", dto.Day))
+	}
+	if dto.VariantID != 0 {
+		builder.Where(query.Eq("variant_id", dto.VariantID))
+	}
+	if dto.LowStock != nil {
+		if *dto.LowStock {
+			builder.Where(query.Expr("on_hand - reserved <= low_stock_threshold"))
+		} else {
+			builder.Where(query.Expr("on_hand - reserved > low_stock_threshold"))
+		}
+	}
+	q, args := builder.OrderBy("day ASC", "variant_id ASC").Build()
+	rows, err := storage.Using(ctx, i.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []Stock{}
+	for rows.Next() {
+		stock, err := scanStock(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, stock)
+	}
+	return result, rows.Err()
+}
+func (i repoImplementation) Get(ctx context.Context, variantID int, day string) (*Stock, error) {
+	return getStock(ctx, storage.Using(ctx, i.db), variantID, day)
+}
+func (i repoImplementation) Set(ctx context.Context, dto SetRequestDto) (*Stock, error) {
+	builder := query.Insert(TableName).
+		Set("variant_id", dto.VariantID).
+		Set("cake_id", dto.CakeID).
+		Set("day", dto.Day).
+		Set("on_hand", dto.OnHand).
+		Set("created_at", storage.TruncateTime(time.Now()))
+	if dto.LowStockThreshold != nil {
+		builder.Set("low_stock_threshold", *dto.LowStockThreshold)
+	}
+	q, args := builder.Build()
+	_, err := storage.Using(ctx, i.db).ExecContext(ctx, q, args...)
+	if err == nil {
+		return i.Get(ctx, dto.VariantID, dto.Day)
+	}
+	if !storage.IsDuplicate(err) {
+		return nil, err
+	}
+
+	// The stock level exists: it may not drop below the units reserved,
+	// checked against the row as the update locks it.
+	update := query.Update(TableName).
+		Set("on_hand", dto.OnHand).
+		SetExpr("version", "version + 1").
+		Set("updated_at", storage.TruncateTime(time.Now()))
+	if dto.LowStockThreshold != nil {
+		update.Set("low_stock_threshold", *dto.LowStockThreshold)
+	}
+	q, args = update.Where(query.Eq("variant_id", dto.VariantID), query.Eq("day", dto.Day), query.Expr("reserved <= ?", dto.OnHand)).Build()
+	res, err := storage.Using(ctx, i.db).ExecContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	if err := affected(res, ErrConflict); err != nil {
+		stock, errGet := i.Get(ctx, dto.VariantID, dto.Day)
+		if errGet != nil {
+			return nil, errGet
+		}
+		return nil, fmt.Errorf("%w: %d units are reserved", ErrConflict, stock.Reserved)
+	}
+	return i.Get(ctx, dto.VariantID, dto.Day)
+}
+func (i repoImplementation) Reserve(ctx context.Context, dto ReserveRequestDto) (*Reservation, error) {
+	var id int64
+	err := i.tx.InTx(ctx, func(ctx context.Context) error {
+		tx := storage.Using(ctx, i.db)
+		// The units are only reserved while that many are available, so
+		// concurrent reservations cannot take more than is on hand.
+		q, args := query.Update(TableName).
+			SetExpr("reserved", "reserved + ?", dto.Quantity).
+			SetExpr("version", "version + 1").
+			Set("updated_at", storage.TruncateTime(time.Now())).
+			Where(query.Eq("variant_id", dto.VariantID), query.Eq("day", dto.Day), query.Expr("on_hand - reserved >= ?", dto.Quantity)).
+			Build()
+		res, err := tx.ExecContext(ctx, q, args...)
+		if err != nil {
+			return err
+		}
+		if err := affected(res, ErrInsufficient); err != nil {
+			if _, errGet := getStock(ctx, tx, dto.VariantID, dto.Day); errGet != nil {
+				return errGet
+			}
+			return err
+		}
+
+		q, args = query.Insert(ReservationsName).
+			Set("variant_id", dto.VariantID).
+			Set("day", dto.Day).
+			Set("quantity", dto.Quantity).
+			Set("status", StatusHeld).
+			Set("created_at", storage.TruncateTime(time.Now())).
+			Build()
+		res, err = tx.ExecContext(ctx, q, args...)
+		if err != nil {
+			return err
+		}
+		id, err = res.LastInsertId()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return i.GetReservation(ctx, int(id))
+}
+func (i repoImplementation) GetReservation(ctx context.Context, id int) (*Reservation, error) {
+	return getReservation(ctx, storage.Using(ctx, i.db), id)
+}
+func (i repoImplementation) Release(ctx context.Context, id int) (*Reservation, error) {
+	return i.settle(ctx, id, StatusReleased)
+}
+func (i repoImplementation) Commit(ctx context.Context, id int) (*Reservation, error) {
+	return i.settle(ctx, id, StatusCommitted)
+}
+
+// settle moves a held reservation to status and its units out of the
+// reserved ones, off the stock too when committed.
+func (i repoImplementation) settle(ctx context.Context, id int, status string) (*Reservation, error) {
+	err := i.tx.InTx(ctx, func(ctx context.Context) error {
+		tx := storage.Using(ctx, i.db)
+		reservation, err := getReservation(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if reservation.Status != StatusHeld {
+			return fmt.Errorf("%w: reservation is already %s", ErrConflict, reservation.Status)
+		}
+
+		// Only one of concurrent settlements moves the reservation on.
+		q, args := query.Update(ReservationsName).
+			Set("status", status).
+			Set("updated_at", storage.TruncateTime(time.Now())).
+			Where(query.Eq("id", id), query.Eq("status", StatusHeld)).
+			Build()
+		res, err := tx.ExecContext(ctx, q, args...)
+		if err != nil {
+			return err
+		}
+		if err := affected(res, fmt.Errorf("%w: reservation was settled concurrently", ErrConflict)); err != nil {
+			return err
+		}
+
+		update := query.Update(TableName).
+			SetExpr("reserved", "reserved - ?", reservation.Quantity).
+			SetExpr("version", "version + 1").
+			Set("updated_at", storage.TruncateTime(time.Now()))
+		if status == StatusCommitted {
+			update.SetExpr("on_hand", "on_hand - ?", reservation.Quantity)
+		}
+		q, args = update.Where(query.Eq("variant_id", reservation.VariantID), query.Eq("day", reservation.Day)).Build()
+		res, err = tx.ExecContext(ctx, q, args...)
+		if err != nil {
+			return err
+		}
+		return affected(res, ErrNotFound)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return i.GetReservation(ctx, id)
+}
+func (i repoImplementation) CakesInStock(ctx context.Context, day string) (query.IDs, error) {
+	return query.Subquery(query.Select(TableName, "cake_id").
+		Where(query.Eq("day", day), query.Expr("on_hand - reserved > 0"))), nil
+}
+func (i repoImplementation) InStock(ctx context.Context, day string, cakeIDs []int) ([]int, error) {
+	q, args := query.Select(TableName, "DISTINCT cake_id").
+		Where(query.Eq("day", day), query.In("cake_id", query.Ints(cakeIDs)...), query.Expr("on_hand - reserved > 0")).
+		Build()
+	rows, err := storage.Using(ctx, i.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		result = append(result, id)
+	}
+	return result, rows.Err()
+}
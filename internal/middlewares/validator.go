@@ -2,13 +2,18 @@ package middlewares
 
 import (
 	"cake-store/internal/helpers"
+	"errors"
 	"fmt"
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
-	"log"
 	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
 )
 
+const internalErrorDetail = "An unexpected error occurred."
+
 type customValidator struct {
 	validator *validator.Validate
 }
@@ -17,39 +22,178 @@ func (cv *customValidator) Validate(i interface{}) error {
 	return cv.validator.Struct(i)
 }
 
+// fieldName reports fields by the name clients send them with.
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "query", "param"} {
+		name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// csvAllowed checks every comma separated term of the field, after trim,
+// against the space separated values of the tag param.
+func csvAllowed(fl validator.FieldLevel, trim func(string) string) bool {
+	allowed := strings.Fields(fl.Param())
+	for _, term := range strings.Split(fl.Field().String(), ",") {
+		value := trim(strings.TrimSpace(term))
+		found := false
+		for _, name := range allowed {
+			found = found || name == value
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// validateSortBy checks a sort expression such as "-rating,title" against
+// the sortable fields in the tag param.
+func validateSortBy(fl validator.FieldLevel) bool {
+	return csvAllowed(fl, func(term string) string {
+		return strings.TrimPrefix(term, "-")
+	})
+}
+
+// validateCSVOneOf checks a list such as "rating,has_image" against the
+// values in the tag param.
+func validateCSVOneOf(fl validator.FieldLevel) bool {
+	return csvAllowed(fl, func(term string) string {
+		return term
+	})
+}
+
+// validateIntList checks a comma separated list of positive integers.
+func validateIntList(fl validator.FieldLevel) bool {
+	for _, term := range strings.Split(fl.Field().String(), ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(term))
+		if err != nil || n <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
 func UseCustomValidatorHandler(e *echo.Echo) {
 	newValidator := validator.New()
+	newValidator.RegisterTagNameFunc(fieldName)
+	newValidator.RegisterValidation("sortby", validateSortBy)
+	newValidator.RegisterValidation("intlist", validateIntList)
+	newValidator.RegisterValidation("csvoneof", validateCSVOneOf)
 	e.Validator = &customValidator{validator: newValidator}
 
 	e.HTTPErrorHandler = func(err error, c echo.Context) {
-		if castedObject, ok := err.(validator.ValidationErrors); ok {
-			var MessageValidation []helpers.ErrorObject
-			for _, err := range castedObject {
-				errObject := helpers.ErrorObject{}
-				switch err.Tag() {
-				case "required", "required_with", "required_without", "required_unless":
-					errObject.Name = err.Field()
-					errObject.Message = fmt.Sprintf("%s is required", err.Field())
-				case "url", "numeric":
-					errObject.Name = err.Field()
-					errObject.Message = fmt.Sprintf("%s is not valid %s",
-						err.Field(), err.Tag())
-				default:
-					errObject.Name = err.Field()
-					errObject.Message = fmt.Sprintf("Validation error on field %s", err.Field())
-				}
-
-				if errObject.Name != "" {
-					MessageValidation = append(MessageValidation, errObject)
-				}
-			}
-			c.JSON(http.StatusUnprocessableEntity, helpers.JSONResponse{Message: "The given data was invalid.", Errors: MessageValidation})
-		} else if castedObject, ok := err.(*echo.HTTPError); ok {
-			log.Println(castedObject.Message)
-			c.JSON(castedObject.Code, helpers.JSONResponse{Message: fmt.Sprintf("%v", castedObject.Message)})
-		} else {
-			c.Logger().Error(err)
-			c.JSON(http.StatusInternalServerError, helpers.JSONResponse{Message: fmt.Sprintf("%v", err.Error())})
+		if c.Response().Committed {
+			return
+		}
+
+		problem := problemFor(err)
+		if problem.Status >= http.StatusInternalServerError {
+			c.Logger().Errorf("request %s: %v", requestID(c), err)
+		}
+		problem.Instance = c.Request().URL.Path
+		problem.RequestID = requestID(c)
+
+		if c.Request().Method == http.MethodHead {
+			c.NoContent(problem.Status)
+			return
 		}
+		WriteProblem(c, problem)
+	}
+}
+
+// WriteProblem sends problem as an application/problem+json response.
+func WriteProblem(c echo.Context, problem helpers.Problem) error {
+	c.Response().Header().Set(echo.HeaderContentType, helpers.MIMEApplicationProblemJSON)
+	return c.JSON(problem.Status, problem)
+}
+
+func requestID(c echo.Context) string {
+	if id := c.Response().Header().Get(echo.HeaderXRequestID); id != "" {
+		return id
+	}
+	return c.Request().Header.Get(echo.HeaderXRequestID)
+}
+
+func problemFor(err error) helpers.Problem {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		problem := helpers.NewProblem(http.StatusUnprocessableEntity, "The given data was invalid.")
+		for _, err := range validationErrors {
+			problem.InvalidParams = append(problem.InvalidParams, helpers.InvalidParam{
+				Name:   err.Field(),
+				Reason: validationReason(err),
+			})
+		}
+		return problem
+	}
+
+	if code, ok := domainErrorStatus(err); ok {
+		return helpers.NewProblem(code, err.Error())
+	}
+
+	var httpError *echo.HTTPError
+	if errors.As(err, &httpError) {
+		if httpError.Code >= http.StatusInternalServerError {
+			return helpers.NewProblem(httpError.Code, internalErrorDetail)
+		}
+		return helpers.NewProblem(httpError.Code, fmt.Sprintf("%v", httpError.Message))
+	}
+
+	return helpers.NewProblem(http.StatusInternalServerError, internalErrorDetail)
+}
+
+func validationReason(err validator.FieldError) string {
+	switch err.Tag() {
+	case "required", "required_if", "required_with", "required_without", "required_unless":
+		return fmt.Sprintf("%s is required", err.Field())
+	case "url", "numeric":
+		return fmt.Sprintf("%s is not valid %s", err.Field(), err.Tag())
+	case "sortby", "csvoneof", "oneof":
+		return fmt.Sprintf("%s only accepts %s", err.Field(), strings.Join(strings.Fields(err.Param()), ", "))
+	case "intlist":
+		return fmt.Sprintf("%s must be a comma separated list of ids", err.Field())
+	case "gte":
+		return fmt.Sprintf("%s must be greater than or equal to %s", err.Field(), err.Param())
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", err.Field(), err.Param())
+	case "min":
+		return fmt.Sprintf("%s must be at least %s", err.Field(), err.Param())
+	case "max":
+		return fmt.Sprintf("%s must be at most %s", err.Field(), err.Param())
+	case "datetime":
+		return fmt.Sprintf("%s must be formatted as %s", err.Field(), err.Param())
+	case "email":
+		return fmt.Sprintf("%s must be an email address", err.Field())
+	case "iso4217":
+		return fmt.Sprintf("%s must be an ISO 4217 currency code", err.Field())
+	default:
+		return fmt.Sprintf("Validation error on field %s", err.Field())
+	}
+}
+
+// domainErrorStatus maps the shared helpers error kinds to a status code.
+func domainErrorStatus(err error) (int, bool) {
+	switch {
+	case errors.Is(err, helpers.ErrNotFound):
+		return http.StatusNotFound, true
+	case errors.Is(err, helpers.ErrConflict):
+		return http.StatusConflict, true
+	case errors.Is(err, helpers.ErrValidation):
+		return http.StatusUnprocessableEntity, true
+	case errors.Is(err, helpers.ErrUnauthorized):
+		return http.StatusUnauthorized, true
+	case errors.Is(err, helpers.ErrForbidden):
+		return http.StatusForbidden, true
+	case errors.Is(err, helpers.ErrRateLimited):
+		return http.StatusTooManyRequests, true
+	case errors.Is(err, helpers.ErrPreconditionFailed):
+		return http.StatusPreconditionFailed, true
+	case errors.Is(err, helpers.ErrPreconditionRequired):
+		return http.StatusPreconditionRequired, true
 	}
+	return 0, false
 }
@@ -0,0 +1,105 @@
+// Package money keeps prices as integer minor units with an ISO 4217
+// currency, so amounts never pass through floating point.
+package money
+
+import (
+	"cake-store/internal/helpers"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is assumed when a request gives an amount without a
+// currency.
+const DefaultCurrency = "USD"
+
+var ErrInvalid = fmt.Errorf("amount %w", helpers.ErrValidation)
+
+// exponents lists the currencies whose minor unit is not a hundredth.
+var exponents = map[string]int{
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLP": 0, "ISK": 0, "JPY": 0, "KRW": 0, "PYG": 0, "UGX": 0, "VND": 0,
+}
+
+// Money is an amount in the minor unit of its currency, e.g. 1250 USD
+// cents, with its decimal rendering for display.
+type Money struct {
+	Amount   int64  `json:"amount" example:"1250"`
+	Currency string `json:"currency" example:"USD"`
+	Decimal  string `json:"decimal" example:"12.50"`
+}
+
+// New returns amount minor units of currency.
+func New(amount int64, currency string) Money {
+	currency = strings.ToUpper(currency)
+	return Money{Amount: amount, Currency: currency, Decimal: Format(amount, currency)}
+}
+
+// Exponent returns the number of decimal places of the currency's minor
+// unit.
+func Exponent(currency string) int {
+	if exponent, ok := exponents[strings.ToUpper(currency)]; ok {
+		return exponent
+	}
+	return 2
+}
+
+// Format renders minor units as a decimal string, 1250 USD as "12.50".
+func Format(amount int64, currency string) string {
+	exponent := Exponent(currency)
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	digits := strconv.FormatInt(amount, 10)
+	if exponent == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+}
+
+// Parse reads a non-negative decimal string such as "12.5" into minor
+// units of currency, rejecting more decimals than the currency has.
+func Parse(s, currency string) (int64, error) {
+	exponent := Exponent(currency)
+	whole, fraction := s, ""
+	if dot := strings.IndexByte(s, '.'); dot >= 0 {
+		whole, fraction = s[:dot], s[dot+1:]
+	}
+	if whole == "" || !digitsOnly(whole) || !digitsOnly(fraction) || len(fraction) > exponent {
+		return 0, fmt.Errorf("%w: %q is not a %s amount", ErrInvalid, s, strings.ToUpper(currency))
+	}
+	fraction += strings.Repeat("0", exponent-len(fraction))
+	amount, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is out of range", ErrInvalid, s)
+	}
+	return amount, nil
+}
+
+func digitsOnly(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Range selects amounts of one currency between Min and Max inclusive. Nil
+// bounds are open.
+type Range struct {
+	Min      *int64
+	Max      *int64
+	Currency string
+}
+
+// Contains reports whether m falls in the range.
+func (r Range) Contains(m Money) bool {
+	return strings.EqualFold(m.Currency, r.Currency) &&
+		(r.Min == nil || m.Amount >= *r.Min) &&
+		(r.Max == nil || m.Amount <= *r.Max)
+}
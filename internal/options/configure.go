@@ -0,0 +1,127 @@
+package options
+
+import (
+	"cake-store/internal/money"
+	"cake-store/internal/variants"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// group returns the group with key, or nil.
+func (s Schema) group(key string) *Group {
+	for i := range s.Groups {
+		if s.Groups[i].Key == key {
+			return &s.Groups[i]
+		}
+	}
+	return nil
+}
+
+// choice returns the choice with key, or nil.
+func (g Group) choice(key string) *Choice {
+	for i := range g.Choices {
+		if g.Choices[i].Key == key {
+			return &g.Choices[i]
+		}
+	}
+	return nil
+}
+
+// bounds describes how many choices a group takes, for error messages.
+func (g Group) bounds() string {
+	switch {
+	case g.Min == 1 && g.Max == 1:
+		return "exactly 1 choice"
+	case g.Min == g.Max:
+		return fmt.Sprintf("exactly %d choices", g.Min)
+	}
+	return fmt.Sprintf("%d to %d choices", g.Min, g.Max)
+}
+
+// Configure validates selections against the schema and returns a line per
+// chosen choice and filled text, in schema order. Groups without a
+// selection must allow none.
+func (s Schema) Configure(selections []SelectionDto) ([]Line, error) {
+	chosen := map[string]SelectionDto{}
+	for _, selection := range selections {
+		key := normalizeKey(selection.Group)
+		if s.group(key) == nil {
+			return nil, fmt.Errorf("%w: unknown option %q", ErrConfiguration, selection.Group)
+		}
+		if _, ok := chosen[key]; ok {
+			return nil, fmt.Errorf("%w: %s is configured twice", ErrConfiguration, key)
+		}
+		chosen[key] = selection
+	}
+
+	lines := []Line{}
+	for _, group := range s.Groups {
+		selection := chosen[group.Key]
+		switch group.Kind {
+		case KindChoice:
+			if selection.Text != "" {
+				return nil, fmt.Errorf("%w: %s takes choices, not a text", ErrConfiguration, group.Key)
+			}
+			seen := map[string]bool{}
+			for _, key := range selection.Choices {
+				key = normalizeKey(key)
+				choice := group.choice(key)
+				if choice == nil {
+					return nil, fmt.Errorf("%w: unknown choice %q for %s", ErrConfiguration, key, group.Key)
+				}
+				if seen[key] {
+					return nil, fmt.Errorf("%w: %s is chosen twice for %s", ErrConfiguration, key, group.Key)
+				}
+				seen[key] = true
+				lines = append(lines, Line{Group: group.Key, Choice: choice.Key, Description: group.Name + ": " + choice.Name, Price: choice.Price})
+			}
+			if len(seen) < group.Min || len(seen) > group.Max {
+				return nil, fmt.Errorf("%w: %s takes %s", ErrConfiguration, group.Key, group.bounds())
+			}
+		case KindText:
+			if len(selection.Choices) != 0 {
+				return nil, fmt.Errorf("%w: %s takes a text, not choices", ErrConfiguration, group.Key)
+			}
+			text := strings.TrimSpace(selection.Text)
+			if text == "" {
+				if group.Min > 0 {
+					return nil, fmt.Errorf("%w: %s is required", ErrConfiguration, group.Key)
+				}
+				continue
+			}
+			if utf8.RuneCountInString(text) > group.MaxLength {
+				return nil, fmt.Errorf("%w: %s is limited to %d characters", ErrConfiguration, group.Key, group.MaxLength)
+			}
+			lines = append(lines, Line{Group: group.Key, Description: group.Name + ": " + text, Price: group.Price})
+		}
+	}
+	return lines, nil
+}
+
+// Quote prices quantity cakes of variant configured with selections. The
+// options must be priced in the currency of the variant.
+func (s Schema) Quote(variant variants.Variant, quantity int, selections []SelectionDto) (*Quote, error) {
+	lines, err := s.Configure(selections)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.Groups) > 0 && s.Currency != variant.Price.Currency {
+		return nil, fmt.Errorf("%w: options are priced in %s, the variant in %s", ErrConfiguration, s.Currency, variant.Price.Currency)
+	}
+
+	lines = append([]Line{{Description: variant.Size, Price: variant.Price}}, lines...)
+	unit := int64(0)
+	for _, line := range lines {
+		unit += line.Price.Amount
+	}
+	currency := variant.Price.Currency
+	return &Quote{
+		CakeID:    variant.CakeID,
+		VariantID: variant.ID,
+		Quantity:  quantity,
+		Lines:     lines,
+		UnitPrice: money.New(unit, currency),
+		Total:     money.New(unit*int64(quantity), currency),
+	}, nil
+}
@@ -0,0 +1,13 @@
+package options
+
+import (
+	"cake-store/internal/helpers"
+	"fmt"
+)
+
+var (
+	ErrValidation = fmt.Errorf("options %w", helpers.ErrValidation)
+	// ErrConfiguration is returned for a configuration the options of a
+	// cake do not allow.
+	ErrConfiguration = fmt.Errorf("configuration %w", helpers.ErrValidation)
+)
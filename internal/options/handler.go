@@ -0,0 +1,145 @@
+package options
+
+import (
+	"cake-store/internal/cakes"
+	"cake-store/internal/variants"
+	"context"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+)
+
+type SvcInterface interface {
+	Get(ctx echo.Context) error
+	Set(ctx echo.Context) error
+	Quote(ctx echo.Context) error
+}
+
+type svcImplementation struct {
+	repo     RepoInterface
+	cakes    cakes.RepoInterface
+	variants variants.RepoInterface
+}
+
+// NewHandler returns the handler of the options of the cakes in cakesRepo,
+// quoting them on top of the prices in variantsRepo.
+func NewHandler(repo RepoInterface, cakesRepo cakes.RepoInterface, variantsRepo variants.RepoInterface) SvcInterface {
+	return svcImplementation{repo, cakesRepo, variantsRepo}
+}
+
+// Get godoc
+// @Summary Get options of cake
+// @Description This endpoint for get the option groups a cake can be customised with
+// @Tags Options
+// @Accept  json
+// @Produce  json
+// @Param id path string true "cake id"
+// @Success 200 {object} Schema
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /cakes/{id}/options [get]
+func (s svcImplementation) Get(ctx echo.Context) error {
+	ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid id")
+	}
+
+	if _, err := s.cakes.Get(context.TODO(), ID); err != nil {
+		return err
+	}
+
+	data, err := s.repo.Get(context.TODO(), ID)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, data)
+}
+
+// Set godoc
+// @Summary Set options of cake
+// @Description This endpoint for replacing the option groups of a cake. Choice groups take min to max choices (max defaults to 1); text groups take a text of up to max_length characters, required when min is 1.
+// @Tags Options
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path string true "cake id"
+// @Param Request body SchemaRequestDto true "Set options"
+// @Success 200 {object} Schema
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /cakes/{id}/options [put]
+func (s svcImplementation) Set(ctx echo.Context) error {
+	request := SchemaRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	if _, err := s.cakes.Get(context.TODO(), request.CakeID); err != nil {
+		return err
+	}
+
+	updated, err := s.repo.Set(context.TODO(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, updated)
+}
+
+// Quote godoc
+// @Summary Quote custom cake
+// @Description This endpoint for validating a configuration of a cake against its options and pricing it, itemised into the variant and every configured option
+// @Tags Options
+// @Accept  json
+// @Produce  json
+// @Param id path string true "cake id"
+// @Param Request body QuoteRequestDto true "Configuration"
+// @Success 200 {object} Quote
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /cakes/{id}/quote [post]
+func (s svcImplementation) Quote(ctx echo.Context) error {
+	request := QuoteRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	if request.Quantity == 0 {
+		request.Quantity = 1
+	}
+
+	if _, err := s.cakes.Get(context.TODO(), request.CakeID); err != nil {
+		return err
+	}
+	variant, err := s.variants.Get(context.TODO(), request.CakeID, request.VariantID)
+	if err != nil {
+		return err
+	}
+	if !variant.Active {
+		return fmt.Errorf("%w: %s is not available", ErrConfiguration, variant.Size)
+	}
+
+	schema, err := s.repo.Get(context.TODO(), request.CakeID)
+	if err != nil {
+		return err
+	}
+	quote, err := schema.Quote(*variant, request.Quantity, request.Options)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, quote)
+}
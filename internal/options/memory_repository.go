@@ -0,0 +1,60 @@
+package options
+
+import (
+	"context"
+	"sync"
+)
+
+type memoryRepoImplementation struct {
+	mu      sync.RWMutex
+	schemas map[int]Schema
+}
+
+// NewMemoryRepository returns a RepoInterface that keeps the options Schema
+// of each cake by its ID.
+func NewMemoryRepository() RepoInterface {
+	return &memoryRepoImplementation{schemas: map[int]Schema{}}
+}
+
+func copySchema(schema Schema) Schema {
+	groups := make([]Group, len(schema.Groups))
+	for i, group := range schema.Groups {
+		if group.Choices != nil {
+			group.Choices = append([]Choice{}, group.Choices...)
+		}
+		groups[i] = group
+	}
+	schema.Groups = groups
+	return schema
+}
+
+func (m *memoryRepoImplementation) Get(ctx context.Context, cakeID int) (*Schema, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	schema, ok := m.schemas[cakeID]
+	if !ok {
+		schema = Schema{CakeID: cakeID, Groups: []Group{}}
+	}
+	result := copySchema(schema)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Set(ctx context.Context, dto SchemaRequestDto) (*Schema, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	schema, err := dto.schema()
+	if err != nil {
+		return nil, err
+	}
+	m.schemas[dto.CakeID] = schema
+	result := copySchema(schema)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) DeleteForCake(ctx context.Context, cakeID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.schemas, cakeID)
+	return nil
+}
@@ -0,0 +1,163 @@
+package options
+
+import (
+	"cake-store/internal/money"
+	"fmt"
+	"strings"
+)
+
+// Option group kinds. Choice groups pick from a list, text groups take a
+// free text such as an inscription.
+const (
+	KindChoice = "choice"
+	KindText   = "text"
+)
+
+type (
+	// Schema lists the options a cake can be customised with, in display
+	// order. All prices are in one currency.
+	Schema struct {
+		CakeID   int     `json:"cake_id"`
+		Currency string  `json:"currency,omitempty" example:"USD"`
+		Groups   []Group `json:"groups"`
+	}
+	// Group is one customisable aspect of a cake. Choice groups take between
+	// Min and Max choices; text groups take a text of up to MaxLength
+	// characters, required when Min is 1, and charge Price when filled.
+	Group struct {
+		Key       string      `json:"key" example:"flavour"`
+		Name      string      `json:"name" example:"Flavour"`
+		Kind      string      `json:"kind" example:"choice"`
+		Min       int         `json:"min"`
+		Max       int         `json:"max,omitempty" example:"1"`
+		MaxLength int         `json:"max_length,omitempty"`
+		Price     money.Money `json:"price"`
+		Choices   []Choice    `json:"choices,omitempty"`
+	}
+	// Choice is a selectable value of a choice group with the price it adds.
+	Choice struct {
+		Key   string      `json:"key" example:"chocolate"`
+		Name  string      `json:"name" example:"Chocolate"`
+		Price money.Money `json:"price"`
+	}
+	SchemaRequestDto struct {
+		CakeID   int        `param:"id" json:"-" swaggerignore:"true"`
+		Currency string     `json:"currency" validate:"required,iso4217" example:"USD"`
+		Groups   []GroupDto `json:"groups" validate:"max=20,dive"`
+	}
+	GroupDto struct {
+		Key        string      `json:"key" validate:"required,max=50,printascii" example:"flavour"`
+		Name       string      `json:"name" validate:"required,max=100" example:"Flavour"`
+		Kind       string      `json:"kind" validate:"required,oneof=choice text" example:"choice"`
+		Min        int         `json:"min" validate:"gte=0"`
+		Max        int         `json:"max" validate:"gte=0" example:"1"`
+		MaxLength  int         `json:"max_length" validate:"gte=0,lte=500"`
+		PriceMinor int64       `json:"price_minor" validate:"gte=0"`
+		Choices    []ChoiceDto `json:"choices" validate:"max=50,dive"`
+	}
+	ChoiceDto struct {
+		Key        string `json:"key" validate:"required,max=50,printascii" example:"chocolate"`
+		Name       string `json:"name" validate:"required,max=100" example:"Chocolate"`
+		PriceMinor int64  `json:"price_minor" validate:"gte=0" example:"300"`
+	}
+	QuoteRequestDto struct {
+		CakeID    int            `param:"id" json:"-" swaggerignore:"true"`
+		VariantID int            `json:"variant_id" validate:"required,gt=0"`
+		Quantity  int            `json:"quantity" validate:"omitempty,gt=0,lte=100"`
+		Options   []SelectionDto `json:"options" validate:"max=20,dive"`
+	}
+	// SelectionDto configures one option group, with choice keys for a
+	// choice group or a text for a text group.
+	SelectionDto struct {
+		Group   string   `json:"group" validate:"required" example:"flavour"`
+		Choices []string `json:"choices" validate:"max=50" example:"chocolate"`
+		Text    string   `json:"text" validate:"max=500"`
+	}
+	// Quote is the price of a configured cake, itemised into the variant and
+	// every configured option.
+	Quote struct {
+		CakeID    int         `json:"cake_id"`
+		VariantID int         `json:"variant_id"`
+		Quantity  int         `json:"quantity"`
+		Lines     []Line      `json:"lines"`
+		UnitPrice money.Money `json:"unit_price"`
+		Total     money.Money `json:"total"`
+	}
+	// Line is one priced part of a quote; Group and Choice are empty for the
+	// variant itself.
+	Line struct {
+		Group       string      `json:"group,omitempty" example:"flavour"`
+		Choice      string      `json:"choice,omitempty" example:"chocolate"`
+		Description string      `json:"description" example:"Flavour: Chocolate"`
+		Price       money.Money `json:"price"`
+	}
+)
+
+// normalizeKey trims and lowercases a group or choice key, so lookups
+// ignore case.
+func normalizeKey(key string) string {
+	return strings.ToLower(strings.TrimSpace(key))
+}
+
+// schema returns the schema a request stores, with normalized keys and
+// choice group maxima defaulting to 1.
+func (dto SchemaRequestDto) schema() (Schema, error) {
+	currency := strings.ToUpper(dto.Currency)
+	schema := Schema{CakeID: dto.CakeID, Currency: currency, Groups: []Group{}}
+	for _, g := range dto.Groups {
+		group := Group{Key: normalizeKey(g.Key), Name: g.Name, Kind: g.Kind, Min: g.Min, Max: g.Max, MaxLength: g.MaxLength,
+			Price: money.New(g.PriceMinor, currency)}
+		if group.Kind == KindChoice && group.Max == 0 {
+			group.Max = 1
+		}
+		for _, c := range g.Choices {
+			group.Choices = append(group.Choices, Choice{Key: normalizeKey(c.Key), Name: c.Name, Price: money.New(c.PriceMinor, currency)})
+		}
+		schema.Groups = append(schema.Groups, group)
+	}
+	if len(schema.Groups) == 0 {
+		schema.Currency = ""
+	}
+	return schema, schema.check()
+}
+
+// check validates what the field tags cannot: unique keys and bounds that
+// fit the kind of each group.
+func (s Schema) check() error {
+	groups := map[string]bool{}
+	for _, group := range s.Groups {
+		if groups[group.Key] {
+			return fmt.Errorf("%w: group %s is listed twice", ErrValidation, group.Key)
+		}
+		groups[group.Key] = true
+
+		switch group.Kind {
+		case KindChoice:
+			if len(group.Choices) == 0 {
+				return fmt.Errorf("%w: group %s has no choices", ErrValidation, group.Key)
+			}
+			if group.Min > group.Max || group.Max > len(group.Choices) {
+				return fmt.Errorf("%w: group %s needs min <= max <= the number of choices", ErrValidation, group.Key)
+			}
+			if group.MaxLength != 0 || group.Price.Amount != 0 {
+				return fmt.Errorf("%w: group %s prices its choices, not a text", ErrValidation, group.Key)
+			}
+		case KindText:
+			if len(group.Choices) != 0 || group.Max != 0 {
+				return fmt.Errorf("%w: text group %s takes no choices", ErrValidation, group.Key)
+			}
+			if group.Min > 1 || group.MaxLength == 0 {
+				return fmt.Errorf("%w: text group %s needs a max_length and a min of 0 or 1", ErrValidation, group.Key)
+			}
+		}
+
+		choices := map[string]bool{}
+		for _, choice := range group.Choices {
+			if choices[choice.Key] {
+				return fmt.Errorf("%w: choice %s is listed twice in group %s", ErrValidation, choice.Key, group.Key)
+			}
+			choices[choice.Key] = true
+		}
+	}
+	return nil
+}
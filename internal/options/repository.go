@@ -0,0 +1,163 @@
+package options
+
+//go:generate mockgen -destination=../../mocks/options/mock_repository.go -package=mock_options -source=repository.go
+
+import (
+	"cake-store/internal/money"
+	"cake-store/internal/query"
+	"cake-store/internal/storage"
+	"context"
+	"database/sql"
+)
+
+const (
+	GroupsName  = "option_groups"
+	ChoicesName = "option_choices"
+)
+
+// GroupColumns and ChoiceColumns list the columns in the order scanned by
+// Get.
+var (
+	GroupColumns  = []string{"id", "slug", "name", "kind", "min_select", "max_select", "max_length", "price_minor", "currency"}
+	ChoiceColumns = []string{"group_id", "slug", "name", "price_minor"}
+)
+
+type repoImplementation struct {
+	db *sql.DB
+	tx storage.Transactor
+}
+
+type RepoInterface interface {
+	// Get returns the options of a cake, with no groups when it has none.
+	Get(ctx context.Context, cakeID int) (*Schema, error)
+	// Set replaces the options of a cake, or returns ErrValidation when the
+	// groups are inconsistent.
+	Set(ctx context.Context, dto SchemaRequestDto) (*Schema, error)
+	// DeleteForCake removes the options of a deleted cake.
+	DeleteForCake(ctx context.Context, cakeID int) error
+}
+
+// NewRepository returns a RepoInterface storing each cake's options as
+// option_groups and option_choices rows, replaced together by Set.
+func NewRepository(db *sql.DB) RepoInterface {
+	return repoImplementation{db, storage.NewTransactor(db)}
+}
+
+func (i repoImplementation) Get(ctx context.Context, cakeID int) (*Schema, error) {
+	schema := Schema{CakeID: cakeID, Groups: []Group{}}
+	q, args := query.Select(GroupsName, GroupColumns...).Where(query.Eq("cake_id", cakeID)).OrderBy("position ASC").Build()
+	rows, err := storage.Using(ctx, i.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	positions := map[int]int{}
+	for rows.Next() {
+		var (
+			id     int
+			group  Group
+			amount int64
+		)
+		if err := rows.Scan(&id, &group.Key, &group.Name, &group.Kind, &group.Min, &group.Max, &group.MaxLength, &amount, &schema.Currency); err != nil {
+			return nil, err
+		}
+		group.Price = money.New(amount, schema.Currency)
+		positions[id] = len(schema.Groups)
+		schema.Groups = append(schema.Groups, group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	q, args = query.Select(ChoicesName, ChoiceColumns...).Where(query.Eq("cake_id", cakeID)).OrderBy("position ASC").Build()
+	rows, err = storage.Using(ctx, i.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			groupID int
+			choice  Choice
+			amount  int64
+		)
+		if err := rows.Scan(&groupID, &choice.Key, &choice.Name, &amount); err != nil {
+			return nil, err
+		}
+		choice.Price = money.New(amount, schema.Currency)
+		group := &schema.Groups[positions[groupID]]
+		group.Choices = append(group.Choices, choice)
+	}
+	return &schema, rows.Err()
+}
+func (i repoImplementation) Set(ctx context.Context, dto SchemaRequestDto) (*Schema, error) {
+	schema, err := dto.schema()
+	if err != nil {
+		return nil, err
+	}
+	err = i.tx.InTx(ctx, func(ctx context.Context) error {
+		tx := storage.Using(ctx, i.db)
+		if err := deleteForCake(ctx, tx, dto.CakeID); err != nil {
+			return err
+		}
+		for position, group := range schema.Groups {
+			q, args := query.Insert(GroupsName).
+				Set("cake_id", dto.CakeID).
+				Set("slug", group.Key).
+				Set("name", group.Name).
+				Set("kind", group.Kind).
+				Set("min_select", group.Min).
+				Set("max_select", group.Max).
+				Set("max_length", group.MaxLength).
+				Set("price_minor", group.Price.Amount).
+				Set("currency", schema.Currency).
+				Set("position", position).
+				Build()
+			res, err := tx.ExecContext(ctx, q, args...)
+			if err != nil {
+				return err
+			}
+			groupID, err := res.LastInsertId()
+			if err != nil {
+				return err
+			}
+			for position, choice := range group.Choices {
+				q, args := query.Insert(ChoicesName).
+					Set("group_id", groupID).
+					Set("cake_id", dto.CakeID).
+					Set("slug", choice.Key).
+					Set("name", choice.Name).
+					Set("price_minor", choice.Price.Amount).
+					Set("position", position).
+					Build()
+				if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return i.Get(ctx, dto.CakeID)
+}
+func (i repoImplementation) DeleteForCake(ctx context.Context, cakeID int) error {
+	return i.tx.InTx(ctx, func(ctx context.Context) error {
+		return deleteForCake(ctx, storage.Using(ctx, i.db), cakeID)
+	})
+}
+
+// deleteForCake removes the choices and groups of a cake within tx.
+func deleteForCake(ctx context.Context, tx storage.Conn, cakeID int) error {
+	for _, table := range []string{ChoicesName, GroupsName} {
+		q, args := query.Delete(table).Where(query.Eq("cake_id", cakeID)).Build()
+		if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package orders
+
+import (
+	"cake-store/internal/cakes"
+	"cake-store/internal/money"
+	"cake-store/internal/stores"
+	"cake-store/internal/variants"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Catalog prices line items from the cakes and their variants, so totals
+// never come from the client.
+type Catalog struct {
+	cakes    cakes.RepoInterface
+	variants variants.RepoInterface
+	menu     *stores.Menu
+}
+
+// CatalogOption configures the packages items are priced with.
+type CatalogOption func(*Catalog)
+
+// WithMenu prices the items of orders and carts placed with a store from
+// the menu of the store.
+func WithMenu(menu stores.Menu) CatalogOption {
+	return func(c *Catalog) {
+		c.menu = &menu
+	}
+}
+
+func NewCatalog(cakesRepo cakes.RepoInterface, variantsRepo variants.RepoInterface, options ...CatalogOption) Catalog {
+	c := Catalog{cakes: cakesRepo, variants: variantsRepo}
+	for _, option := range options {
+		option(&c)
+	}
+	return c
+}
+
+// Store returns ErrUnavailable when items cannot be priced at storeID,
+// which is either zero, for the catalog prices, or a store.
+func (c Catalog) Store(ctx context.Context, storeID int) error {
+	if storeID == 0 {
+		return nil
+	}
+	if c.menu == nil {
+		return fmt.Errorf("%w: stores are not enabled", ErrUnavailable)
+	}
+	err := c.menu.Exists(ctx, storeID)
+	if errors.Is(err, stores.ErrNotFound) {
+		return fmt.Errorf("%w: store %d does not exist", ErrUnavailable, storeID)
+	}
+	return err
+}
+
+// Item returns a line of quantity units of a variant of a cake at its
+// current price, at storeID when it is not zero. It returns ErrUnavailable
+// when the cake or variant does not exist, the variant is not for sale or
+// the store does not sell the cake.
+func (c Catalog) Item(ctx context.Context, storeID int, dto ItemDto) (Item, error) {
+	cake, err := c.cakes.Get(ctx, dto.CakeID)
+	if errors.Is(err, cakes.ErrNotFound) {
+		return Item{}, fmt.Errorf("%w: cake %d does not exist", ErrUnavailable, dto.CakeID)
+	}
+	if err != nil {
+		return Item{}, err
+	}
+	variant, err := c.variants.Get(ctx, dto.CakeID, dto.VariantID)
+	if errors.Is(err, variants.ErrNotFound) {
+		return Item{}, fmt.Errorf("%w: variant %d is not a size of cake %d", ErrUnavailable, dto.VariantID, dto.CakeID)
+	}
+	if err != nil {
+		return Item{}, err
+	}
+	if !variant.Active {
+		return Item{}, fmt.Errorf("%w: variant %d is not for sale", ErrUnavailable, dto.VariantID)
+	}
+	if storeID != 0 {
+		if err := c.Store(ctx, storeID); err != nil {
+			return Item{}, err
+		}
+		price, ok, err := c.menu.Price(ctx, storeID, *variant)
+		if err != nil {
+			return Item{}, err
+		}
+		if !ok {
+			return Item{}, fmt.Errorf("%w: cake %d is not on the menu of store %d", ErrUnavailable, dto.CakeID, storeID)
+		}
+		variant.Price = price
+	}
+	return Item{
+		CakeID:    cake.ID,
+		VariantID: variant.ID,
+		Title:     cake.Title,
+		Size:      variant.Size,
+		SKU:       variant.SKU,
+		Quantity:  dto.Quantity,
+		UnitPrice: variant.Price,
+		LineTotal: money.New(variant.Price.Amount*int64(dto.Quantity), variant.Price.Currency),
+	}, nil
+}
+
+// Price returns the items priced by Item at storeID and their total. Every
+// item must be priced in the same currency.
+func (c Catalog) Price(ctx context.Context, storeID int, dtos []ItemDto) ([]Item, money.Money, error) {
+	items := make([]Item, 0, len(dtos))
+	for _, dto := range dtos {
+		item, err := c.Item(ctx, storeID, dto)
+		if err != nil {
+			return nil, money.Money{}, err
+		}
+		items = append(items, item)
+	}
+	total, err := Total(items)
+	if err != nil {
+		return nil, money.Money{}, err
+	}
+	return items, total, nil
+}
+
+// Total sums the line totals of items, or returns ErrValidation when they
+// are in different currencies.
+func Total(items []Item) (money.Money, error) {
+	if len(items) == 0 {
+		return money.New(0, money.DefaultCurrency), nil
+	}
+	currency := items[0].LineTotal.Currency
+	var amount int64
+	for _, item := range items {
+		if item.LineTotal.Currency != currency {
+			return money.Money{}, fmt.Errorf("%w: items are priced in both %s and %s", ErrValidation, currency, item.LineTotal.Currency)
+		}
+		amount += item.LineTotal.Amount
+	}
+	return money.New(amount, currency), nil
+}
@@ -0,0 +1,16 @@
+package orders
+
+import (
+	"cake-store/internal/helpers"
+	"fmt"
+)
+
+var (
+	ErrNotFound   = fmt.Errorf("order %w", helpers.ErrNotFound)
+	ErrConflict   = fmt.Errorf("order %w", helpers.ErrConflict)
+	ErrValidation = fmt.Errorf("order %w", helpers.ErrValidation)
+
+	// ErrUnavailable is returned for items whose cake or variant is gone or
+	// no longer for sale.
+	ErrUnavailable = fmt.Errorf("item %w", helpers.ErrValidation)
+)
@@ -0,0 +1,206 @@
+package orders
+
+import (
+	"cake-store/internal/auth"
+	"context"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+)
+
+type SvcInterface interface {
+	List(ctx echo.Context) error
+	// Mine lists the orders of the signed-in user.
+	Mine(ctx echo.Context) error
+	Get(ctx echo.Context) error
+	Create(ctx echo.Context) error
+	// Transition returns the handler moving an order to status.
+	Transition(status string) echo.HandlerFunc
+}
+
+type svcImplementation struct {
+	repo   RepoInterface
+	placer Placer
+}
+
+// NewHandler returns the order handler, placing new orders through placer.
+func NewHandler(repo RepoInterface, placer Placer) SvcInterface {
+	return svcImplementation{repo, placer}
+}
+
+// List godoc
+// @Summary List orders
+// @Description This endpoint for get orders, newest first, e.g. the order history of a customer
+// @Tags Orders
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param customer_id path string false "customer id"
+// @Param services query ListRequestDto true "Find query"
+// @Success 200 {array} Order
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /orders [get]
+// @Router /customers/{customer_id}/orders [get]
+func (s svcImplementation) List(ctx echo.Context) error {
+	return s.list(ctx, "")
+}
+
+// Mine godoc
+// @Summary List my orders
+// @Description This endpoint for get the orders of the signed-in user, newest first
+// @Tags Orders
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param services query ListRequestDto true "Find query"
+// @Success 200 {array} Order
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /users/me/orders [get]
+func (s svcImplementation) Mine(ctx echo.Context) error {
+	principal, ok := auth.PrincipalFrom(ctx)
+	if !ok {
+		return auth.ErrUnauthorized
+	}
+	return s.list(ctx, principal.CustomerID())
+}
+
+// list lists orders, only those of customerID when it is given.
+func (s svcImplementation) list(ctx echo.Context, customerID string) error {
+	request := ListRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+	if customerID != "" {
+		request.CustomerID = customerID
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	if request.Limit == 0 {
+		request.Limit = 20
+	}
+
+	res, err := s.repo.List(context.TODO(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// Get godoc
+// @Summary Get detail of order
+// @Description This endpoint for get detail of an order with its items
+// @Tags Orders
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path string true "order id"
+// @Success 200 {object} Order
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /orders/{id} [get]
+func (s svcImplementation) Get(ctx echo.Context) error {
+	ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid id")
+	}
+
+	data, err := s.repo.Get(context.TODO(), ID)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, data)
+}
+
+// Create godoc
+// @Summary Place order
+// @Description This endpoint for placing a pending order; prices and the total are taken from the cake variants. Orders of customers are placed for themselves, whatever customer_id is sent.
+// @Tags Orders
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param Request body RequestDto true "Place order"
+// @Param Idempotency-Key header string false "retries with the same key and body get the first response"
+// @Success 201 {object} Order
+// @Header 201 {string} Location "/orders/{id}"
+// @Failure 409 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /orders [post]
+func (s svcImplementation) Create(ctx echo.Context) error {
+	request := RequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+	if principal, ok := auth.Customer(ctx); ok {
+		request.CustomerID = principal.CustomerID()
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	created, err := s.placer.Place(context.TODO(), request)
+	if err != nil {
+		return err
+	}
+	ctx.Response().Header().Set(echo.HeaderLocation, "/orders/"+strconv.Itoa(created.ID))
+	return ctx.JSON(http.StatusCreated, created)
+}
+
+// Transition godoc
+// @Summary Move order to the next status
+// @Description These endpoints move an order along pending, confirmed, baking, ready and picked_up or delivered; pending and confirmed orders can be cancelled. Other moves are rejected with 409.
+// @Tags Orders
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path string true "order id"
+// @Success 200 {object} Order
+// @Failure 404 {object} helpers.Problem
+// @Failure 409 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /orders/{id}/confirm [post]
+// @Router /orders/{id}/bake [post]
+// @Router /orders/{id}/ready [post]
+// @Router /orders/{id}/pick-up [post]
+// @Router /orders/{id}/deliver [post]
+// @Router /orders/{id}/cancel [post]
+func (s svcImplementation) Transition(status string) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		ID, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid id")
+		}
+
+		order, err := s.repo.Get(context.TODO(), ID)
+		if err != nil {
+			return err
+		}
+
+		updated, err := s.placer.Transition(context.TODO(), *order, status)
+		if err != nil {
+			return err
+		}
+		return ctx.JSON(http.StatusOK, updated)
+	}
+}
@@ -0,0 +1,108 @@
+package orders
+
+import (
+	"cake-store/internal/storage"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+type memoryRepoImplementation struct {
+	mu     sync.RWMutex
+	orders map[int]Order
+	nextID int
+}
+
+// NewMemoryRepository returns a RepoInterface that keeps orders with their
+// items, numbering them from 1 like the orders table does.
+func NewMemoryRepository() RepoInterface {
+	return &memoryRepoImplementation{
+		orders: map[int]Order{},
+		nextID: 1,
+	}
+}
+
+func copyOrder(order Order) Order {
+	if order.UpdatedAt != nil {
+		updatedAt := *order.UpdatedAt
+		order.UpdatedAt = &updatedAt
+	}
+	order.Items = append([]Item{}, order.Items...)
+	return order
+}
+
+func (m *memoryRepoImplementation) List(ctx context.Context, dto ListRequestDto) ([]Order, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := []Order{}
+	for _, order := range m.orders {
+		if dto.CustomerID != "" && order.CustomerID != dto.CustomerID {
+			continue
+		}
+		if dto.Status != "" && order.Status != dto.Status {
+			continue
+		}
+		result = append(result, copyOrder(order))
+	}
+	sort.Slice(result, func(a, b int) bool {
+		if !result[a].CreatedAt.Equal(result[b].CreatedAt) {
+			return result[a].CreatedAt.After(result[b].CreatedAt)
+		}
+		return result[a].ID > result[b].ID
+	})
+	if dto.Limit > 0 {
+		if dto.Offset >= len(result) {
+			return []Order{}, nil
+		}
+		result = result[dto.Offset:]
+		if len(result) > dto.Limit {
+			result = result[:dto.Limit]
+		}
+	}
+	return result, nil
+}
+func (m *memoryRepoImplementation) Get(ctx context.Context, id int) (*Order, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	order, ok := m.orders[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	result := copyOrder(order)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Create(ctx context.Context, order Order) (*Order, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	order.ID = m.nextID
+	order.CreatedAt = storage.TruncateTime(time.Now())
+	order.UpdatedAt = nil
+	order = copyOrder(order)
+	m.orders[order.ID] = order
+	m.nextID++
+	result := copyOrder(order)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Transition(ctx context.Context, id int, from, to string) (*Order, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	order, ok := m.orders[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if order.Status != from {
+		return nil, fmt.Errorf("%w: the order is %s now", ErrConflict, order.Status)
+	}
+	updatedAt := storage.TruncateTime(time.Now())
+	order.Status = to
+	order.UpdatedAt = &updatedAt
+	m.orders[id] = order
+	result := copyOrder(order)
+	return &result, nil
+}
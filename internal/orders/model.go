@@ -0,0 +1,66 @@
+package orders
+
+import (
+	"cake-store/internal/money"
+	"time"
+)
+
+// Fulfilment methods.
+const (
+	FulfilmentPickup   = "pickup"
+	FulfilmentDelivery = "delivery"
+)
+
+type (
+	Order struct {
+		ID              int         `json:"id"`
+		CustomerID      string      `json:"customer_id" example:"customer-42"`
+		StoreID         *int        `json:"store_id,omitempty" example:"2"`
+		Status          string      `json:"status" example:"pending"`
+		Fulfilment      string      `json:"fulfilment" example:"pickup"`
+		DeliveryAddress string      `json:"delivery_address,omitempty"`
+		Day             string      `json:"day" example:"2026-10-18"`
+		Slot            string      `json:"slot,omitempty" example:"09:00"`
+		BookingID       *int        `json:"booking_id,omitempty"`
+		Items           []Item      `json:"items"`
+		Total           money.Money `json:"total"`
+		CreatedAt       time.Time   `json:"created_at"`
+		UpdatedAt       *time.Time  `json:"updated_at,omitempty"`
+	}
+	// Item is a line of an order, priced when the order was placed.
+	Item struct {
+		CakeID        int         `json:"cake_id"`
+		VariantID     int         `json:"variant_id"`
+		Title         string      `json:"title"`
+		Size          string      `json:"size"`
+		SKU           string      `json:"sku"`
+		Quantity      int         `json:"quantity"`
+		UnitPrice     money.Money `json:"unit_price"`
+		LineTotal     money.Money `json:"line_total"`
+		ReservationID *int        `json:"reservation_id,omitempty"`
+	}
+	ListRequestDto struct {
+		CustomerID string `param:"customer_id" query:"customer_id" json:"customer_id"`
+		Status     string `query:"status" json:"status" validate:"omitempty,oneof=pending confirmed baking ready picked_up delivered cancelled"`
+		Offset     int    `query:"offset" json:"offset" validate:"omitempty,gte=0"`
+		Limit      int    `query:"limit" json:"limit" validate:"omitempty,gte=0,lte=100"`
+	}
+	RequestDto struct {
+		CustomerID string `json:"customer_id" validate:"required,max=64" example:"customer-42"`
+		// StoreID is the store the order is priced at and placed with; the
+		// catalog prices apply without one.
+		StoreID         int    `json:"store_id" validate:"omitempty,gt=0" example:"2"`
+		Fulfilment      string `json:"fulfilment" validate:"omitempty,oneof=pickup delivery" example:"pickup"`
+		DeliveryAddress string `json:"delivery_address" validate:"required_if=Fulfilment delivery,max=255"`
+		Day             string `json:"day" validate:"required,datetime=2006-01-02" example:"2026-10-18"`
+		// Slot is when the order is picked up or delivered on its day,
+		// booked when scheduling is enabled.
+		Slot  string    `json:"slot" validate:"omitempty,datetime=15:04" example:"09:00"`
+		Items []ItemDto `json:"items" validate:"required,min=1,max=50,dive"`
+	}
+	ItemDto struct {
+		CakeID    int `json:"cake_id" validate:"required,gt=0"`
+		VariantID int `json:"variant_id" validate:"required,gt=0"`
+		Quantity  int `json:"quantity" validate:"required,gt=0,lte=100"`
+	}
+)
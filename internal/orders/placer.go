@@ -0,0 +1,229 @@
+package orders
+
+import (
+	"cake-store/internal/inventory"
+	"cake-store/internal/scheduling"
+	"cake-store/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+// Option configures the packages orders are placed with.
+type Option func(*Placer)
+
+// WithInventory reserves the stock of new orders, commits it when they are
+// handed over and releases it when they are cancelled.
+func WithInventory(repo inventory.RepoInterface) Option {
+	return func(p *Placer) {
+		p.stock = repo
+	}
+}
+
+// WithScheduling books the slot of new orders and releases it when they are
+// cancelled.
+func WithScheduling(scheduler scheduling.Scheduler) Option {
+	return func(p *Placer) {
+		p.scheduler = &scheduler
+	}
+}
+
+// WithTransactions moves orders to a new status in the same transaction as
+// the reservations and booking they commit or release, so an order is never
+// left in a status its stock and slot were not settled for.
+func WithTransactions(tx storage.Transactor) Option {
+	return func(p *Placer) {
+		p.tx = tx
+	}
+}
+
+// WithLogger logs the reservations and bookings that could not be given
+// back after placing an order failed. Without it they go to the default
+// logger.
+func WithLogger(logger echo.Logger) Option {
+	return func(p *Placer) {
+		p.logger = logger
+	}
+}
+
+// Placer prices, reserves and stores new orders, and moves them through
+// their statuses. It is shared by the order handler and anything else
+// turning items into an order.
+type Placer struct {
+	repo      RepoInterface
+	catalog   Catalog
+	stock     inventory.RepoInterface
+	scheduler *scheduling.Scheduler
+	tx        storage.Transactor
+	logger    echo.Logger
+}
+
+func NewPlacer(repo RepoInterface, catalog Catalog, options ...Option) Placer {
+	p := Placer{repo: repo, catalog: catalog, tx: storage.NewTransactor(nil), logger: log.New("orders")}
+	for _, option := range options {
+		option(&p)
+	}
+	return p
+}
+
+// Place stores request as a pending order priced from the catalog, or the
+// menu of its store, holding
+// the stock of its items when inventory is enabled and its slot when
+// scheduling is.
+func (p Placer) Place(ctx context.Context, request RequestDto) (*Order, error) {
+	if request.Day < inventory.Today() {
+		return nil, fmt.Errorf("%w: %s has passed", ErrValidation, request.Day)
+	}
+	if request.Fulfilment == "" {
+		request.Fulfilment = FulfilmentPickup
+	}
+
+	items, total, err := p.catalog.Price(ctx, request.StoreID, request.Items)
+	if err != nil {
+		return nil, err
+	}
+	order := Order{
+		CustomerID:      request.CustomerID,
+		StoreID:         storeRef(request.StoreID),
+		Status:          StatusPending,
+		Fulfilment:      request.Fulfilment,
+		DeliveryAddress: request.DeliveryAddress,
+		Day:             request.Day,
+		Slot:            request.Slot,
+		Items:           items,
+		Total:           total,
+	}
+	if order.BookingID, err = p.book(ctx, order); err != nil {
+		return nil, err
+	}
+	if err := p.reserve(ctx, request.Day, items); err != nil {
+		p.undo(ctx, Order{BookingID: order.BookingID})
+		return nil, err
+	}
+
+	created, err := p.repo.Create(ctx, order)
+	if err != nil {
+		p.undo(ctx, order)
+		return nil, err
+	}
+	return created, nil
+}
+
+// Transition moves order to status, committing or releasing what it holds
+// in the same transaction, so the order keeps its status when they cannot
+// be settled.
+func (p Placer) Transition(ctx context.Context, order Order, status string) (*Order, error) {
+	if err := CanTransition(order, status); err != nil {
+		return nil, err
+	}
+	var updated *Order
+	err := p.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		if updated, err = p.repo.Transition(ctx, order.ID, order.Status, status); err != nil {
+			return err
+		}
+		return p.settle(ctx, *updated, status)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// book takes a place in the slot of order, if it has one.
+func (p Placer) book(ctx context.Context, order Order) (*int, error) {
+	if order.Slot == "" {
+		return nil, nil
+	}
+	if p.scheduler == nil {
+		return nil, fmt.Errorf("%w: slots are not enabled", ErrValidation)
+	}
+	cakeIDs := make([]int, len(order.Items))
+	for n, item := range order.Items {
+		cakeIDs[n] = item.CakeID
+	}
+	booking, err := p.scheduler.Book(ctx, scheduling.BookRequestDto{Day: order.Day, Start: order.Slot, CakeIDs: cakeIDs})
+	if errors.Is(err, scheduling.ErrFull) {
+		err = fmt.Errorf("%w: the %s slot on %s is fully booked", ErrConflict, order.Slot, order.Day)
+	}
+	if errors.Is(err, scheduling.ErrValidation) {
+		err = fmt.Errorf("%w: %s on %s is not a slot these cakes can be ordered for", ErrValidation, order.Slot, order.Day)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &booking.ID, nil
+}
+
+// reserve holds the stock of each item for day, releasing what it held when
+// an item is short.
+func (p Placer) reserve(ctx context.Context, day string, items []Item) error {
+	if p.stock == nil {
+		return nil
+	}
+	for n := range items {
+		reservation, err := p.stock.Reserve(ctx, inventory.ReserveRequestDto{VariantID: items[n].VariantID, Day: day, Quantity: items[n].Quantity})
+		if errors.Is(err, inventory.ErrInsufficient) {
+			err = fmt.Errorf("%w: %s (%s) is sold out for %s", ErrConflict, items[n].Title, items[n].Size, day)
+		}
+		if errors.Is(err, inventory.ErrNotFound) {
+			err = fmt.Errorf("%w: %s (%s) is not stocked for %s", ErrConflict, items[n].Title, items[n].Size, day)
+		}
+		if err != nil {
+			p.undo(ctx, Order{Items: items[:n]})
+			return err
+		}
+		items[n].ReservationID = &reservation.ID
+	}
+	return nil
+}
+
+// undo releases what an order held once placing it failed. The error placing
+// it is the one returned, so what cannot be released is logged.
+func (p Placer) undo(ctx context.Context, order Order) {
+	if err := p.settle(ctx, order, StatusCancelled); err != nil {
+		p.logger.Warnf("orders: releasing the stock and slot of an order that failed: %v", err)
+	}
+}
+
+// settle commits the reservations of the items of order once handed over
+// and releases them, and its slot, once cancelled. A reservation or booking
+// settled directly through their own endpoints, or a reservation deleted
+// with its cake, is left as it is.
+func (p Placer) settle(ctx context.Context, order Order, status string) error {
+	if p.scheduler != nil && order.BookingID != nil && status == StatusCancelled {
+		_, err := p.scheduler.Release(ctx, *order.BookingID)
+		if err != nil && !errors.Is(err, scheduling.ErrConflict) {
+			return err
+		}
+	}
+	if p.stock == nil {
+		return nil
+	}
+	for _, item := range order.Items {
+		if item.ReservationID == nil {
+			continue
+		}
+		var err error
+		switch status {
+		case StatusCancelled:
+			_, err = p.stock.Release(ctx, *item.ReservationID)
+		case StatusPickedUp, StatusDelivered:
+			_, err = p.stock.Commit(ctx, *item.ReservationID)
+		}
+		if err != nil && !errors.Is(err, inventory.ErrConflict) && !errors.Is(err, inventory.ErrReservationNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// storeRef returns the nullable store of an order placed at storeID.
+func storeRef(storeID int) *int {
+	if storeID == 0 {
+		return nil
+	}
+	return &storeID
+}
@@ -0,0 +1,248 @@
+package orders
+
+//go:generate mockgen -destination=../../mocks/orders/mock_repository.go -package=mock_orders -source=repository.go
+
+import (
+	"cake-store/internal/money"
+	"cake-store/internal/query"
+	"cake-store/internal/storage"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const (
+	TableName = "orders"
+	ItemsName = "order_items"
+)
+
+// Columns lists the orders columns in the order scanned by scanOrder.
+var Columns = []string{"id", "customer_id", "store_id", "status", "fulfilment", "delivery_address", "day", "slot", "booking_id", "currency", "total_minor", "created_at", "updated_at"}
+
+// ItemColumns lists the order_items columns in the order scanned by
+// scanItem.
+var ItemColumns = []string{"order_id", "cake_id", "variant_id", "title", "size", "sku", "quantity", "unit_price_minor", "line_total_minor", "reservation_id"}
+
+type repoImplementation struct {
+	db *sql.DB
+	tx storage.Transactor
+}
+
+type RepoInterface interface {
+	// List returns the orders matching dto, newest first.
+	List(ctx context.Context, dto ListRequestDto) ([]Order, error)
+	Get(ctx context.Context, id int) (*Order, error)
+	// Create stores a priced order with its items.
+	Create(ctx context.Context, order Order) (*Order, error)
+	// Transition moves an order from one status to another, or returns
+	// ErrConflict when the order is no longer in from.
+	Transition(ctx context.Context, id int, from, to string) (*Order, error)
+}
+
+// NewRepository returns a RepoInterface storing orders with their
+// order_items, both written in one transaction.
+func NewRepository(db *sql.DB) RepoInterface {
+	return repoImplementation{db, storage.NewTransactor(db)}
+}
+
+func scanOrder(scan func(dest ...interface{}) error) (order Order, err error) {
+	var (
+		currency  string
+		total     int64
+		bookingID sql.NullInt64
+		storeID   sql.NullInt64
+	)
+	err = scan(&order.ID, &order.CustomerID, &storeID, &order.Status, &order.Fulfilment, &order.DeliveryAddress, &order.Day, &order.Slot, &bookingID, &currency, &total, &order.CreatedAt, &order.UpdatedAt)
+	if bookingID.Valid {
+		id := int(bookingID.Int64)
+		order.BookingID = &id
+	}
+	if storeID.Valid {
+		id := int(storeID.Int64)
+		order.StoreID = &id
+	}
+	order.Total = money.New(total, currency)
+	return
+}
+
+func scanItem(scan func(dest ...interface{}) error) (orderID int, item Item, err error) {
+	var (
+		unitPrice, lineTotal int64
+		reservationID        sql.NullInt64
+	)
+	err = scan(&orderID, &item.CakeID, &item.VariantID, &item.Title, &item.Size, &item.SKU, &item.Quantity, &unitPrice, &lineTotal, &reservationID)
+	if reservationID.Valid {
+		id := int(reservationID.Int64)
+		item.ReservationID = &id
+	}
+	// The currency is the order's, set by withItems.
+	item.UnitPrice.Amount, item.LineTotal.Amount = unitPrice, lineTotal
+	return
+}
+
+// withItems loads the items of each of the orders.
+func withItems(ctx context.Context, db storage.Conn, orders []Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+	ids := make([]int, len(orders))
+	for n, order := range orders {
+		ids[n] = order.ID
+	}
+	q, args := query.Select(ItemsName, ItemColumns...).
+		Where(query.In("order_id", query.Ints(ids)...)).
+		OrderBy("id ASC").
+		Build()
+	rows, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	items := map[int][]Item{}
+	for rows.Next() {
+		orderID, item, err := scanItem(rows.Scan)
+		if err != nil {
+			return err
+		}
+		items[orderID] = append(items[orderID], item)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for n := range orders {
+		currency := orders[n].Total.Currency
+		orders[n].Items = []Item{}
+		for _, item := range items[orders[n].ID] {
+			item.UnitPrice = money.New(item.UnitPrice.Amount, currency)
+			item.LineTotal = money.New(item.LineTotal.Amount, currency)
+			orders[n].Items = append(orders[n].Items, item)
+		}
+	}
+	return nil
+}
+
+func (i repoImplementation) List(ctx context.Context, dto ListRequestDto) ([]Order, error) {
+	builder := query.Select(TableName, Columns...)
+	if dto.CustomerID != "" {
+		builder.Where(query.Eq("customer_id", dto.CustomerID))
+	}
+	if dto.Status != "" {
+		builder.Where(query.Eq("status", dto.Status))
+	}
+	builder.OrderBy("created_at DESC", "id DESC")
+	if dto.Limit > 0 {
+		builder.Limit(dto.Limit).Offset(dto.Offset)
+	}
+	q, args := builder.Build()
+	rows, err := storage.Using(ctx, i.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []Order{}
+	for rows.Next() {
+		order, err := scanOrder(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, order)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+	if err := withItems(ctx, storage.Using(ctx, i.db), result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+func (i repoImplementation) Get(ctx context.Context, id int) (*Order, error) {
+	q, args := query.Select(TableName, Columns...).Where(query.Eq("id", id)).Build()
+	order, err := scanOrder(storage.Using(ctx, i.db).QueryRowContext(ctx, q, args...).Scan)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := []Order{order}
+	if err := withItems(ctx, storage.Using(ctx, i.db), result); err != nil {
+		return nil, err
+	}
+	return &result[0], nil
+}
+func (i repoImplementation) Create(ctx context.Context, order Order) (*Order, error) {
+	var id int64
+	err := i.tx.InTx(ctx, func(ctx context.Context) error {
+		tx := storage.Using(ctx, i.db)
+		q, args := query.Insert(TableName).
+			Set("customer_id", order.CustomerID).
+			Set("store_id", order.StoreID).
+			Set("status", order.Status).
+			Set("fulfilment", order.Fulfilment).
+			Set("delivery_address", order.DeliveryAddress).
+			Set("day", order.Day).
+			Set("slot", order.Slot).
+			Set("booking_id", order.BookingID).
+			Set("currency", order.Total.Currency).
+			Set("total_minor", order.Total.Amount).
+			Set("created_at", storage.TruncateTime(time.Now())).
+			Build()
+		res, err := tx.ExecContext(ctx, q, args...)
+		if err != nil {
+			return err
+		}
+		id, err = res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		for _, item := range order.Items {
+			q, args := query.Insert(ItemsName).
+				Set("order_id", id).
+				Set("cake_id", item.CakeID).
+				Set("variant_id", item.VariantID).
+				Set("title", item.Title).
+				Set("size", item.Size).
+				Set("sku", item.SKU).
+				Set("quantity", item.Quantity).
+				Set("unit_price_minor", item.UnitPrice.Amount).
+				Set("line_total_minor", item.LineTotal.Amount).
+				Set("reservation_id", item.ReservationID).
+				Build()
+			if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return i.Get(ctx, int(id))
+}
+func (i repoImplementation) Transition(ctx context.Context, id int, from, to string) (*Order, error) {
+	q, args := query.Update(TableName).
+		Set("status", to).
+		Set("updated_at", storage.TruncateTime(time.Now())).
+		Where(query.Eq("id", id), query.Eq("status", from)).
+		Build()
+	res, err := storage.Using(ctx, i.db).ExecContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		current, err := i.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: the order is %s now", ErrConflict, current.Status)
+	}
+	return i.Get(ctx, id)
+}
@@ -0,0 +1,46 @@
+package orders
+
+import "fmt"
+
+// Order statuses. Picked up, delivered and cancelled orders are final.
+const (
+	StatusPending   = "pending"
+	StatusConfirmed = "confirmed"
+	StatusBaking    = "baking"
+	StatusReady     = "ready"
+	StatusPickedUp  = "picked_up"
+	StatusDelivered = "delivered"
+	StatusCancelled = "cancelled"
+)
+
+// transitions lists the statuses each status can move to. Orders can only
+// be cancelled before baking starts.
+var transitions = map[string][]string{
+	StatusPending:   {StatusConfirmed, StatusCancelled},
+	StatusConfirmed: {StatusBaking, StatusCancelled},
+	StatusBaking:    {StatusReady},
+	StatusReady:     {StatusPickedUp, StatusDelivered},
+}
+
+// CanTransition returns ErrConflict unless order can move to status. Ready
+// orders are picked up or delivered according to their fulfilment.
+func CanTransition(order Order, status string) error {
+	allowed := false
+	for _, next := range transitions[order.Status] {
+		allowed = allowed || next == status
+	}
+	switch {
+	case status == StatusPickedUp && order.Fulfilment != FulfilmentPickup,
+		status == StatusDelivered && order.Fulfilment != FulfilmentDelivery:
+		allowed = false
+	}
+	if !allowed {
+		return fmt.Errorf("%w: cannot move a %s order to %s", ErrConflict, order.Status, status)
+	}
+	return nil
+}
+
+// Final reports whether no status follows status.
+func Final(status string) bool {
+	return len(transitions[status]) == 0
+}
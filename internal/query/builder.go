@@ -0,0 +1,310 @@
+// Package query builds parameterized SQL statements.
+//
+// Builders never interpolate values into the statement text: every value is
+// rendered as a `?` placeholder and returned in the argument slice, which is
+// understood by both the MySQL and SQLite drivers.
+package query
+
+import (
+	"strings"
+)
+
+// LikeEscape is the escape character used by Like conditions. It is passed
+// explicitly with ESCAPE so the behaviour does not depend on SQL modes.
+const LikeEscape = "!"
+
+var likeReplacer = strings.NewReplacer(LikeEscape, LikeEscape+LikeEscape, "%", LikeEscape+"%", "_", LikeEscape+"_")
+
+// Condition is a SQL boolean expression with its placeholder arguments.
+type Condition struct {
+	Expr string
+	Args []interface{}
+}
+
+// Expr returns a raw condition. The expression must only contain `?`
+// placeholders for values, never the values themselves.
+func Expr(expr string, args ...interface{}) Condition {
+	return Condition{Expr: expr, Args: args}
+}
+
+// Eq returns `column = ?`.
+func Eq(column string, value interface{}) Condition {
+	return Condition{Expr: column + " = ?", Args: []interface{}{value}}
+}
+
+// Like returns a condition matching column against value as a substring,
+// escaping LIKE wildcards contained in value.
+func Like(column string, value string) Condition {
+	return Condition{
+		Expr: column + " LIKE ? ESCAPE '" + LikeEscape + "'",
+		Args: []interface{}{"%" + likeReplacer.Replace(value) + "%"},
+	}
+}
+
+// In returns `column IN (?, ?, ...)`. An empty list matches nothing.
+func In(column string, values ...interface{}) Condition {
+	if len(values) == 0 {
+		return Condition{Expr: "1 = 0"}
+	}
+	return Condition{Expr: column + " IN (" + placeholders(len(values)) + ")", Args: values}
+}
+
+// NotIn returns `column NOT IN (?, ?, ...)`. An empty list matches everything.
+func NotIn(column string, values ...interface{}) Condition {
+	if len(values) == 0 {
+		return Condition{Expr: "1 = 1"}
+	}
+	return Condition{Expr: column + " NOT IN (" + placeholders(len(values)) + ")", Args: values}
+}
+
+type where struct {
+	conditions []Condition
+}
+
+func (w *where) add(c Condition) {
+	w.conditions = append(w.conditions, c)
+}
+
+func (w where) build(sb *strings.Builder, args []interface{}) []interface{} {
+	if len(w.conditions) == 0 {
+		return args
+	}
+	sb.WriteString(" WHERE ")
+	for i, c := range w.conditions {
+		if i > 0 {
+			sb.WriteString(" AND ")
+		}
+		sb.WriteString("(" + c.Expr + ")")
+		args = append(args, c.Args...)
+	}
+	return args
+}
+
+// SelectBuilder builds SELECT and SELECT COUNT(*) statements.
+type SelectBuilder struct {
+	table      string
+	columns    []string
+	columnArgs []interface{}
+	where      where
+	groupBy    []string
+	orderBy    []string
+	orderArgs  []interface{}
+	limit      *int
+	offset     *int
+}
+
+// Select starts a SELECT of columns from table.
+func Select(table string, columns ...string) *SelectBuilder {
+	return &SelectBuilder{table: table, columns: columns}
+}
+
+// Column appends a selected expression with its placeholder arguments.
+func (b *SelectBuilder) Column(expr string, args ...interface{}) *SelectBuilder {
+	b.columns = append(b.columns, expr)
+	b.columnArgs = append(b.columnArgs, args...)
+	return b
+}
+
+// Where adds conditions joined with AND.
+func (b *SelectBuilder) Where(conditions ...Condition) *SelectBuilder {
+	for _, c := range conditions {
+		b.where.add(c)
+	}
+	return b
+}
+
+// GroupBy appends GROUP BY terms. Terms are written verbatim.
+func (b *SelectBuilder) GroupBy(terms ...string) *SelectBuilder {
+	b.groupBy = append(b.groupBy, terms...)
+	return b
+}
+
+// OrderBy appends ORDER BY terms such as "rating DESC". Terms are written
+// verbatim and must never come from user input.
+func (b *SelectBuilder) OrderBy(terms ...string) *SelectBuilder {
+	b.orderBy = append(b.orderBy, terms...)
+	return b
+}
+
+// OrderByExpr appends an ORDER BY term that contains placeholders.
+func (b *SelectBuilder) OrderByExpr(term string, args ...interface{}) *SelectBuilder {
+	b.orderBy = append(b.orderBy, term)
+	b.orderArgs = append(b.orderArgs, args...)
+	return b
+}
+
+// Limit sets the LIMIT clause.
+func (b *SelectBuilder) Limit(n int) *SelectBuilder {
+	b.limit = &n
+	return b
+}
+
+// Offset sets the OFFSET clause.
+func (b *SelectBuilder) Offset(n int) *SelectBuilder {
+	b.offset = &n
+	return b
+}
+
+// Build returns the SELECT statement and its arguments.
+func (b *SelectBuilder) Build() (string, []interface{}) {
+	var sb strings.Builder
+	sb.WriteString("SELECT " + strings.Join(b.columns, ", ") + " FROM " + b.table)
+	args := b.where.build(&sb, append([]interface{}{}, b.columnArgs...))
+	if len(b.groupBy) > 0 {
+		sb.WriteString(" GROUP BY " + strings.Join(b.groupBy, ", "))
+	}
+	if len(b.orderBy) > 0 {
+		sb.WriteString(" ORDER BY " + strings.Join(b.orderBy, ", "))
+		args = append(args, b.orderArgs...)
+	}
+	if b.limit != nil {
+		sb.WriteString(" LIMIT ?")
+		args = append(args, *b.limit)
+	}
+	if b.offset != nil {
+		sb.WriteString(" OFFSET ?")
+		args = append(args, *b.offset)
+	}
+	return sb.String(), args
+}
+
+// Count returns a SELECT COUNT(*) statement with the same conditions,
+// ignoring ordering and pagination.
+func (b *SelectBuilder) Count() (string, []interface{}) {
+	var sb strings.Builder
+	sb.WriteString("SELECT COUNT(*) FROM " + b.table)
+	args := b.where.build(&sb, nil)
+	return sb.String(), args
+}
+
+type assignment struct {
+	column string
+	expr   string
+	value  interface{}
+	args   []interface{}
+}
+
+// InsertBuilder builds single-row INSERT statements.
+type InsertBuilder struct {
+	table       string
+	assignments []assignment
+}
+
+// Insert starts an INSERT into table.
+func Insert(table string) *InsertBuilder {
+	return &InsertBuilder{table: table}
+}
+
+// Set adds a column and its value.
+func (b *InsertBuilder) Set(column string, value interface{}) *InsertBuilder {
+	b.assignments = append(b.assignments, assignment{column: column, value: value})
+	return b
+}
+
+// Build returns the INSERT statement and its arguments.
+func (b *InsertBuilder) Build() (string, []interface{}) {
+	columns := make([]string, 0, len(b.assignments))
+	args := make([]interface{}, 0, len(b.assignments))
+	for _, a := range b.assignments {
+		columns = append(columns, a.column)
+		args = append(args, a.value)
+	}
+	return "INSERT INTO " + b.table + " (" + strings.Join(columns, ", ") + ") VALUES (" + placeholders(len(args)) + ")", args
+}
+
+// UpdateBuilder builds UPDATE statements.
+type UpdateBuilder struct {
+	table       string
+	assignments []assignment
+	where       where
+}
+
+// Update starts an UPDATE of table.
+func Update(table string) *UpdateBuilder {
+	return &UpdateBuilder{table: table}
+}
+
+// Set assigns a value to column.
+func (b *UpdateBuilder) Set(column string, value interface{}) *UpdateBuilder {
+	b.assignments = append(b.assignments, assignment{column: column, value: value})
+	return b
+}
+
+// SetExpr assigns a raw SQL expression such as CURRENT_TIMESTAMP or
+// "stock + ?" to column, with the arguments of its placeholders.
+func (b *UpdateBuilder) SetExpr(column string, expr string, args ...interface{}) *UpdateBuilder {
+	b.assignments = append(b.assignments, assignment{column: column, expr: expr, args: args})
+	return b
+}
+
+// Where adds conditions joined with AND.
+func (b *UpdateBuilder) Where(conditions ...Condition) *UpdateBuilder {
+	for _, c := range conditions {
+		b.where.add(c)
+	}
+	return b
+}
+
+// Build returns the UPDATE statement and its arguments.
+func (b *UpdateBuilder) Build() (string, []interface{}) {
+	var sb strings.Builder
+	var args []interface{}
+	sb.WriteString("UPDATE " + b.table + " SET ")
+	for i, a := range b.assignments {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		if a.expr != "" {
+			sb.WriteString(a.column + " = " + a.expr)
+			args = append(args, a.args...)
+			continue
+		}
+		sb.WriteString(a.column + " = ?")
+		args = append(args, a.value)
+	}
+	args = b.where.build(&sb, args)
+	return sb.String(), args
+}
+
+// DeleteBuilder builds DELETE statements.
+type DeleteBuilder struct {
+	table string
+	where where
+}
+
+// Delete starts a DELETE from table.
+func Delete(table string) *DeleteBuilder {
+	return &DeleteBuilder{table: table}
+}
+
+// Where adds conditions joined with AND.
+func (b *DeleteBuilder) Where(conditions ...Condition) *DeleteBuilder {
+	for _, c := range conditions {
+		b.where.add(c)
+	}
+	return b
+}
+
+// Build returns the DELETE statement and its arguments.
+func (b *DeleteBuilder) Build() (string, []interface{}) {
+	var sb strings.Builder
+	sb.WriteString("DELETE FROM " + b.table)
+	args := b.where.build(&sb, nil)
+	return sb.String(), args
+}
+
+func placeholders(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strings.Repeat("?, ", n-1) + "?"
+}
+
+// Ints converts ints into arguments for In.
+func Ints(values []int) []interface{} {
+	args := make([]interface{}, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return args
+}
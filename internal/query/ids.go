@@ -0,0 +1,49 @@
+package query
+
+// IDs are the IDs of the rows another repository picked, for filtering on
+// them with InIDs and NotInIDs. SQL repositories return a subquery, so the
+// database filters on it within the one statement; memory repositories,
+// which have no statement to join, return the IDs themselves.
+type IDs struct {
+	// Query selects a single column of IDs, with the arguments of its
+	// placeholders in Args. It is empty for a List.
+	Query string
+	Args  []interface{}
+	// List holds the IDs when there is no Query.
+	List []int
+}
+
+// Subquery returns the IDs builder selects. Its first column must be the
+// ID.
+func Subquery(builder *SelectBuilder) IDs {
+	q, args := builder.Build()
+	return IDs{Query: q, Args: args}
+}
+
+// Contains reports whether id is in a List. Subqueries can only be
+// checked by the database and contain nothing.
+func (ids IDs) Contains(id int) bool {
+	for _, candidate := range ids.List {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+// InIDs returns `column IN (...)` over ids. An empty list matches nothing.
+func InIDs(column string, ids IDs) Condition {
+	if ids.Query == "" {
+		return In(column, Ints(ids.List)...)
+	}
+	return Condition{Expr: column + " IN (" + ids.Query + ")", Args: ids.Args}
+}
+
+// NotInIDs returns `column NOT IN (...)` over ids. An empty list matches
+// everything.
+func NotInIDs(column string, ids IDs) Condition {
+	if ids.Query == "" {
+		return NotIn(column, Ints(ids.List)...)
+	}
+	return Condition{Expr: column + " NOT IN (" + ids.Query + ")", Args: ids.Args}
+}
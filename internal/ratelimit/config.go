@@ -0,0 +1,90 @@
+// Package ratelimit throttles clients with token buckets, keyed by API key,
+// user or IP address.
+package ratelimit
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit lets a client make Requests requests per Period. Unused requests
+// add up to at most Requests, so a quiet client may burst. The zero Limit
+// does not limit at all.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Unlimited reports whether the limit lets every request through.
+func (l Limit) Unlimited() bool {
+	return l.Requests <= 0 || l.Period <= 0
+}
+
+// String formats the limit as ParseLimit reads it.
+func (l Limit) String() string {
+	if l.Unlimited() {
+		return "0"
+	}
+	return strconv.Itoa(l.Requests) + "/" + l.Period.String()
+}
+
+// ParseLimit reads a limit such as "120/1m"; "0" is no limit.
+func ParseLimit(value string) (Limit, error) {
+	if strings.TrimSpace(value) == "0" {
+		return Limit{}, nil
+	}
+	requests, period, found := strings.Cut(value, "/")
+	if !found {
+		return Limit{}, fmt.Errorf("%w: %q is not a limit such as 120/1m", ErrConfig, value)
+	}
+	limit := Limit{}
+	var err error
+	if limit.Requests, err = strconv.Atoi(strings.TrimSpace(requests)); err != nil || limit.Requests <= 0 {
+		return Limit{}, fmt.Errorf("%w: %q is not a positive number of requests", ErrConfig, requests)
+	}
+	if limit.Period, err = time.ParseDuration(strings.TrimSpace(period)); err != nil || limit.Period <= 0 {
+		return Limit{}, fmt.Errorf("%w: %q is not a positive duration", ErrConfig, period)
+	}
+	return limit, nil
+}
+
+var (
+	defaultLimit     = Limit{Requests: 120, Period: time.Minute}
+	defaultAuthLimit = Limit{Requests: 10, Period: time.Minute}
+	defaultIPLimit   = Limit{Requests: 600, Period: time.Minute}
+)
+
+// Config holds the limits of the routes.
+type Config struct {
+	// Default applies to every request.
+	Default Limit
+	// Auth applies to logging in, registering and refreshing tokens on
+	// top of Default, to slow down password guessing.
+	Auth Limit
+	// IP applies to every request from an IP address, checked before its
+	// credentials. It is looser than Default, as clients behind one
+	// address share it.
+	IP Limit
+}
+
+// ConfigFromEnv reads RATE_LIMIT, RATE_LIMIT_AUTH and RATE_LIMIT_IP,
+// defaulting to 120 requests a minute and 10 auth requests a minute per
+// client, and 600 requests a minute per IP address.
+func ConfigFromEnv() (Config, error) {
+	config := Config{Default: defaultLimit, Auth: defaultAuthLimit, IP: defaultIPLimit}
+	for name, limit := range map[string]*Limit{"RATE_LIMIT": &config.Default, "RATE_LIMIT_AUTH": &config.Auth, "RATE_LIMIT_IP": &config.IP} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := ParseLimit(value)
+		if err != nil {
+			return config, fmt.Errorf("%s: %w", name, err)
+		}
+		*limit = parsed
+	}
+	return config, nil
+}
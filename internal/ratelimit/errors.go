@@ -0,0 +1,11 @@
+package ratelimit
+
+import (
+	"cake-store/internal/helpers"
+	"fmt"
+)
+
+var (
+	ErrLimited = fmt.Errorf("request %w", helpers.ErrRateLimited)
+	ErrConfig  = fmt.Errorf("rate limit configuration %w", helpers.ErrValidation)
+)
@@ -0,0 +1,88 @@
+package ratelimit
+
+import (
+	"cake-store/internal/auth"
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Response headers, after the IETF RateLimit header fields draft.
+const (
+	HeaderLimit      = "RateLimit-Limit"
+	HeaderRemaining  = "RateLimit-Remaining"
+	HeaderReset      = "RateLimit-Reset"
+	HeaderRetryAfter = "Retry-After"
+)
+
+// Limiter throttles requests with buckets kept in a Store.
+type Limiter struct {
+	store Store
+}
+
+func NewLimiter(store Store) Limiter {
+	return Limiter{store}
+}
+
+// Limit throttles each client to limit, counting requests in a bucket of
+// its own per name, so routes can share a limit or have their own. It must
+// run after the authentication middlewares: clients are told apart by API
+// key, then by user, then by IP address. Refused requests get 429 with
+// Retry-After; every response reports the most exhausted bucket in the
+// RateLimit-* headers.
+func (l Limiter) Limit(name string, limit Limit) echo.MiddlewareFunc {
+	return l.limit(name, limit, auth.ClientID)
+}
+
+// LimitIP is Limit per IP address. It runs before the authentication
+// middlewares, so requests with invalid tokens or API keys are throttled
+// before their credentials are looked up.
+func (l Limiter) LimitIP(name string, limit Limit) echo.MiddlewareFunc {
+	return l.limit(name, limit, func(ctx echo.Context) string {
+		return "ip:" + ctx.RealIP()
+	})
+}
+
+func (l Limiter) limit(name string, limit Limit, client func(ctx echo.Context) string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if limit.Unlimited() {
+			return next
+		}
+		return func(ctx echo.Context) error {
+			result, err := l.store.Take(context.TODO(), name+":"+client(ctx), limit, time.Now())
+			if err != nil {
+				// Better to serve clients unthrottled than not at all.
+				ctx.Logger().Warnf("rate limit %s: %v", name, err)
+				return next(ctx)
+			}
+			setHeaders(ctx, limit, result)
+			if !result.Allowed {
+				retryAfter := seconds(result.RetryAfter)
+				ctx.Response().Header().Set(HeaderRetryAfter, strconv.Itoa(retryAfter))
+				return fmt.Errorf("%w: try again in %d seconds", ErrLimited, retryAfter)
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// setHeaders reports result, unless a limit applied before left fewer
+// requests.
+func setHeaders(ctx echo.Context, limit Limit, result Result) {
+	header := ctx.Response().Header()
+	if previous, err := strconv.Atoi(header.Get(HeaderRemaining)); err == nil && previous < result.Remaining {
+		return
+	}
+	header.Set(HeaderLimit, strconv.Itoa(limit.Requests))
+	header.Set(HeaderRemaining, strconv.Itoa(result.Remaining))
+	header.Set(HeaderReset, strconv.Itoa(seconds(result.ResetAfter)))
+}
+
+// seconds rounds d up to whole seconds, as the headers carry them.
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,103 @@
+package ratelimit
+
+//go:generate mockgen -destination=../../mocks/ratelimit/mock_store.go -package=mock_ratelimit -source=store.go
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Result is the state of a bucket after taking a request from it.
+type Result struct {
+	Allowed bool
+	// Remaining is how many more requests the bucket allows right now.
+	Remaining int
+	// RetryAfter is how long until the next request is allowed, zero when
+	// it already is.
+	RetryAfter time.Duration
+	// ResetAfter is how long until the bucket is full again.
+	ResetAfter time.Duration
+}
+
+// Store keeps the buckets. The memory store suits a single API process;
+// processes sharing limits need a shared store implementing the same
+// interface.
+type Store interface {
+	// Take takes a request from the bucket of key at now, refilled at the
+	// rate of limit, and reports whether it was allowed. Concurrent takes
+	// from one bucket never allow more than limit.
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error)
+}
+
+// sweepInterval is how often the memory store drops idle buckets.
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	// full is when the bucket is full again if left alone, so it can be
+	// dropped.
+	full time.Time
+}
+
+type memoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+}
+
+// NewMemoryStore returns a Store keeping buckets in process memory. Full
+// buckets are dropped, so memory grows with the clients of the last
+// period only.
+func NewMemoryStore() Store {
+	return &memoryStore{buckets: map[string]*bucket{}}
+}
+
+func (m *memoryStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sweep(now)
+	capacity := float64(limit.Requests)
+	rate := capacity / float64(limit.Period) // tokens per nanosecond
+
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now}
+		m.buckets[key] = b
+	}
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+float64(elapsed)*rate)
+		b.updated = now
+	}
+	// Shrinking a limit leaves buckets fuller than it allows.
+	b.tokens = math.Min(b.tokens, capacity)
+
+	result := Result{}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration(math.Ceil((1 - b.tokens) / rate))
+	}
+	result.Remaining = int(b.tokens)
+	result.ResetAfter = time.Duration(math.Ceil((capacity - b.tokens) / rate))
+	b.full = now.Add(result.ResetAfter)
+	return result, nil
+}
+
+// sweep drops the buckets that are full by now, at most once per
+// sweepInterval.
+func (m *memoryStore) sweep(now time.Time) {
+	if now.Sub(m.swept) < sweepInterval {
+		return
+	}
+	m.swept = now
+	for key, b := range m.buckets {
+		if !now.Before(b.full) {
+			delete(m.buckets, key)
+		}
+	}
+}
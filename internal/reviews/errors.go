@@ -0,0 +1,12 @@
+package reviews
+
+import (
+	"cake-store/internal/helpers"
+	"fmt"
+)
+
+var (
+	ErrNotFound   = fmt.Errorf("review %w", helpers.ErrNotFound)
+	ErrConflict   = fmt.Errorf("review %w", helpers.ErrConflict)
+	ErrValidation = fmt.Errorf("review %w", helpers.ErrValidation)
+)
@@ -0,0 +1,251 @@
+package reviews
+
+import (
+	"cake-store/internal/auth"
+	"cake-store/internal/cakes"
+	"cake-store/internal/storage"
+	"context"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+)
+
+type SvcInterface interface {
+	List(ctx echo.Context) error
+	Get(ctx echo.Context) error
+	Create(ctx echo.Context) error
+	Delete(ctx echo.Context) error
+	// Transition returns the handler moderating a review to status.
+	Transition(status string) echo.HandlerFunc
+}
+
+type svcImplementation struct {
+	repo  RepoInterface
+	cakes cakes.RepoInterface
+	tx    storage.Transactor
+}
+
+// NewHandler returns the review handler, keeping the ratings of the cakes
+// in cakesRepo in line with their approved reviews: every moderation
+// changes the review and the rating in one transaction of tx.
+func NewHandler(repo RepoInterface, cakesRepo cakes.RepoInterface, tx storage.Transactor) SvcInterface {
+	return svcImplementation{repo, cakesRepo, tx}
+}
+
+// rate applies the rating change of moving review from status from to
+// status to. Reviews of deleted cakes go with them, so a missing cake is
+// not an error.
+func (s svcImplementation) rate(ctx context.Context, review Review, from, to string) error {
+	stars, count := RatingChange(review, from, to)
+	if count == 0 {
+		return nil
+	}
+	err := s.cakes.AddRating(ctx, review.CakeID, stars, count)
+	if errors.Is(err, cakes.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// List godoc
+// @Summary List reviews
+// @Description This endpoint for get reviews, newest first. The reviews of a cake are the approved ones, other statuses only for staff; the moderation queue defaults to the pending ones.
+// @Tags Reviews
+// @Accept  json
+// @Produce  json
+// @Param id path string false "cake id"
+// @Param services query ListRequestDto true "Find query"
+// @Success 200 {array} Review
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /cakes/{id}/reviews [get]
+// @Router /reviews [get]
+func (s svcImplementation) List(ctx echo.Context) error {
+	request := ListRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	if request.CakeID != 0 {
+		if _, err := s.cakes.Get(context.TODO(), request.CakeID); err != nil {
+			return err
+		}
+	}
+	// The reviews of a cake are public only once approved; the queue is
+	// for staff only already.
+	if request.CakeID != 0 && (request.Status == "" || !auth.HasRole(ctx, auth.RoleAdmin, auth.RoleStaff)) {
+		request.Status = StatusApproved
+	}
+	if request.Status == "" {
+		request.Status = StatusPending
+	}
+	if request.Limit == 0 {
+		request.Limit = 20
+	}
+
+	res, err := s.repo.List(context.TODO(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// Get godoc
+// @Summary Get detail of review
+// @Description This endpoint for get detail of a review
+// @Tags Reviews
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path string true "review id"
+// @Success 200 {object} Review
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /reviews/{id} [get]
+func (s svcImplementation) Get(ctx echo.Context) error {
+	ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid id")
+	}
+
+	data, err := s.repo.Get(context.TODO(), ID)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, data)
+}
+
+// Create godoc
+// @Summary Review cake
+// @Description This endpoint for posting a review of a cake; it counts towards the rating once approved. Reviews of customers are signed with their name, whatever author is sent
+// @Tags Reviews
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path string true "cake id"
+// @Param Request body RequestDto true "Create review"
+// @Success 201 {object} Review
+// @Header 201 {string} Location "/reviews/{id}"
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /cakes/{id}/reviews [post]
+func (s svcImplementation) Create(ctx echo.Context) error {
+	request := RequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+	if principal, ok := auth.Customer(ctx); ok {
+		request.Author = principal.DisplayName()
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	if _, err := s.cakes.Get(context.TODO(), request.CakeID); err != nil {
+		return err
+	}
+
+	created, err := s.repo.Create(context.TODO(), request)
+	if err != nil {
+		return err
+	}
+	ctx.Response().Header().Set(echo.HeaderLocation, "/reviews/"+strconv.Itoa(created.ID))
+	return ctx.JSON(http.StatusCreated, created)
+}
+
+// Transition godoc
+// @Summary Moderate review
+// @Description These endpoints approve or reject a review. Approved reviews can be rejected and rejected ones approved later; the cake rating follows.
+// @Tags Reviews
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path string true "review id"
+// @Success 200 {object} Review
+// @Failure 404 {object} helpers.Problem
+// @Failure 409 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /reviews/{id}/approve [post]
+// @Router /reviews/{id}/reject [post]
+func (s svcImplementation) Transition(status string) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		ID, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid id")
+		}
+
+		review, err := s.repo.Get(context.TODO(), ID)
+		if err != nil {
+			return err
+		}
+		if err := CanTransition(*review, status); err != nil {
+			return err
+		}
+
+		var updated *Review
+		err = s.tx.InTx(context.TODO(), func(tx context.Context) error {
+			var err error
+			if updated, err = s.repo.Transition(tx, ID, review.Status, status); err != nil {
+				return err
+			}
+			return s.rate(tx, *review, review.Status, status)
+		})
+		if err != nil {
+			return err
+		}
+		return ctx.JSON(http.StatusOK, updated)
+	}
+}
+
+// Delete godoc
+// @Summary Delete review
+// @Description This endpoint for deleting a review; an approved review stops counting towards the rating
+// @Tags Reviews
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path string true "review id"
+// @Success 200 {string} string
+// @Failure 404 {object} helpers.Problem
+// @Failure 409 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /reviews/{id} [delete]
+func (s svcImplementation) Delete(ctx echo.Context) error {
+	ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid id")
+	}
+
+	review, err := s.repo.Get(context.TODO(), ID)
+	if err != nil {
+		return err
+	}
+	err = s.tx.InTx(context.TODO(), func(tx context.Context) error {
+		if err := s.repo.Delete(tx, ID, review.Status); err != nil {
+			return err
+		}
+		return s.rate(tx, *review, review.Status, "")
+	})
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, "Success")
+}
@@ -0,0 +1,137 @@
+package reviews
+
+import (
+	"cake-store/internal/storage"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+type memoryRepoImplementation struct {
+	mu      sync.RWMutex
+	reviews map[int]Review
+	nextID  int
+}
+
+// NewMemoryRepository returns a RepoInterface that keeps reviews by ID and
+// lists them newest first, like the SQL repository.
+func NewMemoryRepository() RepoInterface {
+	return &memoryRepoImplementation{
+		reviews: map[int]Review{},
+		nextID:  1,
+	}
+}
+
+func copyReview(review Review) Review {
+	if review.UpdatedAt != nil {
+		updatedAt := *review.UpdatedAt
+		review.UpdatedAt = &updatedAt
+	}
+	return review
+}
+
+func (m *memoryRepoImplementation) List(ctx context.Context, dto ListRequestDto) ([]Review, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := []Review{}
+	for _, review := range m.reviews {
+		if dto.CakeID != 0 && review.CakeID != dto.CakeID {
+			continue
+		}
+		if dto.Status != "" && review.Status != dto.Status {
+			continue
+		}
+		result = append(result, copyReview(review))
+	}
+	sort.Slice(result, func(a, b int) bool {
+		if !result[a].CreatedAt.Equal(result[b].CreatedAt) {
+			return result[a].CreatedAt.After(result[b].CreatedAt)
+		}
+		return result[a].ID > result[b].ID
+	})
+	if dto.Limit > 0 {
+		if dto.Offset >= len(result) {
+			return []Review{}, nil
+		}
+		result = result[dto.Offset:]
+		if len(result) > dto.Limit {
+			result = result[:dto.Limit]
+		}
+	}
+	return result, nil
+}
+func (m *memoryRepoImplementation) Get(ctx context.Context, id int) (*Review, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	review, ok := m.reviews[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	result := copyReview(review)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Create(ctx context.Context, dto RequestDto) (*Review, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	review := Review{
+		ID:        m.nextID,
+		CakeID:    dto.CakeID,
+		Author:    dto.Author,
+		Stars:     dto.Stars,
+		Text:      dto.Text,
+		Status:    StatusPending,
+		CreatedAt: storage.TruncateTime(time.Now()),
+	}
+	m.reviews[review.ID] = review
+	m.nextID++
+	result := copyReview(review)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Transition(ctx context.Context, id int, from, to string) (*Review, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	review, ok := m.reviews[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if review.Status != from {
+		return nil, fmt.Errorf("%w: the review is %s now", ErrConflict, review.Status)
+	}
+	updatedAt := storage.TruncateTime(time.Now())
+	review.Status = to
+	review.UpdatedAt = &updatedAt
+	m.reviews[id] = review
+	result := copyReview(review)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Delete(ctx context.Context, id int, status string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	review, ok := m.reviews[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if review.Status != status {
+		return fmt.Errorf("%w: the review is %s now", ErrConflict, review.Status)
+	}
+	delete(m.reviews, id)
+	return nil
+}
+func (m *memoryRepoImplementation) DeleteForCake(ctx context.Context, cakeID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, review := range m.reviews {
+		if review.CakeID == cakeID {
+			delete(m.reviews, id)
+		}
+	}
+	return nil
+}
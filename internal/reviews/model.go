@@ -0,0 +1,31 @@
+package reviews
+
+import "time"
+
+type (
+	// Review is a customer's rating of a cake. Only approved reviews are
+	// listed with the cake and count towards its rating.
+	Review struct {
+		ID        int        `json:"id"`
+		CakeID    int        `json:"cake_id"`
+		Author    string     `json:"author" example:"Ana"`
+		Stars     int        `json:"stars" example:"5"`
+		Text      string     `json:"text" example:"Light and not too sweet."`
+		Status    string     `json:"status" example:"pending"`
+		CreatedAt time.Time  `json:"created_at"`
+		UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	}
+	ListRequestDto struct {
+		// CakeID limits the listing to the reviews of a cake when set.
+		CakeID int    `param:"id" json:"-" swaggerignore:"true"`
+		Status string `query:"status" json:"status" validate:"omitempty,oneof=pending approved rejected"`
+		Offset int    `query:"offset" json:"offset" validate:"omitempty,gte=0"`
+		Limit  int    `query:"limit" json:"limit" validate:"omitempty,gte=0,lte=100"`
+	}
+	RequestDto struct {
+		CakeID int    `param:"id" json:"-" swaggerignore:"true"`
+		Author string `json:"author" validate:"required,max=100" example:"Ana"`
+		Stars  int    `json:"stars" validate:"required,gte=1,lte=5" example:"5"`
+		Text   string `json:"text" validate:"omitempty,max=2000" example:"Light and not too sweet."`
+	}
+)
@@ -0,0 +1,167 @@
+package reviews
+
+//go:generate mockgen -destination=../../mocks/reviews/mock_repository.go -package=mock_reviews -source=repository.go
+
+import (
+	"cake-store/internal/query"
+	"cake-store/internal/storage"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const TableName = "reviews"
+
+// Columns lists the reviews columns in the order scanned by scanReview.
+var Columns = []string{"id", "cake_id", "author", "stars", "body", "status", "created_at", "updated_at"}
+
+type repoImplementation struct {
+	db *sql.DB
+}
+
+type RepoInterface interface {
+	// List returns a page of reviews, newest first, optionally of one cake
+	// or in one status.
+	List(ctx context.Context, dto ListRequestDto) ([]Review, error)
+	Get(ctx context.Context, id int) (*Review, error)
+	// Create stores a pending review.
+	Create(ctx context.Context, dto RequestDto) (*Review, error)
+	// Transition moves a review from status from to status to, or returns
+	// ErrConflict when it is no longer in from.
+	Transition(ctx context.Context, id int, from, to string) (*Review, error)
+	// Delete removes a review still in status, or returns ErrConflict when
+	// it was moderated meanwhile.
+	Delete(ctx context.Context, id int, status string) error
+	// DeleteForCake removes every review of a deleted cake.
+	DeleteForCake(ctx context.Context, cakeID int) error
+}
+
+// NewRepository returns a RepoInterface over the reviews table. Get,
+// Transition and Delete join the transaction of their context, so
+// moderation can update the cake rating in the same one.
+func NewRepository(db *sql.DB) RepoInterface {
+	return repoImplementation{db}
+}
+
+func scanReview(scan func(dest ...interface{}) error) (review Review, err error) {
+	var text sql.NullString
+	err = scan(&review.ID, &review.CakeID, &review.Author, &review.Stars, &text, &review.Status, &review.CreatedAt, &review.UpdatedAt)
+	review.Text = text.String
+	return
+}
+
+func nullableString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func (i repoImplementation) List(ctx context.Context, dto ListRequestDto) ([]Review, error) {
+	builder := query.Select(TableName, Columns...)
+	if dto.CakeID != 0 {
+		builder.Where(query.Eq("cake_id", dto.CakeID))
+	}
+	if dto.Status != "" {
+		builder.Where(query.Eq("status", dto.Status))
+	}
+	builder.OrderBy("created_at DESC", "id DESC")
+	if dto.Limit > 0 {
+		builder.Limit(dto.Limit).Offset(dto.Offset)
+	}
+	q, args := builder.Build()
+	rows, err := storage.Using(ctx, i.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []Review{}
+	for rows.Next() {
+		review, err := scanReview(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, review)
+	}
+	return result, rows.Err()
+}
+func (i repoImplementation) Get(ctx context.Context, id int) (*Review, error) {
+	q, args := query.Select(TableName, Columns...).Where(query.Eq("id", id)).Build()
+	result, err := scanReview(storage.Using(ctx, i.db).QueryRowContext(ctx, q, args...).Scan)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+func (i repoImplementation) Create(ctx context.Context, dto RequestDto) (*Review, error) {
+	q, args := query.Insert(TableName).
+		Set("cake_id", dto.CakeID).
+		Set("author", dto.Author).
+		Set("stars", dto.Stars).
+		Set("body", nullableString(dto.Text)).
+		Set("status", StatusPending).
+		Set("created_at", storage.TruncateTime(time.Now())).
+		Build()
+	res, err := storage.Using(ctx, i.db).ExecContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return i.Get(ctx, int(id))
+}
+func (i repoImplementation) Transition(ctx context.Context, id int, from, to string) (*Review, error) {
+	q, args := query.Update(TableName).
+		Set("status", to).
+		Set("updated_at", storage.TruncateTime(time.Now())).
+		Where(query.Eq("id", id), query.Eq("status", from)).
+		Build()
+	res, err := storage.Using(ctx, i.db).ExecContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, i.moved(ctx, id)
+	}
+	return i.Get(ctx, id)
+}
+func (i repoImplementation) Delete(ctx context.Context, id int, status string) error {
+	q, args := query.Delete(TableName).Where(query.Eq("id", id), query.Eq("status", status)).Build()
+	res, err := storage.Using(ctx, i.db).ExecContext(ctx, q, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return i.moved(ctx, id)
+	}
+	return nil
+}
+func (i repoImplementation) DeleteForCake(ctx context.Context, cakeID int) error {
+	q, args := query.Delete(TableName).Where(query.Eq("cake_id", cakeID)).Build()
+	_, err := storage.Using(ctx, i.db).ExecContext(ctx, q, args...)
+	return err
+}
+
+// moved explains why a conditional write on a review matched no row.
+func (i repoImplementation) moved(ctx context.Context, id int) error {
+	current, err := i.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: the review is %s now", ErrConflict, current.Status)
+}
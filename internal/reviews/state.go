@@ -0,0 +1,42 @@
+package reviews
+
+import "fmt"
+
+// Review statuses. New reviews wait for moderation.
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+)
+
+// transitions lists the statuses each status can move to. Moderators can
+// change their mind, so approved and rejected reviews can swap.
+var transitions = map[string][]string{
+	StatusPending:  {StatusApproved, StatusRejected},
+	StatusApproved: {StatusRejected},
+	StatusRejected: {StatusApproved},
+}
+
+// CanTransition returns ErrConflict unless review can move to status.
+func CanTransition(review Review, status string) error {
+	for _, next := range transitions[review.Status] {
+		if next == status {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: cannot move a %s review to %s", ErrConflict, review.Status, status)
+}
+
+// RatingChange returns the stars and the number of reviews that moving
+// review from status from to status to adds to the rating of its cake; to
+// is empty for a deleted review. Both are negative when an approved review
+// is withdrawn.
+func RatingChange(review Review, from, to string) (stars, count int) {
+	switch {
+	case from != StatusApproved && to == StatusApproved:
+		return review.Stars, 1
+	case from == StatusApproved && to != StatusApproved:
+		return -review.Stars, -1
+	}
+	return 0, 0
+}
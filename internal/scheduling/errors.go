@@ -0,0 +1,18 @@
+package scheduling
+
+import (
+	"cake-store/internal/helpers"
+	"fmt"
+)
+
+var (
+	ErrNotFound   = fmt.Errorf("booking %w", helpers.ErrNotFound)
+	ErrConflict   = fmt.Errorf("booking %w", helpers.ErrConflict)
+	ErrValidation = fmt.Errorf("schedule %w", helpers.ErrValidation)
+	ErrConfig     = fmt.Errorf("schedule configuration %w", helpers.ErrValidation)
+
+	ErrHolidayNotFound = fmt.Errorf("holiday %w", helpers.ErrNotFound)
+	// ErrFull is returned when a booking asks for a slot with no capacity
+	// left.
+	ErrFull = fmt.Errorf("%w: the slot is fully booked", ErrConflict)
+)
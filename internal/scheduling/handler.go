@@ -0,0 +1,358 @@
+package scheduling
+
+import (
+	"cake-store/internal/cakes"
+	"context"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+)
+
+type SvcInterface interface {
+	Hours(ctx echo.Context) error
+	SetHours(ctx echo.Context) error
+	Holidays(ctx echo.Context) error
+	SetHoliday(ctx echo.Context) error
+	DeleteHoliday(ctx echo.Context) error
+	LeadTime(ctx echo.Context) error
+	SetLeadTime(ctx echo.Context) error
+	Slots(ctx echo.Context) error
+	Book(ctx echo.Context) error
+	GetBooking(ctx echo.Context) error
+	Release(ctx echo.Context) error
+}
+
+type svcImplementation struct {
+	repo      RepoInterface
+	scheduler Scheduler
+	cakes     cakes.RepoInterface
+}
+
+// NewHandler returns the handler of the schedule in repo, working out and
+// booking slots for the cakes in cakesRepo through scheduler.
+func NewHandler(repo RepoInterface, scheduler Scheduler, cakesRepo cakes.RepoInterface) SvcInterface {
+	return svcImplementation{repo, scheduler, cakesRepo}
+}
+
+// checkCakes returns cakes.ErrNotFound unless all of the cakes exist.
+func (s svcImplementation) checkCakes(ctx context.Context, cakeIDs []int) error {
+	for _, cakeID := range cakeIDs {
+		if _, err := s.cakes.Get(ctx, cakeID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Hours godoc
+// @Summary Get opening hours
+// @Description This endpoint for get the opening hours and slot capacity of each weekday the store is open
+// @Tags Scheduling
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} Hours
+// @Failure 500 {object} helpers.Problem
+// @Router /schedule/hours [get]
+func (s svcImplementation) Hours(ctx echo.Context) error {
+	res, err := s.repo.Hours(context.TODO())
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// SetHours godoc
+// @Summary Set opening hours
+// @Description This endpoint for replacing the opening hours of the week, in the time zone of the shop (SHOP_TIME_ZONE). Slots last an hour from opening; weekdays left out are closed.
+// @Tags Scheduling
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param Request body HoursRequestDto true "Set opening hours"
+// @Success 200 {array} Hours
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /schedule/hours [put]
+func (s svcImplementation) SetHours(ctx echo.Context) error {
+	request := HoursRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	updated, err := s.repo.SetHours(context.TODO(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, updated)
+}
+
+// Holidays godoc
+// @Summary List holidays
+// @Description This endpoint for get the days the store is closed on
+// @Tags Scheduling
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} Holiday
+// @Failure 500 {object} helpers.Problem
+// @Router /holidays [get]
+func (s svcImplementation) Holidays(ctx echo.Context) error {
+	res, err := s.repo.Holidays(context.TODO())
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// SetHoliday godoc
+// @Summary Set holiday
+// @Description This endpoint for closing the store on a day; existing bookings for the day are kept
+// @Tags Scheduling
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param day path string true "day" example(2026-12-25)
+// @Param Request body HolidayRequestDto true "Set holiday"
+// @Success 200 {object} Holiday
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /holidays/{day} [put]
+func (s svcImplementation) SetHoliday(ctx echo.Context) error {
+	request := HolidayRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	updated, err := s.repo.SetHoliday(context.TODO(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, updated)
+}
+
+// DeleteHoliday godoc
+// @Summary Delete holiday
+// @Description This endpoint for opening the store again on a holiday
+// @Tags Scheduling
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param day path string true "day" example(2026-12-25)
+// @Success 200 {string} string
+// @Failure 404 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /holidays/{day} [delete]
+func (s svcImplementation) DeleteHoliday(ctx echo.Context) error {
+	if err := s.repo.DeleteHoliday(context.TODO(), ctx.Param("day")); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, "Success")
+}
+
+// LeadTime godoc
+// @Summary Get lead time of cake
+// @Description This endpoint for get how many hours ahead a cake has to be ordered
+// @Tags Scheduling
+// @Accept  json
+// @Produce  json
+// @Param id path string true "cake id"
+// @Success 200 {object} LeadTime
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /cakes/{id}/lead-time [get]
+func (s svcImplementation) LeadTime(ctx echo.Context) error {
+	ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid id")
+	}
+
+	if _, err := s.cakes.Get(context.TODO(), ID); err != nil {
+		return err
+	}
+
+	leadTimes, err := s.repo.LeadTimes(context.TODO(), []int{ID})
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, LeadTime{CakeID: ID, Hours: leadTimes[ID]})
+}
+
+// SetLeadTime godoc
+// @Summary Set lead time of cake
+// @Description This endpoint for setting how many hours ahead a cake has to be ordered; 0 removes the lead time
+// @Tags Scheduling
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path string true "cake id"
+// @Param Request body LeadTimeRequestDto true "Set lead time"
+// @Success 200 {object} LeadTime
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /cakes/{id}/lead-time [put]
+func (s svcImplementation) SetLeadTime(ctx echo.Context) error {
+	request := LeadTimeRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	if _, err := s.cakes.Get(context.TODO(), request.CakeID); err != nil {
+		return err
+	}
+
+	if err := s.repo.SetLeadTime(context.TODO(), request); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, LeadTime{CakeID: request.CakeID, Hours: request.Hours})
+}
+
+// Slots godoc
+// @Summary List slots of day
+// @Description This endpoint for get the pickup and delivery slots of a day that can still be booked for the cakes, after their lead times, with the places they have left. Closed days and holidays have no slots.
+// @Tags Scheduling
+// @Accept  json
+// @Produce  json
+// @Param services query SlotsRequestDto true "Find query"
+// @Success 200 {array} Slot
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /slots [get]
+func (s svcImplementation) Slots(ctx echo.Context) error {
+	request := SlotsRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	if err := s.checkCakes(context.TODO(), request.CakeIDs); err != nil {
+		return err
+	}
+
+	res, err := s.scheduler.Slots(context.TODO(), request.Day, request.CakeIDs)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// Book godoc
+// @Summary Book slot
+// @Description This endpoint for taking a place in a slot, such as for an order taken over the phone; a slot with no place left is refused with 409. Customers book through their orders and carts.
+// @Tags Scheduling
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param Request body BookRequestDto true "Book slot"
+// @Success 201 {object} Booking
+// @Header 201 {string} Location "/bookings/{id}"
+// @Failure 404 {object} helpers.Problem
+// @Failure 409 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /bookings [post]
+func (s svcImplementation) Book(ctx echo.Context) error {
+	request := BookRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	if err := s.checkCakes(context.TODO(), request.CakeIDs); err != nil {
+		return err
+	}
+
+	created, err := s.scheduler.Book(context.TODO(), request)
+	if err != nil {
+		return err
+	}
+	ctx.Response().Header().Set(echo.HeaderLocation, "/bookings/"+strconv.Itoa(created.ID))
+	return ctx.JSON(http.StatusCreated, created)
+}
+
+// GetBooking godoc
+// @Summary Get detail of booking
+// @Description This endpoint for get detail of a slot booking
+// @Tags Scheduling
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path string true "booking id"
+// @Success 200 {object} Booking
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /bookings/{id} [get]
+func (s svcImplementation) GetBooking(ctx echo.Context) error {
+	ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid id")
+	}
+
+	data, err := s.repo.GetBooking(context.TODO(), ID)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, data)
+}
+
+// Release godoc
+// @Summary Release booking
+// @Description This endpoint for giving the place of a booking back to its slot
+// @Tags Scheduling
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path string true "booking id"
+// @Success 200 {object} Booking
+// @Failure 404 {object} helpers.Problem
+// @Failure 409 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /bookings/{id}/release [post]
+func (s svcImplementation) Release(ctx echo.Context) error {
+	ID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid id")
+	}
+
+	data, err := s.scheduler.Release(context.TODO(), ID)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, data)
+}
@@ -0,0 +1,201 @@
+package scheduling
+
+import (
+	"cake-store/internal/storage"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// slotKey identifies a slot of a day.
+type slotKey struct {
+	day   string
+	start string
+}
+
+type memoryRepoImplementation struct {
+	mu          sync.RWMutex
+	hours       []Hours
+	holidays    map[string]Holiday
+	leadTimes   map[int]int
+	booked      map[slotKey]int
+	bookings    map[int]Booking
+	nextBooking int
+}
+
+// NewMemoryRepository returns a RepoInterface that keeps the schedule in
+// maps. Bookings count up the places of their slot under the same lock that
+// checks its capacity.
+func NewMemoryRepository() RepoInterface {
+	return &memoryRepoImplementation{
+		hours:       []Hours{},
+		holidays:    map[string]Holiday{},
+		leadTimes:   map[int]int{},
+		booked:      map[slotKey]int{},
+		bookings:    map[int]Booking{},
+		nextBooking: 1,
+	}
+}
+
+func copyBooking(booking Booking) Booking {
+	if booking.UpdatedAt != nil {
+		updatedAt := *booking.UpdatedAt
+		booking.UpdatedAt = &updatedAt
+	}
+	return booking
+}
+
+func (m *memoryRepoImplementation) Hours(ctx context.Context) ([]Hours, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]Hours{}, m.hours...), nil
+}
+func (m *memoryRepoImplementation) SetHours(ctx context.Context, dto HoursRequestDto) ([]Hours, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hours, err := dto.hours()
+	if err != nil {
+		return nil, err
+	}
+	m.hours = hours
+	return append([]Hours{}, hours...), nil
+}
+func (m *memoryRepoImplementation) Holidays(ctx context.Context) ([]Holiday, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := []Holiday{}
+	for _, holiday := range m.holidays {
+		result = append(result, holiday)
+	}
+	sort.Slice(result, func(a, b int) bool {
+		return result[a].Day < result[b].Day
+	})
+	return result, nil
+}
+func (m *memoryRepoImplementation) GetHoliday(ctx context.Context, day string) (*Holiday, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	holiday, ok := m.holidays[day]
+	if !ok {
+		return nil, ErrHolidayNotFound
+	}
+	return &holiday, nil
+}
+func (m *memoryRepoImplementation) SetHoliday(ctx context.Context, dto HolidayRequestDto) (*Holiday, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	holiday := Holiday{Day: dto.Day, Name: dto.Name}
+	m.holidays[dto.Day] = holiday
+	return &holiday, nil
+}
+func (m *memoryRepoImplementation) DeleteHoliday(ctx context.Context, day string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.holidays[day]; !ok {
+		return ErrHolidayNotFound
+	}
+	delete(m.holidays, day)
+	return nil
+}
+func (m *memoryRepoImplementation) LeadTimes(ctx context.Context, cakeIDs []int) (map[int]int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := map[int]int{}
+	for _, cakeID := range cakeIDs {
+		if hours, ok := m.leadTimes[cakeID]; ok {
+			result[cakeID] = hours
+		}
+	}
+	return result, nil
+}
+func (m *memoryRepoImplementation) SetLeadTime(ctx context.Context, dto LeadTimeRequestDto) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if dto.Hours == 0 {
+		delete(m.leadTimes, dto.CakeID)
+		return nil
+	}
+	m.leadTimes[dto.CakeID] = dto.Hours
+	return nil
+}
+func (m *memoryRepoImplementation) DeleteForCake(ctx context.Context, cakeID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.leadTimes, cakeID)
+	return nil
+}
+func (m *memoryRepoImplementation) Booked(ctx context.Context, day string) (map[string]int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := map[string]int{}
+	for key, booked := range m.booked {
+		if key.day == day {
+			result[key.start] = booked
+		}
+	}
+	return result, nil
+}
+func (m *memoryRepoImplementation) Book(ctx context.Context, slot Slot) (*Booking, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := slotKey{slot.Day, slot.Start}
+	if m.booked[key] >= slot.Capacity {
+		return nil, ErrFull
+	}
+	m.booked[key]++
+
+	booking := Booking{
+		ID:        m.nextBooking,
+		Day:       slot.Day,
+		Start:     slot.Start,
+		Status:    StatusBooked,
+		CreatedAt: storage.TruncateTime(time.Now()),
+	}
+	m.bookings[booking.ID] = booking
+	m.nextBooking++
+	result := copyBooking(booking)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) GetBooking(ctx context.Context, id int) (*Booking, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	booking, ok := m.bookings[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	result := copyBooking(booking)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Release(ctx context.Context, id int) (*Booking, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	booking, ok := m.bookings[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if booking.Status != StatusBooked {
+		return nil, fmt.Errorf("%w: the booking is %s now", ErrConflict, booking.Status)
+	}
+	updatedAt := storage.TruncateTime(time.Now())
+	booking.Status = StatusReleased
+	booking.UpdatedAt = &updatedAt
+	m.bookings[id] = booking
+	m.booked[slotKey{booking.Day, booking.Start}]--
+	result := copyBooking(booking)
+	return &result, nil
+}
@@ -0,0 +1,124 @@
+package scheduling
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// DayLayout is the format of the days slots are booked for.
+	DayLayout = "2006-01-02"
+	// TimeLayout is the format of the times slots start and end at. Like
+	// the days, they are in the time zone of the shop.
+	TimeLayout = "15:04"
+	// SlotLength is how long a slot lasts. Slots follow each other from
+	// opening until the last one that ends by closing time.
+	SlotLength = time.Hour
+)
+
+// Booking statuses. Only booked bookings hold a place in their slot.
+const (
+	StatusBooked   = "booked"
+	StatusReleased = "released"
+)
+
+type (
+	// Hours are the opening hours of a weekday and how many orders each of
+	// its slots takes. Weekdays without hours are closed.
+	Hours struct {
+		// Weekday counts from Sunday, 0, to Saturday, 6.
+		Weekday  int    `json:"weekday" validate:"gte=0,lte=6" example:"1"`
+		Opens    string `json:"opens" validate:"required,datetime=15:04" example:"09:00"`
+		Closes   string `json:"closes" validate:"required,datetime=15:04" example:"18:00"`
+		Capacity int    `json:"capacity" validate:"required,gt=0,lte=1000" example:"4"`
+	}
+	// Holiday is a day the store is closed on whatever its weekday.
+	Holiday struct {
+		Day  string `json:"day" example:"2026-12-25"`
+		Name string `json:"name" example:"Christmas"`
+	}
+	// LeadTime is how many hours ahead a cake has to be ordered.
+	LeadTime struct {
+		CakeID int `json:"cake_id"`
+		Hours  int `json:"hours" example:"48"`
+	}
+	Slot struct {
+		Day       string `json:"day" example:"2026-10-20"`
+		Start     string `json:"start" example:"09:00"`
+		End       string `json:"end" example:"10:00"`
+		Capacity  int    `json:"capacity"`
+		Booked    int    `json:"booked"`
+		Available int    `json:"available"`
+	}
+	Booking struct {
+		ID        int        `json:"id"`
+		Day       string     `json:"day" example:"2026-10-20"`
+		Start     string     `json:"start" example:"09:00"`
+		Status    string     `json:"status" example:"booked"`
+		CreatedAt time.Time  `json:"created_at"`
+		UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	}
+	// HoursRequestDto replaces the opening hours of the whole week.
+	HoursRequestDto struct {
+		Hours []Hours `json:"hours" validate:"max=7,dive"`
+	}
+	HolidayRequestDto struct {
+		Day  string `param:"day" json:"-" swaggerignore:"true" validate:"datetime=2006-01-02"`
+		Name string `json:"name" validate:"max=100" example:"Christmas"`
+	}
+	// LeadTimeRequestDto sets the lead time of a cake; 0 hours removes it.
+	LeadTimeRequestDto struct {
+		CakeID int `param:"id" json:"-" swaggerignore:"true"`
+		Hours  int `json:"hours" validate:"gte=0,lte=720" example:"48"`
+	}
+	SlotsRequestDto struct {
+		CakeIDs []int  `query:"cake_id" json:"cake_id" validate:"max=50,dive,gt=0"`
+		Day     string `query:"date" json:"date" validate:"required,datetime=2006-01-02" example:"2026-10-20"`
+	}
+	// BookRequestDto books a slot for an order of the cakes, whose lead
+	// times it has to respect.
+	BookRequestDto struct {
+		Day     string `json:"day" validate:"required,datetime=2006-01-02" example:"2026-10-20"`
+		Start   string `json:"start" validate:"required,datetime=15:04" example:"09:00"`
+		CakeIDs []int  `json:"cake_ids" validate:"max=50,dive,gt=0"`
+	}
+)
+
+// clock returns how long after midnight a time of day is.
+func clock(value string) (time.Duration, error) {
+	t, err := time.Parse(TimeLayout, value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s is not a time of day", ErrValidation, value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// hours checks the opening hours are one per weekday and fit at least a
+// slot, and returns them ordered by weekday.
+func (dto HoursRequestDto) hours() ([]Hours, error) {
+	result := []Hours{}
+	seen := map[int]bool{}
+	for _, hours := range dto.Hours {
+		if seen[hours.Weekday] {
+			return nil, fmt.Errorf("%w: weekday %d has more than one set of hours", ErrValidation, hours.Weekday)
+		}
+		seen[hours.Weekday] = true
+		opens, err := clock(hours.Opens)
+		if err != nil {
+			return nil, err
+		}
+		closes, err := clock(hours.Closes)
+		if err != nil {
+			return nil, err
+		}
+		if closes-opens < SlotLength {
+			return nil, fmt.Errorf("%w: weekday %d closes before its first slot ends", ErrValidation, hours.Weekday)
+		}
+		result = append(result, hours)
+	}
+	sort.Slice(result, func(a, b int) bool {
+		return result[a].Weekday < result[b].Weekday
+	})
+	return result, nil
+}
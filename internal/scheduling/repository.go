@@ -0,0 +1,338 @@
+package scheduling
+
+//go:generate mockgen -destination=../../mocks/scheduling/mock_repository.go -package=mock_scheduling -source=repository.go
+
+import (
+	"cake-store/internal/query"
+	"cake-store/internal/storage"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const (
+	HoursName     = "opening_hours"
+	HolidaysName  = "holidays"
+	LeadTimesName = "lead_times"
+	SlotsName     = "slots"
+	BookingsName  = "slot_bookings"
+)
+
+// BookingColumns lists the slot_bookings columns in the order scanned by
+// getBooking.
+var BookingColumns = []string{"id", "day", "start_at", "status", "created_at", "updated_at"}
+
+type repoImplementation struct {
+	db *sql.DB
+	tx storage.Transactor
+}
+
+type RepoInterface interface {
+	// Hours returns the opening hours ordered by weekday.
+	Hours(ctx context.Context) ([]Hours, error)
+	// SetHours replaces the opening hours, or returns ErrValidation when
+	// they are inconsistent.
+	SetHours(ctx context.Context, dto HoursRequestDto) ([]Hours, error)
+
+	// Holidays returns the holidays ordered by day.
+	Holidays(ctx context.Context) ([]Holiday, error)
+	GetHoliday(ctx context.Context, day string) (*Holiday, error)
+	SetHoliday(ctx context.Context, dto HolidayRequestDto) (*Holiday, error)
+	DeleteHoliday(ctx context.Context, day string) error
+
+	// LeadTimes returns the lead times in hours of those of the cakes that
+	// have one.
+	LeadTimes(ctx context.Context, cakeIDs []int) (map[int]int, error)
+	SetLeadTime(ctx context.Context, dto LeadTimeRequestDto) error
+	// DeleteForCake removes the lead time of a deleted cake.
+	DeleteForCake(ctx context.Context, cakeID int) error
+
+	// Booked returns how many bookings the slots of a day hold, by start.
+	Booked(ctx context.Context, day string) (map[string]int, error)
+	// Book takes a place in a slot, or returns ErrFull when it already
+	// holds its capacity.
+	Book(ctx context.Context, slot Slot) (*Booking, error)
+	GetBooking(ctx context.Context, id int) (*Booking, error)
+	// Release gives the place of a booking back to its slot, or returns
+	// ErrConflict when it was released already.
+	Release(ctx context.Context, id int) (*Booking, error)
+}
+
+// NewRepository returns a RepoInterface over the opening hours, holidays,
+// lead times and slot bookings. Each slot has a row counting its bookings,
+// so a booking takes a place in a single conditional update.
+func NewRepository(db *sql.DB) RepoInterface {
+	return repoImplementation{db, storage.NewTransactor(db)}
+}
+
+func getBooking(ctx context.Context, db storage.Conn, id int) (*Booking, error) {
+	q, args := query.Select(BookingsName, BookingColumns...).Where(query.Eq("id", id)).Build()
+	booking := Booking{}
+	err := db.QueryRowContext(ctx, q, args...).Scan(&booking.ID, &booking.Day, &booking.Start, &booking.Status, &booking.CreatedAt, &booking.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &booking, nil
+}
+
+func (i repoImplementation) Hours(ctx context.Context) ([]Hours, error) {
+	q, args := query.Select(HoursName, "weekday", "opens", "closes", "capacity").OrderBy("weekday ASC").Build()
+	rows, err := storage.Using(ctx, i.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []Hours{}
+	for rows.Next() {
+		hours := Hours{}
+		if err := rows.Scan(&hours.Weekday, &hours.Opens, &hours.Closes, &hours.Capacity); err != nil {
+			return nil, err
+		}
+		result = append(result, hours)
+	}
+	return result, rows.Err()
+}
+func (i repoImplementation) SetHours(ctx context.Context, dto HoursRequestDto) ([]Hours, error) {
+	hours, err := dto.hours()
+	if err != nil {
+		return nil, err
+	}
+	err = i.tx.InTx(ctx, func(ctx context.Context) error {
+		tx := storage.Using(ctx, i.db)
+		q, args := query.Delete(HoursName).Build()
+		if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+			return err
+		}
+		for _, day := range hours {
+			q, args := query.Insert(HoursName).
+				Set("weekday", day.Weekday).
+				Set("opens", day.Opens).
+				Set("closes", day.Closes).
+				Set("capacity", day.Capacity).
+				Build()
+			if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return i.Hours(ctx)
+}
+func (i repoImplementation) Holidays(ctx context.Context) ([]Holiday, error) {
+	q, args := query.Select(HolidaysName, "day", "name").OrderBy("day ASC").Build()
+	rows, err := storage.Using(ctx, i.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []Holiday{}
+	for rows.Next() {
+		holiday := Holiday{}
+		if err := rows.Scan(&holiday.Day, &holiday.Name); err != nil {
+			return nil, err
+		}
+		result = append(result, holiday)
+	}
+	return result, rows.Err()
+}
+func (i repoImplementation) GetHoliday(ctx context.Context, day string) (*Holiday, error) {
+	q, args := query.Select(HolidaysName, "day", "name").Where(query.Eq("day", day)).Build()
+	holiday := Holiday{}
+	err := storage.Using(ctx, i.db).QueryRowContext(ctx, q, args...).Scan(&holiday.Day, &holiday.Name)
+	if err == sql.ErrNoRows {
+		return nil, ErrHolidayNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &holiday, nil
+}
+func (i repoImplementation) SetHoliday(ctx context.Context, dto HolidayRequestDto) (*Holiday, error) {
+	err := i.tx.InTx(ctx, func(ctx context.Context) error {
+		tx := storage.Using(ctx, i.db)
+		q, args := query.Delete(HolidaysName).Where(query.Eq("day", dto.Day)).Build()
+		if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+			return err
+		}
+		q, args = query.Insert(HolidaysName).Set("day", dto.Day).Set("name", dto.Name).Build()
+		_, err := tx.ExecContext(ctx, q, args...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return i.GetHoliday(ctx, dto.Day)
+}
+func (i repoImplementation) DeleteHoliday(ctx context.Context, day string) error {
+	q, args := query.Delete(HolidaysName).Where(query.Eq("day", day)).Build()
+	res, err := storage.Using(ctx, i.db).ExecContext(ctx, q, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrHolidayNotFound
+	}
+	return nil
+}
+func (i repoImplementation) LeadTimes(ctx context.Context, cakeIDs []int) (map[int]int, error) {
+	result := map[int]int{}
+	if len(cakeIDs) == 0 {
+		return result, nil
+	}
+	q, args := query.Select(LeadTimesName, "cake_id", "hours").Where(query.In("cake_id", query.Ints(cakeIDs)...)).Build()
+	rows, err := storage.Using(ctx, i.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cakeID, hours int
+		if err := rows.Scan(&cakeID, &hours); err != nil {
+			return nil, err
+		}
+		result[cakeID] = hours
+	}
+	return result, rows.Err()
+}
+func (i repoImplementation) SetLeadTime(ctx context.Context, dto LeadTimeRequestDto) error {
+	return i.tx.InTx(ctx, func(ctx context.Context) error {
+		tx := storage.Using(ctx, i.db)
+		q, args := query.Delete(LeadTimesName).Where(query.Eq("cake_id", dto.CakeID)).Build()
+		if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+			return err
+		}
+		if dto.Hours == 0 {
+			return nil
+		}
+		q, args = query.Insert(LeadTimesName).Set("cake_id", dto.CakeID).Set("hours", dto.Hours).Build()
+		_, err := tx.ExecContext(ctx, q, args...)
+		return err
+	})
+}
+func (i repoImplementation) DeleteForCake(ctx context.Context, cakeID int) error {
+	q, args := query.Delete(LeadTimesName).Where(query.Eq("cake_id", cakeID)).Build()
+	_, err := storage.Using(ctx, i.db).ExecContext(ctx, q, args...)
+	return err
+}
+func (i repoImplementation) Booked(ctx context.Context, day string) (map[string]int, error) {
+	q, args := query.Select(SlotsName, "start_at", "booked").Where(query.Eq("day", day)).Build()
+	rows, err := storage.Using(ctx, i.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := map[string]int{}
+	for rows.Next() {
+		var (
+			start  string
+			booked int
+		)
+		if err := rows.Scan(&start, &booked); err != nil {
+			return nil, err
+		}
+		result[start] = booked
+	}
+	return result, rows.Err()
+}
+func (i repoImplementation) Book(ctx context.Context, slot Slot) (*Booking, error) {
+	// The row counting the bookings of a slot is created by its first
+	// booking, unless a concurrent one got there first.
+	q, args := query.Insert(SlotsName).Set("day", slot.Day).Set("start_at", slot.Start).Build()
+	if _, err := storage.Using(ctx, i.db).ExecContext(ctx, q, args...); err != nil && !storage.IsDuplicate(err) {
+		return nil, err
+	}
+
+	var id int64
+	err := i.tx.InTx(ctx, func(ctx context.Context) error {
+		tx := storage.Using(ctx, i.db)
+		// The count only goes up while it is below the capacity, checked
+		// against the row as the update locks it, so concurrent bookings
+		// cannot take more places than the slot has.
+		q, args := query.Update(SlotsName).
+			SetExpr("booked", "booked + 1").
+			Where(query.Eq("day", slot.Day), query.Eq("start_at", slot.Start), query.Expr("booked < ?", slot.Capacity)).
+			Build()
+		res, err := tx.ExecContext(ctx, q, args...)
+		if err != nil {
+			return err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrFull
+		}
+
+		q, args = query.Insert(BookingsName).
+			Set("day", slot.Day).
+			Set("start_at", slot.Start).
+			Set("status", StatusBooked).
+			Set("created_at", storage.TruncateTime(time.Now())).
+			Build()
+		res, err = tx.ExecContext(ctx, q, args...)
+		if err != nil {
+			return err
+		}
+		id, err = res.LastInsertId()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return i.GetBooking(ctx, int(id))
+}
+func (i repoImplementation) GetBooking(ctx context.Context, id int) (*Booking, error) {
+	return getBooking(ctx, storage.Using(ctx, i.db), id)
+}
+func (i repoImplementation) Release(ctx context.Context, id int) (*Booking, error) {
+	err := i.tx.InTx(ctx, func(ctx context.Context) error {
+		tx := storage.Using(ctx, i.db)
+		booking, err := getBooking(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		q, args := query.Update(BookingsName).
+			Set("status", StatusReleased).
+			Set("updated_at", storage.TruncateTime(time.Now())).
+			Where(query.Eq("id", id), query.Eq("status", StatusBooked)).
+			Build()
+		res, err := tx.ExecContext(ctx, q, args...)
+		if err != nil {
+			return err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return fmt.Errorf("%w: the booking is %s now", ErrConflict, booking.Status)
+		}
+
+		q, args = query.Update(SlotsName).
+			SetExpr("booked", "booked - 1").
+			Where(query.Eq("day", booking.Day), query.Eq("start_at", booking.Start)).
+			Build()
+		_, err = tx.ExecContext(ctx, q, args...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return i.GetBooking(ctx, id)
+}
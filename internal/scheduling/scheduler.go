@@ -0,0 +1,159 @@
+package scheduling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Option configures how slots are worked out.
+type Option func(*Scheduler)
+
+// WithLocation works out the slots in the time zone of the shop, which its
+// opening hours and days are given in. Without it they are in UTC.
+func WithLocation(location *time.Location) Option {
+	return func(s *Scheduler) {
+		s.location = location
+	}
+}
+
+// LocationFromEnv loads the time zone SHOP_TIME_ZONE names, such as
+// Europe/Paris, defaulting to UTC.
+func LocationFromEnv() (*time.Location, error) {
+	name := os.Getenv("SHOP_TIME_ZONE")
+	if name == "" {
+		return time.UTC, nil
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: SHOP_TIME_ZONE must be a time zone such as Europe/Paris", ErrConfig)
+	}
+	return location, nil
+}
+
+// Scheduler works out the slots of a day from the opening hours, holidays
+// and lead times, and books them. It is shared by the scheduling handler
+// and the orders placed for a slot.
+type Scheduler struct {
+	repo     RepoInterface
+	location *time.Location
+}
+
+func NewScheduler(repo RepoInterface, options ...Option) Scheduler {
+	s := Scheduler{repo: repo, location: time.UTC}
+	for _, option := range options {
+		option(&s)
+	}
+	return s
+}
+
+// Slots returns the slots of day that start after the longest lead time of
+// the cakes, with the places they have left. Closed days and holidays have
+// none.
+func (s Scheduler) Slots(ctx context.Context, day string, cakeIDs []int) ([]Slot, error) {
+	date, err := time.ParseInLocation(DayLayout, day, s.location)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s is not a day", ErrValidation, day)
+	}
+	result := []Slot{}
+	_, err = s.repo.GetHoliday(ctx, day)
+	if err == nil {
+		return result, nil
+	}
+	if !errors.Is(err, ErrHolidayNotFound) {
+		return nil, err
+	}
+
+	week, err := s.repo.Hours(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var hours *Hours
+	for n := range week {
+		if week[n].Weekday == int(date.Weekday()) {
+			hours = &week[n]
+		}
+	}
+	if hours == nil {
+		return result, nil
+	}
+	opens, err := clock(hours.Opens)
+	if err != nil {
+		return nil, err
+	}
+	closes, err := clock(hours.Closes)
+	if err != nil {
+		return nil, err
+	}
+
+	earliest, err := s.earliest(ctx, cakeIDs)
+	if err != nil {
+		return nil, err
+	}
+	booked, err := s.repo.Booked(ctx, day)
+	if err != nil {
+		return nil, err
+	}
+	for start := opens; start+SlotLength <= closes; start += SlotLength {
+		at := s.at(date, start)
+		if at.Before(earliest) {
+			continue
+		}
+		slot := Slot{
+			Day:      day,
+			Start:    at.Format(TimeLayout),
+			End:      s.at(date, start+SlotLength).Format(TimeLayout),
+			Capacity: hours.Capacity,
+		}
+		slot.Booked = booked[slot.Start]
+		if slot.Available = slot.Capacity - slot.Booked; slot.Available < 0 {
+			slot.Available = 0
+		}
+		result = append(result, slot)
+	}
+	return result, nil
+}
+
+// at returns the time of day on the clock of the shop on date, which stays
+// the same on days daylight saving time makes shorter or longer.
+func (s Scheduler) at(date time.Time, timeOfDay time.Duration) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, int(timeOfDay/time.Minute), 0, 0, s.location)
+}
+
+// earliest returns when the first slot for the cakes may start, once the
+// longest of their lead times has passed.
+func (s Scheduler) earliest(ctx context.Context, cakeIDs []int) (time.Time, error) {
+	leadTimes, err := s.repo.LeadTimes(ctx, cakeIDs)
+	if err != nil {
+		return time.Time{}, err
+	}
+	longest := 0
+	for _, hours := range leadTimes {
+		if hours > longest {
+			longest = hours
+		}
+	}
+	return time.Now().Add(time.Duration(longest) * time.Hour), nil
+}
+
+// Book takes a place in the slot of dto, or returns ErrValidation when it is
+// not one of the slots of its day and ErrFull when no place is left.
+func (s Scheduler) Book(ctx context.Context, dto BookRequestDto) (*Booking, error) {
+	slots, err := s.Slots(ctx, dto.Day, dto.CakeIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, slot := range slots {
+		if slot.Start == dto.Start {
+			return s.repo.Book(ctx, slot)
+		}
+	}
+	return nil, fmt.Errorf("%w: no slot starts at %s on %s for these cakes", ErrValidation, dto.Start, dto.Day)
+}
+
+// Release gives the place of a booking back to its slot.
+func (s Scheduler) Release(ctx context.Context, id int) (*Booking, error) {
+	return s.repo.Release(ctx, id)
+}
@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const mysqlDuplicateEntry = 1062
+
+// IsDuplicate reports whether err is a unique constraint violation on any of
+// the supported drivers.
+func IsDuplicate(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlDuplicateEntry
+	}
+	return err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"database/sql"
+	"embed"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/sqlite/*.up.sql
+var sqliteMigrations embed.FS
+
+// migrateSQLite applies the embedded up migrations that have not been
+// recorded in schema_migrations yet, in version order.
+func migrateSQLite(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER NOT NULL PRIMARY KEY)`); err != nil {
+		return err
+	}
+
+	files, err := fs.Glob(sqliteMigrations, "migrations/sqlite/*.up.sql")
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		name := file[strings.LastIndex(file, "/")+1:]
+		version, err := strconv.Atoi(name[:strings.Index(name, "_")])
+		if err != nil {
+			return err
+		}
+
+		var applied int
+		if err = db.QueryRow(`SELECT COUNT(*) FROM schema_migrations WHERE version = ?`, version).Scan(&applied); err != nil {
+			return err
+		}
+		if applied > 0 {
+			continue
+		}
+
+		content, err := sqliteMigrations.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err = tx.Exec(string(content)); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if _, err = tx.Exec(`INSERT INTO schema_migrations (version) VALUES (?)`, version); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
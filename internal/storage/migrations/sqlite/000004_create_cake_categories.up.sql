@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS cake_categories (
+    cake_id INTEGER NOT NULL,
+    category_id INTEGER NOT NULL,
+    PRIMARY KEY (cake_id, category_id)
+);
+CREATE INDEX IF NOT EXISTS idx_cake_categories_category ON cake_categories (category_id);
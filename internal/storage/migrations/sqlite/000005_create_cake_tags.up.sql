@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS cake_tags (
+    cake_id INTEGER NOT NULL,
+    tag VARCHAR(50) NOT NULL,
+    PRIMARY KEY (cake_id, tag)
+);
+CREATE INDEX IF NOT EXISTS idx_cake_tags_tag ON cake_tags (tag);
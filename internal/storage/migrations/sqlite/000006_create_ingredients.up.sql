@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS ingredients (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    vegan BOOLEAN NOT NULL DEFAULT FALSE,
+    halal BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT NULL
+);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS ingredient_allergens (
+    ingredient_id INTEGER NOT NULL,
+    allergen VARCHAR(20) NOT NULL,
+    PRIMARY KEY (ingredient_id, allergen)
+);
+CREATE INDEX IF NOT EXISTS idx_ingredient_allergens_allergen ON ingredient_allergens (allergen);
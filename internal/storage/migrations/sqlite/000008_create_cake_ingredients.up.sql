@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS cake_ingredients (
+    cake_id INTEGER NOT NULL,
+    ingredient_id INTEGER NOT NULL,
+    quantity DOUBLE NOT NULL,
+    unit VARCHAR(10) NOT NULL,
+    PRIMARY KEY (cake_id, ingredient_id)
+);
+CREATE INDEX IF NOT EXISTS idx_cake_ingredients_ingredient ON cake_ingredients (ingredient_id);
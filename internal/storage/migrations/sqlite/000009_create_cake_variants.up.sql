@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS cake_variants (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    cake_id INTEGER NOT NULL,
+    size VARCHAR(50) NOT NULL,
+    sku VARCHAR(64) NOT NULL UNIQUE,
+    price_minor BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    weight_grams INT NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_cake_variants_cake ON cake_variants (cake_id);
+CREATE INDEX IF NOT EXISTS idx_cake_variants_price ON cake_variants (currency, price_minor);
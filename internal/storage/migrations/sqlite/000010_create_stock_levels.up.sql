@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS stock_levels (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    variant_id INTEGER NOT NULL,
+    cake_id INTEGER NOT NULL,
+    day CHAR(10) NOT NULL,
+    on_hand INT NOT NULL DEFAULT 0,
+    reserved INT NOT NULL DEFAULT 0,
+    low_stock_threshold INT NOT NULL DEFAULT 0,
+    version INT NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT NULL,
+    UNIQUE (variant_id, day)
+);
+CREATE INDEX IF NOT EXISTS idx_stock_levels_day_cake ON stock_levels (day, cake_id);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    variant_id INTEGER NOT NULL,
+    day CHAR(10) NOT NULL,
+    quantity INT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'held',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_variant_day ON stock_reservations (variant_id, day);
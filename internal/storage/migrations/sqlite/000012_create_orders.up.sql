@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS orders (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    customer_id VARCHAR(64) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    fulfilment VARCHAR(16) NOT NULL DEFAULT 'pickup',
+    delivery_address VARCHAR(255) NOT NULL DEFAULT '',
+    day CHAR(10) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    total_minor BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_orders_customer ON orders (customer_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_orders_status ON orders (status);
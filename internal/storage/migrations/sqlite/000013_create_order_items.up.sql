@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS order_items (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    order_id INTEGER NOT NULL,
+    cake_id INTEGER NOT NULL,
+    variant_id INTEGER NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    size VARCHAR(50) NOT NULL,
+    sku VARCHAR(64) NOT NULL,
+    quantity INT NOT NULL,
+    unit_price_minor BIGINT NOT NULL,
+    line_total_minor BIGINT NOT NULL,
+    reservation_id INTEGER NULL DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_order_items_order ON order_items (order_id);
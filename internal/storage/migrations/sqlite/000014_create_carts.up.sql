@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS carts (
+    id CHAR(32) NOT NULL PRIMARY KEY,
+    customer_id VARCHAR(64) NOT NULL DEFAULT '',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_carts_expires ON carts (expires_at);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS cart_items (
+    cart_id CHAR(32) NOT NULL,
+    cake_id INTEGER NOT NULL,
+    variant_id INTEGER NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    size VARCHAR(50) NOT NULL,
+    quantity INT NOT NULL,
+    unit_price_minor BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    position INT NOT NULL,
+    PRIMARY KEY (cart_id, variant_id)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE cakes ADD COLUMN rating_count INT NOT NULL DEFAULT 0;
+ALTER TABLE cakes ADD COLUMN rating_sum INT NOT NULL DEFAULT 0;
+UPDATE cakes SET rating_count = 1, rating_sum = MIN(MAX(ROUND(rating), 1), 5) WHERE rating > 0;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS reviews (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    cake_id INTEGER NOT NULL,
+    author VARCHAR(100) NOT NULL,
+    stars INT NOT NULL,
+    body TEXT NULL DEFAULT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_reviews_cake ON reviews (cake_id, status);
+CREATE INDEX IF NOT EXISTS idx_reviews_status ON reviews (status);
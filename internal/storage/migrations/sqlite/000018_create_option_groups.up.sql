@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS option_groups (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    cake_id INTEGER NOT NULL,
+    slug VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    min_select INT NOT NULL DEFAULT 0,
+    max_select INT NOT NULL DEFAULT 0,
+    max_length INT NOT NULL DEFAULT 0,
+    price_minor BIGINT NOT NULL DEFAULT 0,
+    currency CHAR(3) NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    UNIQUE (cake_id, slug)
+);
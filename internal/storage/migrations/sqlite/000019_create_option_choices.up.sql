@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS option_choices (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    group_id INTEGER NOT NULL,
+    cake_id INTEGER NOT NULL,
+    slug VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    price_minor BIGINT NOT NULL DEFAULT 0,
+    position INT NOT NULL DEFAULT 0,
+    UNIQUE (group_id, slug)
+);
+CREATE INDEX IF NOT EXISTS idx_option_choices_cake ON option_choices (cake_id);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS opening_hours (
+    weekday INTEGER NOT NULL PRIMARY KEY,
+    opens CHAR(5) NOT NULL,
+    closes CHAR(5) NOT NULL,
+    capacity INT NOT NULL
+);
@@ -0,0 +1,4 @@
+CREATE TABLE IF NOT EXISTS holidays (
+    day CHAR(10) NOT NULL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL DEFAULT ''
+);
@@ -0,0 +1,4 @@
+CREATE TABLE IF NOT EXISTS lead_times (
+    cake_id INTEGER NOT NULL PRIMARY KEY,
+    hours INT NOT NULL
+);
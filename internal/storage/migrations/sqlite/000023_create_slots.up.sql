@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS slots (
+    day CHAR(10) NOT NULL,
+    start_at CHAR(5) NOT NULL,
+    booked INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (day, start_at)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS slot_bookings (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    day CHAR(10) NOT NULL,
+    start_at CHAR(5) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'booked',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_slot_bookings_slot ON slot_bookings (day, start_at);
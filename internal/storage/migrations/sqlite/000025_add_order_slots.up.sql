@@ -0,0 +1,2 @@
+ALTER TABLE orders ADD COLUMN slot CHAR(5) NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN booking_id INTEGER NULL DEFAULT NULL;
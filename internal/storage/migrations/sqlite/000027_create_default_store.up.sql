@@ -0,0 +1 @@
+INSERT INTO stores (id, name, slug, is_default) VALUES (1, 'Main store', 'main', TRUE);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS store_cakes (
+    store_id INTEGER NOT NULL,
+    cake_id INTEGER NOT NULL,
+    available BOOLEAN NOT NULL DEFAULT TRUE,
+    PRIMARY KEY (store_id, cake_id)
+);
+CREATE INDEX IF NOT EXISTS idx_store_cakes_cake ON store_cakes (cake_id);
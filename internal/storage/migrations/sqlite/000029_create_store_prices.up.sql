@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS store_prices (
+    store_id INTEGER NOT NULL,
+    cake_id INTEGER NOT NULL,
+    variant_id INTEGER NOT NULL,
+    price_minor BIGINT NOT NULL,
+    PRIMARY KEY (store_id, variant_id)
+);
+CREATE INDEX IF NOT EXISTS idx_store_prices_cake ON store_prices (store_id, cake_id);
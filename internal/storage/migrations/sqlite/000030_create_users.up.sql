@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS users (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    email VARCHAR(254) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL DEFAULT '',
+    password_hash VARCHAR(100) NOT NULL,
+    role VARCHAR(16) NOT NULL DEFAULT 'customer',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT NULL
+);
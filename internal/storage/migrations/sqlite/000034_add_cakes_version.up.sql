@@ -0,0 +1 @@
+ALTER TABLE cakes ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN store_id INTEGER NULL DEFAULT NULL;
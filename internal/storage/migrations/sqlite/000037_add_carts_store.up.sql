@@ -0,0 +1 @@
+ALTER TABLE carts ADD COLUMN store_id INTEGER NOT NULL DEFAULT 0;
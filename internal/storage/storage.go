@@ -0,0 +1,89 @@
+// Package storage opens the database selected by STORAGE_DRIVER.
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+
+	_ "github.com/glebarez/go-sqlite"
+	"github.com/go-sql-driver/mysql"
+)
+
+const (
+	DriverMySQL  = "mysql"
+	DriverSQLite = "sqlite"
+	DriverMemory = "memory"
+)
+
+const defaultSQLitePath = "cake-shop.db"
+
+// Driver returns the configured storage driver, defaulting to MySQL.
+func Driver() string {
+	if driver := os.Getenv("STORAGE_DRIVER"); driver != "" {
+		return driver
+	}
+	return DriverMySQL
+}
+
+// Open connects to the database for driver. The memory driver has no
+// database and returns a nil *sql.DB.
+func Open(driver string) (*sql.DB, error) {
+	switch driver {
+	case DriverMySQL:
+		return openMySQL()
+	case DriverSQLite:
+		path := os.Getenv("SQLITE_PATH")
+		if path == "" {
+			path = defaultSQLitePath
+		}
+		return OpenSQLite(path)
+	case DriverMemory:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", driver)
+	}
+}
+
+func openMySQL() (*sql.DB, error) {
+	conf := mysql.Config{
+		User:                 os.Getenv("DB_USER"),
+		Passwd:               os.Getenv("DB_PASSWORD"),
+		Net:                  os.Getenv("DB_NET"),
+		Addr:                 os.Getenv("DB_ADDRESS"),
+		DBName:               os.Getenv("DB_NAME"),
+		AllowNativePasswords: true,
+		ParseTime:            true,
+	}
+	db, err := sql.Open("mysql", conf.FormatDSN())
+	if err != nil {
+		return nil, err
+	}
+	if err = db.Ping(); err != nil {
+		return nil, err
+	}
+	db.SetConnMaxIdleTime(3)
+	db.SetMaxOpenConns(10)
+	db.SetConnMaxLifetime(time.Hour)
+	return db, nil
+}
+
+// OpenSQLite opens the SQLite database at path and applies the embedded
+// schema. Use ":memory:" for a throwaway database.
+func OpenSQLite(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows a single writer; one connection also keeps a
+	// ":memory:" database alive for the lifetime of the pool.
+	db.SetMaxOpenConns(1)
+	db.SetConnMaxIdleTime(0)
+	db.SetConnMaxLifetime(0)
+	if err = migrateSQLite(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
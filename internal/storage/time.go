@@ -0,0 +1,9 @@
+package storage
+
+import "time"
+
+// TruncateTime drops the sub-second part MySQL DATETIME columns do not
+// store, so every driver returns the same timestamps.
+func TruncateTime(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Second)
+}
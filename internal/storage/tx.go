@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Conn is implemented by both *sql.DB and *sql.Tx.
+type Conn interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// txKey is the context key InTx stores its transaction under.
+type txKey struct{}
+
+// txState is the transaction InTx runs in, with what to run once it commits.
+type txState struct {
+	tx    *sql.Tx
+	after []func()
+}
+
+// Transactor runs writes of several repositories in one transaction.
+type Transactor interface {
+	// InTx runs fn in a transaction, committed when fn succeeds. Repository
+	// methods called with the context fn gets join it through Using; in a
+	// context already in a transaction, fn simply joins that one.
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type transactor struct {
+	db *sql.DB
+}
+
+// NewTransactor returns the Transactor of db. The memory driver has no
+// database: with a nil db, fn runs as it is.
+func NewTransactor(db *sql.DB) Transactor {
+	return transactor{db}
+}
+
+func (t transactor) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if t.db == nil {
+		return fn(ctx)
+	}
+	if _, ok := ctx.Value(txKey{}).(*txState); ok {
+		return fn(ctx)
+	}
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	state := &txState{tx: tx}
+	if err = fn(context.WithValue(ctx, txKey{}, state)); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	for _, after := range state.after {
+		after()
+	}
+	return nil
+}
+
+// AfterCommit runs fn once the transaction ctx runs in commits, and never
+// if it rolls back; outside of a transaction, fn runs right away. State kept
+// in process next to the database, such as search indexes, is updated
+// through it so it only ever holds committed writes.
+func AfterCommit(ctx context.Context, fn func()) {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		state.after = append(state.after, fn)
+		return
+	}
+	fn()
+}
+
+// Using returns the transaction ctx runs in, or db outside of one. Every
+// statement of a repository method that can run inside InTx must go
+// through it: SQLite has a single connection, held by the transaction.
+func Using(ctx context.Context, db *sql.DB) Conn {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return state.tx
+	}
+	return db
+}
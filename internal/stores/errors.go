@@ -0,0 +1,12 @@
+package stores
+
+import (
+	"cake-store/internal/helpers"
+	"fmt"
+)
+
+var (
+	ErrNotFound   = fmt.Errorf("store %w", helpers.ErrNotFound)
+	ErrConflict   = fmt.Errorf("store %w", helpers.ErrConflict)
+	ErrValidation = fmt.Errorf("store %w", helpers.ErrValidation)
+)
@@ -0,0 +1,291 @@
+package stores
+
+import (
+	"cake-store/internal/cakes"
+	"cake-store/internal/variants"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+)
+
+type SvcInterface interface {
+	List(ctx echo.Context) error
+	Get(ctx echo.Context) error
+	Create(ctx echo.Context) error
+	Update(ctx echo.Context) error
+	Delete(ctx echo.Context) error
+	GetCake(ctx echo.Context) error
+	SetCake(ctx echo.Context) error
+	DeleteCake(ctx echo.Context) error
+}
+
+type svcImplementation struct {
+	repo  RepoInterface
+	menu  Menu
+	cakes cakes.RepoInterface
+}
+
+// NewHandler returns the store handler, overriding the cakes in cakesRepo
+// and reading them back through menu.
+func NewHandler(repo RepoInterface, menu Menu, cakesRepo cakes.RepoInterface) SvcInterface {
+	return svcImplementation{repo, menu, cakesRepo}
+}
+
+// storeCake parses the store and cake ids from the path and checks both
+// exist.
+func (s svcImplementation) storeCake(ctx echo.Context) (storeID, cakeID int, err error) {
+	storeID, errStore := strconv.Atoi(ctx.Param("id"))
+	cakeID, errCake := strconv.Atoi(ctx.Param("cake_id"))
+	if errStore != nil || errCake != nil {
+		return 0, 0, echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid id")
+	}
+	if _, err := s.repo.Get(context.TODO(), storeID); err != nil {
+		return 0, 0, err
+	}
+	if _, err := s.cakes.Get(context.TODO(), cakeID); err != nil {
+		return 0, 0, err
+	}
+	return storeID, cakeID, nil
+}
+
+// List godoc
+// @Summary List all stores
+// @Description This endpoint for get list of stores, the default store first
+// @Tags Stores
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} Store
+// @Failure 500 {object} helpers.Problem
+// @Router /stores [get]
+func (s svcImplementation) List(ctx echo.Context) error {
+	res, err := s.repo.List(context.TODO())
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// Get godoc
+// @Summary Get detail of store
+// @Description This endpoint for get detail of store
+// @Tags Stores
+// @Accept  json
+// @Produce  json
+// @Param id path string true "store id"
+// @Success 200 {object} Store
+// @Failure 422 {object} helpers.Problem
+// @Failure 404 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /stores/{id} [get]
+func (s svcImplementation) Get(ctx echo.Context) error {
+	ID, errConv := strconv.Atoi(ctx.Param("id"))
+	if errConv != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid id")
+	}
+
+	data, err := s.repo.Get(context.TODO(), ID)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, data)
+}
+
+// Create godoc
+// @Summary Create store
+// @Description This endpoint for creating store, the slug defaults to the slugified name
+// @Tags Stores
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param Request body RequestDto true "Create store"
+// @Success 201 {object} Store
+// @Header 201 {string} Location "/stores/{id}"
+// @Failure 409 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /stores [post]
+func (s svcImplementation) Create(ctx echo.Context) error {
+	request := RequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	created, err := s.repo.Create(context.TODO(), request)
+	if err != nil {
+		return err
+	}
+	ctx.Response().Header().Set(echo.HeaderLocation, "/stores/"+strconv.Itoa(created.ID))
+	return ctx.JSON(http.StatusCreated, created)
+}
+
+// Update godoc
+// @Summary Update store
+// @Description This endpoint for updating store
+// @Tags Stores
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path string true "store id"
+// @Param Request body UpdateRequestDto true "Update store"
+// @Success 200 {object} Store
+// @Failure 422 {object} helpers.Problem
+// @Failure 404 {object} helpers.Problem
+// @Failure 409 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /stores/{id} [patch]
+func (s svcImplementation) Update(ctx echo.Context) error {
+	request := UpdateRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	updated, err := s.repo.Update(context.TODO(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, updated)
+}
+
+// Delete godoc
+// @Summary Delete store
+// @Description This endpoint for deleting store with its overrides; the default store cannot be deleted
+// @Tags Stores
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path string true "store id"
+// @Success 200 {string} string
+// @Failure 422 {object} helpers.Problem
+// @Failure 404 {object} helpers.Problem
+// @Failure 409 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /stores/{id} [delete]
+func (s svcImplementation) Delete(ctx echo.Context) error {
+	ID, errConv := strconv.Atoi(ctx.Param("id"))
+	if errConv != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid id")
+	}
+
+	if err := s.repo.Delete(context.TODO(), ID); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, "Success")
+}
+
+// GetCake godoc
+// @Summary Get cake of store
+// @Description This endpoint for get whether a store sells a cake and the prices of its active variants there
+// @Tags Stores
+// @Accept  json
+// @Produce  json
+// @Param id path string true "store id"
+// @Param cake_id path string true "cake id"
+// @Success 200 {object} MenuItem
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /stores/{id}/cakes/{cake_id} [get]
+func (s svcImplementation) GetCake(ctx echo.Context) error {
+	storeID, cakeID, err := s.storeCake(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := s.menu.Item(context.TODO(), storeID, cakeID)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, data)
+}
+
+// SetCake godoc
+// @Summary Override cake in store
+// @Description This endpoint for taking a cake off the menu of a store or pricing its variants differently there; it replaces the previous override
+// @Tags Stores
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path string true "store id"
+// @Param cake_id path string true "cake id"
+// @Param Request body OverrideRequestDto true "Override cake"
+// @Success 200 {object} Override
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /stores/{id}/cakes/{cake_id} [put]
+func (s svcImplementation) SetCake(ctx echo.Context) error {
+	request := OverrideRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	if _, _, err := s.storeCake(ctx); err != nil {
+		return err
+	}
+	for _, price := range request.Prices {
+		_, err := s.menu.variants.Get(context.TODO(), request.CakeID, price.VariantID)
+		if errors.Is(err, variants.ErrNotFound) {
+			return fmt.Errorf("%w: %d is not a variant of cake %d", ErrValidation, price.VariantID, request.CakeID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	updated, err := s.repo.SetOverride(context.TODO(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, updated)
+}
+
+// DeleteCake godoc
+// @Summary Reset cake in store
+// @Description This endpoint for removing the override of a cake in a store, putting it back on the menu at the catalog prices
+// @Tags Stores
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path string true "store id"
+// @Param cake_id path string true "cake id"
+// @Success 200 {string} string
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /stores/{id}/cakes/{cake_id} [delete]
+func (s svcImplementation) DeleteCake(ctx echo.Context) error {
+	storeID, cakeID, err := s.storeCake(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteOverride(context.TODO(), storeID, cakeID); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, "Success")
+}
@@ -0,0 +1,273 @@
+package stores
+
+import (
+	"cake-store/internal/money"
+	"cake-store/internal/query"
+	"cake-store/internal/storage"
+	"cake-store/internal/variants"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// overrideKey identifies the override of a cake in a store.
+type overrideKey struct {
+	storeID int
+	cakeID  int
+}
+
+type memoryRepoImplementation struct {
+	mu        sync.RWMutex
+	stores    map[int]Store
+	nextID    int
+	overrides map[overrideKey]Override
+	variants  variants.RepoInterface
+}
+
+// NewMemoryRepository returns a RepoInterface that keeps stores and the
+// overrides of their menus in maps, starting with the default store like
+// the migrations do. Menus are priced from the variants of variantsRepo.
+func NewMemoryRepository(variantsRepo variants.RepoInterface) RepoInterface {
+	return &memoryRepoImplementation{
+		stores: map[int]Store{
+			DefaultID: {ID: DefaultID, Name: "Main store", Slug: "main", Default: true, CreatedAt: storage.TruncateTime(time.Now())},
+		},
+		nextID:    DefaultID + 1,
+		overrides: map[overrideKey]Override{},
+		variants:  variantsRepo,
+	}
+}
+
+func copyStore(store Store) Store {
+	if store.UpdatedAt != nil {
+		updatedAt := *store.UpdatedAt
+		store.UpdatedAt = &updatedAt
+	}
+	return store
+}
+
+func copyOverride(override Override) Override {
+	override.Prices = append([]Price{}, override.Prices...)
+	return override
+}
+
+// slugTaken reports whether another store than id uses slug.
+func (m *memoryRepoImplementation) slugTaken(slug string, id int) bool {
+	for _, store := range m.stores {
+		if store.Slug == slug && store.ID != id {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *memoryRepoImplementation) List(ctx context.Context) ([]Store, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := []Store{}
+	for _, store := range m.stores {
+		result = append(result, copyStore(store))
+	}
+	sort.Slice(result, func(a, b int) bool {
+		if result[a].Default != result[b].Default {
+			return result[a].Default
+		}
+		if result[a].Name != result[b].Name {
+			return result[a].Name < result[b].Name
+		}
+		return result[a].ID < result[b].ID
+	})
+	return result, nil
+}
+func (m *memoryRepoImplementation) Get(ctx context.Context, id int) (*Store, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	store, ok := m.stores[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	result := copyStore(store)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Create(ctx context.Context, dto RequestDto) (*Store, error) {
+	slug, err := slugFor(dto.Name, dto.Slug)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.slugTaken(slug, 0) {
+		return nil, ErrConflict
+	}
+	store := Store{
+		ID:        m.nextID,
+		Name:      dto.Name,
+		Slug:      slug,
+		Address:   dto.Address,
+		CreatedAt: storage.TruncateTime(time.Now()),
+	}
+	m.stores[store.ID] = store
+	m.nextID++
+	result := copyStore(store)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Update(ctx context.Context, dto UpdateRequestDto) (*Store, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	store, ok := m.stores[dto.ID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if dto.Name != "" {
+		store.Name = dto.Name
+	}
+	if dto.Slug != "" {
+		slug, err := slugFor("", dto.Slug)
+		if err != nil {
+			return nil, err
+		}
+		if m.slugTaken(slug, dto.ID) {
+			return nil, ErrConflict
+		}
+		store.Slug = slug
+	}
+	if dto.Address != "" {
+		store.Address = dto.Address
+	}
+	updatedAt := storage.TruncateTime(time.Now())
+	store.UpdatedAt = &updatedAt
+	m.stores[dto.ID] = store
+	result := copyStore(store)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Delete(ctx context.Context, id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	store, ok := m.stores[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if store.Default {
+		return fmt.Errorf("%w: the default store cannot be deleted", ErrConflict)
+	}
+	delete(m.stores, id)
+	for key := range m.overrides {
+		if key.storeID == id {
+			delete(m.overrides, key)
+		}
+	}
+	return nil
+}
+func (m *memoryRepoImplementation) GetOverride(ctx context.Context, storeID, cakeID int) (*Override, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	override, ok := m.overrides[overrideKey{storeID, cakeID}]
+	if !ok {
+		override = Override{StoreID: storeID, CakeID: cakeID, Available: true}
+	}
+	result := copyOverride(override)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) SetOverride(ctx context.Context, dto OverrideRequestDto) (*Override, error) {
+	override, err := dto.override()
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sort.Slice(override.Prices, func(a, b int) bool {
+		return override.Prices[a].VariantID < override.Prices[b].VariantID
+	})
+	m.overrides[overrideKey{dto.StoreID, dto.CakeID}] = override
+	result := copyOverride(override)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) DeleteOverride(ctx context.Context, storeID, cakeID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.overrides, overrideKey{storeID, cakeID})
+	return nil
+}
+func (m *memoryRepoImplementation) OffMenu(ctx context.Context, storeID int) (query.IDs, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := []int{}
+	for key, override := range m.overrides {
+		if key.storeID == storeID && !override.Available {
+			result = append(result, key.cakeID)
+		}
+	}
+	sort.Ints(result)
+	return query.IDs{List: result}, nil
+}
+func (m *memoryRepoImplementation) CakesInPriceRange(ctx context.Context, storeID int, r money.Range) (query.IDs, error) {
+	inRange, err := m.variants.CakesInPriceRange(ctx, r)
+	if err != nil {
+		return query.IDs{}, err
+	}
+	prices, err := m.Prices(ctx, storeID)
+	if err != nil {
+		return query.IDs{}, err
+	}
+
+	result := []int{}
+	for _, id := range inRange.List {
+		if _, overridden := prices[id]; !overridden {
+			result = append(result, id)
+		}
+	}
+	cakeIDs := make([]int, 0, len(prices))
+	for cakeID := range prices {
+		cakeIDs = append(cakeIDs, cakeID)
+	}
+	list, err := m.variants.ListActive(ctx, cakeIDs)
+	if err != nil {
+		return query.IDs{}, err
+	}
+	matched := map[int]bool{}
+	for _, variant := range list {
+		priced := (Override{Prices: prices[variant.CakeID]}).apply([]variants.Variant{variant})[0]
+		if !matched[variant.CakeID] && r.Contains(priced.Price) {
+			matched[variant.CakeID] = true
+			result = append(result, variant.CakeID)
+		}
+	}
+	sort.Ints(result)
+	return query.IDs{List: result}, nil
+}
+func (m *memoryRepoImplementation) Prices(ctx context.Context, storeID int) (map[int][]Price, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := map[int][]Price{}
+	for key, override := range m.overrides {
+		if key.storeID == storeID && len(override.Prices) > 0 {
+			result[key.cakeID] = append([]Price{}, override.Prices...)
+		}
+	}
+	return result, nil
+}
+func (m *memoryRepoImplementation) DeleteForCake(ctx context.Context, cakeID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key := range m.overrides {
+		if key.cakeID == cakeID {
+			delete(m.overrides, key)
+		}
+	}
+	return nil
+}
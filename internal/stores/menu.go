@@ -0,0 +1,106 @@
+package stores
+
+import (
+	"cake-store/internal/cakes"
+	"cake-store/internal/money"
+	"cake-store/internal/query"
+	"cake-store/internal/variants"
+	"context"
+	"sort"
+)
+
+// Menu combines the catalog with the overrides of the stores. It scopes
+// the cake listing to a store and is shared by the store handler.
+type Menu struct {
+	repo     RepoInterface
+	variants variants.RepoInterface
+}
+
+func NewMenu(repo RepoInterface, variantsRepo variants.RepoInterface) Menu {
+	return Menu{repo, variantsRepo}
+}
+
+// Item returns a cake as a store sells it, with its active variants at the
+// prices of the store.
+func (m Menu) Item(ctx context.Context, storeID, cakeID int) (*MenuItem, error) {
+	override, err := m.repo.GetOverride(ctx, storeID, cakeID)
+	if err != nil {
+		return nil, err
+	}
+	active := true
+	list, err := m.variants.List(ctx, variants.ListRequestDto{CakeID: cakeID, Active: &active})
+	if err != nil {
+		return nil, err
+	}
+	list = override.apply(list)
+	sort.SliceStable(list, func(a, b int) bool {
+		return list[a].Price.Amount < list[b].Price.Amount
+	})
+	return &MenuItem{StoreID: storeID, CakeID: cakeID, Available: override.Available, Variants: list}, nil
+}
+
+// Prices returns the active variants of a cake at the prices of a store,
+// cheapest first, for single cakes read for the store.
+func (m Menu) Prices(ctx context.Context, storeID, cakeID int) ([]cakes.StorePrice, error) {
+	item, err := m.Item(ctx, storeID, cakeID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]cakes.StorePrice, len(item.Variants))
+	for n, variant := range item.Variants {
+		result[n] = cakes.StorePrice{VariantID: variant.ID, Size: variant.Size, Price: variant.Price}
+	}
+	return result, nil
+}
+
+// Exists returns ErrNotFound when there is no store storeID.
+func (m Menu) Exists(ctx context.Context, storeID int) error {
+	_, err := m.repo.Get(ctx, storeID)
+	return err
+}
+
+// Price returns what a store charges for a variant, and false when the
+// store does not sell its cake.
+func (m Menu) Price(ctx context.Context, storeID int, variant variants.Variant) (money.Money, bool, error) {
+	override, err := m.repo.GetOverride(ctx, storeID, variant.CakeID)
+	if err != nil {
+		return money.Money{}, false, err
+	}
+	if !override.Available {
+		return money.Money{}, false, nil
+	}
+	return override.apply([]variants.Variant{variant})[0].Price, true, nil
+}
+
+// Sells reports whether a store sells a cake, or returns ErrNotFound when
+// there is no such store.
+func (m Menu) Sells(ctx context.Context, storeID, cakeID int) (bool, error) {
+	if err := m.Exists(ctx, storeID); err != nil {
+		return false, err
+	}
+	override, err := m.repo.GetOverride(ctx, storeID, cakeID)
+	if err != nil {
+		return false, err
+	}
+	return override.Available, nil
+}
+
+// OffMenu selects the IDs of the cakes a store does not sell, or returns
+// ErrNotFound when there is no such store.
+func (m Menu) OffMenu(ctx context.Context, storeID int) (query.IDs, error) {
+	if err := m.Exists(ctx, storeID); err != nil {
+		return query.IDs{}, err
+	}
+	return m.repo.OffMenu(ctx, storeID)
+}
+
+// CakesInPriceRange selects the IDs of the cakes with an active variant
+// priced within r at a store.
+func (m Menu) CakesInPriceRange(ctx context.Context, storeID int, r money.Range) (query.IDs, error) {
+	return m.repo.CakesInPriceRange(ctx, storeID, r)
+}
+
+// DeleteForCake removes the overrides of a deleted cake in every store.
+func (m Menu) DeleteForCake(ctx context.Context, cakeID int) error {
+	return m.repo.DeleteForCake(ctx, cakeID)
+}
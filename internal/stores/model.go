@@ -0,0 +1,115 @@
+package stores
+
+import (
+	"cake-store/internal/categories"
+	"cake-store/internal/money"
+	"cake-store/internal/variants"
+	"fmt"
+	"time"
+)
+
+// DefaultID is the store the single-store catalog became, created by the
+// migrations. It cannot be deleted.
+const DefaultID = 1
+
+type (
+	// Store is a branch of the bakery. Every cake is on the menu of every
+	// store at its variant prices unless the store overrides them.
+	Store struct {
+		ID        int        `json:"id"`
+		Name      string     `json:"name" example:"Riverside"`
+		Slug      string     `json:"slug" example:"riverside"`
+		Address   string     `json:"address"`
+		Default   bool       `json:"default"`
+		CreatedAt time.Time  `json:"created_at"`
+		UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	}
+	// Override is how a store departs from the catalog for a cake.
+	Override struct {
+		StoreID   int     `json:"store_id"`
+		CakeID    int     `json:"cake_id"`
+		Available bool    `json:"available"`
+		Prices    []Price `json:"prices"`
+	}
+	// Price replaces the price of a variant in a store, in the currency of
+	// the variant.
+	Price struct {
+		VariantID  int   `json:"variant_id"`
+		PriceMinor int64 `json:"price_minor" example:"1350"`
+	}
+	// MenuItem is a cake as a store sells it, with the prices of its active
+	// variants there.
+	MenuItem struct {
+		StoreID   int                `json:"store_id"`
+		CakeID    int                `json:"cake_id"`
+		Available bool               `json:"available"`
+		Variants  []variants.Variant `json:"variants"`
+	}
+	RequestDto struct {
+		Name    string `json:"name" validate:"required,max=100" example:"Riverside"`
+		Slug    string `json:"slug" validate:"omitempty,max=100" example:"riverside"`
+		Address string `json:"address" validate:"omitempty,max=255"`
+	}
+	UpdateRequestDto struct {
+		ID      int    `param:"id"`
+		Name    string `json:"name" validate:"omitempty,max=100"`
+		Slug    string `json:"slug" validate:"omitempty,max=100"`
+		Address string `json:"address" validate:"omitempty,max=255"`
+	}
+	// OverrideRequestDto replaces the override of a cake in a store.
+	OverrideRequestDto struct {
+		StoreID int `param:"id" json:"-" swaggerignore:"true"`
+		CakeID  int `param:"cake_id" json:"-" swaggerignore:"true"`
+		// Available defaults to true.
+		Available *bool      `json:"available"`
+		Prices    []PriceDto `json:"prices" validate:"omitempty,max=50,dive"`
+	}
+	PriceDto struct {
+		VariantID  int   `json:"variant_id" validate:"required,gt=0"`
+		PriceMinor int64 `json:"price_minor" validate:"gte=0" example:"1350"`
+	}
+)
+
+// override returns the override dto describes, or ErrValidation when it
+// prices a variant twice.
+func (dto OverrideRequestDto) override() (Override, error) {
+	override := Override{StoreID: dto.StoreID, CakeID: dto.CakeID, Available: true, Prices: []Price{}}
+	if dto.Available != nil {
+		override.Available = *dto.Available
+	}
+	seen := map[int]bool{}
+	for _, price := range dto.Prices {
+		if seen[price.VariantID] {
+			return override, fmt.Errorf("%w: variant %d is priced twice", ErrValidation, price.VariantID)
+		}
+		seen[price.VariantID] = true
+		override.Prices = append(override.Prices, Price{price.VariantID, price.PriceMinor})
+	}
+	return override, nil
+}
+
+// apply returns the variants at the prices of override.
+func (o Override) apply(list []variants.Variant) []variants.Variant {
+	result := make([]variants.Variant, len(list))
+	for n, variant := range list {
+		for _, price := range o.Prices {
+			if price.VariantID == variant.ID {
+				variant.Price = money.New(price.PriceMinor, variant.Price.Currency)
+			}
+		}
+		result[n] = variant
+	}
+	return result
+}
+
+// slugFor returns the slug of a store, derived from its name unless one is
+// given.
+func slugFor(name, slug string) (string, error) {
+	if slug == "" {
+		slug = name
+	}
+	if slug = categories.Slugify(slug); slug == "" {
+		return "", fmt.Errorf("%w: slug must contain a letter or digit", ErrValidation)
+	}
+	return slug, nil
+}
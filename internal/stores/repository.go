@@ -0,0 +1,301 @@
+package stores
+
+//go:generate mockgen -destination=../../mocks/stores/mock_repository.go -package=mock_stores -source=repository.go
+
+import (
+	"cake-store/internal/money"
+	"cake-store/internal/query"
+	"cake-store/internal/storage"
+	"cake-store/internal/variants"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	TableName  = "stores"
+	CakesName  = "store_cakes"
+	PricesName = "store_prices"
+)
+
+// Columns lists the stores columns in the order scanned by scanStore.
+var Columns = []string{"id", "name", "slug", "address", "is_default", "created_at", "updated_at"}
+
+type repoImplementation struct {
+	db *sql.DB
+	tx storage.Transactor
+}
+
+type RepoInterface interface {
+	// List returns every store, the default one first, then by name.
+	List(ctx context.Context) ([]Store, error)
+	Get(ctx context.Context, id int) (*Store, error)
+	Create(ctx context.Context, dto RequestDto) (*Store, error)
+	Update(ctx context.Context, dto UpdateRequestDto) (*Store, error)
+	// Delete removes a store with its overrides, or returns ErrConflict for
+	// the default store.
+	Delete(ctx context.Context, id int) error
+
+	// GetOverride returns the override of a cake in a store, available at
+	// the catalog prices when it has none.
+	GetOverride(ctx context.Context, storeID, cakeID int) (*Override, error)
+	// SetOverride replaces the override of a cake in a store.
+	SetOverride(ctx context.Context, dto OverrideRequestDto) (*Override, error)
+	// DeleteOverride puts a cake back on the menu of a store at the catalog
+	// prices.
+	DeleteOverride(ctx context.Context, storeID, cakeID int) error
+	// OffMenu selects the IDs of the cakes a store does not sell.
+	OffMenu(ctx context.Context, storeID int) (query.IDs, error)
+	// CakesInPriceRange selects the IDs of the cakes with an active variant
+	// priced within r at a store. Variants the store prices differently are
+	// checked against its prices, the others against the catalog.
+	CakesInPriceRange(ctx context.Context, storeID int, r money.Range) (query.IDs, error)
+	// Prices returns the prices a store overrides, by cake.
+	Prices(ctx context.Context, storeID int) (map[int][]Price, error)
+	// DeleteForCake removes the overrides of a deleted cake.
+	DeleteForCake(ctx context.Context, cakeID int) error
+}
+
+// NewRepository returns a RepoInterface over the stores table and the
+// store_cakes and store_prices overrides of their menus.
+func NewRepository(db *sql.DB) RepoInterface {
+	return repoImplementation{db, storage.NewTransactor(db)}
+}
+
+func scanStore(scan func(dest ...interface{}) error) (store Store, err error) {
+	err = scan(&store.ID, &store.Name, &store.Slug, &store.Address, &store.Default, &store.CreatedAt, &store.UpdatedAt)
+	return
+}
+
+func (i repoImplementation) List(ctx context.Context) ([]Store, error) {
+	q, args := query.Select(TableName, Columns...).OrderBy("is_default DESC", "name ASC", "id ASC").Build()
+	rows, err := storage.Using(ctx, i.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []Store{}
+	for rows.Next() {
+		store, err := scanStore(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, store)
+	}
+	return result, rows.Err()
+}
+func (i repoImplementation) Get(ctx context.Context, id int) (*Store, error) {
+	q, args := query.Select(TableName, Columns...).Where(query.Eq("id", id)).Build()
+	result, err := scanStore(storage.Using(ctx, i.db).QueryRowContext(ctx, q, args...).Scan)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+func (i repoImplementation) Create(ctx context.Context, dto RequestDto) (*Store, error) {
+	slug, err := slugFor(dto.Name, dto.Slug)
+	if err != nil {
+		return nil, err
+	}
+	q, args := query.Insert(TableName).
+		Set("name", dto.Name).
+		Set("slug", slug).
+		Set("address", dto.Address).
+		Set("created_at", storage.TruncateTime(time.Now())).
+		Build()
+	res, err := storage.Using(ctx, i.db).ExecContext(ctx, q, args...)
+	if storage.IsDuplicate(err) {
+		return nil, ErrConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return i.Get(ctx, int(id))
+}
+func (i repoImplementation) Update(ctx context.Context, dto UpdateRequestDto) (*Store, error) {
+	builder := query.Update(TableName).Set("updated_at", storage.TruncateTime(time.Now()))
+	if dto.Name != "" {
+		builder.Set("name", dto.Name)
+	}
+	if dto.Slug != "" {
+		slug, err := slugFor("", dto.Slug)
+		if err != nil {
+			return nil, err
+		}
+		builder.Set("slug", slug)
+	}
+	if dto.Address != "" {
+		builder.Set("address", dto.Address)
+	}
+
+	q, args := builder.Where(query.Eq("id", dto.ID)).Build()
+	_, err := storage.Using(ctx, i.db).ExecContext(ctx, q, args...)
+	if storage.IsDuplicate(err) {
+		return nil, ErrConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+	return i.Get(ctx, dto.ID)
+}
+func (i repoImplementation) Delete(ctx context.Context, id int) error {
+	store, err := i.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if store.Default {
+		return fmt.Errorf("%w: the default store cannot be deleted", ErrConflict)
+	}
+	return i.tx.InTx(ctx, func(ctx context.Context) error {
+		tx := storage.Using(ctx, i.db)
+		if err := deleteOverride(ctx, tx, query.Eq("store_id", id)); err != nil {
+			return err
+		}
+		q, args := query.Delete(TableName).Where(query.Eq("id", id)).Build()
+		res, err := tx.ExecContext(ctx, q, args...)
+		if err != nil {
+			return err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+}
+
+func (i repoImplementation) GetOverride(ctx context.Context, storeID, cakeID int) (*Override, error) {
+	override := Override{StoreID: storeID, CakeID: cakeID, Available: true, Prices: []Price{}}
+	q, args := query.Select(CakesName, "available").Where(query.Eq("store_id", storeID), query.Eq("cake_id", cakeID)).Build()
+	err := storage.Using(ctx, i.db).QueryRowContext(ctx, q, args...).Scan(&override.Available)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	q, args = query.Select(PricesName, "variant_id", "price_minor").
+		Where(query.Eq("store_id", storeID), query.Eq("cake_id", cakeID)).
+		OrderBy("variant_id ASC").
+		Build()
+	rows, err := storage.Using(ctx, i.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		price := Price{}
+		if err := rows.Scan(&price.VariantID, &price.PriceMinor); err != nil {
+			return nil, err
+		}
+		override.Prices = append(override.Prices, price)
+	}
+	return &override, rows.Err()
+}
+func (i repoImplementation) SetOverride(ctx context.Context, dto OverrideRequestDto) (*Override, error) {
+	override, err := dto.override()
+	if err != nil {
+		return nil, err
+	}
+	err = i.tx.InTx(ctx, func(ctx context.Context) error {
+		tx := storage.Using(ctx, i.db)
+		if err := deleteOverride(ctx, tx, query.Eq("store_id", dto.StoreID), query.Eq("cake_id", dto.CakeID)); err != nil {
+			return err
+		}
+		q, args := query.Insert(CakesName).
+			Set("store_id", dto.StoreID).
+			Set("cake_id", dto.CakeID).
+			Set("available", override.Available).
+			Build()
+		if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+			return err
+		}
+		for _, price := range override.Prices {
+			q, args := query.Insert(PricesName).
+				Set("store_id", dto.StoreID).
+				Set("cake_id", dto.CakeID).
+				Set("variant_id", price.VariantID).
+				Set("price_minor", price.PriceMinor).
+				Build()
+			if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return i.GetOverride(ctx, dto.StoreID, dto.CakeID)
+}
+func (i repoImplementation) DeleteOverride(ctx context.Context, storeID, cakeID int) error {
+	return deleteOverride(ctx, storage.Using(ctx, i.db), query.Eq("store_id", storeID), query.Eq("cake_id", cakeID))
+}
+
+// deleteOverride removes the overrides matching conditions.
+func deleteOverride(ctx context.Context, db storage.Conn, conditions ...query.Condition) error {
+	for _, table := range []string{CakesName, PricesName} {
+		q, args := query.Delete(table).Where(conditions...).Build()
+		if _, err := db.ExecContext(ctx, q, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (i repoImplementation) OffMenu(ctx context.Context, storeID int) (query.IDs, error) {
+	return query.Subquery(query.Select(CakesName, "cake_id").
+		Where(query.Eq("store_id", storeID), query.Eq("available", false))), nil
+}
+func (i repoImplementation) CakesInPriceRange(ctx context.Context, storeID int, r money.Range) (query.IDs, error) {
+	price := "COALESCE((SELECT " + PricesName + ".price_minor FROM " + PricesName +
+		" WHERE " + PricesName + ".store_id = ? AND " + PricesName + ".variant_id = " + variants.TableName + ".id), " +
+		variants.TableName + ".price_minor)"
+	builder := query.Select(variants.TableName, variants.TableName+".cake_id").
+		Where(query.Eq(variants.TableName+".active", true), query.Eq(variants.TableName+".currency", strings.ToUpper(r.Currency)))
+	if r.Min != nil {
+		builder.Where(query.Expr(price+" >= ?", storeID, *r.Min))
+	}
+	if r.Max != nil {
+		builder.Where(query.Expr(price+" <= ?", storeID, *r.Max))
+	}
+	return query.Subquery(builder), nil
+}
+func (i repoImplementation) Prices(ctx context.Context, storeID int) (map[int][]Price, error) {
+	q, args := query.Select(PricesName, "cake_id", "variant_id", "price_minor").
+		Where(query.Eq("store_id", storeID)).
+		OrderBy("cake_id ASC", "variant_id ASC").
+		Build()
+	rows, err := storage.Using(ctx, i.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := map[int][]Price{}
+	for rows.Next() {
+		var (
+			cakeID int
+			price  Price
+		)
+		if err := rows.Scan(&cakeID, &price.VariantID, &price.PriceMinor); err != nil {
+			return nil, err
+		}
+		result[cakeID] = append(result[cakeID], price)
+	}
+	return result, rows.Err()
+}
+func (i repoImplementation) DeleteForCake(ctx context.Context, cakeID int) error {
+	return deleteOverride(ctx, storage.Using(ctx, i.db), query.Eq("cake_id", cakeID))
+}
@@ -0,0 +1,154 @@
+package users
+
+import (
+	"cake-store/internal/auth"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// refreshTokenBytes is the entropy of a refresh token.
+const refreshTokenBytes = 32
+
+// dummyHash is compared against on logins with an unknown email, so they
+// take as long as logins with a wrong password.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("cake-store"), bcrypt.DefaultCost)
+
+// Authenticator registers users and opens, refreshes and closes their
+// sessions.
+type Authenticator struct {
+	repo   RepoInterface
+	tokens auth.Tokens
+}
+
+func NewAuthenticator(repo RepoInterface, tokens auth.Tokens) Authenticator {
+	return Authenticator{repo, tokens}
+}
+
+// Register creates a customer account.
+func (a Authenticator) Register(ctx context.Context, dto RegisterRequestDto) (*User, error) {
+	return a.create(ctx, dto, auth.RoleCustomer)
+}
+
+// create stores a user with role and the bcrypt hash of the password of dto.
+func (a Authenticator) create(ctx context.Context, dto RegisterRequestDto, role string) (*User, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(dto.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	return a.repo.Create(ctx, User{Email: dto.Email, Name: dto.Name, Role: role, PasswordHash: string(hash)})
+}
+
+// Login opens a session for the user with the email and password of dto,
+// or returns ErrCredentials.
+func (a Authenticator) Login(ctx context.Context, dto LoginRequestDto) (*Session, error) {
+	user, err := a.repo.GetByEmail(ctx, dto.Email)
+	if errors.Is(err, ErrNotFound) {
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(dto.Password))
+		return nil, ErrCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(dto.Password)) != nil {
+		return nil, ErrCredentials
+	}
+	return a.open(ctx, *user)
+}
+
+// Refresh trades a refresh token for a new session. The token is used up,
+// and the new access token carries the current role of the user.
+func (a Authenticator) Refresh(ctx context.Context, dto RefreshRequestDto) (*Session, error) {
+	token, err := a.repo.UseRefreshToken(ctx, hashToken(dto.RefreshToken), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	user, err := a.repo.Get(ctx, token.UserID)
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return a.open(ctx, *user)
+}
+
+// Logout revokes a refresh token. Unknown tokens are ignored, so logging
+// out twice succeeds.
+func (a Authenticator) Logout(ctx context.Context, dto RefreshRequestDto) error {
+	_, err := a.repo.UseRefreshToken(ctx, hashToken(dto.RefreshToken), time.Now())
+	if errors.Is(err, ErrRefreshToken) {
+		return nil
+	}
+	return err
+}
+
+// Bootstrap creates the admin with email and password, unless the password
+// is blank or a placeholder. It does nothing without an email or when the
+// admin exists, and never changes the password of an existing user. As
+// anyone can register, an existing user with email who is not an admin is
+// refused with ErrConflict rather than promoted.
+func (a Authenticator) Bootstrap(ctx context.Context, email, password string) error {
+	if email == "" {
+		return nil
+	}
+	user, err := a.repo.GetByEmail(ctx, email)
+	if errors.Is(err, ErrNotFound) {
+		if auth.Placeholder(password) {
+			return fmt.Errorf("%w: AUTH_ADMIN_PASSWORD must be set to create the admin", ErrValidation)
+		}
+		if len(password) < 8 || len(password) > 72 {
+			return fmt.Errorf("%w: the admin password must be 8 to 72 characters", ErrValidation)
+		}
+		user, err = a.create(ctx, RegisterRequestDto{Email: email, Name: "Admin", Password: password}, auth.RoleAdmin)
+	}
+	if err != nil {
+		return err
+	}
+	if user.Role != auth.RoleAdmin {
+		return fmt.Errorf("%w: %s is registered as a %s, not an admin; set AUTH_ADMIN_EMAIL to an unused address", ErrConflict, email, user.Role)
+	}
+	return nil
+}
+
+// open issues an access token and a refresh token for user.
+func (a Authenticator) open(ctx context.Context, user User) (*Session, error) {
+	access, err := a.tokens.Issue(user.principal())
+	if err != nil {
+		return nil, err
+	}
+	raw := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	refresh := base64.RawURLEncoding.EncodeToString(raw)
+	err = a.repo.SaveRefreshToken(ctx, RefreshToken{
+		Hash:      hashToken(refresh),
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(a.tokens.RefreshTTL()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Session{
+		AccessToken:  access,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(a.tokens.AccessTTL().Seconds()),
+		RefreshToken: refresh,
+		User:         user,
+	}, nil
+}
+
+// hashToken is how refresh tokens are stored, so a leaked table cannot be
+// used to log in.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
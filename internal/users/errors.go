@@ -0,0 +1,20 @@
+package users
+
+import (
+	"cake-store/internal/auth"
+	"cake-store/internal/helpers"
+	"fmt"
+)
+
+var (
+	ErrNotFound   = fmt.Errorf("user %w", helpers.ErrNotFound)
+	ErrConflict   = fmt.Errorf("user %w", helpers.ErrConflict)
+	ErrValidation = fmt.Errorf("user %w", helpers.ErrValidation)
+
+	// ErrCredentials is returned for a login with an unknown email or the
+	// wrong password, without telling which.
+	ErrCredentials = fmt.Errorf("%w: the email or password is wrong", auth.ErrUnauthorized)
+	// ErrRefreshToken is returned for a refresh token that is unknown,
+	// expired or already used.
+	ErrRefreshToken = fmt.Errorf("%w: the refresh token is invalid or expired", auth.ErrUnauthorized)
+)
@@ -0,0 +1,243 @@
+package users
+
+import (
+	"cake-store/internal/auth"
+	"context"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+)
+
+type SvcInterface interface {
+	Register(ctx echo.Context) error
+	Login(ctx echo.Context) error
+	Refresh(ctx echo.Context) error
+	Logout(ctx echo.Context) error
+	Me(ctx echo.Context) error
+	Get(ctx echo.Context) error
+	SetRole(ctx echo.Context) error
+}
+
+type svcImplementation struct {
+	repo          RepoInterface
+	authenticator Authenticator
+}
+
+func NewHandler(repo RepoInterface, authenticator Authenticator) SvcInterface {
+	return svcImplementation{repo, authenticator}
+}
+
+// Register godoc
+// @Summary Register
+// @Description This endpoint for creating a customer account
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Param Request body RegisterRequestDto true "Register"
+// @Success 201 {object} User
+// @Header 201 {string} Location "/users/{id}"
+// @Failure 409 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 429 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /auth/register [post]
+func (s svcImplementation) Register(ctx echo.Context) error {
+	request := RegisterRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	created, err := s.authenticator.Register(context.TODO(), request)
+	if err != nil {
+		return err
+	}
+	ctx.Response().Header().Set(echo.HeaderLocation, "/users/"+strconv.Itoa(created.ID))
+	return ctx.JSON(http.StatusCreated, created)
+}
+
+// Login godoc
+// @Summary Log in
+// @Description This endpoint for trading an email and password for an access token and a refresh token
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Param Request body LoginRequestDto true "Log in"
+// @Success 200 {object} Session
+// @Failure 401 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 429 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /auth/login [post]
+func (s svcImplementation) Login(ctx echo.Context) error {
+	request := LoginRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	session, err := s.authenticator.Login(context.TODO(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, session)
+}
+
+// Refresh godoc
+// @Summary Refresh session
+// @Description This endpoint for trading a refresh token for new tokens; each refresh token works once
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Param Request body RefreshRequestDto true "Refresh"
+// @Success 200 {object} Session
+// @Failure 401 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 429 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /auth/refresh [post]
+func (s svcImplementation) Refresh(ctx echo.Context) error {
+	request := RefreshRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	session, err := s.authenticator.Refresh(context.TODO(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, session)
+}
+
+// Logout godoc
+// @Summary Log out
+// @Description This endpoint for revoking a refresh token; the access token stays valid until it expires
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Param Request body RefreshRequestDto true "Log out"
+// @Success 200 {string} string
+// @Failure 422 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /auth/logout [post]
+func (s svcImplementation) Logout(ctx echo.Context) error {
+	request := RefreshRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	if err := s.authenticator.Logout(context.TODO(), request); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, "Success")
+}
+
+// Me godoc
+// @Summary Get current user
+// @Description This endpoint for get the user the access token was issued to
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Success 200 {object} User
+// @Failure 401 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /users/me [get]
+func (s svcImplementation) Me(ctx echo.Context) error {
+	principal, ok := auth.PrincipalFrom(ctx)
+	if !ok {
+		return auth.ErrUnauthorized
+	}
+
+	data, err := s.repo.Get(context.TODO(), principal.UserID)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, data)
+}
+
+// Get godoc
+// @Summary Get detail of user
+// @Description This endpoint for get detail of user
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path string true "user id"
+// @Success 200 {object} User
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /users/{id} [get]
+func (s svcImplementation) Get(ctx echo.Context) error {
+	ID, errConv := strconv.Atoi(ctx.Param("id"))
+	if errConv != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid id")
+	}
+
+	data, err := s.repo.Get(context.TODO(), ID)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, data)
+}
+
+// SetRole godoc
+// @Summary Set role of user
+// @Description This endpoint for granting a user the admin, staff or customer role; it applies to the access tokens issued from then on. The refresh tokens of demoted users are revoked, so they must log in again once their access token runs out
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path string true "user id"
+// @Param Request body RoleRequestDto true "Set role"
+// @Success 200 {object} User
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /users/{id}/role [put]
+func (s svcImplementation) SetRole(ctx echo.Context) error {
+	request := RoleRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	user, err := s.repo.Get(context.TODO(), request.ID)
+	if err != nil {
+		return err
+	}
+	updated, err := s.repo.SetRole(context.TODO(), request.ID, request.Role)
+	if err != nil {
+		return err
+	}
+	// Refresh tokens would keep demoted users signed in; their access
+	// tokens still run out within the access token lifetime.
+	if demotes(user.Role, updated.Role) {
+		if err := s.repo.RevokeRefreshTokens(context.TODO(), updated.ID); err != nil {
+			return err
+		}
+	}
+	return ctx.JSON(http.StatusOK, updated)
+}
@@ -0,0 +1,125 @@
+package users
+
+import (
+	"cake-store/internal/storage"
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryRepoImplementation struct {
+	mu     sync.RWMutex
+	users  map[int]User
+	nextID int
+	tokens map[string]RefreshToken
+}
+
+// NewMemoryRepository returns a RepoInterface that keeps users by ID and
+// their refresh tokens by hash, numbering users from 1.
+func NewMemoryRepository() RepoInterface {
+	return &memoryRepoImplementation{
+		users:  map[int]User{},
+		nextID: 1,
+		tokens: map[string]RefreshToken{},
+	}
+}
+
+func copyUser(user User) User {
+	if user.UpdatedAt != nil {
+		updatedAt := *user.UpdatedAt
+		user.UpdatedAt = &updatedAt
+	}
+	return user
+}
+
+func (m *memoryRepoImplementation) Get(ctx context.Context, id int) (*User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	user, ok := m.users[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	result := copyUser(user)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) GetByEmail(ctx context.Context, email string) (*User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	email = normalizeEmail(email)
+	for _, user := range m.users {
+		if user.Email == email {
+			result := copyUser(user)
+			return &result, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+func (m *memoryRepoImplementation) Create(ctx context.Context, user User) (*User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user.Email = normalizeEmail(user.Email)
+	for _, existing := range m.users {
+		if existing.Email == user.Email {
+			return nil, ErrConflict
+		}
+	}
+	user.ID = m.nextID
+	user.CreatedAt = storage.TruncateTime(time.Now())
+	user.UpdatedAt = nil
+	m.users[user.ID] = user
+	m.nextID++
+	result := copyUser(user)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) SetRole(ctx context.Context, id int, role string) (*User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	user.Role = role
+	updatedAt := storage.TruncateTime(time.Now())
+	user.UpdatedAt = &updatedAt
+	m.users[id] = user
+	result := copyUser(user)
+	return &result, nil
+}
+
+func (m *memoryRepoImplementation) SaveRefreshToken(ctx context.Context, token RefreshToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	token.ExpiresAt = storage.TruncateTime(token.ExpiresAt)
+	m.tokens[token.Hash] = token
+	return nil
+}
+func (m *memoryRepoImplementation) UseRefreshToken(ctx context.Context, hash string, now time.Time) (*RefreshToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	token, ok := m.tokens[hash]
+	if !ok {
+		return nil, ErrRefreshToken
+	}
+	delete(m.tokens, hash)
+	if !now.Before(token.ExpiresAt) {
+		return nil, ErrRefreshToken
+	}
+	return &token, nil
+}
+func (m *memoryRepoImplementation) RevokeRefreshTokens(ctx context.Context, userID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for hash, token := range m.tokens {
+		if token.UserID == userID {
+			delete(m.tokens, hash)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package users
+
+import (
+	"cake-store/internal/auth"
+	"strings"
+	"time"
+)
+
+type (
+	// User is an account that can log in. Customers register themselves;
+	// admins grant the staff and admin roles.
+	User struct {
+		ID           int        `json:"id"`
+		Email        string     `json:"email" example:"sam@example.com"`
+		Name         string     `json:"name" example:"Sam"`
+		Role         string     `json:"role" example:"customer"`
+		PasswordHash string     `json:"-"`
+		CreatedAt    time.Time  `json:"created_at"`
+		UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+	}
+	// RefreshToken is a stored refresh token. Only the SHA-256 hash of the
+	// token is kept.
+	RefreshToken struct {
+		Hash      string
+		UserID    int
+		ExpiresAt time.Time
+	}
+	// Session is what a login or a refresh returns.
+	Session struct {
+		AccessToken  string `json:"access_token"`
+		TokenType    string `json:"token_type" example:"Bearer"`
+		ExpiresIn    int    `json:"expires_in" example:"900"`
+		RefreshToken string `json:"refresh_token"`
+		User         User   `json:"user"`
+	}
+	RegisterRequestDto struct {
+		Email    string `json:"email" validate:"required,email,max=254" example:"sam@example.com"`
+		Name     string `json:"name" validate:"omitempty,max=100" example:"Sam"`
+		Password string `json:"password" validate:"required,min=8,max=72"`
+	}
+	LoginRequestDto struct {
+		Email    string `json:"email" validate:"required" example:"sam@example.com"`
+		Password string `json:"password" validate:"required"`
+	}
+	RefreshRequestDto struct {
+		RefreshToken string `json:"refresh_token" validate:"required"`
+	}
+	RoleRequestDto struct {
+		ID   int    `param:"id" json:"-" swaggerignore:"true"`
+		Role string `json:"role" validate:"required,oneof=admin staff customer" example:"staff"`
+	}
+)
+
+// principal is who an access token issued to u speaks for.
+func (u User) principal() auth.Principal {
+	return auth.Principal{UserID: u.ID, Email: u.Email, Name: u.Name, Role: u.Role}
+}
+
+// demotes reports whether moving from role from to role to takes
+// privileges away; auth.Roles lists the roles from the most privileged.
+func demotes(from, to string) bool {
+	rank := func(role string) int {
+		for n, name := range auth.Roles {
+			if name == role {
+				return n
+			}
+		}
+		return len(auth.Roles)
+	}
+	return rank(to) > rank(from)
+}
+
+// normalizeEmail trims and lowercases an email, so logins ignore case.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,163 @@
+package users
+
+//go:generate mockgen -destination=../../mocks/users/mock_repository.go -package=mock_users -source=repository.go
+
+import (
+	"cake-store/internal/query"
+	"cake-store/internal/storage"
+	"context"
+	"database/sql"
+	"time"
+)
+
+const (
+	TableName  = "users"
+	TokensName = "refresh_tokens"
+)
+
+// Columns lists the users columns in the order scanned by scanUser.
+var Columns = []string{"id", "email", "name", "role", "password_hash", "created_at", "updated_at"}
+
+type repoImplementation struct {
+	db *sql.DB
+}
+
+type RepoInterface interface {
+	Get(ctx context.Context, id int) (*User, error)
+	// GetByEmail looks a user up by normalized email.
+	GetByEmail(ctx context.Context, email string) (*User, error)
+	// Create stores a user, or returns ErrConflict when the email is taken.
+	Create(ctx context.Context, user User) (*User, error)
+	SetRole(ctx context.Context, id int, role string) (*User, error)
+
+	SaveRefreshToken(ctx context.Context, token RefreshToken) error
+	// UseRefreshToken removes the refresh token with hash and returns it,
+	// or returns ErrRefreshToken when it is unknown or expired at now. Of
+	// two concurrent uses only one succeeds.
+	UseRefreshToken(ctx context.Context, hash string, now time.Time) (*RefreshToken, error)
+	// RevokeRefreshTokens removes every refresh token of a user.
+	RevokeRefreshTokens(ctx context.Context, userID int) error
+}
+
+// NewRepository returns a RepoInterface over the users and their
+// refresh_tokens, of which only hashes are stored.
+func NewRepository(db *sql.DB) RepoInterface {
+	return repoImplementation{db}
+}
+
+func scanUser(scan func(dest ...interface{}) error) (user User, err error) {
+	err = scan(&user.ID, &user.Email, &user.Name, &user.Role, &user.PasswordHash, &user.CreatedAt, &user.UpdatedAt)
+	return
+}
+
+func (i repoImplementation) getWhere(ctx context.Context, condition query.Condition) (*User, error) {
+	q, args := query.Select(TableName, Columns...).Where(condition).Build()
+	result, err := scanUser(i.db.QueryRowContext(ctx, q, args...).Scan)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (i repoImplementation) Get(ctx context.Context, id int) (*User, error) {
+	return i.getWhere(ctx, query.Eq("id", id))
+}
+func (i repoImplementation) GetByEmail(ctx context.Context, email string) (*User, error) {
+	return i.getWhere(ctx, query.Eq("email", normalizeEmail(email)))
+}
+func (i repoImplementation) Create(ctx context.Context, user User) (*User, error) {
+	q, args := query.Insert(TableName).
+		Set("email", normalizeEmail(user.Email)).
+		Set("name", user.Name).
+		Set("role", user.Role).
+		Set("password_hash", user.PasswordHash).
+		Set("created_at", storage.TruncateTime(time.Now())).
+		Build()
+	res, err := i.db.ExecContext(ctx, q, args...)
+	if storage.IsDuplicate(err) {
+		return nil, ErrConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return i.Get(ctx, int(id))
+}
+func (i repoImplementation) SetRole(ctx context.Context, id int, role string) (*User, error) {
+	q, args := query.Update(TableName).
+		Set("role", role).
+		Set("updated_at", storage.TruncateTime(time.Now())).
+		Where(query.Eq("id", id)).
+		Build()
+	res, err := i.db.ExecContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, ErrNotFound
+	}
+	return i.Get(ctx, id)
+}
+
+func (i repoImplementation) SaveRefreshToken(ctx context.Context, token RefreshToken) error {
+	q, args := query.Insert(TokensName).
+		Set("token_hash", token.Hash).
+		Set("user_id", token.UserID).
+		Set("expires_at", storage.TruncateTime(token.ExpiresAt)).
+		Set("created_at", storage.TruncateTime(time.Now())).
+		Build()
+	_, err := i.db.ExecContext(ctx, q, args...)
+	return err
+}
+func (i repoImplementation) UseRefreshToken(ctx context.Context, hash string, now time.Time) (*RefreshToken, error) {
+	tx, err := i.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	token := RefreshToken{Hash: hash}
+	q, args := query.Select(TokensName, "user_id", "expires_at").Where(query.Eq("token_hash", hash)).Build()
+	err = tx.QueryRowContext(ctx, q, args...).Scan(&token.UserID, &token.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	q, args = query.Delete(TokensName).Where(query.Eq("token_hash", hash)).Build()
+	res, err := tx.ExecContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, ErrRefreshToken
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	if !now.Before(token.ExpiresAt) {
+		return nil, ErrRefreshToken
+	}
+	return &token, nil
+}
+func (i repoImplementation) RevokeRefreshTokens(ctx context.Context, userID int) error {
+	q, args := query.Delete(TokensName).Where(query.Eq("user_id", userID)).Build()
+	_, err := i.db.ExecContext(ctx, q, args...)
+	return err
+}
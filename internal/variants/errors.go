@@ -0,0 +1,12 @@
+package variants
+
+import (
+	"cake-store/internal/helpers"
+	"fmt"
+)
+
+var (
+	ErrNotFound   = fmt.Errorf("variant %w", helpers.ErrNotFound)
+	ErrConflict   = fmt.Errorf("variant %w", helpers.ErrConflict)
+	ErrValidation = fmt.Errorf("variant %w", helpers.ErrValidation)
+)
@@ -0,0 +1,199 @@
+package variants
+
+import (
+	"cake-store/internal/cakes"
+	"context"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+)
+
+type SvcInterface interface {
+	List(ctx echo.Context) error
+	Get(ctx echo.Context) error
+	Create(ctx echo.Context) error
+	Update(ctx echo.Context) error
+	Delete(ctx echo.Context) error
+}
+
+type svcImplementation struct {
+	repo  RepoInterface
+	cakes cakes.RepoInterface
+}
+
+// NewHandler returns the handler of the variants nested under a cake,
+// checking the cake exists through cakesRepo.
+func NewHandler(repo RepoInterface, cakesRepo cakes.RepoInterface) SvcInterface {
+	return svcImplementation{repo, cakesRepo}
+}
+
+// ids parses the cake and variant ids from the path.
+func ids(ctx echo.Context) (cakeID, id int, err error) {
+	cakeID, errCake := strconv.Atoi(ctx.Param("id"))
+	id, errVariant := strconv.Atoi(ctx.Param("variant_id"))
+	if errCake != nil || errVariant != nil {
+		return 0, 0, echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid id")
+	}
+	return cakeID, id, nil
+}
+
+// List godoc
+// @Summary List variants of cake
+// @Description This endpoint for get the sizes of a cake ordered by price
+// @Tags Variants
+// @Accept  json
+// @Produce  json
+// @Param id path string true "cake id"
+// @Param services query ListRequestDto true "Find query"
+// @Success 200 {array} Variant
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /cakes/{id}/variants [get]
+func (s svcImplementation) List(ctx echo.Context) error {
+	request := ListRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if _, err := s.cakes.Get(context.TODO(), request.CakeID); err != nil {
+		return err
+	}
+
+	res, err := s.repo.List(context.TODO(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// Get godoc
+// @Summary Get detail of variant
+// @Description This endpoint for get detail of a cake variant
+// @Tags Variants
+// @Accept  json
+// @Produce  json
+// @Param id path string true "cake id"
+// @Param variant_id path string true "variant id"
+// @Success 200 {object} Variant
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /cakes/{id}/variants/{variant_id} [get]
+func (s svcImplementation) Get(ctx echo.Context) error {
+	cakeID, id, err := ids(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := s.repo.Get(context.TODO(), cakeID, id)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, data)
+}
+
+// Create godoc
+// @Summary Create variant
+// @Description This endpoint for adding a size of a cake, active unless told otherwise
+// @Tags Variants
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path string true "cake id"
+// @Param Request body RequestDto true "Create variant"
+// @Success 201 {object} Variant
+// @Header 201 {string} Location "/cakes/{id}/variants/{variant_id}"
+// @Failure 404 {object} helpers.Problem
+// @Failure 409 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /cakes/{id}/variants [post]
+func (s svcImplementation) Create(ctx echo.Context) error {
+	request := RequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	if _, err := s.cakes.Get(context.TODO(), request.CakeID); err != nil {
+		return err
+	}
+
+	created, err := s.repo.Create(context.TODO(), request)
+	if err != nil {
+		return err
+	}
+	ctx.Response().Header().Set(echo.HeaderLocation, "/cakes/"+strconv.Itoa(created.CakeID)+"/variants/"+strconv.Itoa(created.ID))
+	return ctx.JSON(http.StatusCreated, created)
+}
+
+// Update godoc
+// @Summary Update variant
+// @Description This endpoint for updating a cake variant
+// @Tags Variants
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path string true "cake id"
+// @Param variant_id path string true "variant id"
+// @Param Request body UpdateRequestDto true "Update variant"
+// @Success 200 {object} Variant
+// @Failure 404 {object} helpers.Problem
+// @Failure 409 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /cakes/{id}/variants/{variant_id} [patch]
+func (s svcImplementation) Update(ctx echo.Context) error {
+	request := UpdateRequestDto{}
+	if err := ctx.Bind(&request); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if err := ctx.Validate(&request); err != nil {
+		return err
+	}
+
+	updated, err := s.repo.Update(context.TODO(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, updated)
+}
+
+// Delete godoc
+// @Summary Delete variant
+// @Description This endpoint for deleting a cake variant
+// @Tags Variants
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path string true "cake id"
+// @Param variant_id path string true "variant id"
+// @Success 200 {string} string
+// @Failure 404 {object} helpers.Problem
+// @Failure 422 {object} helpers.Problem
+// @Failure 401 {object} helpers.Problem
+// @Failure 403 {object} helpers.Problem
+// @Failure 500 {object} helpers.Problem
+// @Router /cakes/{id}/variants/{variant_id} [delete]
+func (s svcImplementation) Delete(ctx echo.Context) error {
+	cakeID, id, err := ids(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(context.TODO(), cakeID, id); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, "Success")
+}
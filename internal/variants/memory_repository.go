@@ -0,0 +1,197 @@
+package variants
+
+import (
+	"cake-store/internal/money"
+	"cake-store/internal/query"
+	"cake-store/internal/storage"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type memoryRepoImplementation struct {
+	mu       sync.RWMutex
+	variants map[int]Variant
+	nextID   int
+}
+
+// NewMemoryRepository returns a RepoInterface that keeps variants by ID,
+// checking SKUs are unique under its lock.
+func NewMemoryRepository() RepoInterface {
+	return &memoryRepoImplementation{
+		variants: map[int]Variant{},
+		nextID:   1,
+	}
+}
+
+func copyVariant(variant Variant) Variant {
+	if variant.UpdatedAt != nil {
+		updatedAt := *variant.UpdatedAt
+		variant.UpdatedAt = &updatedAt
+	}
+	return variant
+}
+
+// skuTaken reports whether another variant than id uses sku.
+func (m *memoryRepoImplementation) skuTaken(sku string, id int) bool {
+	for _, variant := range m.variants {
+		if variant.SKU == sku && variant.ID != id {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *memoryRepoImplementation) List(ctx context.Context, dto ListRequestDto) ([]Variant, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := []Variant{}
+	for _, variant := range m.variants {
+		if variant.CakeID == dto.CakeID && (dto.Active == nil || variant.Active == *dto.Active) {
+			result = append(result, copyVariant(variant))
+		}
+	}
+	sort.Slice(result, func(a, b int) bool {
+		if result[a].Price.Amount != result[b].Price.Amount {
+			return result[a].Price.Amount < result[b].Price.Amount
+		}
+		return result[a].ID < result[b].ID
+	})
+	return result, nil
+}
+func (m *memoryRepoImplementation) ListActive(ctx context.Context, cakeIDs []int) ([]Variant, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	wanted := map[int]bool{}
+	for _, id := range cakeIDs {
+		wanted[id] = true
+	}
+	result := []Variant{}
+	for _, variant := range m.variants {
+		if wanted[variant.CakeID] && variant.Active {
+			result = append(result, copyVariant(variant))
+		}
+	}
+	sort.Slice(result, func(a, b int) bool {
+		if result[a].CakeID != result[b].CakeID {
+			return result[a].CakeID < result[b].CakeID
+		}
+		if result[a].Price.Amount != result[b].Price.Amount {
+			return result[a].Price.Amount < result[b].Price.Amount
+		}
+		return result[a].ID < result[b].ID
+	})
+	return result, nil
+}
+func (m *memoryRepoImplementation) Get(ctx context.Context, cakeID, id int) (*Variant, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	variant, ok := m.variants[id]
+	if !ok || variant.CakeID != cakeID {
+		return nil, ErrNotFound
+	}
+	result := copyVariant(variant)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Create(ctx context.Context, dto RequestDto) (*Variant, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sku := normalizeSKU(dto.SKU)
+	if m.skuTaken(sku, 0) {
+		return nil, ErrConflict
+	}
+	variant := Variant{
+		ID:          m.nextID,
+		CakeID:      dto.CakeID,
+		Size:        dto.Size,
+		SKU:         sku,
+		Price:       money.New(dto.PriceMinor, dto.Currency),
+		WeightGrams: dto.WeightGrams,
+		Active:      dto.Active == nil || *dto.Active,
+		CreatedAt:   storage.TruncateTime(time.Now()),
+	}
+	m.variants[variant.ID] = variant
+	m.nextID++
+	result := copyVariant(variant)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Update(ctx context.Context, dto UpdateRequestDto) (*Variant, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	variant, ok := m.variants[dto.ID]
+	if !ok || variant.CakeID != dto.CakeID {
+		return nil, ErrNotFound
+	}
+	if dto.Size != "" {
+		variant.Size = dto.Size
+	}
+	if dto.SKU != "" {
+		sku := normalizeSKU(dto.SKU)
+		if m.skuTaken(sku, dto.ID) {
+			return nil, ErrConflict
+		}
+		variant.SKU = sku
+	}
+	amount, currency := variant.Price.Amount, variant.Price.Currency
+	if dto.PriceMinor != nil {
+		amount = *dto.PriceMinor
+	}
+	if dto.Currency != "" {
+		currency = strings.ToUpper(dto.Currency)
+	}
+	variant.Price = money.New(amount, currency)
+	if dto.WeightGrams != nil {
+		variant.WeightGrams = *dto.WeightGrams
+	}
+	if dto.Active != nil {
+		variant.Active = *dto.Active
+	}
+	updatedAt := storage.TruncateTime(time.Now())
+	variant.UpdatedAt = &updatedAt
+	m.variants[dto.ID] = variant
+	result := copyVariant(variant)
+	return &result, nil
+}
+func (m *memoryRepoImplementation) Delete(ctx context.Context, cakeID, id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	variant, ok := m.variants[id]
+	if !ok || variant.CakeID != cakeID {
+		return ErrNotFound
+	}
+	delete(m.variants, id)
+	return nil
+}
+func (m *memoryRepoImplementation) DeleteForCake(ctx context.Context, cakeID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, variant := range m.variants {
+		if variant.CakeID == cakeID {
+			delete(m.variants, id)
+		}
+	}
+	return nil
+}
+func (m *memoryRepoImplementation) CakesInPriceRange(ctx context.Context, r money.Range) (query.IDs, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	seen := map[int]bool{}
+	result := []int{}
+	for _, variant := range m.variants {
+		if variant.Active && r.Contains(variant.Price) && !seen[variant.CakeID] {
+			seen[variant.CakeID] = true
+			result = append(result, variant.CakeID)
+		}
+	}
+	return query.IDs{List: result}, nil
+}
@@ -0,0 +1,50 @@
+package variants
+
+import (
+	"cake-store/internal/money"
+	"strings"
+	"time"
+)
+
+type (
+	// Variant is a sellable size of a cake with its own SKU and price.
+	Variant struct {
+		ID          int         `json:"id"`
+		CakeID      int         `json:"cake_id"`
+		Size        string      `json:"size" example:"8 inch"`
+		SKU         string      `json:"sku" example:"LEMON-CHEESE-8"`
+		Price       money.Money `json:"price"`
+		WeightGrams int         `json:"weight_grams"`
+		Active      bool        `json:"active"`
+		CreatedAt   time.Time   `json:"created_at"`
+		UpdatedAt   *time.Time  `json:"updated_at,omitempty"`
+	}
+	ListRequestDto struct {
+		CakeID int   `param:"id" swaggerignore:"true"`
+		Active *bool `query:"active" json:"active"`
+	}
+	RequestDto struct {
+		CakeID      int    `param:"id" json:"-" swaggerignore:"true"`
+		Size        string `json:"size" validate:"required,max=50"`
+		SKU         string `json:"sku" validate:"required,max=64,printascii"`
+		PriceMinor  int64  `json:"price_minor" validate:"gte=0" example:"1250"`
+		Currency    string `json:"currency" validate:"required,iso4217" example:"USD"`
+		WeightGrams int    `json:"weight_grams" validate:"gte=0"`
+		Active      *bool  `json:"active"`
+	}
+	UpdateRequestDto struct {
+		CakeID      int    `param:"id" json:"-" swaggerignore:"true"`
+		ID          int    `param:"variant_id" json:"-" swaggerignore:"true"`
+		Size        string `json:"size" validate:"omitempty,max=50"`
+		SKU         string `json:"sku" validate:"omitempty,max=64,printascii"`
+		PriceMinor  *int64 `json:"price_minor" validate:"omitempty,gte=0"`
+		Currency    string `json:"currency" validate:"omitempty,iso4217"`
+		WeightGrams *int   `json:"weight_grams" validate:"omitempty,gte=0"`
+		Active      *bool  `json:"active"`
+	}
+)
+
+// normalizeSKU trims and uppercases a SKU, so lookups ignore case.
+func normalizeSKU(sku string) string {
+	return strings.ToUpper(strings.TrimSpace(sku))
+}
@@ -0,0 +1,192 @@
+package variants
+
+//go:generate mockgen -destination=../../mocks/variants/mock_repository.go -package=mock_variants -source=repository.go
+
+import (
+	"cake-store/internal/money"
+	"cake-store/internal/query"
+	"cake-store/internal/storage"
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+)
+
+const TableName = "cake_variants"
+
+// Columns lists the cake_variants columns in the order scanned by
+// scanVariant.
+var Columns = []string{"id", "cake_id", "size", "sku", "price_minor", "currency", "weight_grams", "active", "created_at", "updated_at"}
+
+type repoImplementation struct {
+	db *sql.DB
+}
+
+type RepoInterface interface {
+	// List returns the variants of a cake ordered by price, optionally only
+	// the active or inactive ones.
+	List(ctx context.Context, dto ListRequestDto) ([]Variant, error)
+	// ListActive returns the active variants of several cakes at once,
+	// ordered by cake and then like List.
+	ListActive(ctx context.Context, cakeIDs []int) ([]Variant, error)
+	// Get returns a variant of a cake, or ErrNotFound when the variant
+	// belongs to another cake.
+	Get(ctx context.Context, cakeID, id int) (*Variant, error)
+	Create(ctx context.Context, dto RequestDto) (*Variant, error)
+	Update(ctx context.Context, dto UpdateRequestDto) (*Variant, error)
+	Delete(ctx context.Context, cakeID, id int) error
+	// DeleteForCake removes every variant of a deleted cake.
+	DeleteForCake(ctx context.Context, cakeID int) error
+	// CakesInPriceRange selects the IDs of the cakes with an active variant
+	// priced within r.
+	CakesInPriceRange(ctx context.Context, r money.Range) (query.IDs, error)
+}
+
+// NewRepository returns a RepoInterface over the cake_variants table,
+// whose SKUs are unique.
+func NewRepository(db *sql.DB) RepoInterface {
+	return repoImplementation{db}
+}
+
+func scanVariant(scan func(dest ...interface{}) error) (variant Variant, err error) {
+	var (
+		amount   int64
+		currency string
+	)
+	err = scan(&variant.ID, &variant.CakeID, &variant.Size, &variant.SKU, &amount, &currency, &variant.WeightGrams, &variant.Active, &variant.CreatedAt, &variant.UpdatedAt)
+	variant.Price = money.New(amount, currency)
+	return
+}
+
+func (i repoImplementation) List(ctx context.Context, dto ListRequestDto) ([]Variant, error) {
+	builder := query.Select(TableName, Columns...).Where(query.Eq("cake_id", dto.CakeID))
+	if dto.Active != nil {
+		builder.Where(query.Eq("active", *dto.Active))
+	}
+	q, args := builder.OrderBy("price_minor ASC", "id ASC").Build()
+	return i.list(ctx, q, args)
+}
+func (i repoImplementation) ListActive(ctx context.Context, cakeIDs []int) ([]Variant, error) {
+	q, args := query.Select(TableName, Columns...).
+		Where(query.In("cake_id", query.Ints(cakeIDs)...), query.Eq("active", true)).
+		OrderBy("cake_id ASC", "price_minor ASC", "id ASC").
+		Build()
+	return i.list(ctx, q, args)
+}
+
+// list returns the variants selected by q.
+func (i repoImplementation) list(ctx context.Context, q string, args []interface{}) ([]Variant, error) {
+	rows, err := storage.Using(ctx, i.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []Variant{}
+	for rows.Next() {
+		variant, err := scanVariant(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, variant)
+	}
+	return result, rows.Err()
+}
+func (i repoImplementation) Get(ctx context.Context, cakeID, id int) (*Variant, error) {
+	q, args := query.Select(TableName, Columns...).Where(query.Eq("id", id), query.Eq("cake_id", cakeID)).Build()
+	result, err := scanVariant(storage.Using(ctx, i.db).QueryRowContext(ctx, q, args...).Scan)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+func (i repoImplementation) Create(ctx context.Context, dto RequestDto) (*Variant, error) {
+	active := dto.Active == nil || *dto.Active
+	q, args := query.Insert(TableName).
+		Set("cake_id", dto.CakeID).
+		Set("size", dto.Size).
+		Set("sku", normalizeSKU(dto.SKU)).
+		Set("price_minor", dto.PriceMinor).
+		Set("currency", strings.ToUpper(dto.Currency)).
+		Set("weight_grams", dto.WeightGrams).
+		Set("active", active).
+		Set("created_at", storage.TruncateTime(time.Now())).
+		Build()
+	res, err := storage.Using(ctx, i.db).ExecContext(ctx, q, args...)
+	if storage.IsDuplicate(err) {
+		return nil, ErrConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return i.Get(ctx, dto.CakeID, int(id))
+}
+func (i repoImplementation) Update(ctx context.Context, dto UpdateRequestDto) (*Variant, error) {
+	builder := query.Update(TableName).Set("updated_at", storage.TruncateTime(time.Now()))
+	if dto.Size != "" {
+		builder.Set("size", dto.Size)
+	}
+	if dto.SKU != "" {
+		builder.Set("sku", normalizeSKU(dto.SKU))
+	}
+	if dto.PriceMinor != nil {
+		builder.Set("price_minor", *dto.PriceMinor)
+	}
+	if dto.Currency != "" {
+		builder.Set("currency", strings.ToUpper(dto.Currency))
+	}
+	if dto.WeightGrams != nil {
+		builder.Set("weight_grams", *dto.WeightGrams)
+	}
+	if dto.Active != nil {
+		builder.Set("active", *dto.Active)
+	}
+
+	q, args := builder.Where(query.Eq("id", dto.ID), query.Eq("cake_id", dto.CakeID)).Build()
+	_, err := storage.Using(ctx, i.db).ExecContext(ctx, q, args...)
+	if storage.IsDuplicate(err) {
+		return nil, ErrConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+	return i.Get(ctx, dto.CakeID, dto.ID)
+}
+func (i repoImplementation) Delete(ctx context.Context, cakeID, id int) error {
+	q, args := query.Delete(TableName).Where(query.Eq("id", id), query.Eq("cake_id", cakeID)).Build()
+	res, err := storage.Using(ctx, i.db).ExecContext(ctx, q, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+func (i repoImplementation) DeleteForCake(ctx context.Context, cakeID int) error {
+	q, args := query.Delete(TableName).Where(query.Eq("cake_id", cakeID)).Build()
+	_, err := storage.Using(ctx, i.db).ExecContext(ctx, q, args...)
+	return err
+}
+func (i repoImplementation) CakesInPriceRange(ctx context.Context, r money.Range) (query.IDs, error) {
+	builder := query.Select(TableName, "cake_id").
+		Where(query.Eq("active", true), query.Eq("currency", strings.ToUpper(r.Currency)))
+	if r.Min != nil {
+		builder.Where(query.Expr("price_minor >= ?", *r.Min))
+	}
+	if r.Max != nil {
+		builder.Where(query.Expr("price_minor <= ?", *r.Max))
+	}
+	return query.Subquery(builder), nil
+}
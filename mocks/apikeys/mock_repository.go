@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_apikeys is a generated GoMock package.
+package mock_apikeys
+
+import (
+	apikeys "cake-store/internal/apikeys"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepoInterface is a mock of RepoInterface interface.
+type MockRepoInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoInterfaceMockRecorder
+}
+
+// MockRepoInterfaceMockRecorder is the mock recorder for MockRepoInterface.
+type MockRepoInterfaceMockRecorder struct {
+	mock *MockRepoInterface
+}
+
+// NewMockRepoInterface creates a new mock instance.
+func NewMockRepoInterface(ctrl *gomock.Controller) *MockRepoInterface {
+	mock := &MockRepoInterface{ctrl: ctrl}
+	mock.recorder = &MockRepoInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepoInterface) EXPECT() *MockRepoInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepoInterface) Create(ctx context.Context, key apikeys.Key) (*apikeys.Key, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, key)
+	ret0, _ := ret[0].(*apikeys.Key)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepoInterfaceMockRecorder) Create(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepoInterface)(nil).Create), ctx, key)
+}
+
+// Get mocks base method.
+func (m *MockRepoInterface) Get(ctx context.Context, id int) (*apikeys.Key, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*apikeys.Key)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepoInterfaceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepoInterface)(nil).Get), ctx, id)
+}
+
+// GetByHash mocks base method.
+func (m *MockRepoInterface) GetByHash(ctx context.Context, hash string) (*apikeys.Key, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, hash)
+	ret0, _ := ret[0].(*apikeys.Key)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockRepoInterfaceMockRecorder) GetByHash(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockRepoInterface)(nil).GetByHash), ctx, hash)
+}
+
+// List mocks base method.
+func (m *MockRepoInterface) List(ctx context.Context) ([]apikeys.Key, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]apikeys.Key)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepoInterfaceMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepoInterface)(nil).List), ctx)
+}
+
+// Revoke mocks base method.
+func (m *MockRepoInterface) Revoke(ctx context.Context, id int) (*apikeys.Key, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id)
+	ret0, _ := ret[0].(*apikeys.Key)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockRepoInterfaceMockRecorder) Revoke(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockRepoInterface)(nil).Revoke), ctx, id)
+}
+
+// Rotate mocks base method.
+func (m *MockRepoInterface) Rotate(ctx context.Context, id int, prefix, hash string) (*apikeys.Key, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", ctx, id, prefix, hash)
+	ret0, _ := ret[0].(*apikeys.Key)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockRepoInterfaceMockRecorder) Rotate(ctx, id, prefix, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockRepoInterface)(nil).Rotate), ctx, id, prefix, hash)
+}
+
+// Touch mocks base method.
+func (m *MockRepoInterface) Touch(ctx context.Context, id int, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", ctx, id, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockRepoInterfaceMockRecorder) Touch(ctx, id, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockRepoInterface)(nil).Touch), ctx, id, now)
+}
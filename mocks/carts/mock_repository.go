@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_carts is a generated GoMock package.
+package mock_carts
+
+import (
+	carts "cake-store/internal/carts"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepoInterface is a mock of RepoInterface interface.
+type MockRepoInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoInterfaceMockRecorder
+}
+
+// MockRepoInterfaceMockRecorder is the mock recorder for MockRepoInterface.
+type MockRepoInterfaceMockRecorder struct {
+	mock *MockRepoInterface
+}
+
+// NewMockRepoInterface creates a new mock instance.
+func NewMockRepoInterface(ctrl *gomock.Controller) *MockRepoInterface {
+	mock := &MockRepoInterface{ctrl: ctrl}
+	mock.recorder = &MockRepoInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepoInterface) EXPECT() *MockRepoInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockRepoInterface) Claim(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockRepoInterfaceMockRecorder) Claim(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockRepoInterface)(nil).Claim), ctx, id)
+}
+
+// Create mocks base method.
+func (m *MockRepoInterface) Create(ctx context.Context, cart carts.Cart) (*carts.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, cart)
+	ret0, _ := ret[0].(*carts.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepoInterfaceMockRecorder) Create(ctx, cart interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepoInterface)(nil).Create), ctx, cart)
+}
+
+// Delete mocks base method.
+func (m *MockRepoInterface) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepoInterfaceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepoInterface)(nil).Delete), ctx, id)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockRepoInterface) DeleteExpired(ctx context.Context, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockRepoInterfaceMockRecorder) DeleteExpired(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockRepoInterface)(nil).DeleteExpired), ctx, now)
+}
+
+// Get mocks base method.
+func (m *MockRepoInterface) Get(ctx context.Context, id string) (*carts.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*carts.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepoInterfaceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepoInterface)(nil).Get), ctx, id)
+}
+
+// Reopen mocks base method.
+func (m *MockRepoInterface) Reopen(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reopen", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reopen indicates an expected call of Reopen.
+func (mr *MockRepoInterfaceMockRecorder) Reopen(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reopen", reflect.TypeOf((*MockRepoInterface)(nil).Reopen), ctx, id)
+}
+
+// Save mocks base method.
+func (m *MockRepoInterface) Save(ctx context.Context, cart carts.Cart) (*carts.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, cart)
+	ret0, _ := ret[0].(*carts.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRepoInterfaceMockRecorder) Save(ctx, cart interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRepoInterface)(nil).Save), ctx, cart)
+}
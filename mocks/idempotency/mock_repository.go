@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_idempotency is a generated GoMock package.
+package mock_idempotency
+
+import (
+	idempotency "cake-store/internal/idempotency"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepoInterface is a mock of RepoInterface interface.
+type MockRepoInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoInterfaceMockRecorder
+}
+
+// MockRepoInterfaceMockRecorder is the mock recorder for MockRepoInterface.
+type MockRepoInterfaceMockRecorder struct {
+	mock *MockRepoInterface
+}
+
+// NewMockRepoInterface creates a new mock instance.
+func NewMockRepoInterface(ctrl *gomock.Controller) *MockRepoInterface {
+	mock := &MockRepoInterface{ctrl: ctrl}
+	mock.recorder = &MockRepoInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepoInterface) EXPECT() *MockRepoInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *MockRepoInterface) Complete(ctx context.Context, key, owner string, response idempotency.Response) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, key, owner, response)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockRepoInterfaceMockRecorder) Complete(ctx, key, owner, response interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockRepoInterface)(nil).Complete), ctx, key, owner, response)
+}
+
+// Purge mocks base method.
+func (m *MockRepoInterface) Purge(ctx context.Context, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockRepoInterfaceMockRecorder) Purge(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockRepoInterface)(nil).Purge), ctx, now)
+}
+
+// Release mocks base method.
+func (m *MockRepoInterface) Release(ctx context.Context, key, owner string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, key, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockRepoInterfaceMockRecorder) Release(ctx, key, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockRepoInterface)(nil).Release), ctx, key, owner)
+}
+
+// Reserve mocks base method.
+func (m *MockRepoInterface) Reserve(ctx context.Context, record idempotency.Record, staleBefore time.Time) (*idempotency.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, record, staleBefore)
+	ret0, _ := ret[0].(*idempotency.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockRepoInterfaceMockRecorder) Reserve(ctx, record, staleBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockRepoInterface)(nil).Reserve), ctx, record, staleBefore)
+}
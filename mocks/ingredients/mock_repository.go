@@ -0,0 +1,213 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_ingredients is a generated GoMock package.
+package mock_ingredients
+
+import (
+	ingredients "cake-store/internal/ingredients"
+	query "cake-store/internal/query"
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepoInterface is a mock of RepoInterface interface.
+type MockRepoInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoInterfaceMockRecorder
+}
+
+// MockRepoInterfaceMockRecorder is the mock recorder for MockRepoInterface.
+type MockRepoInterfaceMockRecorder struct {
+	mock *MockRepoInterface
+}
+
+// NewMockRepoInterface creates a new mock instance.
+func NewMockRepoInterface(ctrl *gomock.Controller) *MockRepoInterface {
+	mock := &MockRepoInterface{ctrl: ctrl}
+	mock.recorder = &MockRepoInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepoInterface) EXPECT() *MockRepoInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Assign mocks base method.
+func (m *MockRepoInterface) Assign(ctx context.Context, cakeID int, quantities []ingredients.QuantityDto) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assign", ctx, cakeID, quantities)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Assign indicates an expected call of Assign.
+func (mr *MockRepoInterfaceMockRecorder) Assign(ctx, cakeID, quantities interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assign", reflect.TypeOf((*MockRepoInterface)(nil).Assign), ctx, cakeID, quantities)
+}
+
+// CakesWithAllergens mocks base method.
+func (m *MockRepoInterface) CakesWithAllergens(ctx context.Context, allergens []string) (query.IDs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CakesWithAllergens", ctx, allergens)
+	ret0, _ := ret[0].(query.IDs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CakesWithAllergens indicates an expected call of CakesWithAllergens.
+func (mr *MockRepoInterfaceMockRecorder) CakesWithAllergens(ctx, allergens interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CakesWithAllergens", reflect.TypeOf((*MockRepoInterface)(nil).CakesWithAllergens), ctx, allergens)
+}
+
+// Check mocks base method.
+func (m *MockRepoInterface) Check(ctx context.Context, quantities []ingredients.QuantityDto) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, quantities)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockRepoInterfaceMockRecorder) Check(ctx, quantities interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockRepoInterface)(nil).Check), ctx, quantities)
+}
+
+// Create mocks base method.
+func (m *MockRepoInterface) Create(ctx context.Context, dto ingredients.RequestDto) (*ingredients.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, dto)
+	ret0, _ := ret[0].(*ingredients.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepoInterfaceMockRecorder) Create(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepoInterface)(nil).Create), ctx, dto)
+}
+
+// Delete mocks base method.
+func (m *MockRepoInterface) Delete(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepoInterfaceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepoInterface)(nil).Delete), ctx, id)
+}
+
+// ForCakes mocks base method.
+func (m *MockRepoInterface) ForCakes(ctx context.Context, cakeIDs []int) (map[int][]ingredients.CakeIngredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForCakes", ctx, cakeIDs)
+	ret0, _ := ret[0].(map[int][]ingredients.CakeIngredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForCakes indicates an expected call of ForCakes.
+func (mr *MockRepoInterfaceMockRecorder) ForCakes(ctx, cakeIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForCakes", reflect.TypeOf((*MockRepoInterface)(nil).ForCakes), ctx, cakeIDs)
+}
+
+// Get mocks base method.
+func (m *MockRepoInterface) Get(ctx context.Context, id int) (*ingredients.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*ingredients.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepoInterfaceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepoInterface)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockRepoInterface) List(ctx context.Context) ([]ingredients.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]ingredients.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepoInterfaceMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepoInterface)(nil).List), ctx)
+}
+
+// Update mocks base method.
+func (m *MockRepoInterface) Update(ctx context.Context, dto ingredients.UpdateRequestDto) (*ingredients.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, dto)
+	ret0, _ := ret[0].(*ingredients.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepoInterfaceMockRecorder) Update(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepoInterface)(nil).Update), ctx, dto)
+}
+
+// Mockquerier is a mock of querier interface.
+type Mockquerier struct {
+	ctrl     *gomock.Controller
+	recorder *MockquerierMockRecorder
+}
+
+// MockquerierMockRecorder is the mock recorder for Mockquerier.
+type MockquerierMockRecorder struct {
+	mock *Mockquerier
+}
+
+// NewMockquerier creates a new mock instance.
+func NewMockquerier(ctrl *gomock.Controller) *Mockquerier {
+	mock := &Mockquerier{ctrl: ctrl}
+	mock.recorder = &MockquerierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockquerier) EXPECT() *MockquerierMockRecorder {
+	return m.recorder
+}
+
+// QueryContext mocks base method.
+func (m *Mockquerier) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryContext", varargs...)
+	ret0, _ := ret[0].(*sql.Rows)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryContext indicates an expected call of QueryContext.
+func (mr *MockquerierMockRecorder) QueryContext(ctx, query interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryContext", reflect.TypeOf((*Mockquerier)(nil).QueryContext), varargs...)
+}
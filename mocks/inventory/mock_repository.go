@@ -0,0 +1,215 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_inventory is a generated GoMock package.
+package mock_inventory
+
+import (
+	inventory "cake-store/internal/inventory"
+	query "cake-store/internal/query"
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepoInterface is a mock of RepoInterface interface.
+type MockRepoInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoInterfaceMockRecorder
+}
+
+// MockRepoInterfaceMockRecorder is the mock recorder for MockRepoInterface.
+type MockRepoInterfaceMockRecorder struct {
+	mock *MockRepoInterface
+}
+
+// NewMockRepoInterface creates a new mock instance.
+func NewMockRepoInterface(ctrl *gomock.Controller) *MockRepoInterface {
+	mock := &MockRepoInterface{ctrl: ctrl}
+	mock.recorder = &MockRepoInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepoInterface) EXPECT() *MockRepoInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CakesInStock mocks base method.
+func (m *MockRepoInterface) CakesInStock(ctx context.Context, day string) (query.IDs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CakesInStock", ctx, day)
+	ret0, _ := ret[0].(query.IDs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CakesInStock indicates an expected call of CakesInStock.
+func (mr *MockRepoInterfaceMockRecorder) CakesInStock(ctx, day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CakesInStock", reflect.TypeOf((*MockRepoInterface)(nil).CakesInStock), ctx, day)
+}
+
+// Commit mocks base method.
+func (m *MockRepoInterface) Commit(ctx context.Context, id int) (*inventory.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Commit", ctx, id)
+	ret0, _ := ret[0].(*inventory.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Commit indicates an expected call of Commit.
+func (mr *MockRepoInterfaceMockRecorder) Commit(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockRepoInterface)(nil).Commit), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockRepoInterface) Get(ctx context.Context, variantID int, day string) (*inventory.Stock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, variantID, day)
+	ret0, _ := ret[0].(*inventory.Stock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepoInterfaceMockRecorder) Get(ctx, variantID, day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepoInterface)(nil).Get), ctx, variantID, day)
+}
+
+// GetReservation mocks base method.
+func (m *MockRepoInterface) GetReservation(ctx context.Context, id int) (*inventory.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservation", ctx, id)
+	ret0, _ := ret[0].(*inventory.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservation indicates an expected call of GetReservation.
+func (mr *MockRepoInterfaceMockRecorder) GetReservation(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservation", reflect.TypeOf((*MockRepoInterface)(nil).GetReservation), ctx, id)
+}
+
+// InStock mocks base method.
+func (m *MockRepoInterface) InStock(ctx context.Context, day string, cakeIDs []int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InStock", ctx, day, cakeIDs)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InStock indicates an expected call of InStock.
+func (mr *MockRepoInterfaceMockRecorder) InStock(ctx, day, cakeIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InStock", reflect.TypeOf((*MockRepoInterface)(nil).InStock), ctx, day, cakeIDs)
+}
+
+// List mocks base method.
+func (m *MockRepoInterface) List(ctx context.Context, dto inventory.ListRequestDto) ([]inventory.Stock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, dto)
+	ret0, _ := ret[0].([]inventory.Stock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepoInterfaceMockRecorder) List(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepoInterface)(nil).List), ctx, dto)
+}
+
+// Release mocks base method.
+func (m *MockRepoInterface) Release(ctx context.Context, id int) (*inventory.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, id)
+	ret0, _ := ret[0].(*inventory.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockRepoInterfaceMockRecorder) Release(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockRepoInterface)(nil).Release), ctx, id)
+}
+
+// Reserve mocks base method.
+func (m *MockRepoInterface) Reserve(ctx context.Context, dto inventory.ReserveRequestDto) (*inventory.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, dto)
+	ret0, _ := ret[0].(*inventory.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockRepoInterfaceMockRecorder) Reserve(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockRepoInterface)(nil).Reserve), ctx, dto)
+}
+
+// Set mocks base method.
+func (m *MockRepoInterface) Set(ctx context.Context, dto inventory.SetRequestDto) (*inventory.Stock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, dto)
+	ret0, _ := ret[0].(*inventory.Stock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockRepoInterfaceMockRecorder) Set(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockRepoInterface)(nil).Set), ctx, dto)
+}
+
+// Mockquerier is a mock of querier interface.
+type Mockquerier struct {
+	ctrl     *gomock.Controller
+	recorder *MockquerierMockRecorder
+}
+
+// MockquerierMockRecorder is the mock recorder for Mockquerier.
+type MockquerierMockRecorder struct {
+	mock *Mockquerier
+}
+
+// NewMockquerier creates a new mock instance.
+func NewMockquerier(ctrl *gomock.Controller) *Mockquerier {
+	mock := &Mockquerier{ctrl: ctrl}
+	mock.recorder = &MockquerierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockquerier) EXPECT() *MockquerierMockRecorder {
+	return m.recorder
+}
+
+// QueryRowContext mocks base method.
+func (m *Mockquerier) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryRowContext", varargs...)
+	ret0, _ := ret[0].(*sql.Row)
+	return ret0
+}
+
+// QueryRowContext indicates an expected call of QueryRowContext.
+func (mr *MockquerierMockRecorder) QueryRowContext(ctx, query interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryRowContext", reflect.TypeOf((*Mockquerier)(nil).QueryRowContext), varargs...)
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_options is a generated GoMock package.
+package mock_options
+
+import (
+	options "cake-store/internal/options"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepoInterface is a mock of RepoInterface interface.
+type MockRepoInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoInterfaceMockRecorder
+}
+
+// MockRepoInterfaceMockRecorder is the mock recorder for MockRepoInterface.
+type MockRepoInterfaceMockRecorder struct {
+	mock *MockRepoInterface
+}
+
+// NewMockRepoInterface creates a new mock instance.
+func NewMockRepoInterface(ctrl *gomock.Controller) *MockRepoInterface {
+	mock := &MockRepoInterface{ctrl: ctrl}
+	mock.recorder = &MockRepoInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepoInterface) EXPECT() *MockRepoInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteForCake mocks base method.
+func (m *MockRepoInterface) DeleteForCake(ctx context.Context, cakeID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteForCake", ctx, cakeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteForCake indicates an expected call of DeleteForCake.
+func (mr *MockRepoInterfaceMockRecorder) DeleteForCake(ctx, cakeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteForCake", reflect.TypeOf((*MockRepoInterface)(nil).DeleteForCake), ctx, cakeID)
+}
+
+// Get mocks base method.
+func (m *MockRepoInterface) Get(ctx context.Context, cakeID int) (*options.Schema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, cakeID)
+	ret0, _ := ret[0].(*options.Schema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepoInterfaceMockRecorder) Get(ctx, cakeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepoInterface)(nil).Get), ctx, cakeID)
+}
+
+// Set mocks base method.
+func (m *MockRepoInterface) Set(ctx context.Context, dto options.SchemaRequestDto) (*options.Schema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, dto)
+	ret0, _ := ret[0].(*options.Schema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockRepoInterfaceMockRecorder) Set(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockRepoInterface)(nil).Set), ctx, dto)
+}
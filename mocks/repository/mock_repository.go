@@ -35,14 +35,29 @@ func (m *MockRepoInterface) EXPECT() *MockRepoInterfaceMockRecorder {
 	return m.recorder
 }
 
-// Create mocks base method.
-func (m *MockRepoInterface) Create(ctx context.Context, dto cakes.RequestDto) error {
+// AddRating mocks base method.
+func (m *MockRepoInterface) AddRating(ctx context.Context, id, stars, count int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, dto)
+	ret := m.ctrl.Call(m, "AddRating", ctx, id, stars, count)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// AddRating indicates an expected call of AddRating.
+func (mr *MockRepoInterfaceMockRecorder) AddRating(ctx, id, stars, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRating", reflect.TypeOf((*MockRepoInterface)(nil).AddRating), ctx, id, stars, count)
+}
+
+// Create mocks base method.
+func (m *MockRepoInterface) Create(ctx context.Context, dto cakes.RequestDto) (*cakes.Cake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, dto)
+	ret0, _ := ret[0].(*cakes.Cake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // Create indicates an expected call of Create.
 func (mr *MockRepoInterfaceMockRecorder) Create(ctx, dto interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
@@ -50,17 +65,32 @@ func (mr *MockRepoInterfaceMockRecorder) Create(ctx, dto interface{}) *gomock.Ca
 }
 
 // Delete mocks base method.
-func (m *MockRepoInterface) Delete(ctx context.Context, id int) error {
+func (m *MockRepoInterface) Delete(ctx context.Context, id, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockRepoInterfaceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+func (mr *MockRepoInterfaceMockRecorder) Delete(ctx, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepoInterface)(nil).Delete), ctx, id, version)
+}
+
+// Facets mocks base method.
+func (m *MockRepoInterface) Facets(ctx context.Context, dto cakes.ListRequestDto) (cakes.Facets, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Facets", ctx, dto)
+	ret0, _ := ret[0].(cakes.Facets)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Facets indicates an expected call of Facets.
+func (mr *MockRepoInterfaceMockRecorder) Facets(ctx, dto interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepoInterface)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Facets", reflect.TypeOf((*MockRepoInterface)(nil).Facets), ctx, dto)
 }
 
 // Get mocks base method.
@@ -94,12 +124,28 @@ func (mr *MockRepoInterfaceMockRecorder) List(ctx, dto interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepoInterface)(nil).List), ctx, dto)
 }
 
+// Suggest mocks base method.
+func (m *MockRepoInterface) Suggest(ctx context.Context, prefix string, limit int) ([]cakes.Suggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", ctx, prefix, limit)
+	ret0, _ := ret[0].([]cakes.Suggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockRepoInterfaceMockRecorder) Suggest(ctx, prefix, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockRepoInterface)(nil).Suggest), ctx, prefix, limit)
+}
+
 // Update mocks base method.
-func (m *MockRepoInterface) Update(ctx context.Context, dto cakes.UpdateRequestDto) error {
+func (m *MockRepoInterface) Update(ctx context.Context, dto cakes.UpdateRequestDto) (*cakes.Cake, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Update", ctx, dto)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*cakes.Cake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
@@ -0,0 +1,271 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_scheduling is a generated GoMock package.
+package mock_scheduling
+
+import (
+	scheduling "cake-store/internal/scheduling"
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepoInterface is a mock of RepoInterface interface.
+type MockRepoInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoInterfaceMockRecorder
+}
+
+// MockRepoInterfaceMockRecorder is the mock recorder for MockRepoInterface.
+type MockRepoInterfaceMockRecorder struct {
+	mock *MockRepoInterface
+}
+
+// NewMockRepoInterface creates a new mock instance.
+func NewMockRepoInterface(ctrl *gomock.Controller) *MockRepoInterface {
+	mock := &MockRepoInterface{ctrl: ctrl}
+	mock.recorder = &MockRepoInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepoInterface) EXPECT() *MockRepoInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Book mocks base method.
+func (m *MockRepoInterface) Book(ctx context.Context, slot scheduling.Slot) (*scheduling.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Book", ctx, slot)
+	ret0, _ := ret[0].(*scheduling.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Book indicates an expected call of Book.
+func (mr *MockRepoInterfaceMockRecorder) Book(ctx, slot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Book", reflect.TypeOf((*MockRepoInterface)(nil).Book), ctx, slot)
+}
+
+// Booked mocks base method.
+func (m *MockRepoInterface) Booked(ctx context.Context, day string) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Booked", ctx, day)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Booked indicates an expected call of Booked.
+func (mr *MockRepoInterfaceMockRecorder) Booked(ctx, day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Booked", reflect.TypeOf((*MockRepoInterface)(nil).Booked), ctx, day)
+}
+
+// DeleteForCake mocks base method.
+func (m *MockRepoInterface) DeleteForCake(ctx context.Context, cakeID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteForCake", ctx, cakeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteForCake indicates an expected call of DeleteForCake.
+func (mr *MockRepoInterfaceMockRecorder) DeleteForCake(ctx, cakeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteForCake", reflect.TypeOf((*MockRepoInterface)(nil).DeleteForCake), ctx, cakeID)
+}
+
+// DeleteHoliday mocks base method.
+func (m *MockRepoInterface) DeleteHoliday(ctx context.Context, day string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHoliday", ctx, day)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHoliday indicates an expected call of DeleteHoliday.
+func (mr *MockRepoInterfaceMockRecorder) DeleteHoliday(ctx, day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHoliday", reflect.TypeOf((*MockRepoInterface)(nil).DeleteHoliday), ctx, day)
+}
+
+// GetBooking mocks base method.
+func (m *MockRepoInterface) GetBooking(ctx context.Context, id int) (*scheduling.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBooking", ctx, id)
+	ret0, _ := ret[0].(*scheduling.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBooking indicates an expected call of GetBooking.
+func (mr *MockRepoInterfaceMockRecorder) GetBooking(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBooking", reflect.TypeOf((*MockRepoInterface)(nil).GetBooking), ctx, id)
+}
+
+// GetHoliday mocks base method.
+func (m *MockRepoInterface) GetHoliday(ctx context.Context, day string) (*scheduling.Holiday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHoliday", ctx, day)
+	ret0, _ := ret[0].(*scheduling.Holiday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHoliday indicates an expected call of GetHoliday.
+func (mr *MockRepoInterfaceMockRecorder) GetHoliday(ctx, day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHoliday", reflect.TypeOf((*MockRepoInterface)(nil).GetHoliday), ctx, day)
+}
+
+// Holidays mocks base method.
+func (m *MockRepoInterface) Holidays(ctx context.Context) ([]scheduling.Holiday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Holidays", ctx)
+	ret0, _ := ret[0].([]scheduling.Holiday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Holidays indicates an expected call of Holidays.
+func (mr *MockRepoInterfaceMockRecorder) Holidays(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Holidays", reflect.TypeOf((*MockRepoInterface)(nil).Holidays), ctx)
+}
+
+// Hours mocks base method.
+func (m *MockRepoInterface) Hours(ctx context.Context) ([]scheduling.Hours, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hours", ctx)
+	ret0, _ := ret[0].([]scheduling.Hours)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hours indicates an expected call of Hours.
+func (mr *MockRepoInterfaceMockRecorder) Hours(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hours", reflect.TypeOf((*MockRepoInterface)(nil).Hours), ctx)
+}
+
+// LeadTimes mocks base method.
+func (m *MockRepoInterface) LeadTimes(ctx context.Context, cakeIDs []int) (map[int]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeadTimes", ctx, cakeIDs)
+	ret0, _ := ret[0].(map[int]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LeadTimes indicates an expected call of LeadTimes.
+func (mr *MockRepoInterfaceMockRecorder) LeadTimes(ctx, cakeIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeadTimes", reflect.TypeOf((*MockRepoInterface)(nil).LeadTimes), ctx, cakeIDs)
+}
+
+// Release mocks base method.
+func (m *MockRepoInterface) Release(ctx context.Context, id int) (*scheduling.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, id)
+	ret0, _ := ret[0].(*scheduling.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockRepoInterfaceMockRecorder) Release(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockRepoInterface)(nil).Release), ctx, id)
+}
+
+// SetHoliday mocks base method.
+func (m *MockRepoInterface) SetHoliday(ctx context.Context, dto scheduling.HolidayRequestDto) (*scheduling.Holiday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHoliday", ctx, dto)
+	ret0, _ := ret[0].(*scheduling.Holiday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetHoliday indicates an expected call of SetHoliday.
+func (mr *MockRepoInterfaceMockRecorder) SetHoliday(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHoliday", reflect.TypeOf((*MockRepoInterface)(nil).SetHoliday), ctx, dto)
+}
+
+// SetHours mocks base method.
+func (m *MockRepoInterface) SetHours(ctx context.Context, dto scheduling.HoursRequestDto) ([]scheduling.Hours, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHours", ctx, dto)
+	ret0, _ := ret[0].([]scheduling.Hours)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetHours indicates an expected call of SetHours.
+func (mr *MockRepoInterfaceMockRecorder) SetHours(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHours", reflect.TypeOf((*MockRepoInterface)(nil).SetHours), ctx, dto)
+}
+
+// SetLeadTime mocks base method.
+func (m *MockRepoInterface) SetLeadTime(ctx context.Context, dto scheduling.LeadTimeRequestDto) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLeadTime", ctx, dto)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLeadTime indicates an expected call of SetLeadTime.
+func (mr *MockRepoInterfaceMockRecorder) SetLeadTime(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLeadTime", reflect.TypeOf((*MockRepoInterface)(nil).SetLeadTime), ctx, dto)
+}
+
+// MockqueryRower is a mock of queryRower interface.
+type MockqueryRower struct {
+	ctrl     *gomock.Controller
+	recorder *MockqueryRowerMockRecorder
+}
+
+// MockqueryRowerMockRecorder is the mock recorder for MockqueryRower.
+type MockqueryRowerMockRecorder struct {
+	mock *MockqueryRower
+}
+
+// NewMockqueryRower creates a new mock instance.
+func NewMockqueryRower(ctrl *gomock.Controller) *MockqueryRower {
+	mock := &MockqueryRower{ctrl: ctrl}
+	mock.recorder = &MockqueryRowerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockqueryRower) EXPECT() *MockqueryRowerMockRecorder {
+	return m.recorder
+}
+
+// QueryRowContext mocks base method.
+func (m *MockqueryRower) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryRowContext", varargs...)
+	ret0, _ := ret[0].(*sql.Row)
+	return ret0
+}
+
+// QueryRowContext indicates an expected call of QueryRowContext.
+func (mr *MockqueryRowerMockRecorder) QueryRowContext(ctx, query interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryRowContext", reflect.TypeOf((*MockqueryRower)(nil).QueryRowContext), varargs...)
+}
@@ -0,0 +1,259 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_stores is a generated GoMock package.
+package mock_stores
+
+import (
+	money "cake-store/internal/money"
+	query "cake-store/internal/query"
+	stores "cake-store/internal/stores"
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepoInterface is a mock of RepoInterface interface.
+type MockRepoInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoInterfaceMockRecorder
+}
+
+// MockRepoInterfaceMockRecorder is the mock recorder for MockRepoInterface.
+type MockRepoInterfaceMockRecorder struct {
+	mock *MockRepoInterface
+}
+
+// NewMockRepoInterface creates a new mock instance.
+func NewMockRepoInterface(ctrl *gomock.Controller) *MockRepoInterface {
+	mock := &MockRepoInterface{ctrl: ctrl}
+	mock.recorder = &MockRepoInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepoInterface) EXPECT() *MockRepoInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CakesInPriceRange mocks base method.
+func (m *MockRepoInterface) CakesInPriceRange(ctx context.Context, storeID int, r money.Range) (query.IDs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CakesInPriceRange", ctx, storeID, r)
+	ret0, _ := ret[0].(query.IDs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CakesInPriceRange indicates an expected call of CakesInPriceRange.
+func (mr *MockRepoInterfaceMockRecorder) CakesInPriceRange(ctx, storeID, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CakesInPriceRange", reflect.TypeOf((*MockRepoInterface)(nil).CakesInPriceRange), ctx, storeID, r)
+}
+
+// Create mocks base method.
+func (m *MockRepoInterface) Create(ctx context.Context, dto stores.RequestDto) (*stores.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, dto)
+	ret0, _ := ret[0].(*stores.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepoInterfaceMockRecorder) Create(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepoInterface)(nil).Create), ctx, dto)
+}
+
+// Delete mocks base method.
+func (m *MockRepoInterface) Delete(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepoInterfaceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepoInterface)(nil).Delete), ctx, id)
+}
+
+// DeleteForCake mocks base method.
+func (m *MockRepoInterface) DeleteForCake(ctx context.Context, cakeID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteForCake", ctx, cakeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteForCake indicates an expected call of DeleteForCake.
+func (mr *MockRepoInterfaceMockRecorder) DeleteForCake(ctx, cakeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteForCake", reflect.TypeOf((*MockRepoInterface)(nil).DeleteForCake), ctx, cakeID)
+}
+
+// DeleteOverride mocks base method.
+func (m *MockRepoInterface) DeleteOverride(ctx context.Context, storeID, cakeID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOverride", ctx, storeID, cakeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOverride indicates an expected call of DeleteOverride.
+func (mr *MockRepoInterfaceMockRecorder) DeleteOverride(ctx, storeID, cakeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOverride", reflect.TypeOf((*MockRepoInterface)(nil).DeleteOverride), ctx, storeID, cakeID)
+}
+
+// Get mocks base method.
+func (m *MockRepoInterface) Get(ctx context.Context, id int) (*stores.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*stores.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepoInterfaceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepoInterface)(nil).Get), ctx, id)
+}
+
+// GetOverride mocks base method.
+func (m *MockRepoInterface) GetOverride(ctx context.Context, storeID, cakeID int) (*stores.Override, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverride", ctx, storeID, cakeID)
+	ret0, _ := ret[0].(*stores.Override)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverride indicates an expected call of GetOverride.
+func (mr *MockRepoInterfaceMockRecorder) GetOverride(ctx, storeID, cakeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverride", reflect.TypeOf((*MockRepoInterface)(nil).GetOverride), ctx, storeID, cakeID)
+}
+
+// List mocks base method.
+func (m *MockRepoInterface) List(ctx context.Context) ([]stores.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]stores.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepoInterfaceMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepoInterface)(nil).List), ctx)
+}
+
+// OffMenu mocks base method.
+func (m *MockRepoInterface) OffMenu(ctx context.Context, storeID int) (query.IDs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OffMenu", ctx, storeID)
+	ret0, _ := ret[0].(query.IDs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OffMenu indicates an expected call of OffMenu.
+func (mr *MockRepoInterfaceMockRecorder) OffMenu(ctx, storeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OffMenu", reflect.TypeOf((*MockRepoInterface)(nil).OffMenu), ctx, storeID)
+}
+
+// Prices mocks base method.
+func (m *MockRepoInterface) Prices(ctx context.Context, storeID int) (map[int][]stores.Price, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prices", ctx, storeID)
+	ret0, _ := ret[0].(map[int][]stores.Price)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Prices indicates an expected call of Prices.
+func (mr *MockRepoInterfaceMockRecorder) Prices(ctx, storeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prices", reflect.TypeOf((*MockRepoInterface)(nil).Prices), ctx, storeID)
+}
+
+// SetOverride mocks base method.
+func (m *MockRepoInterface) SetOverride(ctx context.Context, dto stores.OverrideRequestDto) (*stores.Override, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOverride", ctx, dto)
+	ret0, _ := ret[0].(*stores.Override)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOverride indicates an expected call of SetOverride.
+func (mr *MockRepoInterfaceMockRecorder) SetOverride(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOverride", reflect.TypeOf((*MockRepoInterface)(nil).SetOverride), ctx, dto)
+}
+
+// Update mocks base method.
+func (m *MockRepoInterface) Update(ctx context.Context, dto stores.UpdateRequestDto) (*stores.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, dto)
+	ret0, _ := ret[0].(*stores.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepoInterfaceMockRecorder) Update(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepoInterface)(nil).Update), ctx, dto)
+}
+
+// Mockexecer is a mock of execer interface.
+type Mockexecer struct {
+	ctrl     *gomock.Controller
+	recorder *MockexecerMockRecorder
+}
+
+// MockexecerMockRecorder is the mock recorder for Mockexecer.
+type MockexecerMockRecorder struct {
+	mock *Mockexecer
+}
+
+// NewMockexecer creates a new mock instance.
+func NewMockexecer(ctrl *gomock.Controller) *Mockexecer {
+	mock := &Mockexecer{ctrl: ctrl}
+	mock.recorder = &MockexecerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockexecer) EXPECT() *MockexecerMockRecorder {
+	return m.recorder
+}
+
+// ExecContext mocks base method.
+func (m *Mockexecer) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExecContext", varargs...)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecContext indicates an expected call of ExecContext.
+func (mr *MockexecerMockRecorder) ExecContext(ctx, query interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecContext", reflect.TypeOf((*Mockexecer)(nil).ExecContext), varargs...)
+}
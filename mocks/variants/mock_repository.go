@@ -0,0 +1,156 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_variants is a generated GoMock package.
+package mock_variants
+
+import (
+	money "cake-store/internal/money"
+	query "cake-store/internal/query"
+	variants "cake-store/internal/variants"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepoInterface is a mock of RepoInterface interface.
+type MockRepoInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoInterfaceMockRecorder
+}
+
+// MockRepoInterfaceMockRecorder is the mock recorder for MockRepoInterface.
+type MockRepoInterfaceMockRecorder struct {
+	mock *MockRepoInterface
+}
+
+// NewMockRepoInterface creates a new mock instance.
+func NewMockRepoInterface(ctrl *gomock.Controller) *MockRepoInterface {
+	mock := &MockRepoInterface{ctrl: ctrl}
+	mock.recorder = &MockRepoInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepoInterface) EXPECT() *MockRepoInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CakesInPriceRange mocks base method.
+func (m *MockRepoInterface) CakesInPriceRange(ctx context.Context, r money.Range) (query.IDs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CakesInPriceRange", ctx, r)
+	ret0, _ := ret[0].(query.IDs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CakesInPriceRange indicates an expected call of CakesInPriceRange.
+func (mr *MockRepoInterfaceMockRecorder) CakesInPriceRange(ctx, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CakesInPriceRange", reflect.TypeOf((*MockRepoInterface)(nil).CakesInPriceRange), ctx, r)
+}
+
+// Create mocks base method.
+func (m *MockRepoInterface) Create(ctx context.Context, dto variants.RequestDto) (*variants.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, dto)
+	ret0, _ := ret[0].(*variants.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepoInterfaceMockRecorder) Create(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepoInterface)(nil).Create), ctx, dto)
+}
+
+// Delete mocks base method.
+func (m *MockRepoInterface) Delete(ctx context.Context, cakeID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, cakeID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepoInterfaceMockRecorder) Delete(ctx, cakeID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepoInterface)(nil).Delete), ctx, cakeID, id)
+}
+
+// DeleteForCake mocks base method.
+func (m *MockRepoInterface) DeleteForCake(ctx context.Context, cakeID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteForCake", ctx, cakeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteForCake indicates an expected call of DeleteForCake.
+func (mr *MockRepoInterfaceMockRecorder) DeleteForCake(ctx, cakeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteForCake", reflect.TypeOf((*MockRepoInterface)(nil).DeleteForCake), ctx, cakeID)
+}
+
+// Get mocks base method.
+func (m *MockRepoInterface) Get(ctx context.Context, cakeID, id int) (*variants.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, cakeID, id)
+	ret0, _ := ret[0].(*variants.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepoInterfaceMockRecorder) Get(ctx, cakeID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepoInterface)(nil).Get), ctx, cakeID, id)
+}
+
+// List mocks base method.
+func (m *MockRepoInterface) List(ctx context.Context, dto variants.ListRequestDto) ([]variants.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, dto)
+	ret0, _ := ret[0].([]variants.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepoInterfaceMockRecorder) List(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepoInterface)(nil).List), ctx, dto)
+}
+
+// ListActive mocks base method.
+func (m *MockRepoInterface) ListActive(ctx context.Context, cakeIDs []int) ([]variants.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActive", ctx, cakeIDs)
+	ret0, _ := ret[0].([]variants.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActive indicates an expected call of ListActive.
+func (mr *MockRepoInterfaceMockRecorder) ListActive(ctx, cakeIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActive", reflect.TypeOf((*MockRepoInterface)(nil).ListActive), ctx, cakeIDs)
+}
+
+// Update mocks base method.
+func (m *MockRepoInterface) Update(ctx context.Context, dto variants.UpdateRequestDto) (*variants.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, dto)
+	ret0, _ := ret[0].(*variants.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepoInterfaceMockRecorder) Update(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepoInterface)(nil).Update), ctx, dto)
+}
@@ -0,0 +1 @@
+ALTER TABLE cakes DROP INDEX ft_cakes_title_description;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE cakes ADD FULLTEXT INDEX ft_cakes_title_description (title, description);
\ No newline at end of file
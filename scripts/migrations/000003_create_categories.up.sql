@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS categories (
+    id INT(10) NOT NULL AUTO_INCREMENT,
+    name VARCHAR(100) NOT NULL,
+    slug VARCHAR(100) NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT NULL,
+    PRIMARY KEY (id) USING BTREE,
+    UNIQUE KEY uq_categories_slug (slug)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS cake_categories;
\ No newline at end of file
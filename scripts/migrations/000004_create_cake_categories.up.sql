@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS cake_categories (
+    cake_id INT(10) NOT NULL,
+    category_id INT(10) NOT NULL,
+    PRIMARY KEY (cake_id, category_id),
+    KEY idx_cake_categories_category (category_id),
+    CONSTRAINT fk_cake_categories_cake FOREIGN KEY (cake_id) REFERENCES cakes (id) ON DELETE CASCADE,
+    CONSTRAINT fk_cake_categories_category FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE CASCADE
+);
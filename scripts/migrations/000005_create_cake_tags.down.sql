@@ -0,0 +1 @@
+DROP TABLE IF EXISTS cake_tags;
\ No newline at end of file
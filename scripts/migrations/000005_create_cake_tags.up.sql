@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS cake_tags (
+    cake_id INT(10) NOT NULL,
+    tag VARCHAR(50) NOT NULL,
+    PRIMARY KEY (cake_id, tag),
+    KEY idx_cake_tags_tag (tag),
+    CONSTRAINT fk_cake_tags_cake FOREIGN KEY (cake_id) REFERENCES cakes (id) ON DELETE CASCADE
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS ingredients (
+    id INT(10) NOT NULL AUTO_INCREMENT,
+    name VARCHAR(100) NOT NULL,
+    vegan BOOLEAN NOT NULL DEFAULT FALSE,
+    halal BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT NULL,
+    PRIMARY KEY (id) USING BTREE,
+    UNIQUE KEY uq_ingredients_name (name)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ingredient_allergens;
\ No newline at end of file
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS ingredient_allergens (
+    ingredient_id INT(10) NOT NULL,
+    allergen VARCHAR(20) NOT NULL,
+    PRIMARY KEY (ingredient_id, allergen),
+    KEY idx_ingredient_allergens_allergen (allergen),
+    CONSTRAINT fk_ingredient_allergens_ingredient FOREIGN KEY (ingredient_id) REFERENCES ingredients (id) ON DELETE CASCADE
+);
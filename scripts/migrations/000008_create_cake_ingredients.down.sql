@@ -0,0 +1 @@
+DROP TABLE IF EXISTS cake_ingredients;
\ No newline at end of file
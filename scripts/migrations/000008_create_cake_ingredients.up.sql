@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS cake_ingredients (
+    cake_id INT(10) NOT NULL,
+    ingredient_id INT(10) NOT NULL,
+    quantity DOUBLE NOT NULL,
+    unit VARCHAR(10) NOT NULL,
+    PRIMARY KEY (cake_id, ingredient_id),
+    KEY idx_cake_ingredients_ingredient (ingredient_id),
+    CONSTRAINT fk_cake_ingredients_cake FOREIGN KEY (cake_id) REFERENCES cakes (id) ON DELETE CASCADE,
+    CONSTRAINT fk_cake_ingredients_ingredient FOREIGN KEY (ingredient_id) REFERENCES ingredients (id)
+);
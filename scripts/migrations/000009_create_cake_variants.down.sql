@@ -0,0 +1 @@
+DROP TABLE IF EXISTS cake_variants;
\ No newline at end of file
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS cake_variants (
+    id INT(10) NOT NULL AUTO_INCREMENT,
+    cake_id INT(10) NOT NULL,
+    size VARCHAR(50) NOT NULL,
+    sku VARCHAR(64) NOT NULL,
+    price_minor BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    weight_grams INT NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT NULL,
+    PRIMARY KEY (id) USING BTREE,
+    UNIQUE KEY uq_cake_variants_sku (sku),
+    KEY idx_cake_variants_cake (cake_id),
+    KEY idx_cake_variants_price (currency, price_minor),
+    CONSTRAINT fk_cake_variants_cake FOREIGN KEY (cake_id) REFERENCES cakes (id) ON DELETE CASCADE
+);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS stock_levels (
+    id INT(10) NOT NULL AUTO_INCREMENT,
+    variant_id INT(10) NOT NULL,
+    cake_id INT(10) NOT NULL,
+    day CHAR(10) NOT NULL,
+    on_hand INT NOT NULL DEFAULT 0,
+    reserved INT NOT NULL DEFAULT 0,
+    low_stock_threshold INT NOT NULL DEFAULT 0,
+    version INT NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT NULL,
+    PRIMARY KEY (id) USING BTREE,
+    UNIQUE KEY uq_stock_levels_variant_day (variant_id, day),
+    KEY idx_stock_levels_day_cake (day, cake_id),
+    CONSTRAINT fk_stock_levels_variant FOREIGN KEY (variant_id) REFERENCES cake_variants (id) ON DELETE CASCADE
+);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    id INT(10) NOT NULL AUTO_INCREMENT,
+    variant_id INT(10) NOT NULL,
+    day CHAR(10) NOT NULL,
+    quantity INT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'held',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT NULL,
+    PRIMARY KEY (id) USING BTREE,
+    KEY idx_stock_reservations_variant_day (variant_id, day),
+    CONSTRAINT fk_stock_reservations_variant FOREIGN KEY (variant_id) REFERENCES cake_variants (id) ON DELETE CASCADE
+);
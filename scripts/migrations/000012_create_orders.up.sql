@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS orders (
+    id INT(10) NOT NULL AUTO_INCREMENT,
+    customer_id VARCHAR(64) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    fulfilment VARCHAR(16) NOT NULL DEFAULT 'pickup',
+    delivery_address VARCHAR(255) NOT NULL DEFAULT '',
+    day CHAR(10) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    total_minor BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT NULL,
+    PRIMARY KEY (id) USING BTREE,
+    KEY idx_orders_customer (customer_id, created_at),
+    KEY idx_orders_status (status)
+);
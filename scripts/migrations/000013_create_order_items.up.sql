@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS order_items (
+    id INT(10) NOT NULL AUTO_INCREMENT,
+    order_id INT(10) NOT NULL,
+    cake_id INT(10) NOT NULL,
+    variant_id INT(10) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    size VARCHAR(50) NOT NULL,
+    sku VARCHAR(64) NOT NULL,
+    quantity INT NOT NULL,
+    unit_price_minor BIGINT NOT NULL,
+    line_total_minor BIGINT NOT NULL,
+    reservation_id INT(10) NULL DEFAULT NULL,
+    PRIMARY KEY (id) USING BTREE,
+    KEY idx_order_items_order (order_id),
+    CONSTRAINT fk_order_items_order FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS carts (
+    id CHAR(32) NOT NULL,
+    customer_id VARCHAR(64) NOT NULL DEFAULT '',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT NULL,
+    PRIMARY KEY (id),
+    KEY idx_carts_expires (expires_at)
+);
@@ -0,0 +1 @@
+ALTER TABLE cakes DROP COLUMN rating_sum, DROP COLUMN rating_count;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE cakes ADD COLUMN rating_count INT NOT NULL DEFAULT 0 AFTER rating, ADD COLUMN rating_sum INT NOT NULL DEFAULT 0 AFTER rating_count;
+UPDATE cakes SET rating_count = 1, rating_sum = LEAST(GREATEST(ROUND(rating), 1), 5) WHERE rating > 0;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS reviews (
+    id INT(10) NOT NULL AUTO_INCREMENT,
+    cake_id INT(10) NOT NULL,
+    author VARCHAR(100) NOT NULL,
+    stars TINYINT NOT NULL,
+    body TEXT NULL DEFAULT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT NULL,
+    PRIMARY KEY (id) USING BTREE,
+    KEY idx_reviews_cake (cake_id, status),
+    KEY idx_reviews_status (status),
+    CONSTRAINT fk_reviews_cake FOREIGN KEY (cake_id) REFERENCES cakes (id) ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS option_groups;
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS option_groups (
+    id INT(10) NOT NULL AUTO_INCREMENT,
+    cake_id INT(10) NOT NULL,
+    slug VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    min_select INT NOT NULL DEFAULT 0,
+    max_select INT NOT NULL DEFAULT 0,
+    max_length INT NOT NULL DEFAULT 0,
+    price_minor BIGINT NOT NULL DEFAULT 0,
+    currency CHAR(3) NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (id) USING BTREE,
+    UNIQUE KEY uq_option_groups_slug (cake_id, slug),
+    CONSTRAINT fk_option_groups_cake FOREIGN KEY (cake_id) REFERENCES cakes (id) ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS option_choices;
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS option_choices (
+    id INT(10) NOT NULL AUTO_INCREMENT,
+    group_id INT(10) NOT NULL,
+    cake_id INT(10) NOT NULL,
+    slug VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    price_minor BIGINT NOT NULL DEFAULT 0,
+    position INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (id) USING BTREE,
+    UNIQUE KEY uq_option_choices_slug (group_id, slug),
+    KEY idx_option_choices_cake (cake_id),
+    CONSTRAINT fk_option_choices_group FOREIGN KEY (group_id) REFERENCES option_groups (id) ON DELETE CASCADE
+);
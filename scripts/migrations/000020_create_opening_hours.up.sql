@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS opening_hours (
+    weekday TINYINT NOT NULL,
+    opens CHAR(5) NOT NULL,
+    closes CHAR(5) NOT NULL,
+    capacity INT NOT NULL,
+    PRIMARY KEY (weekday)
+);
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS holidays (
+    day CHAR(10) NOT NULL,
+    name VARCHAR(100) NOT NULL DEFAULT '',
+    PRIMARY KEY (day)
+);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS lead_times (
+    cake_id INT(10) NOT NULL,
+    hours INT NOT NULL,
+    PRIMARY KEY (cake_id),
+    CONSTRAINT fk_lead_times_cake FOREIGN KEY (cake_id) REFERENCES cakes (id) ON DELETE CASCADE
+);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS slot_bookings (
+    id INT(10) NOT NULL AUTO_INCREMENT,
+    day CHAR(10) NOT NULL,
+    start_at CHAR(5) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'booked',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT NULL,
+    PRIMARY KEY (id) USING BTREE,
+    KEY idx_slot_bookings_slot (day, start_at),
+    CONSTRAINT fk_slot_bookings_slot FOREIGN KEY (day, start_at) REFERENCES slots (day, start_at) ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+ALTER TABLE orders DROP COLUMN booking_id, DROP COLUMN slot;
\ No newline at end of file
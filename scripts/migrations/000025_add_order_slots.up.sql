@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN slot CHAR(5) NOT NULL DEFAULT '' AFTER day, ADD COLUMN booking_id INT(10) NULL DEFAULT NULL AFTER slot;
\ No newline at end of file
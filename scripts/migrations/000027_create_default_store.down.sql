@@ -0,0 +1 @@
+DELETE FROM stores WHERE id = 1;
\ No newline at end of file
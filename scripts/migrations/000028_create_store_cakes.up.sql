@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS store_cakes (
+    store_id INT(10) NOT NULL,
+    cake_id INT(10) NOT NULL,
+    available BOOLEAN NOT NULL DEFAULT TRUE,
+    PRIMARY KEY (store_id, cake_id),
+    KEY idx_store_cakes_cake (cake_id),
+    CONSTRAINT fk_store_cakes_store FOREIGN KEY (store_id) REFERENCES stores (id) ON DELETE CASCADE,
+    CONSTRAINT fk_store_cakes_cake FOREIGN KEY (cake_id) REFERENCES cakes (id) ON DELETE CASCADE
+);
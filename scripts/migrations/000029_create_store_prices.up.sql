@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS store_prices (
+    store_id INT(10) NOT NULL,
+    cake_id INT(10) NOT NULL,
+    variant_id INT(10) NOT NULL,
+    price_minor BIGINT NOT NULL,
+    PRIMARY KEY (store_id, variant_id),
+    KEY idx_store_prices_cake (store_id, cake_id),
+    CONSTRAINT fk_store_prices_store FOREIGN KEY (store_id) REFERENCES stores (id) ON DELETE CASCADE,
+    CONSTRAINT fk_store_prices_variant FOREIGN KEY (variant_id) REFERENCES cake_variants (id) ON DELETE CASCADE
+);
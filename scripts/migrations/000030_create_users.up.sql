@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS users (
+    id INT(10) NOT NULL AUTO_INCREMENT,
+    email VARCHAR(254) NOT NULL,
+    name VARCHAR(100) NOT NULL DEFAULT '',
+    password_hash VARCHAR(100) NOT NULL,
+    role VARCHAR(16) NOT NULL DEFAULT 'customer',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT NULL,
+    PRIMARY KEY (id) USING BTREE,
+    UNIQUE KEY uq_users_email (email)
+);
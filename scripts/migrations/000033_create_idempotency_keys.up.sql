@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key_hash CHAR(64) NOT NULL,
+    fingerprint CHAR(64) NOT NULL,
+    status_code INT(10) NOT NULL DEFAULT 0,
+    content_type VARCHAR(255) NOT NULL DEFAULT '',
+    location VARCHAR(255) NOT NULL DEFAULT '',
+    etag VARCHAR(255) NOT NULL DEFAULT '',
+    body MEDIUMBLOB NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (key_hash),
+    KEY idx_idempotency_keys_expires (expires_at)
+);
@@ -0,0 +1 @@
+ALTER TABLE cakes ADD COLUMN version INT(10) NOT NULL DEFAULT 1 AFTER rating_sum;
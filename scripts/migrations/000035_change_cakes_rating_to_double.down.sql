@@ -0,0 +1 @@
+ALTER TABLE cakes MODIFY COLUMN rating FLOAT NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE cakes MODIFY COLUMN rating DOUBLE NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN store_id INT(10) NULL DEFAULT NULL AFTER customer_id;
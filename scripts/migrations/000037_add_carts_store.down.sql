@@ -0,0 +1 @@
+ALTER TABLE carts DROP COLUMN store_id;
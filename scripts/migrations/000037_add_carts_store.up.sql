@@ -0,0 +1 @@
+ALTER TABLE carts ADD COLUMN store_id INT(10) NOT NULL DEFAULT 0 AFTER customer_id;
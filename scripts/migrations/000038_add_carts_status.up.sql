@@ -0,0 +1 @@
+ALTER TABLE carts ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'open' AFTER store_id;
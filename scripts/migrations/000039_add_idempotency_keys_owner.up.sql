@@ -0,0 +1 @@
+ALTER TABLE idempotency_keys ADD COLUMN owner CHAR(32) NOT NULL DEFAULT '' AFTER fingerprint;
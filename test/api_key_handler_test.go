@@ -0,0 +1,98 @@
+package test
+
+import (
+	"cake-store/internal/apikeys"
+	"cake-store/internal/auth"
+	"cake-store/internal/middlewares"
+	mock_apikeys "cake-store/mocks/apikeys"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test API Key Service", func() {
+	var (
+		e                *echo.Echo
+		mockCtrl         *gomock.Controller
+		serviceInterface apikeys.SvcInterface
+		repo             *mock_apikeys.MockRepoInterface
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		repo = mock_apikeys.NewMockRepoInterface(mockCtrl)
+		serviceInterface = apikeys.NewHandler(repo)
+		e = echo.New()
+		middlewares.UseCustomValidatorHandler(e)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	send := func(method, body string, handler echo.HandlerFunc) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(method, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("3")
+		return rec, handler(c)
+	}
+
+	It("issue a key and only store its hash", func() {
+		var stored apikeys.Key
+		repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, key apikeys.Key) (*apikeys.Key, error) {
+			stored = key
+			key.ID = 3
+			return &key, nil
+		})
+		rec, err := send(http.MethodPost, `{"name": "Partner", "scopes": ["cakes:read"]}`, serviceInterface.Create)
+		Expect(err).Should(Succeed())
+		Expect(rec.Code).Should(Equal(http.StatusCreated))
+		Expect(rec.Header().Get(echo.HeaderLocation)).Should(Equal("/api-keys/3"))
+
+		issued := map[string]interface{}{}
+		Expect(json.Unmarshal(rec.Body.Bytes(), &issued)).Should(Succeed())
+		secret, _ := issued["key"].(string)
+		Expect(secret).Should(HavePrefix(stored.Prefix + "_"))
+		Expect(stored.Hash).Should(Equal(apikeys.HashKey(secret)))
+		Expect(rec.Body.String()).ShouldNot(ContainSubstring(stored.Hash))
+	})
+
+	It("return error on unknown scopes", func() {
+		_, err := send(http.MethodPost, `{"name": "Partner", "scopes": ["cakes:delete"]}`, serviceInterface.Create)
+		Expect(err).Should(HaveOccurred())
+		_, err = send(http.MethodPost, `{"name": "Partner", "scopes": []}`, serviceInterface.Create)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("rotate a key", func() {
+		repo.EXPECT().Rotate(gomock.Any(), 3, gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, id int, prefix, hash string) (*apikeys.Key, error) {
+			return &apikeys.Key{ID: id, Prefix: prefix, Hash: hash, Scopes: []string{auth.ScopeCakesRead}}, nil
+		})
+		rec, err := send(http.MethodPost, "", serviceInterface.Rotate)
+		Expect(err).Should(Succeed())
+		Expect(rec.Code).Should(Equal(http.StatusOK))
+		Expect(rec.Body.String()).Should(ContainSubstring(`"key":"ck_`))
+	})
+
+	It("return error rotating a revoked key", func() {
+		repo.EXPECT().Rotate(gomock.Any(), 3, gomock.Any(), gomock.Any()).Return(nil, apikeys.ErrRevoked)
+		_, err := send(http.MethodPost, "", serviceInterface.Rotate)
+		Expect(err).Should(MatchError(apikeys.ErrConflict))
+	})
+
+	It("revoke a key", func() {
+		repo.EXPECT().Revoke(gomock.Any(), 3).Return(&apikeys.Key{ID: 3}, nil)
+		rec, err := send(http.MethodPost, "", serviceInterface.Revoke)
+		Expect(err).Should(Succeed())
+		Expect(rec.Code).Should(Equal(http.StatusOK))
+	})
+})
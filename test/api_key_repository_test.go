@@ -0,0 +1,182 @@
+package test
+
+import (
+	"cake-store/internal/apikeys"
+	"cake-store/internal/auth"
+	"cake-store/internal/middlewares"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = describeConformance("API Key Repository", apikeys.NewMemoryRepository, apikeys.NewRepository, func(subject *repoUnderTest[apikeys.RepoInterface]) {
+	var (
+		repo apikeys.RepoInterface
+		ctx  = context.TODO()
+	)
+
+	BeforeEach(func() {
+		repo = subject.Repo
+	})
+
+	create := func(hash string) *apikeys.Key {
+		created, err := repo.Create(ctx, apikeys.Key{Name: "Partner", Prefix: "ck_00000000", Hash: hash, Scopes: []string{auth.ScopeOrdersRead, auth.ScopeCakesRead, auth.ScopeCakesRead}})
+		Expect(err).Should(Succeed())
+		return created
+	}
+
+	It("creates keys and finds them by hash", func() {
+		created := create("a")
+		Expect(created.Scopes).Should(Equal([]string{auth.ScopeCakesRead, auth.ScopeOrdersRead}))
+		Expect(created.Active()).Should(BeTrue())
+
+		found, err := repo.GetByHash(ctx, "a")
+		Expect(err).Should(Succeed())
+		Expect(found.ID).Should(Equal(created.ID))
+		_, err = repo.GetByHash(ctx, "b")
+		Expect(err).Should(MatchError(apikeys.ErrNotFound))
+
+		_, err = repo.Create(ctx, apikeys.Key{Name: "Other", Prefix: "ck_00000000", Hash: "a"})
+		Expect(err).Should(MatchError(apikeys.ErrConflict))
+
+		second := create("b")
+		list, err := repo.List(ctx)
+		Expect(err).Should(Succeed())
+		Expect(list).Should(HaveLen(2))
+		Expect(list[0].ID).Should(Equal(second.ID))
+	})
+
+	It("rotates active keys only", func() {
+		created := create("a")
+
+		rotated, err := repo.Rotate(ctx, created.ID, "ck_11111111", "b")
+		Expect(err).Should(Succeed())
+		Expect(rotated.Prefix).Should(Equal("ck_11111111"))
+		Expect(rotated.Scopes).Should(Equal(created.Scopes))
+		_, err = repo.GetByHash(ctx, "a")
+		Expect(err).Should(MatchError(apikeys.ErrNotFound))
+
+		_, err = repo.Rotate(ctx, created.ID+1, "ck_22222222", "c")
+		Expect(err).Should(MatchError(apikeys.ErrNotFound))
+
+		_, err = repo.Revoke(ctx, created.ID)
+		Expect(err).Should(Succeed())
+		_, err = repo.Rotate(ctx, created.ID, "ck_22222222", "c")
+		Expect(err).Should(MatchError(apikeys.ErrRevoked))
+	})
+
+	It("revokes keys once", func() {
+		created := create("a")
+
+		revoked, err := repo.Revoke(ctx, created.ID)
+		Expect(err).Should(Succeed())
+		Expect(revoked.Active()).Should(BeFalse())
+
+		again, err := repo.Revoke(ctx, created.ID)
+		Expect(err).Should(Succeed())
+		Expect(again.RevokedAt.Equal(*revoked.RevokedAt)).Should(BeTrue())
+
+		_, err = repo.Revoke(ctx, created.ID+1)
+		Expect(err).Should(MatchError(apikeys.ErrNotFound))
+	})
+
+	It("records the last use at most once per interval", func() {
+		created := create("a")
+		now := time.Now().UTC().Truncate(time.Second)
+
+		Expect(repo.Touch(ctx, created.ID, now)).Should(Succeed())
+		Expect(repo.Touch(ctx, created.ID, now.Add(apikeys.TouchInterval/2))).Should(Succeed())
+		found, err := repo.Get(ctx, created.ID)
+		Expect(err).Should(Succeed())
+		Expect(found.LastUsedAt.Equal(now)).Should(BeTrue())
+
+		later := now.Add(2 * apikeys.TouchInterval)
+		Expect(repo.Touch(ctx, created.ID, later)).Should(Succeed())
+		found, err = repo.Get(ctx, created.ID)
+		Expect(err).Should(Succeed())
+		Expect(found.LastUsedAt.Equal(later)).Should(BeTrue())
+	})
+})
+
+var _ = Describe("API Key Middleware", func() {
+	var (
+		e      *echo.Echo
+		repo   apikeys.RepoInterface
+		tokens auth.Tokens
+		secret string
+		keyID  int
+	)
+
+	BeforeEach(func() {
+		tokens = hs256Tokens()
+		repo = apikeys.NewMemoryRepository()
+		e = echo.New()
+		middlewares.UseCustomValidatorHandler(e)
+		e.Use(auth.Authenticate(tokens))
+		e.Use(apikeys.Authenticate(repo))
+		ok := func(ctx echo.Context) error {
+			return ctx.JSON(http.StatusOK, "Success")
+		}
+		e.GET("/cakes", ok, auth.LimitKeys(auth.ScopeCakesRead))
+		e.POST("/cakes", ok, auth.RequireScope(auth.ScopeCakesWrite, auth.RoleAdmin, auth.RoleStaff))
+		e.GET("/orders", ok, auth.RequireScope(auth.ScopeOrdersRead, auth.RoleAdmin, auth.RoleStaff))
+		e.POST("/staff", ok, auth.Require(auth.RoleAdmin, auth.RoleStaff))
+
+		secret = "ck_0a0b0c0d_secret"
+		created, err := repo.Create(context.TODO(), apikeys.Key{Name: "Partner", Prefix: "ck_0a0b0c0d", Hash: apikeys.HashKey(secret), Scopes: []string{auth.ScopeCakesRead, auth.ScopeOrdersRead}})
+		Expect(err).Should(Succeed())
+		keyID = created.ID
+	})
+
+	send := func(method, target, key, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		if key != "" {
+			req.Header.Set(apikeys.Header, key)
+		}
+		if token != "" {
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	It("lets keys through within their scopes", func() {
+		Expect(send(http.MethodGet, "/cakes", secret, "").Code).Should(Equal(http.StatusOK))
+		Expect(send(http.MethodGet, "/orders", secret, "").Code).Should(Equal(http.StatusOK))
+		Expect(send(http.MethodPost, "/cakes", secret, "").Code).Should(Equal(http.StatusForbidden))
+		Expect(send(http.MethodPost, "/staff", secret, "").Code).Should(Equal(http.StatusForbidden))
+
+		found, err := repo.Get(context.TODO(), keyID)
+		Expect(err).Should(Succeed())
+		Expect(found.LastUsedAt).ShouldNot(BeNil())
+	})
+
+	It("keeps keys without the read scope off the catalog", func() {
+		other := "ck_01020304_other"
+		_, err := repo.Create(context.TODO(), apikeys.Key{Name: "Orders only", Prefix: "ck_01020304", Hash: apikeys.HashKey(other), Scopes: []string{auth.ScopeOrdersRead}})
+		Expect(err).Should(Succeed())
+
+		Expect(send(http.MethodGet, "/cakes", other, "").Code).Should(Equal(http.StatusForbidden))
+		Expect(send(http.MethodGet, "/cakes", "", "").Code).Should(Equal(http.StatusOK))
+	})
+
+	It("refuses unknown, revoked and doubled credentials", func() {
+		Expect(send(http.MethodGet, "/cakes", "ck_0a0b0c0d_wrong", "").Code).Should(Equal(http.StatusUnauthorized))
+
+		token, err := tokens.Issue(auth.Principal{UserID: 1, Role: auth.RoleStaff})
+		Expect(err).Should(Succeed())
+		Expect(send(http.MethodGet, "/cakes", secret, token).Code).Should(Equal(http.StatusUnauthorized))
+		Expect(send(http.MethodPost, "/cakes", "", token).Code).Should(Equal(http.StatusOK))
+
+		_, err = repo.Revoke(context.TODO(), keyID)
+		Expect(err).Should(Succeed())
+		Expect(send(http.MethodGet, "/cakes", secret, "").Code).Should(Equal(http.StatusUnauthorized))
+	})
+})
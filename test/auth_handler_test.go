@@ -0,0 +1,100 @@
+package test
+
+import (
+	"cake-store/internal/auth"
+	"cake-store/internal/middlewares"
+	"cake-store/internal/users"
+	mock_users "cake-store/mocks/users"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test User Service", func() {
+	var (
+		e                *echo.Echo
+		mockCtrl         *gomock.Controller
+		serviceInterface users.SvcInterface
+		repo             *mock_users.MockRepoInterface
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		repo = mock_users.NewMockRepoInterface(mockCtrl)
+		serviceInterface = users.NewHandler(repo, users.NewAuthenticator(repo, hs256Tokens()))
+		e = echo.New()
+		middlewares.UseCustomValidatorHandler(e)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	send := func(method, body string, handler echo.HandlerFunc) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(method, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("2")
+		return rec, handler(c)
+	}
+
+	It("register a customer", func() {
+		repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, user users.User) (*users.User, error) {
+			Expect(user.Role).Should(Equal(auth.RoleCustomer))
+			Expect(user.PasswordHash).ShouldNot(ContainSubstring("correct horse"))
+			user.ID = 2
+			return &user, nil
+		})
+		rec, err := send(http.MethodPost, `{"email": "sam@example.com", "password": "correct horse"}`, serviceInterface.Register)
+		Expect(err).Should(Succeed())
+		Expect(rec.Code).Should(Equal(http.StatusCreated))
+		Expect(rec.Header().Get(echo.HeaderLocation)).Should(Equal("/users/2"))
+		Expect(rec.Body.String()).ShouldNot(ContainSubstring("password"))
+	})
+
+	It("return error on an invalid registration", func() {
+		_, err := send(http.MethodPost, `{"email": "sam", "password": "correct horse"}`, serviceInterface.Register)
+		Expect(err).Should(HaveOccurred())
+		_, err = send(http.MethodPost, `{"email": "sam@example.com", "password": "short"}`, serviceInterface.Register)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("return error on a login with an unknown email", func() {
+		repo.EXPECT().GetByEmail(gomock.Any(), "alex@example.com").Return(nil, users.ErrNotFound)
+		_, err := send(http.MethodPost, `{"email": "alex@example.com", "password": "correct horse"}`, serviceInterface.Login)
+		Expect(err).Should(MatchError(users.ErrCredentials))
+	})
+
+	It("return error on an unknown role", func() {
+		_, err := send(http.MethodPut, `{"role": "owner"}`, serviceInterface.SetRole)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("set the role of a user", func() {
+		repo.EXPECT().Get(gomock.Any(), 2).Return(&users.User{ID: 2, Role: auth.RoleCustomer}, nil)
+		repo.EXPECT().SetRole(gomock.Any(), 2, auth.RoleStaff).Return(&users.User{ID: 2, Role: auth.RoleStaff}, nil)
+		rec, err := send(http.MethodPut, `{"role": "staff"}`, serviceInterface.SetRole)
+		Expect(err).Should(Succeed())
+		Expect(rec.Code).Should(Equal(http.StatusOK))
+	})
+
+	It("revoke the refresh tokens of demoted users", func() {
+		repo.EXPECT().Get(gomock.Any(), 2).Return(&users.User{ID: 2, Role: auth.RoleAdmin}, nil)
+		repo.EXPECT().SetRole(gomock.Any(), 2, auth.RoleStaff).Return(&users.User{ID: 2, Role: auth.RoleStaff}, nil)
+		repo.EXPECT().RevokeRefreshTokens(gomock.Any(), 2).Return(nil)
+		_, err := send(http.MethodPut, `{"role": "staff"}`, serviceInterface.SetRole)
+		Expect(err).Should(Succeed())
+	})
+
+	It("return error for the current user of an anonymous request", func() {
+		_, err := send(http.MethodGet, "", serviceInterface.Me)
+		Expect(err).Should(MatchError(auth.ErrUnauthorized))
+	})
+})
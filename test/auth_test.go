@@ -0,0 +1,187 @@
+package test
+
+import (
+	"cake-store/internal/auth"
+	"cake-store/internal/middlewares"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const testSecret = "test-secret-that-is-long-enough-0123456789"
+
+// hs256Tokens returns tokens signed with testSecret.
+func hs256Tokens() auth.Tokens {
+	tokens, err := auth.NewTokens(auth.Config{Algorithm: auth.AlgorithmHS256, Secret: testSecret, Issuer: "cake-store", AccessTTL: time.Minute, RefreshTTL: time.Hour})
+	Expect(err).Should(Succeed())
+	return tokens
+}
+
+// writeRSAKey writes a new PEM private key and its public key to dir.
+func writeRSAKey(dir string) (privateFile, publicFile string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).Should(Succeed())
+	public, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	Expect(err).Should(Succeed())
+
+	privateFile, publicFile = filepath.Join(dir, "private.pem"), filepath.Join(dir, "public.pem")
+	Expect(os.WriteFile(privateFile, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0o600)).Should(Succeed())
+	Expect(os.WriteFile(publicFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: public}), 0o600)).Should(Succeed())
+	return privateFile, publicFile
+}
+
+var _ = Describe("Auth Tokens", func() {
+	principal := auth.Principal{UserID: 7, Email: "sam@example.com", Name: "Sam", Role: auth.RoleStaff}
+
+	It("issues and parses HS256 tokens", func() {
+		tokens := hs256Tokens()
+		token, err := tokens.Issue(principal)
+		Expect(err).Should(Succeed())
+
+		parsed, err := tokens.Parse(token)
+		Expect(err).Should(Succeed())
+		Expect(*parsed).Should(Equal(principal))
+	})
+
+	It("issues and parses RS256 tokens with local key files", func() {
+		dir, err := os.MkdirTemp("", "keys")
+		Expect(err).Should(Succeed())
+		defer os.RemoveAll(dir)
+		privateFile, publicFile := writeRSAKey(dir)
+
+		tokens, err := auth.NewTokens(auth.Config{Algorithm: auth.AlgorithmRS256, PrivateKeyFile: privateFile, PublicKeyFile: publicFile, Issuer: "cake-store", AccessTTL: time.Minute, RefreshTTL: time.Hour})
+		Expect(err).Should(Succeed())
+		token, err := tokens.Issue(principal)
+		Expect(err).Should(Succeed())
+		parsed, err := tokens.Parse(token)
+		Expect(err).Should(Succeed())
+		Expect(*parsed).Should(Equal(principal))
+
+		_, err = hs256Tokens().Parse(token)
+		Expect(err).Should(MatchError(auth.ErrInvalidToken))
+		token, err = hs256Tokens().Issue(principal)
+		Expect(err).Should(Succeed())
+		_, err = tokens.Parse(token)
+		Expect(err).Should(MatchError(auth.ErrInvalidToken))
+
+		otherDir := filepath.Join(dir, "other")
+		Expect(os.Mkdir(otherDir, 0o700)).Should(Succeed())
+		otherPrivate, _ := writeRSAKey(otherDir)
+		_, err = auth.NewTokens(auth.Config{Algorithm: auth.AlgorithmRS256, PrivateKeyFile: otherPrivate, PublicKeyFile: publicFile, AccessTTL: time.Minute, RefreshTTL: time.Hour})
+		Expect(err).Should(MatchError(auth.ErrConfig))
+	})
+
+	It("rejects expired, tampered and foreign tokens", func() {
+		tokens := hs256Tokens()
+		sign := func(claims auth.Claims, secret string) string {
+			token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+			Expect(err).Should(Succeed())
+			return token
+		}
+		valid := auth.Claims{Role: auth.RoleAdmin, StandardClaims: jwt.StandardClaims{Issuer: "cake-store", Subject: "1", ExpiresAt: time.Now().Add(time.Minute).Unix()}}
+
+		expired := valid
+		expired.ExpiresAt = time.Now().Add(-time.Minute).Unix()
+		endless := valid
+		endless.ExpiresAt = 0
+		foreign := valid
+		foreign.Issuer = "elsewhere"
+		unknownRole := valid
+		unknownRole.Role = "owner"
+
+		for _, token := range []string{
+			sign(expired, testSecret),
+			sign(endless, testSecret),
+			sign(foreign, testSecret),
+			sign(unknownRole, testSecret),
+			sign(valid, "another-secret-that-is-long-enough-0123"),
+			sign(valid, testSecret) + "x",
+			"not-a-token",
+		} {
+			_, err := tokens.Parse(token)
+			Expect(err).Should(MatchError(auth.ErrInvalidToken))
+		}
+		_, err := tokens.Parse(sign(valid, testSecret))
+		Expect(err).Should(Succeed())
+	})
+
+	It("rejects unusable configurations", func() {
+		for _, config := range []auth.Config{
+			{Algorithm: auth.AlgorithmHS256, Secret: "short", AccessTTL: time.Minute, RefreshTTL: time.Hour},
+			{Algorithm: auth.AlgorithmHS256, AccessTTL: time.Minute, RefreshTTL: time.Hour},
+			{Algorithm: auth.AlgorithmHS256, Secret: "change-me-local-development-secret-0123456789", AccessTTL: time.Minute, RefreshTTL: time.Hour},
+			{Algorithm: auth.AlgorithmRS256, PrivateKeyFile: "missing.pem", AccessTTL: time.Minute, RefreshTTL: time.Hour},
+			{Algorithm: "none", Secret: testSecret, AccessTTL: time.Minute, RefreshTTL: time.Hour},
+			{Algorithm: auth.AlgorithmHS256, Secret: testSecret},
+		} {
+			_, err := auth.NewTokens(config)
+			Expect(err).Should(MatchError(auth.ErrConfig))
+		}
+	})
+})
+
+var _ = Describe("Auth Middleware", func() {
+	var (
+		e      *echo.Echo
+		tokens auth.Tokens
+	)
+
+	BeforeEach(func() {
+		tokens = hs256Tokens()
+		e = echo.New()
+		middlewares.UseCustomValidatorHandler(e)
+		e.Use(auth.Authenticate(tokens))
+		ok := func(ctx echo.Context) error {
+			return ctx.JSON(http.StatusOK, "Success")
+		}
+		e.GET("/public", ok)
+		e.POST("/staff", ok, auth.Require(auth.RoleAdmin, auth.RoleStaff))
+	})
+
+	send := func(method, target, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		if token != "" {
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	tokenFor := func(role string) string {
+		token, err := tokens.Issue(auth.Principal{UserID: 1, Role: role})
+		Expect(err).Should(Succeed())
+		return token
+	}
+
+	It("lets anonymous reads through", func() {
+		Expect(send(http.MethodGet, "/public", "").Code).Should(Equal(http.StatusOK))
+	})
+
+	It("challenges anonymous and badly authenticated writes", func() {
+		rec := send(http.MethodPost, "/staff", "")
+		Expect(rec.Code).Should(Equal(http.StatusUnauthorized))
+		Expect(rec.Header().Get(echo.HeaderWWWAuthenticate)).Should(HavePrefix("Bearer"))
+
+		rec = send(http.MethodGet, "/public", "garbage")
+		Expect(rec.Code).Should(Equal(http.StatusUnauthorized))
+	})
+
+	It("guards routes by role", func() {
+		Expect(send(http.MethodPost, "/staff", tokenFor(auth.RoleCustomer)).Code).Should(Equal(http.StatusForbidden))
+		Expect(send(http.MethodPost, "/staff", tokenFor(auth.RoleStaff)).Code).Should(Equal(http.StatusOK))
+		Expect(send(http.MethodPost, "/staff", tokenFor(auth.RoleAdmin)).Code).Should(Equal(http.StatusOK))
+	})
+})
@@ -0,0 +1,210 @@
+package test
+
+import (
+	"cake-store/internal/auth"
+	"cake-store/internal/cakes"
+	"cake-store/internal/carts"
+	"cake-store/internal/inventory"
+	"cake-store/internal/middlewares"
+	"cake-store/internal/money"
+	"cake-store/internal/orders"
+	"cake-store/internal/variants"
+	mock_carts "cake-store/mocks/carts"
+	mock_orders "cake-store/mocks/orders"
+	mock_repository "cake-store/mocks/repository"
+	mock_variants "cake-store/mocks/variants"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test Cart Service", func() {
+	var (
+		e                *echo.Echo
+		mockCtrl         *gomock.Controller
+		serviceInterface carts.SvcInterface
+		repo             *mock_carts.MockRepoInterface
+		ordersRepo       *mock_orders.MockRepoInterface
+		cakesRepo        *mock_repository.MockRepoInterface
+		variantsRepo     *mock_variants.MockRepoInterface
+		lemon            carts.Line
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		repo = mock_carts.NewMockRepoInterface(mockCtrl)
+		ordersRepo = mock_orders.NewMockRepoInterface(mockCtrl)
+		cakesRepo = mock_repository.NewMockRepoInterface(mockCtrl)
+		variantsRepo = mock_variants.NewMockRepoInterface(mockCtrl)
+		catalog := orders.NewCatalog(cakesRepo, variantsRepo)
+		serviceInterface = carts.NewHandler(repo, catalog, orders.NewPlacer(ordersRepo, catalog))
+		e = echo.New()
+		middlewares.UseCustomValidatorHandler(e)
+		lemon = carts.Line{CakeID: 1, VariantID: 2, Title: "Lemon cake", Size: "8 inch", Quantity: 2, UnitPrice: money.New(3250, "USD")}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	// stock expects the catalog lookups of the lemon cake at price.
+	stock := func(price int64) {
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1, Title: "Lemon cake"}, nil)
+		variantsRepo.EXPECT().Get(gomock.Any(), 1, 2).Return(&variants.Variant{ID: 2, CakeID: 1, Size: "8 inch", Price: money.New(price, "USD"), Active: true}, nil)
+	}
+
+	saveEcho := func() {
+		repo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, cart carts.Cart) (*carts.Cart, error) {
+			return &cart, nil
+		})
+	}
+
+	request := func(method, body string) (*httptest.ResponseRecorder, echo.Context) {
+		req := httptest.NewRequest(method, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("cart-1")
+		return rec, c
+	}
+
+	It("create a cart", func() {
+		repo.EXPECT().DeleteExpired(gomock.Any(), gomock.Any()).Return(nil)
+		repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, cart carts.Cart) (*carts.Cart, error) {
+			Expect(cart.ID).Should(HaveLen(32))
+			Expect(cart.CustomerID).Should(Equal("customer-1"))
+			return &cart, nil
+		})
+		rec, c := request(http.MethodPost, `{"customer_id": "customer-1"}`)
+		err := serviceInterface.Create(c)
+		Expect(err).Should(Succeed())
+		Expect(rec.Code).Should(Equal(http.StatusCreated))
+		Expect(rec.Header().Get(echo.HeaderLocation)).Should(HavePrefix("/carts/"))
+	})
+
+	It("re-price the cart and warn about changes", func() {
+		plain := carts.Line{CakeID: 3, VariantID: 4, Title: "Plain cake", Size: "slice", Quantity: 1, UnitPrice: money.New(450, "USD")}
+		repo.EXPECT().Get(gomock.Any(), "cart-1").Return(&carts.Cart{ID: "cart-1", Lines: []carts.Line{lemon, plain}}, nil)
+		stock(3500)
+		cakesRepo.EXPECT().Get(gomock.Any(), 3).Return(nil, cakes.ErrNotFound)
+		repo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, cart carts.Cart) (*carts.Cart, error) {
+			Expect(cart.Lines).Should(HaveLen(1))
+			Expect(cart.Lines[0].UnitPrice).Should(Equal(money.New(3500, "USD")))
+			return &cart, nil
+		})
+		rec, c := request(http.MethodGet, "")
+		err := serviceInterface.Get(c)
+		Expect(err).Should(Succeed())
+		body := rec.Body.String()
+		Expect(body).Should(ContainSubstring(`"total":{"amount":7000,"currency":"USD","decimal":"70.00"}`))
+		Expect(body).Should(ContainSubstring(`"code":"price_changed","message":"Lemon cake (8 inch) now costs 35.00 USD instead of 32.50 USD"`))
+		Expect(body).Should(ContainSubstring(`"code":"unavailable","message":"Plain cake (slice) is no longer available"`))
+	})
+
+	It("set the quantity of an item", func() {
+		repo.EXPECT().Get(gomock.Any(), "cart-1").Return(&carts.Cart{ID: "cart-1", Lines: []carts.Line{lemon}}, nil)
+		stock(3250)
+		stock(3250)
+		saveEcho()
+		rec, c := request(http.MethodPut, `{"cake_id": 1, "variant_id": 2, "quantity": 3}`)
+		err := serviceInterface.SetItem(c)
+		Expect(err).Should(Succeed())
+		Expect(rec.Body.String()).Should(ContainSubstring(`"total":{"amount":9750,"currency":"USD","decimal":"97.50"}`))
+		Expect(rec.Body.String()).Should(ContainSubstring(`"warnings":[]`))
+	})
+
+	It("remove an item with a zero quantity", func() {
+		repo.EXPECT().Get(gomock.Any(), "cart-1").Return(&carts.Cart{ID: "cart-1", Lines: []carts.Line{lemon}}, nil)
+		stock(3250)
+		saveEcho()
+		rec, c := request(http.MethodPut, `{"cake_id": 1, "variant_id": 2, "quantity": 0}`)
+		err := serviceInterface.SetItem(c)
+		Expect(err).Should(Succeed())
+		Expect(rec.Body.String()).Should(ContainSubstring(`"items":[]`))
+	})
+
+	It("refuse to check out a changed cart", func() {
+		repo.EXPECT().Get(gomock.Any(), "cart-1").Return(&carts.Cart{ID: "cart-1", CustomerID: "customer-1", Lines: []carts.Line{lemon}}, nil)
+		stock(3500)
+		_, c := request(http.MethodPost, fmt.Sprintf(`{"day": "%s"}`, inventory.Today()))
+		err := serviceInterface.Checkout(c)
+		Expect(err).Should(MatchError(carts.ErrConflict))
+	})
+
+	It("check out into an order", func() {
+		repo.EXPECT().Get(gomock.Any(), "cart-1").Return(&carts.Cart{ID: "cart-1", CustomerID: "customer-1", Lines: []carts.Line{lemon}}, nil)
+		stock(3250)
+		stock(3250)
+		ordersRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, order orders.Order) (*orders.Order, error) {
+			Expect(order.CustomerID).Should(Equal("customer-1"))
+			Expect(order.Total).Should(Equal(money.New(6500, "USD")))
+			order.ID = 8
+			return &order, nil
+		})
+		gomock.InOrder(
+			repo.EXPECT().Claim(gomock.Any(), "cart-1").Return(nil),
+			repo.EXPECT().Delete(gomock.Any(), "cart-1").Return(nil),
+		)
+		rec, c := request(http.MethodPost, fmt.Sprintf(`{"day": "%s"}`, inventory.Today()))
+		err := serviceInterface.Checkout(c)
+		Expect(err).Should(Succeed())
+		Expect(rec.Code).Should(Equal(http.StatusCreated))
+		Expect(rec.Header().Get(echo.HeaderLocation)).Should(Equal("/orders/8"))
+	})
+
+	It("order a cart only once", func() {
+		repo.EXPECT().Get(gomock.Any(), "cart-1").Return(&carts.Cart{ID: "cart-1", CustomerID: "customer-1", Lines: []carts.Line{lemon}}, nil)
+		stock(3250)
+		repo.EXPECT().Claim(gomock.Any(), "cart-1").Return(carts.ErrNotFound)
+		_, c := request(http.MethodPost, fmt.Sprintf(`{"day": "%s"}`, inventory.Today()))
+		Expect(serviceInterface.Checkout(c)).Should(MatchError(carts.ErrNotFound))
+	})
+
+	It("reopen the cart when its order cannot be placed", func() {
+		repo.EXPECT().Get(gomock.Any(), "cart-1").Return(&carts.Cart{ID: "cart-1", CustomerID: "customer-1", Lines: []carts.Line{lemon}}, nil)
+		stock(3250)
+		gomock.InOrder(
+			repo.EXPECT().Claim(gomock.Any(), "cart-1").Return(nil),
+			repo.EXPECT().Reopen(gomock.Any(), "cart-1").Return(nil),
+		)
+		_, c := request(http.MethodPost, `{"day": "2020-01-01"}`)
+		Expect(serviceInterface.Checkout(c)).Should(MatchError(orders.ErrValidation))
+	})
+
+	It("return error when checking out an empty cart", func() {
+		repo.EXPECT().Get(gomock.Any(), "cart-1").Return(&carts.Cart{ID: "cart-1", CustomerID: "customer-1"}, nil)
+		_, c := request(http.MethodPost, fmt.Sprintf(`{"day": "%s"}`, inventory.Today()))
+		err := serviceInterface.Checkout(c)
+		Expect(err).Should(MatchError(carts.ErrValidation))
+	})
+	It("check out carts of customers for themselves", func() {
+		repo.EXPECT().Get(gomock.Any(), "cart-1").Return(&carts.Cart{ID: "cart-1", Lines: []carts.Line{lemon}}, nil)
+		stock(3250)
+		stock(3250)
+		ordersRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, order orders.Order) (*orders.Order, error) {
+			Expect(order.CustomerID).Should(Equal("user-5"))
+			order.ID = 8
+			return &order, nil
+		})
+		repo.EXPECT().Claim(gomock.Any(), "cart-1").Return(nil)
+		repo.EXPECT().Delete(gomock.Any(), "cart-1").Return(nil)
+		_, c := request(http.MethodPost, fmt.Sprintf(`{"customer_id": "customer-1", "day": "%s"}`, inventory.Today()))
+		auth.SetPrincipal(c, &auth.Principal{UserID: 5, Role: auth.RoleCustomer})
+		Expect(serviceInterface.Checkout(c)).Should(Succeed())
+	})
+
+	It("refuse to check out the cart of another customer", func() {
+		repo.EXPECT().Get(gomock.Any(), "cart-1").Return(&carts.Cart{ID: "cart-1", CustomerID: "user-6", Lines: []carts.Line{lemon}}, nil)
+		stock(3250)
+		_, c := request(http.MethodPost, fmt.Sprintf(`{"day": "%s"}`, inventory.Today()))
+		auth.SetPrincipal(c, &auth.Principal{UserID: 5, Role: auth.RoleCustomer})
+		Expect(serviceInterface.Checkout(c)).Should(MatchError(carts.ErrConflict))
+	})
+})
@@ -0,0 +1,89 @@
+package test
+
+import (
+	"cake-store/internal/carts"
+	"cake-store/internal/money"
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = describeConformance("Cart Repository", carts.NewMemoryRepository, carts.NewRepository, func(subject *repoUnderTest[carts.RepoInterface]) {
+	var (
+		repo carts.RepoInterface
+		ctx  = context.TODO()
+	)
+
+	BeforeEach(func() {
+		repo = subject.Repo
+	})
+
+	It("stores carts with their lines in order", func() {
+		created, err := repo.Create(ctx, carts.Cart{ID: "cart-1", CustomerID: "customer-1", StoreID: 2, ExpiresAt: time.Now().Add(time.Hour)})
+		Expect(err).Should(Succeed())
+		Expect(created.Lines).Should(BeEmpty())
+		Expect(created.StoreID).Should(Equal(2))
+
+		lines := []carts.Line{
+			{CakeID: 2, VariantID: 5, Title: "Plain cake", Size: "slice", Quantity: 3, UnitPrice: money.New(450, "USD")},
+			{CakeID: 1, VariantID: 2, Title: "Lemon cake", Size: "8 inch", Quantity: 1, UnitPrice: money.New(3250, "USD")},
+		}
+		saved, err := repo.Save(ctx, carts.Cart{ID: "cart-1", ExpiresAt: time.Now().Add(2 * time.Hour), Lines: lines})
+		Expect(err).Should(Succeed())
+		Expect(saved.Lines).Should(Equal(lines))
+		Expect(saved.CustomerID).Should(BeEmpty())
+		Expect(saved.ExpiresAt).Should(BeTemporally(">", created.ExpiresAt))
+		Expect(saved.UpdatedAt).ShouldNot(BeNil())
+
+		saved, err = repo.Save(ctx, carts.Cart{ID: "cart-1", ExpiresAt: time.Now().Add(time.Hour), Lines: lines[1:]})
+		Expect(err).Should(Succeed())
+		Expect(saved.Lines).Should(Equal(lines[1:]))
+
+		_, err = repo.Save(ctx, carts.Cart{ID: "cart-9", ExpiresAt: time.Now().Add(time.Hour)})
+		Expect(err).Should(MatchError(carts.ErrNotFound))
+		Expect(repo.Delete(ctx, "cart-1")).Should(Succeed())
+		_, err = repo.Get(ctx, "cart-1")
+		Expect(err).Should(MatchError(carts.ErrNotFound))
+	})
+
+	It("hides and purges expired carts", func() {
+		_, err := repo.Create(ctx, carts.Cart{ID: "expired", ExpiresAt: time.Now().Add(time.Hour)})
+		Expect(err).Should(Succeed())
+		_, err = repo.Save(ctx, carts.Cart{ID: "expired", ExpiresAt: time.Now().Add(-time.Minute)})
+		Expect(err).Should(MatchError(carts.ErrNotFound))
+		_, err = repo.Create(ctx, carts.Cart{ID: "fresh", ExpiresAt: time.Now().Add(time.Hour)})
+		Expect(err).Should(Succeed())
+
+		_, err = repo.Get(ctx, "expired")
+		Expect(err).Should(MatchError(carts.ErrNotFound))
+		Expect(repo.DeleteExpired(ctx, time.Now())).Should(Succeed())
+		Expect(repo.Delete(ctx, "expired")).Should(MatchError(carts.ErrNotFound))
+		_, err = repo.Get(ctx, "fresh")
+		Expect(err).Should(Succeed())
+
+		Expect(repo.DeleteExpired(ctx, time.Now().Add(2*time.Hour))).Should(Succeed())
+		_, err = repo.Get(ctx, "fresh")
+		Expect(err).Should(MatchError(carts.ErrNotFound))
+	})
+
+	It("claims a cart for a single checkout", func() {
+		_, err := repo.Create(ctx, carts.Cart{ID: "cart-1", ExpiresAt: time.Now().Add(time.Hour)})
+		Expect(err).Should(Succeed())
+		Expect(repo.Reopen(ctx, "cart-1")).Should(MatchError(carts.ErrNotFound))
+
+		Expect(repo.Claim(ctx, "cart-1")).Should(Succeed())
+		Expect(repo.Claim(ctx, "cart-1")).Should(MatchError(carts.ErrNotFound))
+		_, err = repo.Get(ctx, "cart-1")
+		Expect(err).Should(MatchError(carts.ErrNotFound))
+		_, err = repo.Save(ctx, carts.Cart{ID: "cart-1", ExpiresAt: time.Now().Add(time.Hour)})
+		Expect(err).Should(MatchError(carts.ErrNotFound))
+
+		Expect(repo.Reopen(ctx, "cart-1")).Should(Succeed())
+		reopened, err := repo.Get(ctx, "cart-1")
+		Expect(err).Should(Succeed())
+		Expect(reopened.Status).Should(Equal(carts.StatusOpen))
+		Expect(repo.Claim(ctx, "cart-9")).Should(MatchError(carts.ErrNotFound))
+	})
+})
@@ -0,0 +1,212 @@
+package test
+
+import (
+	"cake-store/internal/cakes"
+	"cake-store/internal/categories"
+	"cake-store/internal/middlewares"
+	"cake-store/internal/query"
+	mock_categories "cake-store/mocks/categories"
+	mock_repository "cake-store/mocks/repository"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test Category Service", func() {
+	var (
+		e                *echo.Echo
+		mockCtrl         *gomock.Controller
+		serviceInterface categories.SvcInterface
+		repo             *mock_categories.MockRepoInterface
+		mockData         categories.Category
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		repo = mock_categories.NewMockRepoInterface(mockCtrl)
+		serviceInterface = categories.NewHandler(repo)
+		e = echo.New()
+		middlewares.UseCustomValidatorHandler(e)
+		mockData = categories.Category{ID: 1, Name: "Cheesecakes", Slug: "cheesecakes", CreatedAt: time.Now()}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Create Category", func() {
+		It("return succeed", func() {
+			repo.EXPECT().Create(gomock.Any(), categories.RequestDto{Name: "Cheesecakes"}).Return(&mockData, nil)
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name": "Cheesecakes"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			err := serviceInterface.Create(c)
+			Expect(err).Should(Succeed())
+			Expect(rec.Code).Should(Equal(http.StatusCreated))
+			Expect(rec.Header().Get(echo.HeaderLocation)).Should(Equal("/categories/1"))
+			Expect(rec.Body.String()).Should(ContainSubstring(`"slug":"cheesecakes"`))
+		})
+
+		It("return error on validation", func() {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			err := serviceInterface.Create(c)
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("Fetch Categories", func() {
+		It("return succeed", func() {
+			repo.EXPECT().List(gomock.Any()).Return([]categories.Category{mockData}, nil)
+			req := httptest.NewRequest(http.MethodGet, "/categories", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			err := serviceInterface.List(c)
+			Expect(err).Should(Succeed())
+			Expect(rec.Body.String()).Should(ContainSubstring(`"name":"Cheesecakes"`))
+		})
+
+		It("return data not found", func() {
+			repo.EXPECT().Get(gomock.Any(), 2).Return(nil, categories.ErrNotFound)
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("2")
+			err := serviceInterface.Get(c)
+			Expect(err).Should(MatchError(categories.ErrNotFound))
+		})
+	})
+
+	Describe("Delete Category", func() {
+		It("return succeed", func() {
+			repo.EXPECT().Delete(gomock.Any(), 1).Return(nil)
+			req := httptest.NewRequest(http.MethodDelete, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			err := serviceInterface.Delete(c)
+			Expect(err).Should(Succeed())
+			Expect(rec.Code).Should(Equal(http.StatusOK))
+		})
+	})
+})
+
+var _ = Describe("Test Cake Service With Categories", func() {
+	var (
+		e                *echo.Echo
+		mockCtrl         *gomock.Controller
+		serviceInterface cakes.SvcInterface
+		repo             *mock_repository.MockRepoInterface
+		categoriesRepo   *mock_categories.MockRepoInterface
+		mockData         cakes.Cake
+		mockCategory     categories.Category
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		repo = mock_repository.NewMockRepoInterface(mockCtrl)
+		categoriesRepo = mock_categories.NewMockRepoInterface(mockCtrl)
+		serviceInterface = cakes.NewHandler(repo, cakes.WithCategories(categoriesRepo))
+		e = echo.New()
+		middlewares.UseCustomValidatorHandler(e)
+		mockData = cakes.Cake{ID: 1, Title: "Lemon cheesecake", Rating: 7, CreatedAt: time.Now()}
+		mockCategory = categories.Category{ID: 3, Name: "Cheesecakes", Slug: "cheesecakes", CreatedAt: time.Now()}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("embed categories and tags in the listing", func() {
+		repo.EXPECT().List(gomock.Any(), gomock.Any()).Return([]cakes.Cake{mockData}, int64(1), nil)
+		categoriesRepo.EXPECT().ForCakes(gomock.Any(), []int{1}).Return(map[int][]categories.Category{1: {mockCategory}}, nil)
+		categoriesRepo.EXPECT().TagsForCakes(gomock.Any(), []int{1}).Return(map[int][]string{}, nil)
+		req := httptest.NewRequest(http.MethodGet, "/cakes", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err := serviceInterface.List(c)
+		Expect(err).Should(Succeed())
+		Expect(rec.Body.String()).Should(ContainSubstring(`"categories":[{"id":3,"name":"Cheesecakes","slug":"cheesecakes"`))
+		Expect(rec.Body.String()).Should(ContainSubstring(`"tags":[]`))
+	})
+
+	It("restrict the listing to the category and tag", func() {
+		categoriesRepo.EXPECT().CakesInCategory(gomock.Any(), "cheesecakes").Return(query.IDs{List: []int{1, 2}}, nil)
+		categoriesRepo.EXPECT().CakesTagged(gomock.Any(), "vegan").Return(query.IDs{List: []int{2, 4}}, nil)
+		repo.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, dto cakes.ListRequestDto) ([]cakes.Cake, int64, error) {
+			Expect(dto.Within).Should(Equal([]query.IDs{{List: []int{1, 2}}, {List: []int{2, 4}}}))
+			return []cakes.Cake{}, int64(0), nil
+		})
+		req := httptest.NewRequest(http.MethodGet, "/cakes?category=cheesecakes&tag=vegan", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err := serviceInterface.List(c)
+		Expect(err).Should(Succeed())
+	})
+
+	It("link categories and tags on create", func() {
+		categoriesRepo.EXPECT().Check(gomock.Any(), []int{3}).Return(nil)
+		repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&mockData, nil)
+		categoriesRepo.EXPECT().Assign(gomock.Any(), 1, []int{3}).Return(nil)
+		categoriesRepo.EXPECT().Tag(gomock.Any(), 1, []string{"vegan"}).Return(nil)
+		categoriesRepo.EXPECT().ForCakes(gomock.Any(), []int{1}).Return(map[int][]categories.Category{1: {mockCategory}}, nil)
+		categoriesRepo.EXPECT().TagsForCakes(gomock.Any(), []int{1}).Return(map[int][]string{1: {"vegan"}}, nil)
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"title": "Lemon cheesecake", "category_ids": [3], "tags": ["vegan"]}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err := serviceInterface.Create(c)
+		Expect(err).Should(Succeed())
+		Expect(rec.Body.String()).Should(ContainSubstring(`"tags":["vegan"]`))
+	})
+
+	It("reject unknown categories before creating the cake", func() {
+		categoriesRepo.EXPECT().Check(gomock.Any(), []int{9}).Return(categories.ErrValidation)
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"title": "Lemon cheesecake", "category_ids": [9]}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err := serviceInterface.Create(c)
+		Expect(err).Should(MatchError(categories.ErrValidation))
+	})
+
+	It("keep categories on update without category_ids", func() {
+		repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&mockData, nil)
+		categoriesRepo.EXPECT().ForCakes(gomock.Any(), []int{1}).Return(map[int][]categories.Category{}, nil)
+		categoriesRepo.EXPECT().TagsForCakes(gomock.Any(), []int{1}).Return(map[int][]string{}, nil)
+		req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"title": "Lemon cheesecake"}`))
+		req.Header.Set(cakes.HeaderIfMatch, "*")
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		err := serviceInterface.Update(c)
+		Expect(err).Should(Succeed())
+	})
+
+	It("unlink a deleted cake", func() {
+		repo.EXPECT().Delete(gomock.Any(), 1, 0).Return(nil)
+		categoriesRepo.EXPECT().Assign(gomock.Any(), 1, []int{}).Return(nil)
+		categoriesRepo.EXPECT().Tag(gomock.Any(), 1, []string{}).Return(nil)
+		req := httptest.NewRequest(http.MethodDelete, "/", nil)
+		req.Header.Set(cakes.HeaderIfMatch, "*")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		err := serviceInterface.Delete(c)
+		Expect(err).Should(Succeed())
+	})
+})
@@ -0,0 +1,100 @@
+package test
+
+import (
+	"cake-store/internal/categories"
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = describeConformance("Category Repository", categories.NewMemoryRepository, categories.NewRepository, func(subject *repoUnderTest[categories.RepoInterface]) {
+	var (
+		repo categories.RepoInterface
+		ctx  = context.TODO()
+	)
+
+	BeforeEach(func() {
+		repo = subject.Repo
+	})
+
+	create := func(name string) *categories.Category {
+		category, err := repo.Create(ctx, categories.RequestDto{Name: name})
+		Expect(err).Should(Succeed())
+		return category
+	}
+
+	It("creates, lists, updates and deletes categories", func() {
+		created := create("Birthday Cakes!")
+		Expect(created.ID).Should(Equal(1))
+		Expect(created.Slug).Should(Equal("birthday-cakes"))
+		create("Cheesecakes")
+
+		list, err := repo.List(ctx)
+		Expect(err).Should(Succeed())
+		Expect([]string{list[0].Name, list[1].Name}).Should(Equal([]string{"Birthday Cakes!", "Cheesecakes"}))
+
+		updated, err := repo.Update(ctx, categories.UpdateRequestDto{ID: 1, Name: "Birthday", Slug: "Birthday"})
+		Expect(err).Should(Succeed())
+		Expect(updated.Name).Should(Equal("Birthday"))
+		Expect(updated.Slug).Should(Equal("birthday"))
+		Expect(updated.UpdatedAt).ShouldNot(BeNil())
+
+		Expect(repo.Delete(ctx, 1)).Should(Succeed())
+		_, err = repo.Get(ctx, 1)
+		Expect(err).Should(MatchError(categories.ErrNotFound))
+		Expect(repo.Delete(ctx, 1)).Should(MatchError(categories.ErrNotFound))
+	})
+
+	It("rejects duplicate and empty slugs", func() {
+		create("Vegan")
+		_, err := repo.Create(ctx, categories.RequestDto{Name: "VEGAN"})
+		Expect(err).Should(MatchError(categories.ErrConflict))
+
+		create("Gluten free")
+		_, err = repo.Update(ctx, categories.UpdateRequestDto{ID: 2, Slug: "vegan"})
+		Expect(err).Should(MatchError(categories.ErrConflict))
+
+		_, err = repo.Create(ctx, categories.RequestDto{Name: "!!!"})
+		Expect(err).Should(MatchError(categories.ErrValidation))
+	})
+
+	It("assigns categories and tags to cakes", func() {
+		create("Cheesecakes")
+		create("Birthday")
+
+		Expect(repo.Check(ctx, []int{1, 2})).Should(Succeed())
+		Expect(repo.Check(ctx, []int{1, 3})).Should(MatchError(categories.ErrValidation))
+		Expect(repo.Assign(ctx, 10, []int{1, 3})).Should(MatchError(categories.ErrValidation))
+
+		Expect(repo.Assign(ctx, 10, []int{1, 2, 1})).Should(Succeed())
+		Expect(repo.Assign(ctx, 11, []int{1})).Should(Succeed())
+		Expect(repo.Tag(ctx, 10, []string{" Vegan", "nut-free", "vegan"})).Should(Succeed())
+
+		linked, err := repo.ForCakes(ctx, []int{10, 11, 12})
+		Expect(err).Should(Succeed())
+		Expect(linked[10]).Should(HaveLen(2))
+		Expect(linked[10][0].Name).Should(Equal("Birthday"))
+		Expect(linked[11]).Should(HaveLen(1))
+		Expect(linked[12]).Should(BeEmpty())
+
+		tags, err := repo.TagsForCakes(ctx, []int{10, 11})
+		Expect(err).Should(Succeed())
+		Expect(tags[10]).Should(Equal([]string{"nut-free", "vegan"}))
+		Expect(tags[11]).Should(BeEmpty())
+
+		ids, err := repo.CakesInCategory(ctx, "Cheesecakes")
+		Expect(err).Should(Succeed())
+		Expect(subject.selected(ids)).Should(ConsistOf(10, 11))
+
+		ids, err = repo.CakesTagged(ctx, "VEGAN")
+		Expect(err).Should(Succeed())
+		Expect(subject.selected(ids)).Should(ConsistOf(10))
+
+		Expect(repo.Assign(ctx, 10, []int{})).Should(Succeed())
+		Expect(repo.Delete(ctx, 1)).Should(Succeed())
+		ids, err = repo.CakesInCategory(ctx, "cheesecakes")
+		Expect(err).Should(Succeed())
+		Expect(subject.selected(ids)).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,79 @@
+package test
+
+import (
+	"cake-store/internal/query"
+	"cake-store/internal/storage"
+	"database/sql"
+	"sort"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// repoUnderTest is the repository a conformance spec runs against, with the
+// database behind it, nil for the memory repository.
+type repoUnderTest[R any] struct {
+	Repo R
+	DB   *sql.DB
+}
+
+// describeConformance runs specs, the behaviour every implementation of a
+// repository must share, against the repository newMemory returns and the
+// one newSQL returns over an empty SQLite database. Each spec gets new ones.
+func describeConformance[R any](name string, newMemory func() R, newSQL func(db *sql.DB) R, specs func(subject *repoUnderTest[R])) bool {
+	describe := func(driver string, open func() (R, *sql.DB)) {
+		Describe(name+" Conformance: "+driver, func() {
+			subject := &repoUnderTest[R]{}
+
+			BeforeEach(func() {
+				subject.Repo, subject.DB = open()
+			})
+
+			AfterEach(func() {
+				if subject.DB != nil {
+					subject.DB.Close()
+				}
+			})
+
+			specs(subject)
+		})
+	}
+
+	describe("memory", func() (R, *sql.DB) {
+		return newMemory(), nil
+	})
+	describe("sqlite", func() (R, *sql.DB) {
+		db, err := storage.OpenSQLite(":memory:")
+		Expect(err).Should(Succeed())
+		return newSQL(db), db
+	})
+	return true
+}
+
+// selected returns the distinct IDs ids selects, sorted, running the
+// subqueries of SQL repositories on their database.
+func (subject *repoUnderTest[R]) selected(ids query.IDs) []int {
+	list := ids.List
+	if ids.Query != "" {
+		rows, err := subject.DB.Query(ids.Query, ids.Args...)
+		Expect(err).Should(Succeed())
+		defer rows.Close()
+		for rows.Next() {
+			var id int
+			Expect(rows.Scan(&id)).Should(Succeed())
+			list = append(list, id)
+		}
+		Expect(rows.Err()).Should(Succeed())
+	}
+
+	seen := map[int]bool{}
+	result := []int{}
+	for _, id := range list {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	sort.Ints(result)
+	return result
+}
@@ -0,0 +1,243 @@
+package test
+
+import (
+	"cake-store/internal/idempotency"
+	"cake-store/internal/middlewares"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = describeConformance("Idempotency Repository", idempotency.NewMemoryRepository, idempotency.NewRepository, func(subject *repoUnderTest[idempotency.RepoInterface]) {
+	var (
+		repo idempotency.RepoInterface
+		ctx  = context.TODO()
+		now  = time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	)
+
+	BeforeEach(func() {
+		repo = subject.Repo
+	})
+
+	record := func(at time.Time) idempotency.Record {
+		return idempotency.Record{Key: "k", Fingerprint: "f", Owner: "owner", CreatedAt: at, ExpiresAt: at.Add(time.Hour)}
+	}
+
+	It("reserves a key once and replays its response", func() {
+		existing, err := repo.Reserve(ctx, record(now), now.Add(-time.Minute))
+		Expect(err).Should(Succeed())
+		Expect(existing).Should(BeNil())
+
+		existing, err = repo.Reserve(ctx, record(now), now.Add(-time.Minute))
+		Expect(err).Should(Succeed())
+		Expect(existing.InFlight()).Should(BeTrue())
+
+		response := idempotency.Response{StatusCode: http.StatusCreated, ContentType: echo.MIMEApplicationJSON, Location: "/cakes/1", ETag: `"1-abc"`, Body: []byte(`{"id":1}`)}
+		Expect(repo.Complete(ctx, "k", "owner", response)).Should(Succeed())
+		existing, err = repo.Reserve(ctx, record(now.Add(2*time.Minute)), now.Add(time.Minute))
+		Expect(err).Should(Succeed())
+		Expect(existing.Response).Should(Equal(response))
+		Expect(existing.Fingerprint).Should(Equal("f"))
+	})
+
+	It("takes over released, stale and expired keys", func() {
+		_, err := repo.Reserve(ctx, record(now), now.Add(-time.Minute))
+		Expect(err).Should(Succeed())
+		Expect(repo.Release(ctx, "k", "owner")).Should(Succeed())
+		existing, err := repo.Reserve(ctx, record(now), now.Add(-time.Minute))
+		Expect(err).Should(Succeed())
+		Expect(existing).Should(BeNil())
+
+		later := now.Add(2 * time.Minute)
+		existing, err = repo.Reserve(ctx, record(later), later.Add(-time.Minute))
+		Expect(err).Should(Succeed())
+		Expect(existing).Should(BeNil())
+
+		Expect(repo.Complete(ctx, "k", "owner", idempotency.Response{StatusCode: http.StatusOK})).Should(Succeed())
+		Expect(repo.Release(ctx, "k", "owner")).Should(Succeed())
+		existing, err = repo.Reserve(ctx, record(later), later.Add(-time.Minute))
+		Expect(err).Should(Succeed())
+		Expect(existing.StatusCode).Should(Equal(http.StatusOK))
+
+		expired := later.Add(2 * time.Hour)
+		existing, err = repo.Reserve(ctx, record(expired), expired.Add(-time.Minute))
+		Expect(err).Should(Succeed())
+		Expect(existing).Should(BeNil())
+	})
+
+	It("lets only the owner of a key complete or release it", func() {
+		_, err := repo.Reserve(ctx, record(now), now.Add(-time.Minute))
+		Expect(err).Should(Succeed())
+		later := now.Add(2 * time.Minute)
+		takeover := record(later)
+		takeover.Owner = "retry"
+		existing, err := repo.Reserve(ctx, takeover, later.Add(-time.Minute))
+		Expect(err).Should(Succeed())
+		Expect(existing).Should(BeNil())
+
+		Expect(repo.Complete(ctx, "k", "owner", idempotency.Response{StatusCode: http.StatusCreated})).Should(Succeed())
+		Expect(repo.Release(ctx, "k", "owner")).Should(Succeed())
+		existing, err = repo.Reserve(ctx, record(later), later.Add(-time.Minute))
+		Expect(err).Should(Succeed())
+		Expect(existing.InFlight()).Should(BeTrue())
+		Expect(existing.Owner).Should(Equal("retry"))
+
+		Expect(repo.Complete(ctx, "k", "retry", idempotency.Response{StatusCode: http.StatusOK})).Should(Succeed())
+		existing, err = repo.Reserve(ctx, record(later), later.Add(-time.Minute))
+		Expect(err).Should(Succeed())
+		Expect(existing.StatusCode).Should(Equal(http.StatusOK))
+	})
+
+	It("purges expired keys", func() {
+		_, err := repo.Reserve(ctx, record(now), now.Add(-time.Minute))
+		Expect(err).Should(Succeed())
+		Expect(repo.Complete(ctx, "k", "owner", idempotency.Response{StatusCode: http.StatusOK})).Should(Succeed())
+
+		Expect(repo.Purge(ctx, now.Add(time.Minute))).Should(Succeed())
+		existing, err := repo.Reserve(ctx, record(now), now.Add(-time.Minute))
+		Expect(err).Should(Succeed())
+		Expect(existing).ShouldNot(BeNil())
+
+		Expect(repo.Purge(ctx, now.Add(time.Hour))).Should(Succeed())
+		existing, err = repo.Reserve(ctx, record(now), now.Add(-time.Minute))
+		Expect(err).Should(Succeed())
+		Expect(existing).Should(BeNil())
+	})
+
+	It("lets one of concurrent reservations through", func() {
+		var (
+			wg       sync.WaitGroup
+			reserved int32
+		)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer GinkgoRecover()
+				existing, err := repo.Reserve(ctx, record(now), now.Add(-time.Minute))
+				Expect(err).Should(Succeed())
+				if existing == nil {
+					atomic.AddInt32(&reserved, 1)
+				}
+			}()
+		}
+		wg.Wait()
+		Expect(reserved).Should(Equal(int32(1)))
+	})
+})
+
+var _ = Describe("Idempotency Middleware", func() {
+	var (
+		e       *echo.Echo
+		created int32
+		fail    bool
+		started chan struct{}
+		release chan struct{}
+	)
+
+	BeforeEach(func() {
+		atomic.StoreInt32(&created, 0)
+		fail = false
+		started, release = nil, nil
+		e = echo.New()
+		e.IPExtractor = echo.ExtractIPDirect()
+		middlewares.UseCustomValidatorHandler(e)
+		e.Use(idempotency.Middleware(idempotency.NewMemoryRepository(), idempotency.Config{TTL: time.Hour, LockTimeout: time.Minute}))
+		e.POST("/cakes", func(ctx echo.Context) error {
+			if release != nil {
+				started <- struct{}{}
+				<-release
+			}
+			if fail {
+				return errors.New("database down")
+			}
+			id := atomic.AddInt32(&created, 1)
+			ctx.Response().Header().Set(echo.HeaderLocation, "/cakes/1")
+			ctx.Response().Header().Set("ETag", `"1-abc"`)
+			return ctx.JSON(http.StatusCreated, map[string]int32{"id": id})
+		})
+		e.POST("/auth/login", func(ctx echo.Context) error {
+			id := atomic.AddInt32(&created, 1)
+			return ctx.JSON(http.StatusOK, map[string]int32{"access_token": id})
+		}, idempotency.NoStore)
+	})
+
+	sendTo := func(path, key, body, remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.RemoteAddr = remoteAddr
+		if key != "" {
+			req.Header.Set(idempotency.Header, key)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	send := func(key, body, remoteAddr string) *httptest.ResponseRecorder {
+		return sendTo("/cakes", key, body, remoteAddr)
+	}
+
+	It("replays the first response to retries", func() {
+		first := send("abc", `{"title": "Lemon"}`, "10.0.0.1:1000")
+		Expect(first.Code).Should(Equal(http.StatusCreated))
+
+		retry := send("abc", `{"title": "Lemon"}`, "10.0.0.1:1001")
+		Expect(retry.Code).Should(Equal(http.StatusCreated))
+		Expect(retry.Body.String()).Should(Equal(first.Body.String()))
+		Expect(retry.Header().Get(echo.HeaderLocation)).Should(Equal("/cakes/1"))
+		Expect(retry.Header().Get("ETag")).Should(Equal(`"1-abc"`))
+		Expect(retry.Header().Get(idempotency.HeaderReplayed)).Should(Equal("true"))
+		Expect(atomic.LoadInt32(&created)).Should(Equal(int32(1)))
+
+		Expect(send("abc", `{"title": "Lemon"}`, "10.0.0.2:1000").Code).Should(Equal(http.StatusCreated))
+		Expect(send("", `{"title": "Lemon"}`, "10.0.0.1:1000").Code).Should(Equal(http.StatusCreated))
+		Expect(atomic.LoadInt32(&created)).Should(Equal(int32(3)))
+	})
+
+	It("refuses keys reused with another body", func() {
+		Expect(send("abc", `{"title": "Lemon"}`, "10.0.0.1:1000").Code).Should(Equal(http.StatusCreated))
+		Expect(send("abc", `{"title": "Lime"}`, "10.0.0.1:1000").Code).Should(Equal(http.StatusUnprocessableEntity))
+		Expect(send(strings.Repeat("a", 256), `{}`, "10.0.0.1:1000").Code).Should(Equal(http.StatusUnprocessableEntity))
+	})
+
+	It("refuses retries while the first request is in progress", func() {
+		started, release = make(chan struct{}), make(chan struct{})
+		done := make(chan int)
+		go func() {
+			done <- send("abc", `{"title": "Lemon"}`, "10.0.0.1:1000").Code
+		}()
+		<-started
+		Expect(send("abc", `{"title": "Lemon"}`, "10.0.0.1:1000").Code).Should(Equal(http.StatusConflict))
+		close(release)
+		Expect(<-done).Should(Equal(http.StatusCreated))
+		Expect(atomic.LoadInt32(&created)).Should(Equal(int32(1)))
+	})
+
+	It("releases keys of failed requests", func() {
+		fail = true
+		Expect(send("abc", `{"title": "Lemon"}`, "10.0.0.1:1000").Code).Should(Equal(http.StatusInternalServerError))
+		fail = false
+		Expect(send("abc", `{"title": "Lemon"}`, "10.0.0.1:1000").Code).Should(Equal(http.StatusCreated))
+		Expect(atomic.LoadInt32(&created)).Should(Equal(int32(1)))
+	})
+	It("never stores responses of routes marked NoStore", func() {
+		first := sendTo("/auth/login", "abc", `{"email": "ana@example.com"}`, "10.0.0.1:1000")
+		Expect(first.Code).Should(Equal(http.StatusOK))
+		retry := sendTo("/auth/login", "abc", `{"email": "ana@example.com"}`, "10.0.0.1:1000")
+		Expect(retry.Code).Should(Equal(http.StatusOK))
+		Expect(retry.Header().Get(idempotency.HeaderReplayed)).Should(BeEmpty())
+		Expect(retry.Body.String()).ShouldNot(Equal(first.Body.String()))
+		Expect(atomic.LoadInt32(&created)).Should(Equal(int32(2)))
+	})
+})
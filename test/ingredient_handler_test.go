@@ -0,0 +1,177 @@
+package test
+
+import (
+	"cake-store/internal/cakes"
+	"cake-store/internal/ingredients"
+	"cake-store/internal/middlewares"
+	"cake-store/internal/query"
+	mock_ingredients "cake-store/mocks/ingredients"
+	mock_repository "cake-store/mocks/repository"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test Ingredient Service", func() {
+	var (
+		e                *echo.Echo
+		mockCtrl         *gomock.Controller
+		serviceInterface ingredients.SvcInterface
+		repo             *mock_ingredients.MockRepoInterface
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		repo = mock_ingredients.NewMockRepoInterface(mockCtrl)
+		serviceInterface = ingredients.NewHandler(repo)
+		e = echo.New()
+		middlewares.UseCustomValidatorHandler(e)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("create an ingredient", func() {
+		created := ingredients.Ingredient{ID: 1, Name: "Flour", Allergens: []string{"gluten"}, Vegan: true, CreatedAt: time.Now()}
+		repo.EXPECT().Create(gomock.Any(), ingredients.RequestDto{Name: "Flour", Allergens: []string{"gluten"}, Vegan: true}).Return(&created, nil)
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name": "Flour", "allergens": ["gluten"], "vegan": true}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err := serviceInterface.Create(c)
+		Expect(err).Should(Succeed())
+		Expect(rec.Code).Should(Equal(http.StatusCreated))
+		Expect(rec.Header().Get(echo.HeaderLocation)).Should(Equal("/ingredients/1"))
+	})
+
+	It("return error on unknown allergen", func() {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name": "Flour", "allergens": ["wheat"]}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err := serviceInterface.Create(c)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("return conflict when deleting a used ingredient", func() {
+		repo.EXPECT().Delete(gomock.Any(), 1).Return(ingredients.ErrConflict)
+		req := httptest.NewRequest(http.MethodDelete, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		err := serviceInterface.Delete(c)
+		Expect(err).Should(MatchError(ingredients.ErrConflict))
+	})
+})
+
+var _ = Describe("Test Cake Service With Ingredients", func() {
+	var (
+		e                *echo.Echo
+		mockCtrl         *gomock.Controller
+		serviceInterface cakes.SvcInterface
+		repo             *mock_repository.MockRepoInterface
+		ingredientsRepo  *mock_ingredients.MockRepoInterface
+		mockData         cakes.Cake
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		repo = mock_repository.NewMockRepoInterface(mockCtrl)
+		ingredientsRepo = mock_ingredients.NewMockRepoInterface(mockCtrl)
+		serviceInterface = cakes.NewHandler(repo, cakes.WithIngredients(ingredientsRepo))
+		e = echo.New()
+		middlewares.UseCustomValidatorHandler(e)
+		mockData = cakes.Cake{ID: 1, Title: "Almond cake", Rating: 7, CreatedAt: time.Now()}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("derive allergens on the cake detail", func() {
+		repo.EXPECT().Get(gomock.Any(), 1).Return(&mockData, nil)
+		ingredientsRepo.EXPECT().ForCakes(gomock.Any(), []int{1}).Return(map[int][]ingredients.CakeIngredient{1: {
+			{ID: 2, Name: "Almonds", Quantity: 50, Unit: "g", Allergens: []string{"nuts"}, Vegan: true, Halal: true},
+			{ID: 3, Name: "Butter", Quantity: 100, Unit: "g", Allergens: []string{"dairy"}, Halal: true},
+		}}, nil)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		err := serviceInterface.Get(c)
+		Expect(err).Should(Succeed())
+		Expect(rec.Body.String()).Should(ContainSubstring(`"dietary":{"allergens":["dairy","nuts"],"vegan":false,"halal":true}`))
+	})
+
+	It("leave ingredients out of the listing", func() {
+		repo.EXPECT().List(gomock.Any(), gomock.Any()).Return([]cakes.Cake{mockData}, int64(1), nil)
+		req := httptest.NewRequest(http.MethodGet, "/cakes", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err := serviceInterface.List(c)
+		Expect(err).Should(Succeed())
+		Expect(rec.Body.String()).ShouldNot(ContainSubstring(`"dietary"`))
+	})
+
+	It("exclude cakes with the allergens", func() {
+		ingredientsRepo.EXPECT().CakesWithAllergens(gomock.Any(), []string{"nuts", "gluten"}).Return(query.IDs{List: []int{4, 5}}, nil)
+		repo.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, dto cakes.ListRequestDto) ([]cakes.Cake, int64, error) {
+			Expect(dto.Without).Should(Equal([]query.IDs{{List: []int{4, 5}}}))
+			return []cakes.Cake{}, int64(0), nil
+		})
+		req := httptest.NewRequest(http.MethodGet, "/cakes?exclude_allergens=nuts,gluten", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err := serviceInterface.List(c)
+		Expect(err).Should(Succeed())
+	})
+
+	It("return error on unknown allergen filter", func() {
+		req := httptest.NewRequest(http.MethodGet, "/cakes?exclude_allergens=wheat", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err := serviceInterface.List(c)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("assign ingredients on create", func() {
+		quantities := []ingredients.QuantityDto{{IngredientID: 2, Quantity: 50, Unit: "g"}}
+		ingredientsRepo.EXPECT().Check(gomock.Any(), quantities).Return(nil)
+		repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&mockData, nil)
+		ingredientsRepo.EXPECT().Assign(gomock.Any(), 1, quantities).Return(nil)
+		ingredientsRepo.EXPECT().ForCakes(gomock.Any(), []int{1}).Return(map[int][]ingredients.CakeIngredient{}, nil)
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"title": "Almond cake", "ingredients": [{"ingredient_id": 2, "quantity": 50, "unit": "g"}]}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err := serviceInterface.Create(c)
+		Expect(err).Should(Succeed())
+	})
+
+	It("return error on invalid quantities", func() {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"title": "Almond cake", "ingredients": [{"ingredient_id": 2, "quantity": 0, "unit": "cups"}]}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err := serviceInterface.Create(c)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("reject categories when they are not enabled", func() {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"title": "Almond cake", "tags": ["vegan"]}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err := serviceInterface.Create(c)
+		Expect(err).Should(MatchError(cakes.ErrValidation))
+	})
+})
@@ -0,0 +1,111 @@
+package test
+
+import (
+	"cake-store/internal/ingredients"
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = describeConformance("Ingredient Repository", ingredients.NewMemoryRepository, ingredients.NewRepository, func(subject *repoUnderTest[ingredients.RepoInterface]) {
+	var (
+		repo ingredients.RepoInterface
+		ctx  = context.TODO()
+	)
+
+	BeforeEach(func() {
+		repo = subject.Repo
+	})
+
+	create := func(name string, vegan bool, allergens ...string) *ingredients.Ingredient {
+		ingredient, err := repo.Create(ctx, ingredients.RequestDto{Name: name, Allergens: allergens, Vegan: vegan, Halal: true})
+		Expect(err).Should(Succeed())
+		return ingredient
+	}
+
+	It("creates, lists and updates ingredients", func() {
+		created := create("Flour", true, "gluten", "gluten")
+		Expect(created.ID).Should(Equal(1))
+		Expect(created.Allergens).Should(Equal([]string{"gluten"}))
+		Expect(created.Vegan).Should(BeTrue())
+		create("Butter", false, "dairy")
+
+		list, err := repo.List(ctx)
+		Expect(err).Should(Succeed())
+		Expect([]string{list[0].Name, list[1].Name}).Should(Equal([]string{"Butter", "Flour"}))
+		Expect(list[0].Allergens).Should(Equal([]string{"dairy"}))
+
+		_, err = repo.Create(ctx, ingredients.RequestDto{Name: "Flour"})
+		Expect(err).Should(MatchError(ingredients.ErrConflict))
+
+		vegan := false
+		updated, err := repo.Update(ctx, ingredients.UpdateRequestDto{ID: 1, Allergens: []string{"gluten", "soy"}, Vegan: &vegan})
+		Expect(err).Should(Succeed())
+		Expect(updated.Name).Should(Equal("Flour"))
+		Expect(updated.Allergens).Should(Equal([]string{"gluten", "soy"}))
+		Expect(updated.Vegan).Should(BeFalse())
+		Expect(updated.Halal).Should(BeTrue())
+
+		updated, err = repo.Update(ctx, ingredients.UpdateRequestDto{ID: 1, Name: "Wheat flour"})
+		Expect(err).Should(Succeed())
+		Expect(updated.Allergens).Should(Equal([]string{"gluten", "soy"}))
+
+		_, err = repo.Update(ctx, ingredients.UpdateRequestDto{ID: 9, Name: "Sugar"})
+		Expect(err).Should(MatchError(ingredients.ErrNotFound))
+	})
+
+	It("assigns ingredients to cakes and finds cakes by allergen", func() {
+		create("Flour", true, "gluten")
+		create("Almonds", true, "nuts")
+		create("Sugar", true)
+
+		Expect(repo.Check(ctx, []ingredients.QuantityDto{{IngredientID: 1}, {IngredientID: 4}})).Should(MatchError(ingredients.ErrValidation))
+		Expect(repo.Check(ctx, []ingredients.QuantityDto{{IngredientID: 1}, {IngredientID: 1}})).Should(MatchError(ingredients.ErrValidation))
+		Expect(repo.Assign(ctx, 10, []ingredients.QuantityDto{{IngredientID: 4, Quantity: 1, Unit: "g"}})).Should(MatchError(ingredients.ErrValidation))
+
+		Expect(repo.Assign(ctx, 10, []ingredients.QuantityDto{
+			{IngredientID: 1, Quantity: 200, Unit: "g"},
+			{IngredientID: 2, Quantity: 50, Unit: "g"},
+		})).Should(Succeed())
+		Expect(repo.Assign(ctx, 11, []ingredients.QuantityDto{
+			{IngredientID: 1, Quantity: 100, Unit: "g"},
+			{IngredientID: 3, Quantity: 1.5, Unit: "tbsp"},
+		})).Should(Succeed())
+
+		used, err := repo.ForCakes(ctx, []int{10, 11, 12})
+		Expect(err).Should(Succeed())
+		Expect(used[10]).Should(Equal([]ingredients.CakeIngredient{
+			{ID: 2, Name: "Almonds", Quantity: 50, Unit: "g", Allergens: []string{"nuts"}, Vegan: true, Halal: true},
+			{ID: 1, Name: "Flour", Quantity: 200, Unit: "g", Allergens: []string{"gluten"}, Vegan: true, Halal: true},
+		}))
+		Expect(used[11][1].Quantity).Should(Equal(1.5))
+		Expect(used[12]).Should(BeEmpty())
+
+		ids, err := repo.CakesWithAllergens(ctx, []string{"nuts"})
+		Expect(err).Should(Succeed())
+		Expect(subject.selected(ids)).Should(ConsistOf(10))
+		ids, err = repo.CakesWithAllergens(ctx, []string{"nuts", "gluten"})
+		Expect(err).Should(Succeed())
+		Expect(subject.selected(ids)).Should(ConsistOf(10, 11))
+
+		Expect(repo.Delete(ctx, 2)).Should(MatchError(ingredients.ErrConflict))
+		Expect(repo.Assign(ctx, 10, []ingredients.QuantityDto{})).Should(Succeed())
+		Expect(repo.Delete(ctx, 2)).Should(Succeed())
+		_, err = repo.Get(ctx, 2)
+		Expect(err).Should(MatchError(ingredients.ErrNotFound))
+	})
+})
+
+var _ = Describe("Dietary Summary", func() {
+	It("unions allergens and requires every ingredient to be vegan or halal", func() {
+		Expect(ingredients.Summarize(nil)).Should(BeNil())
+		dietary := ingredients.Summarize([]ingredients.CakeIngredient{
+			{Allergens: []string{"gluten"}, Vegan: true, Halal: true},
+			{Allergens: []string{"dairy", "gluten"}, Vegan: false, Halal: true},
+		})
+		Expect(dietary.Allergens).Should(Equal([]string{"dairy", "gluten"}))
+		Expect(dietary.Vegan).Should(BeFalse())
+		Expect(dietary.Halal).Should(BeTrue())
+	})
+})
@@ -0,0 +1,201 @@
+package test
+
+import (
+	"cake-store/internal/cakes"
+	"cake-store/internal/inventory"
+	"cake-store/internal/middlewares"
+	"cake-store/internal/query"
+	"cake-store/internal/variants"
+	mock_inventory "cake-store/mocks/inventory"
+	mock_repository "cake-store/mocks/repository"
+	mock_variants "cake-store/mocks/variants"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test Inventory Service", func() {
+	var (
+		e                *echo.Echo
+		mockCtrl         *gomock.Controller
+		serviceInterface inventory.SvcInterface
+		repo             *mock_inventory.MockRepoInterface
+		variantsRepo     *mock_variants.MockRepoInterface
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		repo = mock_inventory.NewMockRepoInterface(mockCtrl)
+		variantsRepo = mock_variants.NewMockRepoInterface(mockCtrl)
+		serviceInterface = inventory.NewHandler(repo, variantsRepo)
+		e = echo.New()
+		middlewares.UseCustomValidatorHandler(e)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("set the stock of a variant", func() {
+		variantsRepo.EXPECT().Get(gomock.Any(), 1, 2).Return(&variants.Variant{ID: 2, CakeID: 1}, nil)
+		repo.EXPECT().Set(gomock.Any(), inventory.SetRequestDto{CakeID: 1, VariantID: 2, Day: "2026-10-18", OnHand: 12}).Return(&inventory.Stock{ID: 1, VariantID: 2, CakeID: 1, Day: "2026-10-18", OnHand: 12, Available: 12}, nil)
+		req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"on_hand": 12}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id", "variant_id", "day")
+		c.SetParamValues("1", "2", "2026-10-18")
+		err := serviceInterface.Set(c)
+		Expect(err).Should(Succeed())
+		Expect(rec.Code).Should(Equal(http.StatusOK))
+	})
+
+	It("return not found when the variant belongs to another cake", func() {
+		variantsRepo.EXPECT().Get(gomock.Any(), 3, 2).Return(nil, variants.ErrNotFound)
+		req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"on_hand": 12}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id", "variant_id", "day")
+		c.SetParamValues("3", "2", "2026-10-18")
+		err := serviceInterface.Set(c)
+		Expect(err).Should(MatchError(variants.ErrNotFound))
+	})
+
+	It("return error on invalid day", func() {
+		req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"on_hand": 12}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id", "variant_id", "day")
+		c.SetParamValues("1", "2", "18-10-2026")
+		err := serviceInterface.Set(c)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("reserve stock", func() {
+		request := inventory.ReserveRequestDto{VariantID: 2, Day: "2026-10-18", Quantity: 3}
+		repo.EXPECT().Reserve(gomock.Any(), request).Return(&inventory.Reservation{ID: 5, VariantID: 2, Day: "2026-10-18", Quantity: 3, Status: inventory.StatusHeld, CreatedAt: time.Now()}, nil)
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"variant_id": 2, "day": "2026-10-18", "quantity": 3}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err := serviceInterface.Reserve(c)
+		Expect(err).Should(Succeed())
+		Expect(rec.Code).Should(Equal(http.StatusCreated))
+		Expect(rec.Header().Get(echo.HeaderLocation)).Should(Equal("/reservations/5"))
+	})
+
+	It("return conflict when stock runs out", func() {
+		repo.EXPECT().Reserve(gomock.Any(), gomock.Any()).Return(nil, inventory.ErrInsufficient)
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"variant_id": 2, "day": "2026-10-18", "quantity": 30}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err := serviceInterface.Reserve(c)
+		Expect(err).Should(MatchError(inventory.ErrConflict))
+	})
+
+	It("return not found when the variant has no stock for the day", func() {
+		repo.EXPECT().Reserve(gomock.Any(), gomock.Any()).Return(nil, inventory.ErrNotFound)
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"variant_id": 9, "day": "2026-10-18", "quantity": 1}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err := serviceInterface.Reserve(c)
+		Expect(err).Should(MatchError(inventory.ErrNotFound))
+	})
+
+	It("commit a reservation", func() {
+		repo.EXPECT().Commit(gomock.Any(), 5).Return(&inventory.Reservation{ID: 5, Status: inventory.StatusCommitted}, nil)
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("5")
+		err := serviceInterface.Commit(c)
+		Expect(err).Should(Succeed())
+		Expect(rec.Body.String()).Should(ContainSubstring(`"status":"committed"`))
+	})
+})
+
+var _ = Describe("Test Cake Service With Stock", func() {
+	var (
+		e                *echo.Echo
+		mockCtrl         *gomock.Controller
+		serviceInterface cakes.SvcInterface
+		repo             *mock_repository.MockRepoInterface
+		inventoryRepo    *mock_inventory.MockRepoInterface
+		mockData         []cakes.Cake
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		repo = mock_repository.NewMockRepoInterface(mockCtrl)
+		inventoryRepo = mock_inventory.NewMockRepoInterface(mockCtrl)
+		serviceInterface = cakes.NewHandler(repo, cakes.WithStock(inventoryRepo))
+		e = echo.New()
+		middlewares.UseCustomValidatorHandler(e)
+		mockData = []cakes.Cake{{ID: 1, Title: "Lemon cake", CreatedAt: time.Now()}, {ID: 2, Title: "Plain cake", CreatedAt: time.Now()}}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("flag cakes in stock", func() {
+		repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(mockData, int64(2), nil)
+		inventoryRepo.EXPECT().InStock(gomock.Any(), inventory.Today(), []int{1, 2}).Return([]int{2}, nil)
+		req := httptest.NewRequest(http.MethodGet, "/cakes", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err := serviceInterface.List(c)
+		Expect(err).Should(Succeed())
+		Expect(rec.Body.String()).Should(ContainSubstring(`"title":"Lemon cake"`))
+		Expect(strings.Count(rec.Body.String(), `"in_stock":false`)).Should(Equal(1))
+		Expect(strings.Count(rec.Body.String(), `"in_stock":true`)).Should(Equal(1))
+	})
+
+	It("filter cakes in stock on a day", func() {
+		inventoryRepo.EXPECT().CakesInStock(gomock.Any(), "2026-10-20").Return(query.IDs{List: []int{2}}, nil)
+		inventoryRepo.EXPECT().InStock(gomock.Any(), "2026-10-20", []int{2}).Return([]int{2}, nil)
+		repo.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, dto cakes.ListRequestDto) ([]cakes.Cake, int64, error) {
+			Expect(dto.Within).Should(Equal([]query.IDs{{List: []int{2}}}))
+			return mockData[1:], int64(1), nil
+		})
+		req := httptest.NewRequest(http.MethodGet, "/cakes?in_stock=true&available_on=2026-10-20", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err := serviceInterface.List(c)
+		Expect(err).Should(Succeed())
+		Expect(rec.Body.String()).Should(ContainSubstring(`"in_stock":true`))
+	})
+
+	It("exclude cakes in stock", func() {
+		inventoryRepo.EXPECT().CakesInStock(gomock.Any(), inventory.Today()).Return(query.IDs{List: []int{2}}, nil)
+		inventoryRepo.EXPECT().InStock(gomock.Any(), inventory.Today(), []int{1}).Return([]int{}, nil)
+		repo.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, dto cakes.ListRequestDto) ([]cakes.Cake, int64, error) {
+			Expect(dto.Without).Should(Equal([]query.IDs{{List: []int{2}}}))
+			return mockData[:1], int64(1), nil
+		})
+		req := httptest.NewRequest(http.MethodGet, "/cakes?in_stock=false", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err := serviceInterface.List(c)
+		Expect(err).Should(Succeed())
+	})
+
+	It("return error on invalid day", func() {
+		req := httptest.NewRequest(http.MethodGet, "/cakes?in_stock=true&available_on=tomorrow", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err := serviceInterface.List(c)
+		Expect(err).Should(HaveOccurred())
+	})
+})
@@ -0,0 +1,144 @@
+package test
+
+import (
+	"cake-store/internal/inventory"
+	"context"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const stockDay = "2026-10-18"
+
+var _ = describeConformance("Inventory Repository", inventory.NewMemoryRepository, inventory.NewRepository, func(subject *repoUnderTest[inventory.RepoInterface]) {
+	var (
+		repo inventory.RepoInterface
+		ctx  = context.TODO()
+	)
+
+	BeforeEach(func() {
+		repo = subject.Repo
+	})
+
+	set := func(cakeID, variantID, onHand int) *inventory.Stock {
+		threshold := 2
+		stock, err := repo.Set(ctx, inventory.SetRequestDto{CakeID: cakeID, VariantID: variantID, Day: stockDay, OnHand: onHand, LowStockThreshold: &threshold})
+		Expect(err).Should(Succeed())
+		return stock
+	}
+
+	reserve := func(variantID, quantity int) (*inventory.Reservation, error) {
+		return repo.Reserve(ctx, inventory.ReserveRequestDto{VariantID: variantID, Day: stockDay, Quantity: quantity})
+	}
+
+	It("sets stock levels and flags low stock", func() {
+		created := set(1, 1, 10)
+		Expect(created.Available).Should(Equal(10))
+		Expect(created.Version).Should(Equal(1))
+		Expect(created.LowStock).Should(BeFalse())
+		set(2, 2, 1)
+
+		updated, err := repo.Set(ctx, inventory.SetRequestDto{CakeID: 1, VariantID: 1, Day: stockDay, OnHand: 12})
+		Expect(err).Should(Succeed())
+		Expect(updated.OnHand).Should(Equal(12))
+		Expect(updated.LowStockThreshold).Should(Equal(2))
+		Expect(updated.Version).Should(Equal(2))
+
+		low := true
+		list, err := repo.List(ctx, inventory.ListRequestDto{Day: stockDay, LowStock: &low})
+		Expect(err).Should(Succeed())
+		Expect(list).Should(HaveLen(1))
+		Expect(list[0].VariantID).Should(Equal(2))
+
+		_, err = repo.Get(ctx, 1, "2026-10-19")
+		Expect(err).Should(MatchError(inventory.ErrNotFound))
+	})
+
+	It("reserves, releases and commits stock", func() {
+		set(1, 1, 5)
+
+		first, err := reserve(1, 3)
+		Expect(err).Should(Succeed())
+		Expect(first.Status).Should(Equal(inventory.StatusHeld))
+		_, err = reserve(1, 3)
+		Expect(err).Should(MatchError(inventory.ErrInsufficient))
+		_, err = reserve(2, 1)
+		Expect(err).Should(MatchError(inventory.ErrNotFound))
+
+		_, err = repo.Set(ctx, inventory.SetRequestDto{CakeID: 1, VariantID: 1, Day: stockDay, OnHand: 2})
+		Expect(err).Should(MatchError(inventory.ErrConflict))
+
+		second, err := reserve(1, 2)
+		Expect(err).Should(Succeed())
+		released, err := repo.Release(ctx, second.ID)
+		Expect(err).Should(Succeed())
+		Expect(released.Status).Should(Equal(inventory.StatusReleased))
+		committed, err := repo.Commit(ctx, first.ID)
+		Expect(err).Should(Succeed())
+		Expect(committed.Status).Should(Equal(inventory.StatusCommitted))
+
+		_, err = repo.Commit(ctx, second.ID)
+		Expect(err).Should(MatchError(inventory.ErrConflict))
+		_, err = repo.Release(ctx, 99)
+		Expect(err).Should(MatchError(inventory.ErrReservationNotFound))
+
+		stock, err := repo.Get(ctx, 1, stockDay)
+		Expect(err).Should(Succeed())
+		Expect(stock.OnHand).Should(Equal(2))
+		Expect(stock.Reserved).Should(Equal(0))
+	})
+
+	It("reserves exactly what is on hand under concurrent requests", func() {
+		set(1, 1, 10)
+
+		var (
+			wg       sync.WaitGroup
+			mu       sync.Mutex
+			reserved int
+		)
+		for n := 0; n < 25; n++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				if _, err := reserve(1, 1); err == nil {
+					mu.Lock()
+					reserved++
+					mu.Unlock()
+				} else {
+					Expect(err).Should(MatchError(inventory.ErrInsufficient))
+				}
+			}()
+		}
+		wg.Wait()
+
+		stock, err := repo.Get(ctx, 1, stockDay)
+		Expect(err).Should(Succeed())
+		Expect(reserved).Should(Equal(10))
+		Expect(stock.Reserved).Should(Equal(10))
+		Expect(stock.Available).Should(BeZero())
+	})
+
+	It("finds cakes with units available", func() {
+		set(1, 1, 1)
+		set(2, 2, 0)
+		set(3, 3, 4)
+		_, err := reserve(1, 1)
+		Expect(err).Should(Succeed())
+
+		ids, err := repo.CakesInStock(ctx, stockDay)
+		Expect(err).Should(Succeed())
+		Expect(subject.selected(ids)).Should(ConsistOf(3))
+		ids, err = repo.CakesInStock(ctx, "2026-10-19")
+		Expect(err).Should(Succeed())
+		Expect(subject.selected(ids)).Should(BeEmpty())
+
+		inStock, err := repo.InStock(ctx, stockDay, []int{1, 3, 4})
+		Expect(err).Should(Succeed())
+		Expect(inStock).Should(ConsistOf(3))
+		inStock, err = repo.InStock(ctx, stockDay, []int{})
+		Expect(err).Should(Succeed())
+		Expect(inStock).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,107 @@
+package test
+
+import (
+	"cake-store/internal/cakes"
+	"cake-store/internal/middlewares"
+	"cake-store/internal/money"
+	"cake-store/internal/options"
+	"cake-store/internal/variants"
+	mock_options "cake-store/mocks/options"
+	mock_repository "cake-store/mocks/repository"
+	mock_variants "cake-store/mocks/variants"
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test Option Service", func() {
+	var (
+		e                *echo.Echo
+		mockCtrl         *gomock.Controller
+		serviceInterface options.SvcInterface
+		repo             *mock_options.MockRepoInterface
+		cakesRepo        *mock_repository.MockRepoInterface
+		variantsRepo     *mock_variants.MockRepoInterface
+		schema           *options.Schema
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		repo = mock_options.NewMockRepoInterface(mockCtrl)
+		cakesRepo = mock_repository.NewMockRepoInterface(mockCtrl)
+		variantsRepo = mock_variants.NewMockRepoInterface(mockCtrl)
+		serviceInterface = options.NewHandler(repo, cakesRepo, variantsRepo)
+		e = echo.New()
+		middlewares.UseCustomValidatorHandler(e)
+
+		var err error
+		schema, err = options.NewMemoryRepository().Set(context.TODO(), birthdaySchema(1))
+		Expect(err).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	send := func(method, body string, handler echo.HandlerFunc) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(method, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		return rec, handler(c)
+	}
+
+	It("quote a configured cake", func() {
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1}, nil)
+		variantsRepo.EXPECT().Get(gomock.Any(), 1, 2).Return(&variants.Variant{ID: 2, CakeID: 1, Size: "8 inch", Price: money.New(3000, "USD"), Active: true}, nil)
+		repo.EXPECT().Get(gomock.Any(), 1).Return(schema, nil)
+		rec, err := send(http.MethodPost, `{"variant_id": 2, "options": [{"group": "flavour", "choices": ["chocolate"]}, {"group": "inscription", "text": "Happy 30th"}]}`, serviceInterface.Quote)
+		Expect(err).Should(Succeed())
+		Expect(rec.Code).Should(Equal(http.StatusOK))
+		Expect(rec.Body.String()).Should(ContainSubstring(`"description":"Inscription: Happy 30th"`))
+		Expect(rec.Body.String()).Should(ContainSubstring(`"quantity":1`))
+		Expect(rec.Body.String()).Should(ContainSubstring(`"total":{"amount":3800,"currency":"USD","decimal":"38.00"}`))
+	})
+
+	It("return error on an invalid configuration", func() {
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1}, nil)
+		variantsRepo.EXPECT().Get(gomock.Any(), 1, 2).Return(&variants.Variant{ID: 2, CakeID: 1, Price: money.New(3000, "USD"), Active: true}, nil)
+		repo.EXPECT().Get(gomock.Any(), 1).Return(schema, nil)
+		_, err := send(http.MethodPost, `{"variant_id": 2, "options": [{"group": "filling", "choices": ["jam"]}]}`, serviceInterface.Quote)
+		Expect(err).Should(MatchError(options.ErrConfiguration))
+	})
+
+	It("return error on an inactive variant", func() {
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1}, nil)
+		variantsRepo.EXPECT().Get(gomock.Any(), 1, 2).Return(&variants.Variant{ID: 2, CakeID: 1, Price: money.New(3000, "USD")}, nil)
+		_, err := send(http.MethodPost, `{"variant_id": 2}`, serviceInterface.Quote)
+		Expect(err).Should(MatchError(options.ErrConfiguration))
+	})
+
+	It("set the options of a cake", func() {
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1}, nil)
+		repo.EXPECT().Set(gomock.Any(), gomock.Any()).Return(schema, nil)
+		rec, err := send(http.MethodPut, `{"currency": "USD", "groups": [{"key": "flavour", "name": "Flavour", "kind": "choice", "min": 1, "choices": [{"key": "vanilla", "name": "Vanilla"}]}]}`, serviceInterface.Set)
+		Expect(err).Should(Succeed())
+		Expect(rec.Code).Should(Equal(http.StatusOK))
+	})
+
+	It("return error on an unknown group kind", func() {
+		_, err := send(http.MethodPut, `{"currency": "USD", "groups": [{"key": "flavour", "name": "Flavour", "kind": "colour"}]}`, serviceInterface.Set)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("return error for the options of a missing cake", func() {
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(nil, cakes.ErrNotFound)
+		_, err := send(http.MethodGet, "", serviceInterface.Get)
+		Expect(err).Should(MatchError(cakes.ErrNotFound))
+	})
+})
@@ -0,0 +1,162 @@
+package test
+
+import (
+	"cake-store/internal/money"
+	"cake-store/internal/options"
+	"cake-store/internal/variants"
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// birthdaySchema is a custom cake with a required flavour, up to two
+// fillings and an optional inscription.
+func birthdaySchema(cakeID int) options.SchemaRequestDto {
+	return options.SchemaRequestDto{CakeID: cakeID, Currency: "usd", Groups: []options.GroupDto{
+		{Key: "flavour", Name: "Flavour", Kind: options.KindChoice, Min: 1, Choices: []options.ChoiceDto{
+			{Key: "vanilla", Name: "Vanilla"}, {Key: "chocolate", Name: "Chocolate", PriceMinor: 300},
+		}},
+		{Key: "filling", Name: "Filling", Kind: options.KindChoice, Max: 2, Choices: []options.ChoiceDto{
+			{Key: "jam", Name: "Jam", PriceMinor: 150}, {Key: "cream", Name: "Cream", PriceMinor: 200}, {Key: "curd", Name: "Lemon curd", PriceMinor: 250},
+		}},
+		{Key: "inscription", Name: "Inscription", Kind: options.KindText, MaxLength: 20, PriceMinor: 500},
+	}}
+}
+
+var _ = describeConformance("Option Repository", options.NewMemoryRepository, options.NewRepository, func(subject *repoUnderTest[options.RepoInterface]) {
+	var (
+		repo options.RepoInterface
+		ctx  = context.TODO()
+	)
+
+	BeforeEach(func() {
+		repo = subject.Repo
+	})
+
+	It("returns no groups for a cake without options", func() {
+		schema, err := repo.Get(ctx, 1)
+		Expect(err).Should(Succeed())
+		Expect(schema.Groups).Should(BeEmpty())
+	})
+
+	It("stores the options of a cake in order", func() {
+		_, err := repo.Set(ctx, birthdaySchema(1))
+		Expect(err).Should(Succeed())
+
+		schema, err := repo.Get(ctx, 1)
+		Expect(err).Should(Succeed())
+		Expect(schema.Currency).Should(Equal("USD"))
+		Expect(schema.Groups).Should(HaveLen(3))
+		Expect(schema.Groups[0].Key).Should(Equal("flavour"))
+		Expect(schema.Groups[0].Choices[1]).Should(Equal(options.Choice{Key: "chocolate", Name: "Chocolate", Price: money.New(300, "USD")}))
+		Expect(schema.Groups[1].Choices).Should(HaveLen(3))
+		Expect(schema.Groups[1].Choices[2].Key).Should(Equal("curd"))
+		Expect(schema.Groups[2].MaxLength).Should(Equal(20))
+		Expect(schema.Groups[2].Price).Should(Equal(money.New(500, "USD")))
+		Expect(schema.Groups[2].Choices).Should(BeEmpty())
+
+		other, err := repo.Get(ctx, 2)
+		Expect(err).Should(Succeed())
+		Expect(other.Groups).Should(BeEmpty())
+	})
+
+	It("rejects inconsistent options", func() {
+		for _, groups := range [][]options.GroupDto{
+			{{Key: "flavour", Name: "Flavour", Kind: options.KindChoice}},
+			{{Key: "flavour", Name: "Flavour", Kind: options.KindChoice, Min: 2, Choices: []options.ChoiceDto{{Key: "vanilla", Name: "Vanilla"}}}},
+			{{Key: "flavour", Name: "Flavour", Kind: options.KindChoice, Choices: []options.ChoiceDto{{Key: "vanilla", Name: "Vanilla"}, {Key: "Vanilla", Name: "Vanilla"}}}},
+			{{Key: "inscription", Name: "Inscription", Kind: options.KindText}},
+			{{Key: "inscription", Name: "Inscription", Kind: options.KindText, MaxLength: 20, Choices: []options.ChoiceDto{{Key: "gold", Name: "Gold"}}}},
+			append(birthdaySchema(1).Groups, options.GroupDto{Key: "FLAVOUR", Name: "Flavour", Kind: options.KindText, MaxLength: 20}),
+		} {
+			_, err := repo.Set(ctx, options.SchemaRequestDto{CakeID: 1, Currency: "USD", Groups: groups})
+			Expect(err).Should(MatchError(options.ErrValidation))
+		}
+
+		schema, err := repo.Get(ctx, 1)
+		Expect(err).Should(Succeed())
+		Expect(schema.Groups).Should(BeEmpty())
+	})
+
+	It("replaces and deletes the options of a cake", func() {
+		_, err := repo.Set(ctx, birthdaySchema(1))
+		Expect(err).Should(Succeed())
+		_, err = repo.Set(ctx, birthdaySchema(2))
+		Expect(err).Should(Succeed())
+
+		replaced, err := repo.Set(ctx, options.SchemaRequestDto{CakeID: 1, Currency: "USD", Groups: []options.GroupDto{
+			{Key: "tiers", Name: "Tiers", Kind: options.KindChoice, Min: 1, Max: 1, Choices: []options.ChoiceDto{{Key: "2", Name: "Two tiers", PriceMinor: 2000}}},
+		}})
+		Expect(err).Should(Succeed())
+		Expect(replaced.Groups).Should(HaveLen(1))
+		Expect(replaced.Groups[0].Choices).Should(HaveLen(1))
+
+		Expect(repo.DeleteForCake(ctx, 1)).Should(Succeed())
+		schema, err := repo.Get(ctx, 1)
+		Expect(err).Should(Succeed())
+		Expect(schema.Groups).Should(BeEmpty())
+
+		schema, err = repo.Get(ctx, 2)
+		Expect(err).Should(Succeed())
+		Expect(schema.Groups).Should(HaveLen(3))
+	})
+})
+
+var _ = Describe("Option Configuration", func() {
+	var schema options.Schema
+	variant := variants.Variant{ID: 2, CakeID: 1, Size: "8 inch", Price: money.New(3000, "USD"), Active: true}
+
+	BeforeEach(func() {
+		stored, err := options.NewMemoryRepository().Set(context.TODO(), birthdaySchema(1))
+		Expect(err).Should(Succeed())
+		schema = *stored
+	})
+
+	It("quotes a configured cake with an itemised breakdown", func() {
+		quote, err := schema.Quote(variant, 2, []options.SelectionDto{
+			{Group: "inscription", Text: "  Happy 30th, Sam!  "},
+			{Group: "Flavour", Choices: []string{"CHOCOLATE"}},
+			{Group: "filling", Choices: []string{"jam", "cream"}},
+		})
+		Expect(err).Should(Succeed())
+		Expect(quote.Lines).Should(Equal([]options.Line{
+			{Description: "8 inch", Price: money.New(3000, "USD")},
+			{Group: "flavour", Choice: "chocolate", Description: "Flavour: Chocolate", Price: money.New(300, "USD")},
+			{Group: "filling", Choice: "jam", Description: "Filling: Jam", Price: money.New(150, "USD")},
+			{Group: "filling", Choice: "cream", Description: "Filling: Cream", Price: money.New(200, "USD")},
+			{Group: "inscription", Description: "Inscription: Happy 30th, Sam!", Price: money.New(500, "USD")},
+		}))
+		Expect(quote.UnitPrice).Should(Equal(money.New(4150, "USD")))
+		Expect(quote.Total).Should(Equal(money.New(8300, "USD")))
+	})
+
+	It("rejects configurations the options do not allow", func() {
+		flavour := options.SelectionDto{Group: "flavour", Choices: []string{"vanilla"}}
+		for _, selections := range [][]options.SelectionDto{
+			{},
+			{{Group: "flavour", Choices: []string{"vanilla", "chocolate"}}},
+			{{Group: "flavour", Choices: []string{"pistachio"}}},
+			{flavour, {Group: "filling", Choices: []string{"jam", "jam"}}},
+			{flavour, {Group: "filling", Choices: []string{"jam", "cream", "curd"}}},
+			{flavour, {Group: "inscription", Text: "Happy birthday to you, Sam"}},
+			{flavour, {Group: "inscription", Choices: []string{"jam"}}},
+			{flavour, {Group: "flavour", Choices: []string{"vanilla"}}},
+			{flavour, {Group: "sprinkles", Choices: []string{"gold"}}},
+		} {
+			_, err := schema.Configure(selections)
+			Expect(err).Should(MatchError(options.ErrConfiguration))
+		}
+	})
+
+	It("rejects a variant priced in another currency", func() {
+		euro := variant
+		euro.Price = money.New(3000, "EUR")
+		_, err := schema.Quote(euro, 1, []options.SelectionDto{{Group: "flavour", Choices: []string{"vanilla"}}})
+		Expect(err).Should(MatchError(options.ErrConfiguration))
+
+		quote, err := options.Schema{CakeID: 1}.Quote(euro, 1, nil)
+		Expect(err).Should(Succeed())
+		Expect(quote.Total).Should(Equal(money.New(3000, "EUR")))
+	})
+})
@@ -0,0 +1,254 @@
+package test
+
+import (
+	"cake-store/internal/auth"
+	"cake-store/internal/cakes"
+	"cake-store/internal/inventory"
+	"cake-store/internal/middlewares"
+	"cake-store/internal/money"
+	"cake-store/internal/orders"
+	"cake-store/internal/scheduling"
+	"cake-store/internal/stores"
+	"cake-store/internal/variants"
+	mock_inventory "cake-store/mocks/inventory"
+	mock_orders "cake-store/mocks/orders"
+	mock_repository "cake-store/mocks/repository"
+	mock_scheduling "cake-store/mocks/scheduling"
+	mock_stores "cake-store/mocks/stores"
+	mock_variants "cake-store/mocks/variants"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test Order Service", func() {
+	var (
+		e                *echo.Echo
+		mockCtrl         *gomock.Controller
+		serviceInterface orders.SvcInterface
+		repo             *mock_orders.MockRepoInterface
+		cakesRepo        *mock_repository.MockRepoInterface
+		variantsRepo     *mock_variants.MockRepoInterface
+		inventoryRepo    *mock_inventory.MockRepoInterface
+		scheduleRepo     *mock_scheduling.MockRepoInterface
+		storesRepo       *mock_stores.MockRepoInterface
+		day              string
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		repo = mock_orders.NewMockRepoInterface(mockCtrl)
+		cakesRepo = mock_repository.NewMockRepoInterface(mockCtrl)
+		variantsRepo = mock_variants.NewMockRepoInterface(mockCtrl)
+		inventoryRepo = mock_inventory.NewMockRepoInterface(mockCtrl)
+		scheduleRepo = mock_scheduling.NewMockRepoInterface(mockCtrl)
+		storesRepo = mock_stores.NewMockRepoInterface(mockCtrl)
+		catalog := orders.NewCatalog(cakesRepo, variantsRepo, orders.WithMenu(stores.NewMenu(storesRepo, variantsRepo)))
+		serviceInterface = orders.NewHandler(repo, orders.NewPlacer(repo, catalog,
+			orders.WithInventory(inventoryRepo), orders.WithScheduling(scheduling.NewScheduler(scheduleRepo))))
+		e = echo.New()
+		middlewares.UseCustomValidatorHandler(e)
+		day = inventory.Today()
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	post := func(body string) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		return rec, serviceInterface.Create(c)
+	}
+
+	transition := func(status string, order orders.Order) error {
+		repo.EXPECT().Get(gomock.Any(), 1).Return(&order, nil)
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		return serviceInterface.Transition(status)(c)
+	}
+
+	It("place an order priced from the variants", func() {
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1, Title: "Lemon cake"}, nil)
+		variantsRepo.EXPECT().Get(gomock.Any(), 1, 2).Return(&variants.Variant{ID: 2, CakeID: 1, Size: "8 inch", SKU: "LEMON-8", Price: money.New(3250, "USD"), Active: true}, nil)
+		inventoryRepo.EXPECT().Reserve(gomock.Any(), inventory.ReserveRequestDto{VariantID: 2, Day: day, Quantity: 2}).Return(&inventory.Reservation{ID: 7}, nil)
+		repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, order orders.Order) (*orders.Order, error) {
+			Expect(order.Status).Should(Equal(orders.StatusPending))
+			Expect(order.Fulfilment).Should(Equal(orders.FulfilmentPickup))
+			Expect(order.Total).Should(Equal(money.New(6500, "USD")))
+			Expect(*order.Items[0].ReservationID).Should(Equal(7))
+			order.ID = 4
+			return &order, nil
+		})
+		rec, err := post(fmt.Sprintf(`{"customer_id": "customer-1", "day": "%s", "items": [{"cake_id": 1, "variant_id": 2, "quantity": 2}]}`, day))
+		Expect(err).Should(Succeed())
+		Expect(rec.Code).Should(Equal(http.StatusCreated))
+		Expect(rec.Header().Get(echo.HeaderLocation)).Should(Equal("/orders/4"))
+		Expect(rec.Body.String()).Should(ContainSubstring(`"total":{"amount":6500,"currency":"USD","decimal":"65.00"}`))
+	})
+
+	It("release what was reserved when an item is sold out", func() {
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1, Title: "Lemon cake"}, nil).Times(2)
+		variantsRepo.EXPECT().Get(gomock.Any(), 1, 2).Return(&variants.Variant{ID: 2, CakeID: 1, Price: money.New(450, "USD"), Active: true}, nil)
+		variantsRepo.EXPECT().Get(gomock.Any(), 1, 3).Return(&variants.Variant{ID: 3, CakeID: 1, Price: money.New(3250, "USD"), Active: true}, nil)
+		inventoryRepo.EXPECT().Reserve(gomock.Any(), gomock.Any()).Return(&inventory.Reservation{ID: 7}, nil)
+		inventoryRepo.EXPECT().Reserve(gomock.Any(), gomock.Any()).Return(nil, inventory.ErrInsufficient)
+		inventoryRepo.EXPECT().Release(gomock.Any(), 7).Return(&inventory.Reservation{ID: 7}, nil)
+		_, err := post(fmt.Sprintf(`{"customer_id": "customer-1", "day": "%s", "items": [{"cake_id": 1, "variant_id": 2, "quantity": 1}, {"cake_id": 1, "variant_id": 3, "quantity": 5}]}`, day))
+		Expect(err).Should(MatchError(orders.ErrConflict))
+	})
+
+	It("return error on inactive variant", func() {
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1}, nil)
+		variantsRepo.EXPECT().Get(gomock.Any(), 1, 2).Return(&variants.Variant{ID: 2, CakeID: 1, Price: money.New(450, "USD")}, nil)
+		_, err := post(fmt.Sprintf(`{"customer_id": "customer-1", "day": "%s", "items": [{"cake_id": 1, "variant_id": 2, "quantity": 1}]}`, day))
+		Expect(err).Should(MatchError(orders.ErrUnavailable))
+	})
+
+	It("return error on a past day", func() {
+		_, err := post(`{"customer_id": "customer-1", "day": "2020-01-01", "items": [{"cake_id": 1, "variant_id": 2, "quantity": 1}]}`)
+		Expect(err).Should(MatchError(orders.ErrValidation))
+	})
+
+	It("return error on delivery without an address", func() {
+		_, err := post(fmt.Sprintf(`{"customer_id": "customer-1", "fulfilment": "delivery", "day": "%s", "items": [{"cake_id": 1, "variant_id": 2, "quantity": 1}]}`, day))
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("book the slot of an order", func() {
+		slotDay, hours := scheduleDay(3, "09:00", "10:00", 1)
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1, Title: "Lemon cake"}, nil)
+		variantsRepo.EXPECT().Get(gomock.Any(), 1, 2).Return(&variants.Variant{ID: 2, CakeID: 1, Price: money.New(3250, "USD"), Active: true}, nil)
+		scheduleRepo.EXPECT().GetHoliday(gomock.Any(), slotDay).Return(nil, scheduling.ErrHolidayNotFound)
+		scheduleRepo.EXPECT().Hours(gomock.Any()).Return(hours.Hours, nil)
+		scheduleRepo.EXPECT().LeadTimes(gomock.Any(), []int{1}).Return(map[int]int{1: 24}, nil)
+		scheduleRepo.EXPECT().Booked(gomock.Any(), slotDay).Return(map[string]int{}, nil)
+		scheduleRepo.EXPECT().Book(gomock.Any(), gomock.Any()).Return(&scheduling.Booking{ID: 5}, nil)
+		inventoryRepo.EXPECT().Reserve(gomock.Any(), gomock.Any()).Return(&inventory.Reservation{ID: 7}, nil)
+		repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, order orders.Order) (*orders.Order, error) {
+			Expect(order.Slot).Should(Equal("09:00"))
+			Expect(*order.BookingID).Should(Equal(5))
+			order.ID = 4
+			return &order, nil
+		})
+		_, err := post(fmt.Sprintf(`{"customer_id": "customer-1", "day": "%s", "slot": "09:00", "items": [{"cake_id": 1, "variant_id": 2, "quantity": 1}]}`, slotDay))
+		Expect(err).Should(Succeed())
+	})
+
+	It("return conflict when the slot of an order is full", func() {
+		slotDay, hours := scheduleDay(3, "09:00", "10:00", 1)
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1, Title: "Lemon cake"}, nil)
+		variantsRepo.EXPECT().Get(gomock.Any(), 1, 2).Return(&variants.Variant{ID: 2, CakeID: 1, Price: money.New(3250, "USD"), Active: true}, nil)
+		scheduleRepo.EXPECT().GetHoliday(gomock.Any(), slotDay).Return(nil, scheduling.ErrHolidayNotFound)
+		scheduleRepo.EXPECT().Hours(gomock.Any()).Return(hours.Hours, nil)
+		scheduleRepo.EXPECT().LeadTimes(gomock.Any(), []int{1}).Return(map[int]int{}, nil)
+		scheduleRepo.EXPECT().Booked(gomock.Any(), slotDay).Return(map[string]int{"09:00": 1}, nil)
+		scheduleRepo.EXPECT().Book(gomock.Any(), gomock.Any()).Return(nil, scheduling.ErrFull)
+		_, err := post(fmt.Sprintf(`{"customer_id": "customer-1", "day": "%s", "slot": "09:00", "items": [{"cake_id": 1, "variant_id": 2, "quantity": 1}]}`, slotDay))
+		Expect(err).Should(MatchError(orders.ErrConflict))
+	})
+
+	It("reject illegal transitions", func() {
+		err := transition(orders.StatusReady, orders.Order{ID: 1, Status: orders.StatusPending, Fulfilment: orders.FulfilmentPickup})
+		Expect(err).Should(MatchError(orders.ErrConflict))
+	})
+
+	It("release the stock of a cancelled order", func() {
+		reservationID := 7
+		order := orders.Order{ID: 1, Status: orders.StatusConfirmed, Fulfilment: orders.FulfilmentPickup, Items: []orders.Item{{VariantID: 2, ReservationID: &reservationID}}}
+		cancelled := order
+		cancelled.Status = orders.StatusCancelled
+		repo.EXPECT().Transition(gomock.Any(), 1, orders.StatusConfirmed, orders.StatusCancelled).Return(&cancelled, nil)
+		inventoryRepo.EXPECT().Release(gomock.Any(), 7).Return(&inventory.Reservation{ID: 7}, nil)
+		Expect(transition(orders.StatusCancelled, order)).Should(Succeed())
+	})
+
+	It("release the slot of a cancelled order", func() {
+		bookingID := 5
+		order := orders.Order{ID: 1, Status: orders.StatusPending, Fulfilment: orders.FulfilmentPickup, Slot: "09:00", BookingID: &bookingID, Items: []orders.Item{}}
+		cancelled := order
+		cancelled.Status = orders.StatusCancelled
+		repo.EXPECT().Transition(gomock.Any(), 1, orders.StatusPending, orders.StatusCancelled).Return(&cancelled, nil)
+		scheduleRepo.EXPECT().Release(gomock.Any(), 5).Return(&scheduling.Booking{ID: 5, Status: scheduling.StatusReleased}, nil)
+		Expect(transition(orders.StatusCancelled, order)).Should(Succeed())
+	})
+
+	It("commit the stock of a picked up order", func() {
+		reservationID := 7
+		order := orders.Order{ID: 1, Status: orders.StatusReady, Fulfilment: orders.FulfilmentPickup, Items: []orders.Item{{VariantID: 2, ReservationID: &reservationID}}}
+		pickedUp := order
+		pickedUp.Status = orders.StatusPickedUp
+		repo.EXPECT().Transition(gomock.Any(), 1, orders.StatusReady, orders.StatusPickedUp).Return(&pickedUp, nil)
+		inventoryRepo.EXPECT().Commit(gomock.Any(), 7).Return(&inventory.Reservation{ID: 7}, nil)
+		Expect(transition(orders.StatusPickedUp, order)).Should(Succeed())
+	})
+
+	It("list the order history of a customer", func() {
+		repo.EXPECT().List(gomock.Any(), orders.ListRequestDto{CustomerID: "customer-1", Limit: 20}).Return([]orders.Order{}, nil)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("customer_id")
+		c.SetParamValues("customer-1")
+		err := serviceInterface.List(c)
+		Expect(err).Should(Succeed())
+	})
+	It("place orders of customers for themselves", func() {
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1, Title: "Lemon cake"}, nil)
+		variantsRepo.EXPECT().Get(gomock.Any(), 1, 2).Return(&variants.Variant{ID: 2, CakeID: 1, Price: money.New(3250, "USD"), Active: true}, nil)
+		inventoryRepo.EXPECT().Reserve(gomock.Any(), gomock.Any()).Return(&inventory.Reservation{ID: 7}, nil)
+		repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, order orders.Order) (*orders.Order, error) {
+			Expect(order.CustomerID).Should(Equal("user-5"))
+			order.ID = 4
+			return &order, nil
+		})
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(fmt.Sprintf(`{"customer_id": "customer-1", "day": "%s", "items": [{"cake_id": 1, "variant_id": 2, "quantity": 1}]}`, day)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		c := e.NewContext(req, httptest.NewRecorder())
+		auth.SetPrincipal(c, &auth.Principal{UserID: 5, Role: auth.RoleCustomer})
+		Expect(serviceInterface.Create(c)).Should(Succeed())
+	})
+
+	It("list the orders of the signed-in user", func() {
+		repo.EXPECT().List(gomock.Any(), orders.ListRequestDto{CustomerID: "user-5", Limit: 20}).Return([]orders.Order{}, nil)
+		req := httptest.NewRequest(http.MethodGet, "/?customer_id=customer-1", nil)
+		c := e.NewContext(req, httptest.NewRecorder())
+		auth.SetPrincipal(c, &auth.Principal{UserID: 5, Role: auth.RoleCustomer})
+		Expect(serviceInterface.Mine(c)).Should(Succeed())
+	})
+	It("price orders placed with a store from its menu", func() {
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1, Title: "Lemon cake"}, nil)
+		variantsRepo.EXPECT().Get(gomock.Any(), 1, 2).Return(&variants.Variant{ID: 2, CakeID: 1, Price: money.New(3250, "USD"), Active: true}, nil)
+		storesRepo.EXPECT().Get(gomock.Any(), 2).Return(&stores.Store{ID: 2}, nil)
+		storesRepo.EXPECT().GetOverride(gomock.Any(), 2, 1).Return(&stores.Override{StoreID: 2, CakeID: 1, Available: true, Prices: []stores.Price{{VariantID: 2, PriceMinor: 2800}}}, nil)
+		inventoryRepo.EXPECT().Reserve(gomock.Any(), gomock.Any()).Return(&inventory.Reservation{ID: 7}, nil)
+		repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, order orders.Order) (*orders.Order, error) {
+			Expect(*order.StoreID).Should(Equal(2))
+			Expect(order.Total).Should(Equal(money.New(5600, "USD")))
+			order.ID = 4
+			return &order, nil
+		})
+		_, err := post(fmt.Sprintf(`{"customer_id": "customer-1", "store_id": 2, "day": "%s", "items": [{"cake_id": 1, "variant_id": 2, "quantity": 2}]}`, day))
+		Expect(err).Should(Succeed())
+	})
+
+	It("return error on a cake off the menu of the store", func() {
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1, Title: "Lemon cake"}, nil)
+		variantsRepo.EXPECT().Get(gomock.Any(), 1, 2).Return(&variants.Variant{ID: 2, CakeID: 1, Price: money.New(3250, "USD"), Active: true}, nil)
+		storesRepo.EXPECT().Get(gomock.Any(), 2).Return(&stores.Store{ID: 2}, nil)
+		storesRepo.EXPECT().GetOverride(gomock.Any(), 2, 1).Return(&stores.Override{StoreID: 2, CakeID: 1, Available: false}, nil)
+		_, err := post(fmt.Sprintf(`{"customer_id": "customer-1", "store_id": 2, "day": "%s", "items": [{"cake_id": 1, "variant_id": 2, "quantity": 2}]}`, day))
+		Expect(err).Should(MatchError(orders.ErrUnavailable))
+	})
+})
@@ -0,0 +1,171 @@
+package test
+
+import (
+	"cake-store/internal/inventory"
+	"cake-store/internal/money"
+	"cake-store/internal/orders"
+	"cake-store/internal/storage"
+	"context"
+	"database/sql"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = describeConformance("Order Repository", orders.NewMemoryRepository, orders.NewRepository, func(subject *repoUnderTest[orders.RepoInterface]) {
+	var (
+		repo orders.RepoInterface
+		ctx  = context.TODO()
+	)
+
+	BeforeEach(func() {
+		repo = subject.Repo
+	})
+
+	place := func(customerID string, quantity int) *orders.Order {
+		reservationID := 7
+		item := orders.Item{CakeID: 1, VariantID: 2, Title: "Lemon cake", Size: "8 inch", SKU: "LEMON-8", Quantity: quantity,
+			UnitPrice: money.New(3250, "USD"), LineTotal: money.New(3250*int64(quantity), "USD"), ReservationID: &reservationID}
+		order, err := repo.Create(ctx, orders.Order{CustomerID: customerID, Status: orders.StatusPending, Fulfilment: orders.FulfilmentPickup,
+			Day: "2026-10-18", Items: []orders.Item{item}, Total: item.LineTotal})
+		Expect(err).Should(Succeed())
+		return order
+	}
+
+	It("stores orders with their items", func() {
+		created := place("customer-1", 2)
+		Expect(created.ID).Should(Equal(1))
+		Expect(created.Total).Should(Equal(money.New(6500, "USD")))
+		Expect(created.Items).Should(HaveLen(1))
+		Expect(created.Items[0].UnitPrice).Should(Equal(money.New(3250, "USD")))
+		Expect(*created.Items[0].ReservationID).Should(Equal(7))
+
+		_, err := repo.Get(ctx, 9)
+		Expect(err).Should(MatchError(orders.ErrNotFound))
+	})
+
+	It("stores the slot booked for an order and its store", func() {
+		bookingID, storeID := 5, 2
+		created, err := repo.Create(ctx, orders.Order{CustomerID: "customer-1", StoreID: &storeID, Status: orders.StatusPending, Fulfilment: orders.FulfilmentPickup,
+			Day: "2026-10-18", Slot: "09:00", BookingID: &bookingID, Items: []orders.Item{}, Total: money.New(0, "USD")})
+		Expect(err).Should(Succeed())
+		Expect(created.Slot).Should(Equal("09:00"))
+		Expect(*created.BookingID).Should(Equal(5))
+		Expect(*created.StoreID).Should(Equal(2))
+	})
+
+	It("lists the order history of a customer, newest first", func() {
+		place("customer-1", 1)
+		place("customer-2", 1)
+		place("customer-1", 3)
+
+		history, err := repo.List(ctx, orders.ListRequestDto{CustomerID: "customer-1"})
+		Expect(err).Should(Succeed())
+		Expect([]int{history[0].ID, history[1].ID}).Should(Equal([]int{3, 1}))
+		Expect(history[0].Items[0].Quantity).Should(Equal(3))
+
+		page, err := repo.List(ctx, orders.ListRequestDto{Limit: 1, Offset: 1})
+		Expect(err).Should(Succeed())
+		Expect(page).Should(HaveLen(1))
+		Expect(page[0].ID).Should(Equal(2))
+	})
+
+	It("moves orders only from the status they are in", func() {
+		place("customer-1", 1)
+
+		confirmed, err := repo.Transition(ctx, 1, orders.StatusPending, orders.StatusConfirmed)
+		Expect(err).Should(Succeed())
+		Expect(confirmed.Status).Should(Equal(orders.StatusConfirmed))
+		Expect(confirmed.UpdatedAt).ShouldNot(BeNil())
+
+		_, err = repo.Transition(ctx, 1, orders.StatusPending, orders.StatusCancelled)
+		Expect(err).Should(MatchError(orders.ErrConflict))
+		_, err = repo.Transition(ctx, 9, orders.StatusPending, orders.StatusConfirmed)
+		Expect(err).Should(MatchError(orders.ErrNotFound))
+
+		list, err := repo.List(ctx, orders.ListRequestDto{Status: orders.StatusCancelled})
+		Expect(err).Should(Succeed())
+		Expect(list).Should(BeEmpty())
+	})
+})
+
+var _ = Describe("Order Transitions", func() {
+	var (
+		db        *sql.DB
+		repo      orders.RepoInterface
+		stockRepo inventory.RepoInterface
+		placer    orders.Placer
+		order     *orders.Order
+		ctx       = context.TODO()
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, err = storage.OpenSQLite(":memory:")
+		Expect(err).Should(Succeed())
+		repo = orders.NewRepository(db)
+		stockRepo = inventory.NewRepository(db)
+		placer = orders.NewPlacer(repo, orders.NewCatalog(nil, nil),
+			orders.WithInventory(stockRepo), orders.WithTransactions(storage.NewTransactor(db)))
+
+		_, err = stockRepo.Set(ctx, inventory.SetRequestDto{CakeID: 1, VariantID: 2, Day: stockDay, OnHand: 5})
+		Expect(err).Should(Succeed())
+		reservation, err := stockRepo.Reserve(ctx, inventory.ReserveRequestDto{VariantID: 2, Day: stockDay, Quantity: 1})
+		Expect(err).Should(Succeed())
+		item := orders.Item{CakeID: 1, VariantID: 2, Title: "Lemon cake", Size: "8 inch", SKU: "LEMON-8", Quantity: 1,
+			UnitPrice: money.New(3250, "USD"), LineTotal: money.New(3250, "USD"), ReservationID: &reservation.ID}
+		order, err = repo.Create(ctx, orders.Order{CustomerID: "customer-1", Status: orders.StatusPending, Fulfilment: orders.FulfilmentPickup,
+			Day: stockDay, Items: []orders.Item{item}, Total: item.LineTotal})
+		Expect(err).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		db.Close()
+	})
+
+	It("keeps the status of an order whose stock cannot be released", func() {
+		_, err := db.Exec("DELETE FROM " + inventory.TableName)
+		Expect(err).Should(Succeed())
+
+		_, err = placer.Transition(ctx, *order, orders.StatusCancelled)
+		Expect(err).Should(MatchError(inventory.ErrNotFound))
+		current, err := repo.Get(ctx, order.ID)
+		Expect(err).Should(Succeed())
+		Expect(current.Status).Should(Equal(orders.StatusPending))
+		reservation, err := stockRepo.GetReservation(ctx, *order.Items[0].ReservationID)
+		Expect(err).Should(Succeed())
+		Expect(reservation.Status).Should(Equal(inventory.StatusHeld))
+	})
+
+	It("cancels an order whose reservation was deleted", func() {
+		_, err := db.Exec("DELETE FROM " + inventory.ReservationsName)
+		Expect(err).Should(Succeed())
+
+		cancelled, err := placer.Transition(ctx, *order, orders.StatusCancelled)
+		Expect(err).Should(Succeed())
+		Expect(cancelled.Status).Should(Equal(orders.StatusCancelled))
+	})
+})
+
+var _ = Describe("Order State Machine", func() {
+	pickup := orders.Order{Status: orders.StatusPending, Fulfilment: orders.FulfilmentPickup}
+
+	It("walks an order from pending to picked up", func() {
+		order := pickup
+		for _, status := range []string{orders.StatusConfirmed, orders.StatusBaking, orders.StatusReady, orders.StatusPickedUp} {
+			Expect(orders.CanTransition(order, status)).Should(Succeed())
+			order.Status = status
+		}
+		Expect(orders.Final(order.Status)).Should(BeTrue())
+	})
+
+	It("rejects illegal moves", func() {
+		Expect(orders.CanTransition(pickup, orders.StatusBaking)).Should(MatchError(orders.ErrConflict))
+		baking := orders.Order{Status: orders.StatusBaking, Fulfilment: orders.FulfilmentPickup}
+		Expect(orders.CanTransition(baking, orders.StatusCancelled)).Should(MatchError(orders.ErrConflict))
+		ready := orders.Order{Status: orders.StatusReady, Fulfilment: orders.FulfilmentPickup}
+		Expect(orders.CanTransition(ready, orders.StatusDelivered)).Should(MatchError(orders.ErrConflict))
+		cancelled := orders.Order{Status: orders.StatusCancelled}
+		Expect(orders.CanTransition(cancelled, orders.StatusConfirmed)).Should(MatchError(orders.ErrConflict))
+	})
+})
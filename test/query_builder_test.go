@@ -0,0 +1,160 @@
+package test
+
+import (
+	"cake-store/internal/cakes"
+	"cake-store/internal/query"
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var hostileTitles = []string{
+	"O'Reilly's cheesecake",
+	`Robert'); DROP TABLE cakes;--`,
+	`100% "real" cake_with\backslash`,
+	"' OR '1'='1",
+}
+
+var _ = Describe("Test Query Builder", func() {
+	Describe("Select", func() {
+		It("renders values as placeholders", func() {
+			q, args := query.Select("cakes", "id", "title").
+				Where(query.Eq("id", 1), query.Like("title", "50%_off!")).
+				OrderBy("rating DESC").
+				Limit(10).
+				Offset(20).
+				Build()
+			Expect(q).Should(Equal("SELECT id, title FROM cakes WHERE (id = ?) AND (title LIKE ? ESCAPE '!') ORDER BY rating DESC LIMIT ? OFFSET ?"))
+			Expect(args).Should(Equal([]interface{}{1, "%50!%!_off!!%", 10, 20}))
+		})
+
+		It("counts with the same conditions", func() {
+			q, args := query.Select("cakes", "id").Where(query.Eq("title", "x")).OrderBy("id").Limit(1).Count()
+			Expect(q).Should(Equal("SELECT COUNT(*) FROM cakes WHERE (title = ?)"))
+			Expect(args).Should(Equal([]interface{}{"x"}))
+		})
+
+		It("matches nothing for an empty IN list", func() {
+			q, args := query.Select("cakes", "id").Where(query.In("id")).Build()
+			Expect(q).Should(Equal("SELECT id FROM cakes WHERE (1 = 0)"))
+			Expect(args).Should(BeEmpty())
+		})
+	})
+
+	Describe("Insert, Update and Delete", func() {
+		It("keeps hostile values out of the statement", func() {
+			for _, title := range hostileTitles {
+				q, args := query.Insert("cakes").Set("title", title).Set("rating", 7).Build()
+				Expect(q).Should(Equal("INSERT INTO cakes (title, rating) VALUES (?, ?)"))
+				Expect(args).Should(Equal([]interface{}{title, 7}))
+
+				q, args = query.Update("cakes").SetExpr("updated_at", "CURRENT_TIMESTAMP").Set("title", title).Where(query.Eq("id", 3)).Build()
+				Expect(q).Should(Equal("UPDATE cakes SET updated_at = CURRENT_TIMESTAMP, title = ? WHERE (id = ?)"))
+				Expect(args).Should(Equal([]interface{}{title, 3}))
+			}
+
+			q, args := query.Delete("cakes").Where(query.Eq("id", 3)).Build()
+			Expect(q).Should(Equal("DELETE FROM cakes WHERE (id = ?)"))
+			Expect(args).Should(Equal([]interface{}{3}))
+		})
+	})
+
+	Describe("Cake Repository", func() {
+		var (
+			mock sqlmock.Sqlmock
+			repo cakes.RepoInterface
+		)
+
+		BeforeEach(func() {
+			db, m, err := sqlmock.New()
+			Expect(err).Should(Succeed())
+			mock = m
+			repo = cakes.NewRepository(db)
+		})
+
+		AfterEach(func() {
+			Expect(mock.ExpectationsWereMet()).Should(Succeed())
+		})
+
+		It("round-trips hostile titles and descriptions", func() {
+			for i, title := range hostileTitles {
+				description := "desc: " + title
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO cakes (title, description, image, created_at) VALUES (?, ?, ?, ?)")).
+					WithArgs(title, description, nil, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(int64(i+1), 1))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, description, rating, rating_count, image, created_at, updated_at, version FROM cakes WHERE (id = ?)")).
+					WithArgs(i + 1).
+					WillReturnRows(sqlmock.NewRows(cakes.Columns).AddRow(i+1, title, description, 0, 0, nil, time.Now(), nil, 1))
+				cake, err := repo.Create(context.TODO(), cakes.RequestDto{Title: title, Description: description})
+				Expect(err).Should(Succeed())
+				Expect(cake.ID).Should(Equal(i + 1))
+				Expect(cake.Title).Should(Equal(title))
+				Expect(cake.Description).Should(Equal(description))
+			}
+		})
+
+		It("passes list filters as arguments", func() {
+			title := hostileTitles[1]
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM cakes WHERE (title LIKE ? ESCAPE '!')")).
+				WithArgs("%" + title + "%").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, description, rating, rating_count, image, created_at, updated_at, version FROM cakes WHERE (title LIKE ? ESCAPE '!') ORDER BY rating DESC, title ASC, id ASC LIMIT ? OFFSET ?")).
+				WithArgs("%"+title+"%", 10, 0).
+				WillReturnRows(sqlmock.NewRows(cakes.Columns).AddRow(1, title, "", 5, 1, nil, time.Now(), nil, 1))
+			res, total, err := repo.List(context.TODO(), cakes.ListRequestDto{Title: title, Limit: 10})
+			Expect(err).Should(Succeed())
+			Expect(total).Should(Equal(int64(1)))
+			Expect(res[0].Title).Should(Equal(title))
+		})
+
+		It("searches through the FULLTEXT index on MySQL", func() {
+			match := "MATCH(title, description) AGAINST (? IN NATURAL LANGUAGE MODE)"
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM cakes WHERE (" + match + ")")).
+				WithArgs(hostileTitles[0]).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, description, rating, rating_count, image, created_at, updated_at, version, "+match+" AS score FROM cakes WHERE ("+match+") ORDER BY "+match+" DESC, id ASC LIMIT ? OFFSET ?")).
+				WithArgs(hostileTitles[0], hostileTitles[0], hostileTitles[0], 10, 0).
+				WillReturnRows(sqlmock.NewRows(append(cakes.Columns, "score")).AddRow(1, "Lemon", "", 5, 1, nil, time.Now(), nil, 1, 0.5))
+			res, _, err := repo.List(context.TODO(), cakes.ListRequestDto{Q: hostileTitles[0], Limit: 10})
+			Expect(err).Should(Succeed())
+			Expect(*res[0].Score).Should(Equal(0.5))
+		})
+
+		It("loads the suggestion index once on MySQL", func() {
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, description, rating, rating_count, image, created_at, updated_at, version FROM cakes")).
+				WillReturnRows(sqlmock.NewRows(cakes.Columns).AddRow(1, hostileTitles[0], "", 5, 1, nil, time.Now(), nil, 1))
+			for i := 0; i < 2; i++ {
+				res, err := repo.Suggest(context.TODO(), "o'rei", 5)
+				Expect(err).Should(Succeed())
+				Expect(res).Should(Equal([]cakes.Suggestion{{ID: 1, Title: hostileTitles[0]}}))
+			}
+		})
+
+		It("updates only the given fields", func() {
+			mock.ExpectExec(regexp.QuoteMeta("UPDATE cakes SET updated_at = ?, title = ?, version = version + 1 WHERE (id = ?) AND (version = ?)")).
+				WithArgs(sqlmock.AnyArg(), hostileTitles[0], 2, 1).
+				WillReturnResult(driver.RowsAffected(1))
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, description, rating, rating_count, image, created_at, updated_at, version FROM cakes WHERE (id = ?)")).
+				WithArgs(2).
+				WillReturnRows(sqlmock.NewRows(cakes.Columns).AddRow(2, hostileTitles[0], "", 4.5, 2, nil, time.Now(), time.Now(), 1))
+			cake, err := repo.Update(context.TODO(), cakes.UpdateRequestDto{ID: 2, Title: hostileTitles[0], Version: 1})
+			Expect(err).Should(Succeed())
+			Expect(cake.Title).Should(Equal(hostileTitles[0]))
+		})
+
+		It("averages the rating before adding to the totals", func() {
+			mock.ExpectExec(regexp.QuoteMeta("UPDATE cakes SET rating = CASE WHEN rating_count + ? > 0 THEN (rating_sum + ?) * 1.0 / (rating_count + ?) ELSE 0 END, rating_sum = rating_sum + ?, rating_count = rating_count + ? WHERE (id = ?)")).
+				WithArgs(1, 4, 1, 4, 1, 2).
+				WillReturnResult(driver.RowsAffected(1))
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, description, rating, rating_count, image, created_at, updated_at, version FROM cakes WHERE (id = ?)")).
+				WithArgs(2).
+				WillReturnRows(sqlmock.NewRows(cakes.Columns).AddRow(2, "Lemon", "", 4, 1, nil, time.Now(), nil, 1))
+			Expect(repo.AddRating(context.TODO(), 2, 4, 1)).Should(Succeed())
+		})
+	})
+})
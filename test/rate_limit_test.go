@@ -0,0 +1,194 @@
+package test
+
+import (
+	"cake-store/internal/auth"
+	"cake-store/internal/middlewares"
+	"cake-store/internal/ratelimit"
+	mock_ratelimit "cake-store/mocks/ratelimit"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rate Limit Store", func() {
+	var (
+		store ratelimit.Store
+		ctx   = context.TODO()
+		limit = ratelimit.Limit{Requests: 2, Period: 10 * time.Second}
+		now   = time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	)
+
+	BeforeEach(func() {
+		store = ratelimit.NewMemoryStore()
+	})
+
+	It("allows bursts up to the limit and refills over time", func() {
+		result, err := store.Take(ctx, "a", limit, now)
+		Expect(err).Should(Succeed())
+		Expect(result.Allowed).Should(BeTrue())
+		Expect(result.Remaining).Should(Equal(1))
+		Expect(result.ResetAfter).Should(Equal(5 * time.Second))
+
+		result, _ = store.Take(ctx, "a", limit, now)
+		Expect(result.Allowed).Should(BeTrue())
+		Expect(result.Remaining).Should(Equal(0))
+
+		result, _ = store.Take(ctx, "a", limit, now.Add(time.Second))
+		Expect(result.Allowed).Should(BeFalse())
+		Expect(result.RetryAfter).Should(Equal(4 * time.Second))
+
+		result, _ = store.Take(ctx, "b", limit, now.Add(time.Second))
+		Expect(result.Allowed).Should(BeTrue())
+
+		result, _ = store.Take(ctx, "a", limit, now.Add(5*time.Second))
+		Expect(result.Allowed).Should(BeTrue())
+		result, _ = store.Take(ctx, "a", limit, now.Add(time.Hour))
+		Expect(result.Remaining).Should(Equal(1))
+	})
+
+	It("never allows more than the limit to concurrent takes", func() {
+		var (
+			wg      sync.WaitGroup
+			allowed int32
+		)
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer GinkgoRecover()
+				result, err := store.Take(ctx, "a", ratelimit.Limit{Requests: 5, Period: time.Hour}, now)
+				Expect(err).Should(Succeed())
+				if result.Allowed {
+					atomic.AddInt32(&allowed, 1)
+				}
+			}()
+		}
+		wg.Wait()
+		Expect(allowed).Should(Equal(int32(5)))
+	})
+
+	It("parses limits", func() {
+		parsed, err := ratelimit.ParseLimit("120/1m")
+		Expect(err).Should(Succeed())
+		Expect(parsed).Should(Equal(ratelimit.Limit{Requests: 120, Period: time.Minute}))
+
+		parsed, err = ratelimit.ParseLimit("0")
+		Expect(err).Should(Succeed())
+		Expect(parsed.Unlimited()).Should(BeTrue())
+
+		for _, value := range []string{"120", "-1/1m", "10/0s", "ten/1m"} {
+			_, err = ratelimit.ParseLimit(value)
+			Expect(err).Should(MatchError(ratelimit.ErrConfig))
+		}
+	})
+})
+
+var _ = Describe("Rate Limit Middleware", func() {
+	var (
+		e        *echo.Echo
+		mockCtrl *gomock.Controller
+		limiter  ratelimit.Limiter
+	)
+
+	ok := func(ctx echo.Context) error {
+		return ctx.JSON(http.StatusOK, "Success")
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		limiter = ratelimit.NewLimiter(ratelimit.NewMemoryStore())
+		e = echo.New()
+		e.IPExtractor = echo.ExtractIPDirect()
+		middlewares.UseCustomValidatorHandler(e)
+		e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(ctx echo.Context) error {
+				if ctx.Request().Header.Get("X-User") != "" {
+					auth.SetPrincipal(ctx, &auth.Principal{UserID: 1, Role: auth.RoleCustomer})
+				}
+				return next(ctx)
+			}
+		})
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	send := func(target, remoteAddr string, user bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.RemoteAddr = remoteAddr
+		if user {
+			req.Header.Set("X-User", "1")
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	It("refuses clients over their limit with 429", func() {
+		e.Use(limiter.Limit("default", ratelimit.Limit{Requests: 2, Period: time.Hour}))
+		e.GET("/cakes", ok)
+
+		rec := send("/cakes", "10.0.0.1:1000", false)
+		Expect(rec.Code).Should(Equal(http.StatusOK))
+		Expect(rec.Header().Get(ratelimit.HeaderLimit)).Should(Equal("2"))
+		Expect(rec.Header().Get(ratelimit.HeaderRemaining)).Should(Equal("1"))
+		Expect(send("/cakes", "10.0.0.1:1001", false).Code).Should(Equal(http.StatusOK))
+
+		rec = send("/cakes", "10.0.0.1:1002", false)
+		Expect(rec.Code).Should(Equal(http.StatusTooManyRequests))
+		Expect(rec.Header().Get(echo.HeaderContentType)).Should(Equal("application/problem+json"))
+		Expect(rec.Header().Get(ratelimit.HeaderRetryAfter)).Should(Equal("1800"))
+		Expect(rec.Header().Get(ratelimit.HeaderRemaining)).Should(Equal("0"))
+
+		Expect(send("/cakes", "10.0.0.2:1000", false).Code).Should(Equal(http.StatusOK))
+		Expect(send("/cakes", "10.0.0.1:1003", true).Code).Should(Equal(http.StatusOK))
+	})
+
+	It("applies route limits on top and reports the tighter one", func() {
+		e.Use(limiter.Limit("default", ratelimit.Limit{Requests: 10, Period: time.Hour}))
+		e.GET("/login", ok, limiter.Limit("auth", ratelimit.Limit{Requests: 1, Period: time.Hour}))
+		e.GET("/cakes", ok)
+
+		rec := send("/login", "10.0.0.1:1000", false)
+		Expect(rec.Code).Should(Equal(http.StatusOK))
+		Expect(rec.Header().Get(ratelimit.HeaderLimit)).Should(Equal("1"))
+		Expect(rec.Header().Get(ratelimit.HeaderRemaining)).Should(Equal("0"))
+		Expect(send("/login", "10.0.0.1:1000", false).Code).Should(Equal(http.StatusTooManyRequests))
+
+		rec = send("/cakes", "10.0.0.1:1000", false)
+		Expect(rec.Code).Should(Equal(http.StatusOK))
+		Expect(rec.Header().Get(ratelimit.HeaderRemaining)).Should(Equal("7"))
+	})
+
+	It("limits addresses whoever the requests claim to be", func() {
+		e.Pre(limiter.LimitIP("ip", ratelimit.Limit{Requests: 2, Period: time.Hour}))
+		e.GET("/cakes", ok)
+
+		Expect(send("/cakes", "10.0.0.1:1000", false).Code).Should(Equal(http.StatusOK))
+		Expect(send("/cakes", "10.0.0.1:1000", true).Code).Should(Equal(http.StatusOK))
+		Expect(send("/cakes", "10.0.0.1:1000", true).Code).Should(Equal(http.StatusTooManyRequests))
+		Expect(send("/cakes", "10.0.0.2:1000", true).Code).Should(Equal(http.StatusOK))
+	})
+
+	It("lets requests through when the store fails", func() {
+		store := mock_ratelimit.NewMockStore(mockCtrl)
+		store.EXPECT().Take(gomock.Any(), "default:ip:10.0.0.1", gomock.Any(), gomock.Any()).Return(ratelimit.Result{}, errors.New("store down"))
+		e.Use(ratelimit.NewLimiter(store).Limit("default", ratelimit.Limit{Requests: 1, Period: time.Hour}))
+		e.GET("/cakes", ok)
+
+		rec := send("/cakes", "10.0.0.1:1000", false)
+		Expect(rec.Code).Should(Equal(http.StatusOK))
+		Expect(rec.Header().Get(ratelimit.HeaderLimit)).Should(BeEmpty())
+	})
+})
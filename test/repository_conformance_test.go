@@ -0,0 +1,535 @@
+package test
+
+import (
+	"cake-store/internal/cakes"
+	"cake-store/internal/query"
+	"cake-store/internal/storage"
+	mock_reviews "cake-store/mocks/reviews"
+	"context"
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// newCakeSQLiteRepository returns the SQLite repository of db, whose search
+// index starts out empty.
+func newCakeSQLiteRepository(db *sql.DB) cakes.RepoInterface {
+	repo, err := cakes.NewSQLiteRepository(db)
+	Expect(err).Should(Succeed())
+	return repo
+}
+
+var _ = describeConformance("Cake Repository", cakes.NewMemoryRepository, newCakeSQLiteRepository, func(subject *repoUnderTest[cakes.RepoInterface]) {
+	var (
+		repo cakes.RepoInterface
+		ctx  = context.TODO()
+	)
+
+	BeforeEach(func() {
+		repo = subject.Repo
+	})
+
+	// selecting returns the IDs other repositories would select for ids: a
+	// subquery for the SQL repository and a list for the memory one.
+	selecting := func(ids ...int) query.IDs {
+		if subject.DB == nil {
+			return query.IDs{List: append([]int{}, ids...)}
+		}
+		return query.Subquery(query.Select(cakes.TableName, "id").Where(query.In("id", query.Ints(ids)...)))
+	}
+
+	// create stores a cake rated by one approved review of stars, or
+	// unrated for 0.
+	create := func(title, description string, stars int) *cakes.Cake {
+		cake, err := repo.Create(ctx, cakes.RequestDto{Title: title, Description: description})
+		Expect(err).Should(Succeed())
+		if stars > 0 {
+			Expect(repo.AddRating(ctx, cake.ID, stars, 1)).Should(Succeed())
+		}
+		return cake
+	}
+
+	It("creates and gets a cake", func() {
+		created := create("O'Reilly's cheesecake", `100% "real"`, 4)
+		Expect(created.ID).Should(Equal(1))
+		Expect(created.CreatedAt.IsZero()).Should(BeFalse())
+
+		cake, err := repo.Get(ctx, created.ID)
+		Expect(err).Should(Succeed())
+		Expect(cake.ID).Should(Equal(1))
+		Expect(cake.Title).Should(Equal("O'Reilly's cheesecake"))
+		Expect(cake.Description).Should(Equal(`100% "real"`))
+		Expect(cake.Rating).Should(Equal(float64(4)))
+		Expect(cake.RatingCount).Should(Equal(1))
+		Expect(cake.Image).Should(BeNil())
+		Expect(cake.CreatedAt.IsZero()).Should(BeFalse())
+		Expect(cake.UpdatedAt).Should(BeNil())
+	})
+
+	It("returns ErrNotFound for a missing cake", func() {
+		cake, err := repo.Get(ctx, 42)
+		Expect(err).Should(MatchError(cakes.ErrNotFound))
+		Expect(cake).Should(BeNil())
+
+		_, err = repo.Update(ctx, cakes.UpdateRequestDto{ID: 42, Title: "Missing"})
+		Expect(err).Should(MatchError(cakes.ErrNotFound))
+
+		Expect(repo.AddRating(ctx, 42, 5, 1)).Should(MatchError(cakes.ErrNotFound))
+
+		Expect(repo.Delete(ctx, 42, 0)).Should(MatchError(cakes.ErrNotFound))
+		Expect(repo.Delete(ctx, 42, 1)).Should(MatchError(cakes.ErrNotFound))
+	})
+
+	It("writes only the version a write is conditioned on", func() {
+		created := create("Lemon cheesecake", "lemon", 0)
+		Expect(created.Version).Should(Equal(1))
+
+		updated, err := repo.Update(ctx, cakes.UpdateRequestDto{ID: created.ID, Title: "Lime cheesecake", Version: 1})
+		Expect(err).Should(Succeed())
+		Expect(updated.Version).Should(Equal(2))
+
+		_, err = repo.Update(ctx, cakes.UpdateRequestDto{ID: created.ID, Title: "Orange cheesecake", Version: 1})
+		Expect(err).Should(MatchError(cakes.ErrStale))
+		Expect(repo.Delete(ctx, created.ID, 1)).Should(MatchError(cakes.ErrStale))
+
+		// Reviews are not edits of the cake.
+		Expect(repo.AddRating(ctx, created.ID, 5, 1)).Should(Succeed())
+		updated, err = repo.Update(ctx, cakes.UpdateRequestDto{ID: created.ID, Title: "Orange cheesecake"})
+		Expect(err).Should(Succeed())
+		Expect(updated.Title).Should(Equal("Orange cheesecake"))
+		Expect(updated.Version).Should(Equal(3))
+
+		Expect(repo.Delete(ctx, created.ID, 3)).Should(Succeed())
+	})
+
+	It("lists ordered by rating then title with pagination", func() {
+		create("Lemon cheesecake", "lemon", 4)
+		create("Blueberry cheesecake", "blueberry", 5)
+		create("Apple cheesecake", "apple", 5)
+
+		res, total, err := repo.List(ctx, cakes.ListRequestDto{Limit: 2})
+		Expect(err).Should(Succeed())
+		Expect(total).Should(Equal(int64(3)))
+		Expect(res).Should(HaveLen(2))
+		Expect(res[0].Title).Should(Equal("Apple cheesecake"))
+		Expect(res[1].Title).Should(Equal("Blueberry cheesecake"))
+
+		res, _, err = repo.List(ctx, cakes.ListRequestDto{Limit: 2, Offset: 2})
+		Expect(err).Should(Succeed())
+		Expect(res).Should(HaveLen(1))
+		Expect(res[0].Title).Should(Equal("Lemon cheesecake"))
+
+		res, _, err = repo.List(ctx, cakes.ListRequestDto{Limit: 2, Offset: 5})
+		Expect(err).Should(Succeed())
+		Expect(res).Should(BeEmpty())
+	})
+
+	It("pages with a cursor without skips or duplicates", func() {
+		defaultSort, err := cakes.ParseSort("")
+		Expect(err).Should(Succeed())
+		create("Cake B", "", 4)
+		create("Cake A", "", 4)
+		create("Cake A", "", 4)
+		create("Cake C", "", 3)
+
+		res, total, err := repo.List(ctx, cakes.ListRequestDto{Limit: 2, SkipCount: true})
+		Expect(err).Should(Succeed())
+		Expect(total).Should(Equal(int64(-1)))
+		Expect(res).Should(HaveLen(2))
+		Expect([]int{res[0].ID, res[1].ID}).Should(Equal([]int{2, 3}))
+
+		// A cake inserted before the cursor must not shift the next page.
+		create("Cake 0", "", 5)
+
+		after, err := cakes.DecodeCursor(defaultSort, cakes.EncodeCursor(defaultSort, res[1]))
+		Expect(err).Should(Succeed())
+		res, _, err = repo.List(ctx, cakes.ListRequestDto{Limit: 2, After: after})
+		Expect(err).Should(Succeed())
+		Expect(res).Should(HaveLen(2))
+		Expect([]int{res[0].ID, res[1].ID}).Should(Equal([]int{1, 4}))
+
+		after, err = cakes.DecodeCursor(defaultSort, cakes.EncodeCursor(defaultSort, res[1]))
+		Expect(err).Should(Succeed())
+		res, _, err = repo.List(ctx, cakes.ListRequestDto{Limit: 2, After: after})
+		Expect(err).Should(Succeed())
+		Expect(res).Should(BeEmpty())
+	})
+
+	It("sorts by the requested fields with id as tiebreak", func() {
+		create("Banana", "", 2)
+		create("Apple", "", 3)
+		create("Banana", "", 5)
+
+		keys, err := cakes.ParseSort("title,-rating")
+		Expect(err).Should(Succeed())
+		res, _, err := repo.List(ctx, cakes.ListRequestDto{Limit: 10, SortKeys: keys})
+		Expect(err).Should(Succeed())
+		Expect([]int{res[0].ID, res[1].ID, res[2].ID}).Should(Equal([]int{2, 3, 1}))
+
+		after, err := cakes.DecodeCursor(keys, cakes.EncodeCursor(keys, res[1]))
+		Expect(err).Should(Succeed())
+		res, _, err = repo.List(ctx, cakes.ListRequestDto{Limit: 10, SortKeys: keys, After: after})
+		Expect(err).Should(Succeed())
+		Expect(res).Should(HaveLen(1))
+		Expect(res[0].ID).Should(Equal(1))
+
+		res, _, err = repo.List(ctx, cakes.ListRequestDto{Limit: 10, Sort: "-id"})
+		Expect(err).Should(Succeed())
+		Expect([]int{res[0].ID, res[1].ID, res[2].ID}).Should(Equal([]int{3, 2, 1}))
+	})
+
+	It("pages through ratings that are not exact decimals", func() {
+		for _, title := range []string{"Cake A", "Cake B", "Cake C"} {
+			cake := create(title, "", 0)
+			Expect(repo.AddRating(ctx, cake.ID, 13, 3)).Should(Succeed())
+		}
+
+		keys, err := cakes.ParseSort("")
+		Expect(err).Should(Succeed())
+		seen := []int{}
+		request := cakes.ListRequestDto{Limit: 1, SortKeys: keys, SkipCount: true}
+		for {
+			res, _, err := repo.List(ctx, request)
+			Expect(err).Should(Succeed())
+			if len(res) == 0 {
+				break
+			}
+			seen = append(seen, res[0].ID)
+			request.After, err = cakes.DecodeCursor(keys, cakes.EncodeCursor(keys, res[0]))
+			Expect(err).Should(Succeed())
+		}
+		Expect(seen).Should(Equal([]int{1, 2, 3}))
+	})
+
+	It("filters by rating range, creation time, image and ids", func() {
+		create("Plain", "", 1)
+		create("Lemon", "", 3)
+		create("Apple", "", 5)
+		_, err := repo.Update(ctx, cakes.UpdateRequestDto{ID: 2, Image: "https://example.com/lemon.jpg"})
+		Expect(err).Should(Succeed())
+
+		ratingMin, ratingMax := 2.0, 4.0
+		res, total, err := repo.List(ctx, cakes.ListRequestDto{Limit: 10, RatingMin: &ratingMin, RatingMax: &ratingMax})
+		Expect(err).Should(Succeed())
+		Expect(total).Should(Equal(int64(1)))
+		Expect(res[0].Title).Should(Equal("Lemon"))
+
+		hasImage := false
+		res, _, err = repo.List(ctx, cakes.ListRequestDto{Limit: 10, HasImage: &hasImage})
+		Expect(err).Should(Succeed())
+		Expect(res).Should(HaveLen(2))
+
+		res, _, err = repo.List(ctx, cakes.ListRequestDto{Limit: 10, IDs: "1,3,99"})
+		Expect(err).Should(Succeed())
+		Expect([]int{res[0].ID, res[1].ID}).Should(Equal([]int{3, 1}))
+
+		past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+		_, total, err = repo.List(ctx, cakes.ListRequestDto{Limit: 10, CreatedAfter: &past, CreatedBefore: &future})
+		Expect(err).Should(Succeed())
+		Expect(total).Should(Equal(int64(3)))
+
+		_, total, err = repo.List(ctx, cakes.ListRequestDto{Limit: 10, CreatedAfter: &future})
+		Expect(err).Should(Succeed())
+		Expect(total).Should(Equal(int64(0)))
+
+		_, _, err = repo.List(ctx, cakes.ListRequestDto{Limit: 10, RatingMin: &ratingMax, RatingMax: &ratingMin})
+		Expect(err).Should(MatchError(cakes.ErrValidation))
+
+		res, total, err = repo.List(ctx, cakes.ListRequestDto{Limit: 10, IDs: "1,2", Within: []query.IDs{selecting(2, 3)}})
+		Expect(err).Should(Succeed())
+		Expect(total).Should(Equal(int64(1)))
+		Expect(res[0].ID).Should(Equal(2))
+
+		_, total, err = repo.List(ctx, cakes.ListRequestDto{Limit: 10, Within: []query.IDs{selecting()}})
+		Expect(err).Should(Succeed())
+		Expect(total).Should(Equal(int64(0)))
+
+		res, total, err = repo.List(ctx, cakes.ListRequestDto{Limit: 10, Within: []query.IDs{selecting(1, 2), selecting(2, 3)}})
+		Expect(err).Should(Succeed())
+		Expect(total).Should(Equal(int64(1)))
+		Expect(res[0].ID).Should(Equal(2))
+
+		res, _, err = repo.List(ctx, cakes.ListRequestDto{Limit: 10, Without: []query.IDs{selecting(1), selecting(3)}})
+		Expect(err).Should(Succeed())
+		Expect(res).Should(HaveLen(1))
+		Expect(res[0].ID).Should(Equal(2))
+
+		_, total, err = repo.List(ctx, cakes.ListRequestDto{Limit: 10, Without: []query.IDs{selecting()}})
+		Expect(err).Should(Succeed())
+		Expect(total).Should(Equal(int64(3)))
+	})
+
+	It("counts facets over the filtered cakes", func() {
+		create("Plain", "", 0)
+		create("Lemon", "", 3)
+		create("Apple", "", 4)
+		create("Cherry", "", 5)
+		_, err := repo.Update(ctx, cakes.UpdateRequestDto{ID: 2, Image: "https://example.com/lemon.jpg"})
+		Expect(err).Should(Succeed())
+
+		facets, err := repo.Facets(ctx, cakes.ListRequestDto{Facets: "rating,has_image"})
+		Expect(err).Should(Succeed())
+		Expect(facets["rating"]).Should(Equal([]cakes.FacetBucket{
+			{Value: "unrated", Count: 1}, {Value: "1-2", Count: 0}, {Value: "2-3", Count: 0},
+			{Value: "3-4", Count: 1}, {Value: "4-5", Count: 2},
+		}))
+		Expect(facets["has_image"]).Should(Equal([]cakes.FacetBucket{{Value: "true", Count: 1}, {Value: "false", Count: 3}}))
+
+		ratingMin := 3.0
+		facets, err = repo.Facets(ctx, cakes.ListRequestDto{Facets: "has_image", RatingMin: &ratingMin})
+		Expect(err).Should(Succeed())
+		Expect(facets).Should(HaveLen(1))
+		Expect(facets["has_image"]).Should(Equal([]cakes.FacetBucket{{Value: "true", Count: 1}, {Value: "false", Count: 2}}))
+
+		_, err = repo.Facets(ctx, cakes.ListRequestDto{Facets: "flavour"})
+		Expect(err).Should(MatchError(cakes.ErrValidation))
+	})
+
+	It("searches titles and descriptions by relevance", func() {
+		create("Lemon cheesecake", "Tangy lemon curd", 5)
+		create("Blueberry cheesecake", "Fresh blueberries", 4)
+		create("Chocolate fudge cake", "Rich dark chocolate", 3)
+		create("Lemon drizzle", "Lemon sponge with lemon icing", 2)
+
+		res, total, err := repo.List(ctx, cakes.ListRequestDto{Q: "LEMON", Limit: 10})
+		Expect(err).Should(Succeed())
+		Expect(total).Should(Equal(int64(2)))
+		Expect([]int{res[0].ID, res[1].ID}).Should(Equal([]int{4, 1}))
+		Expect(*res[0].Score).Should(BeNumerically(">", *res[1].Score))
+		Expect(*res[1].Score).Should(BeNumerically(">", 0))
+
+		keys, err := cakes.ParseSort(cakes.RelevanceSort)
+		Expect(err).Should(Succeed())
+		after, err := cakes.DecodeCursor(keys, cakes.EncodeCursor(keys, res[0]))
+		Expect(err).Should(Succeed())
+		res, _, err = repo.List(ctx, cakes.ListRequestDto{Q: "lemon", Limit: 10, SortKeys: keys, After: after})
+		Expect(err).Should(Succeed())
+		Expect(res).Should(HaveLen(1))
+		Expect(res[0].ID).Should(Equal(1))
+
+		res, _, err = repo.List(ctx, cakes.ListRequestDto{Q: "cheesecake", Sort: "title", Limit: 10})
+		Expect(err).Should(Succeed())
+		Expect([]int{res[0].ID, res[1].ID}).Should(Equal([]int{2, 1}))
+
+		Expect(repo.Delete(ctx, 4, 0)).Should(Succeed())
+		res, _, err = repo.List(ctx, cakes.ListRequestDto{Q: "lemon", Limit: 10})
+		Expect(err).Should(Succeed())
+		Expect(res).Should(HaveLen(1))
+
+		res, total, err = repo.List(ctx, cakes.ListRequestDto{Q: "the", Limit: 10})
+		Expect(err).Should(Succeed())
+		Expect(total).Should(Equal(int64(0)))
+		Expect(res).Should(BeEmpty())
+
+		_, _, err = repo.List(ctx, cakes.ListRequestDto{Sort: "-score", Limit: 10})
+		Expect(err).Should(MatchError(cakes.ErrValidation))
+	})
+
+	It("suggests titles by prefix then rating", func() {
+		create("Lemon drizzle", "", 2)
+		create("Lemon cheesecake", "", 4)
+		create("Sicilian lemon tart", "", 5)
+		create("Carrot cake", "", 3)
+
+		res, err := repo.Suggest(ctx, "LEM", 5)
+		Expect(err).Should(Succeed())
+		Expect(res).Should(Equal([]cakes.Suggestion{
+			{ID: 2, Title: "Lemon cheesecake"},
+			{ID: 1, Title: "Lemon drizzle"},
+			{ID: 3, Title: "Sicilian lemon tart"},
+		}))
+
+		res, err = repo.Suggest(ctx, "lemon c", 1)
+		Expect(err).Should(Succeed())
+		Expect(res).Should(Equal([]cakes.Suggestion{{ID: 2, Title: "Lemon cheesecake"}}))
+
+		_, err = repo.Update(ctx, cakes.UpdateRequestDto{ID: 4, Title: "Lemon carrot cake"})
+		Expect(err).Should(Succeed())
+		Expect(repo.Delete(ctx, 2, 0)).Should(Succeed())
+		res, err = repo.Suggest(ctx, "lemon c", 5)
+		Expect(err).Should(Succeed())
+		Expect(res).Should(Equal([]cakes.Suggestion{{ID: 4, Title: "Lemon carrot cake"}}))
+
+		res, err = repo.Suggest(ctx, "car", 5)
+		Expect(err).Should(Succeed())
+		Expect(res).Should(Equal([]cakes.Suggestion{{ID: 4, Title: "Lemon carrot cake"}}))
+
+		res, err = repo.Suggest(ctx, "xyz", 5)
+		Expect(err).Should(Succeed())
+		Expect(res).Should(BeEmpty())
+	})
+
+	It("filters by title and description substrings literally", func() {
+		create("100% cocoa", "dark", 2)
+		create("1000 layers", "crepe", 3)
+
+		res, total, err := repo.List(ctx, cakes.ListRequestDto{Title: "0%", Limit: 10})
+		Expect(err).Should(Succeed())
+		Expect(total).Should(Equal(int64(1)))
+		Expect(res[0].Title).Should(Equal("100% cocoa"))
+
+		res, total, err = repo.List(ctx, cakes.ListRequestDto{Description: "rep", Limit: 10})
+		Expect(err).Should(Succeed())
+		Expect(total).Should(Equal(int64(1)))
+		Expect(res[0].Title).Should(Equal("1000 layers"))
+	})
+
+	It("updates only the given fields", func() {
+		create("Lemon cheesecake", "lemon", 4)
+		updated, err := repo.Update(ctx, cakes.UpdateRequestDto{ID: 1, Image: "https://example.com/lemon.jpg"})
+		Expect(err).Should(Succeed())
+		Expect(updated.Rating).Should(Equal(float64(4)))
+
+		cake, err := repo.Get(ctx, 1)
+		Expect(err).Should(Succeed())
+		Expect(cake.Title).Should(Equal("Lemon cheesecake"))
+		Expect(cake.Rating).Should(Equal(float64(4)))
+		Expect(*cake.Image).Should(Equal("https://example.com/lemon.jpg"))
+		Expect(cake.UpdatedAt).ShouldNot(BeNil())
+	})
+
+	It("averages the rating over the added reviews", func() {
+		create("Lemon cheesecake", "lemon", 5)
+		Expect(repo.AddRating(ctx, 1, 4, 1)).Should(Succeed())
+
+		cake, err := repo.Get(ctx, 1)
+		Expect(err).Should(Succeed())
+		Expect(cake.Rating).Should(Equal(4.5))
+		Expect(cake.RatingCount).Should(Equal(2))
+
+		Expect(repo.AddRating(ctx, 1, -5, -1)).Should(Succeed())
+		cake, err = repo.Get(ctx, 1)
+		Expect(err).Should(Succeed())
+		Expect(cake.Rating).Should(Equal(float64(4)))
+		Expect(cake.RatingCount).Should(Equal(1))
+
+		Expect(repo.AddRating(ctx, 1, -4, -1)).Should(Succeed())
+		cake, err = repo.Get(ctx, 1)
+		Expect(err).Should(Succeed())
+		Expect(cake.Rating).Should(Equal(float64(0)))
+		Expect(cake.RatingCount).Should(Equal(0))
+	})
+
+	It("deletes a cake", func() {
+		create("Lemon cheesecake", "lemon", 4)
+		Expect(repo.Delete(ctx, 1, 0)).Should(Succeed())
+		_, err := repo.Get(ctx, 1)
+		Expect(err).Should(MatchError(cakes.ErrNotFound))
+	})
+
+	It("handles concurrent writes", func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer GinkgoRecover()
+				create("Concurrent cake", "", 1)
+			}()
+		}
+		wg.Wait()
+
+		_, total, err := repo.List(ctx, cakes.ListRequestDto{Limit: 1})
+		Expect(err).Should(Succeed())
+		Expect(total).Should(Equal(int64(20)))
+	})
+})
+
+var _ = Describe("SQLite Search Index", func() {
+	It("is rebuilt from existing rows", func() {
+		db, err := storage.OpenSQLite(":memory:")
+		Expect(err).Should(Succeed())
+		defer db.Close()
+
+		repo, err := cakes.NewSQLiteRepository(db)
+		Expect(err).Should(Succeed())
+		for _, title := range []string{"Lemon drizzle", "Carrot cake", "Apple pie"} {
+			_, err = repo.Create(context.TODO(), cakes.RequestDto{Title: title})
+			Expect(err).Should(Succeed())
+		}
+
+		reopened, err := cakes.NewSQLiteRepository(db)
+		Expect(err).Should(Succeed())
+		res, _, err := reopened.List(context.TODO(), cakes.ListRequestDto{Q: "carrot", Limit: 10})
+		Expect(err).Should(Succeed())
+		Expect(res).Should(HaveLen(1))
+		Expect(res[0].Title).Should(Equal("Carrot cake"))
+	})
+})
+
+var _ = Describe("Cake Repository Transactions", func() {
+	var (
+		db   *sql.DB
+		repo cakes.RepoInterface
+		tx   storage.Transactor
+		ctx  = context.TODO()
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, err = storage.OpenSQLite(":memory:")
+		Expect(err).Should(Succeed())
+		repo = newCakeSQLiteRepository(db)
+		tx = storage.NewTransactor(db)
+		for _, title := range []string{"Lemon cake", "Lemon tart"} {
+			_, err := repo.Create(ctx, cakes.RequestDto{Title: title})
+			Expect(err).Should(Succeed())
+		}
+	})
+
+	AfterEach(func() {
+		db.Close()
+	})
+
+	suggested := func() []string {
+		suggestions, err := repo.Suggest(ctx, "lemon", 5)
+		Expect(err).Should(Succeed())
+		titles := []string{}
+		for _, suggestion := range suggestions {
+			titles = append(titles, suggestion.Title)
+		}
+		return titles
+	}
+
+	It("only indexes ratings once their transaction commits", func() {
+		err := tx.InTx(ctx, func(ctx context.Context) error {
+			Expect(repo.AddRating(ctx, 2, 5, 1)).Should(Succeed())
+			return errSomething
+		})
+		Expect(err).Should(MatchError(errSomething))
+		Expect(suggested()).Should(Equal([]string{"Lemon cake", "Lemon tart"}))
+
+		Expect(tx.InTx(ctx, func(ctx context.Context) error {
+			Expect(repo.AddRating(ctx, 2, 5, 1)).Should(Succeed())
+			Expect(suggested()).Should(Equal([]string{"Lemon cake", "Lemon tart"}))
+			return nil
+		})).Should(Succeed())
+		Expect(suggested()).Should(Equal([]string{"Lemon tart", "Lemon cake"}))
+	})
+
+	It("keeps a cake whose links fail to delete", func() {
+		mockCtrl := gomock.NewController(GinkgoT())
+		defer mockCtrl.Finish()
+		reviewsRepo := mock_reviews.NewMockRepoInterface(mockCtrl)
+		reviewsRepo.EXPECT().DeleteForCake(gomock.Any(), 2).Return(errSomething)
+		handler := cakes.NewHandler(repo, cakes.WithReviews(reviewsRepo), cakes.WithTransactions(tx))
+
+		req := httptest.NewRequest(http.MethodDelete, "/", nil)
+		req.Header.Set(cakes.HeaderIfMatch, "*")
+		c := echo.New().NewContext(req, httptest.NewRecorder())
+		c.SetParamNames("id")
+		c.SetParamValues("2")
+		Expect(handler.Delete(c)).Should(MatchError(errSomething))
+
+		_, err := repo.Get(ctx, 2)
+		Expect(err).Should(Succeed())
+		Expect(suggested()).Should(Equal([]string{"Lemon cake", "Lemon tart"}))
+	})
+})
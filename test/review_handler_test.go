@@ -0,0 +1,223 @@
+package test
+
+import (
+	"cake-store/internal/auth"
+	"cake-store/internal/cakes"
+	"cake-store/internal/middlewares"
+	"cake-store/internal/reviews"
+	"cake-store/internal/storage"
+	mock_repository "cake-store/mocks/repository"
+	mock_reviews "cake-store/mocks/reviews"
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test Review Service", func() {
+	var (
+		e                *echo.Echo
+		mockCtrl         *gomock.Controller
+		serviceInterface reviews.SvcInterface
+		repo             *mock_reviews.MockRepoInterface
+		cakesRepo        *mock_repository.MockRepoInterface
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		repo = mock_reviews.NewMockRepoInterface(mockCtrl)
+		cakesRepo = mock_repository.NewMockRepoInterface(mockCtrl)
+		serviceInterface = reviews.NewHandler(repo, cakesRepo, storage.NewTransactor(nil))
+		e = echo.New()
+		middlewares.UseCustomValidatorHandler(e)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	post := func(body string) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		return rec, serviceInterface.Create(c)
+	}
+
+	moderate := func(status string, review reviews.Review) error {
+		repo.EXPECT().Get(gomock.Any(), 3).Return(&review, nil)
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("3")
+		return serviceInterface.Transition(status)(c)
+	}
+
+	It("post a pending review of a cake", func() {
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1}, nil)
+		repo.EXPECT().Create(gomock.Any(), reviews.RequestDto{CakeID: 1, Author: "Ana", Stars: 5, Text: "Lovely"}).
+			Return(&reviews.Review{ID: 3, CakeID: 1, Author: "Ana", Stars: 5, Status: reviews.StatusPending}, nil)
+		rec, err := post(`{"author": "Ana", "stars": 5, "text": "Lovely"}`)
+		Expect(err).Should(Succeed())
+		Expect(rec.Code).Should(Equal(http.StatusCreated))
+		Expect(rec.Header().Get(echo.HeaderLocation)).Should(Equal("/reviews/3"))
+	})
+
+	It("sign reviews of customers with their name", func() {
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1}, nil).Times(2)
+		repo.EXPECT().Create(gomock.Any(), reviews.RequestDto{CakeID: 1, Author: "Sam", Stars: 5}).Return(&reviews.Review{ID: 3}, nil)
+		repo.EXPECT().Create(gomock.Any(), reviews.RequestDto{CakeID: 1, Author: "kim", Stars: 5}).Return(&reviews.Review{ID: 4}, nil)
+		review := func(principal *auth.Principal) error {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"author": "Ana", "stars": 5}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, httptest.NewRecorder())
+			auth.SetPrincipal(c, principal)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			return serviceInterface.Create(c)
+		}
+		Expect(review(&auth.Principal{UserID: 5, Name: "Sam", Role: auth.RoleCustomer})).Should(Succeed())
+		Expect(review(&auth.Principal{UserID: 6, Email: "kim@example.com", Role: auth.RoleCustomer})).Should(Succeed())
+	})
+
+	It("return error on stars out of range", func() {
+		_, err := post(`{"author": "Ana", "stars": 6}`)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("return error on a missing cake", func() {
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(nil, cakes.ErrNotFound)
+		_, err := post(`{"author": "Ana", "stars": 5}`)
+		Expect(err).Should(MatchError(cakes.ErrNotFound))
+	})
+
+	It("add approved reviews to the cake rating", func() {
+		review := reviews.Review{ID: 3, CakeID: 1, Stars: 4, Status: reviews.StatusPending}
+		approved := review
+		approved.Status = reviews.StatusApproved
+		repo.EXPECT().Transition(gomock.Any(), 3, reviews.StatusPending, reviews.StatusApproved).Return(&approved, nil)
+		cakesRepo.EXPECT().AddRating(gomock.Any(), 1, 4, 1).Return(nil)
+		Expect(moderate(reviews.StatusApproved, review)).Should(Succeed())
+	})
+
+	It("take rejected reviews out of the cake rating", func() {
+		review := reviews.Review{ID: 3, CakeID: 1, Stars: 4, Status: reviews.StatusApproved}
+		rejected := review
+		rejected.Status = reviews.StatusRejected
+		repo.EXPECT().Transition(gomock.Any(), 3, reviews.StatusApproved, reviews.StatusRejected).Return(&rejected, nil)
+		cakesRepo.EXPECT().AddRating(gomock.Any(), 1, -4, -1).Return(nil)
+		Expect(moderate(reviews.StatusRejected, review)).Should(Succeed())
+	})
+
+	It("leave the rating alone when rejecting a pending review", func() {
+		review := reviews.Review{ID: 3, CakeID: 1, Stars: 4, Status: reviews.StatusPending}
+		rejected := review
+		rejected.Status = reviews.StatusRejected
+		repo.EXPECT().Transition(gomock.Any(), 3, reviews.StatusPending, reviews.StatusRejected).Return(&rejected, nil)
+		Expect(moderate(reviews.StatusRejected, review)).Should(Succeed())
+	})
+
+	It("reject moderating a review twice", func() {
+		err := moderate(reviews.StatusApproved, reviews.Review{ID: 3, CakeID: 1, Stars: 4, Status: reviews.StatusApproved})
+		Expect(err).Should(MatchError(reviews.ErrConflict))
+	})
+
+	It("take deleted approved reviews out of the cake rating", func() {
+		repo.EXPECT().Get(gomock.Any(), 3).Return(&reviews.Review{ID: 3, CakeID: 1, Stars: 5, Status: reviews.StatusApproved}, nil)
+		repo.EXPECT().Delete(gomock.Any(), 3, reviews.StatusApproved).Return(nil)
+		cakesRepo.EXPECT().AddRating(gomock.Any(), 1, -5, -1).Return(cakes.ErrNotFound)
+		req := httptest.NewRequest(http.MethodDelete, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("3")
+		Expect(serviceInterface.Delete(c)).Should(Succeed())
+	})
+
+	It("list the approved reviews of a cake", func() {
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1}, nil)
+		repo.EXPECT().List(gomock.Any(), reviews.ListRequestDto{CakeID: 1, Status: reviews.StatusApproved, Limit: 20}).Return([]reviews.Review{}, nil)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		Expect(serviceInterface.List(c)).Should(Succeed())
+	})
+
+	It("list only approved reviews of a cake to the public", func() {
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1}, nil).Times(3)
+		repo.EXPECT().List(gomock.Any(), reviews.ListRequestDto{CakeID: 1, Status: reviews.StatusApproved, Limit: 20}).Return([]reviews.Review{}, nil).Times(2)
+		repo.EXPECT().List(gomock.Any(), reviews.ListRequestDto{CakeID: 1, Status: reviews.StatusRejected, Limit: 20}).Return([]reviews.Review{}, nil)
+		list := func(principal *auth.Principal) error {
+			req := httptest.NewRequest(http.MethodGet, "/?status=rejected", nil)
+			c := e.NewContext(req, httptest.NewRecorder())
+			if principal != nil {
+				auth.SetPrincipal(c, principal)
+			}
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			return serviceInterface.List(c)
+		}
+		Expect(list(nil)).Should(Succeed())
+		Expect(list(&auth.Principal{UserID: 2, Role: auth.RoleCustomer})).Should(Succeed())
+		Expect(list(&auth.Principal{UserID: 3, Role: auth.RoleStaff})).Should(Succeed())
+	})
+
+	It("list the pending reviews for moderation", func() {
+		repo.EXPECT().List(gomock.Any(), reviews.ListRequestDto{Status: reviews.StatusPending, Limit: 20}).Return([]reviews.Review{}, nil)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		Expect(serviceInterface.List(c)).Should(Succeed())
+	})
+})
+
+var _ = Describe("Test Review Moderation Transactions", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		serviceInterface reviews.SvcInterface
+		repo             reviews.RepoInterface
+		cakesRepo        *mock_repository.MockRepoInterface
+		cleanup          func()
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		db, err := storage.OpenSQLite(":memory:")
+		Expect(err).Should(Succeed())
+		cleanup = func() { db.Close() }
+		repo = reviews.NewRepository(db)
+		cakesRepo = mock_repository.NewMockRepoInterface(mockCtrl)
+		serviceInterface = reviews.NewHandler(repo, cakesRepo, storage.NewTransactor(db))
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+		cleanup()
+	})
+
+	It("leave the review as it was when the rating cannot be updated", func() {
+		created, err := repo.Create(context.TODO(), reviews.RequestDto{CakeID: 1, Author: "Ana", Stars: 4})
+		Expect(err).Should(Succeed())
+		cakesRepo.EXPECT().AddRating(gomock.Any(), 1, 4, 1).Return(errSomething)
+
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodPost, "/", nil), httptest.NewRecorder())
+		c.SetParamNames("id")
+		c.SetParamValues(strconv.Itoa(created.ID))
+		Expect(serviceInterface.Transition(reviews.StatusApproved)(c)).Should(MatchError(errSomething))
+
+		review, err := repo.Get(context.TODO(), created.ID)
+		Expect(err).Should(Succeed())
+		Expect(review.Status).Should(Equal(reviews.StatusPending))
+	})
+})
@@ -0,0 +1,114 @@
+package test
+
+import (
+	"cake-store/internal/reviews"
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = describeConformance("Review Repository", reviews.NewMemoryRepository, reviews.NewRepository, func(subject *repoUnderTest[reviews.RepoInterface]) {
+	var (
+		repo reviews.RepoInterface
+		ctx  = context.TODO()
+	)
+
+	BeforeEach(func() {
+		repo = subject.Repo
+	})
+
+	post := func(cakeID, stars int) *reviews.Review {
+		review, err := repo.Create(ctx, reviews.RequestDto{CakeID: cakeID, Author: "Ana", Stars: stars, Text: "Light and not too sweet."})
+		Expect(err).Should(Succeed())
+		return review
+	}
+
+	It("stores pending reviews", func() {
+		created := post(1, 5)
+		Expect(created.ID).Should(Equal(1))
+		Expect(created.Status).Should(Equal(reviews.StatusPending))
+
+		review, err := repo.Get(ctx, 1)
+		Expect(err).Should(Succeed())
+		Expect(review.Stars).Should(Equal(5))
+		Expect(review.Text).Should(Equal("Light and not too sweet."))
+
+		_, err = repo.Get(ctx, 9)
+		Expect(err).Should(MatchError(reviews.ErrNotFound))
+	})
+
+	It("lists the reviews of a cake by status, newest first", func() {
+		post(1, 5)
+		post(2, 3)
+		post(1, 4)
+		_, err := repo.Transition(ctx, 3, reviews.StatusPending, reviews.StatusApproved)
+		Expect(err).Should(Succeed())
+
+		list, err := repo.List(ctx, reviews.ListRequestDto{CakeID: 1})
+		Expect(err).Should(Succeed())
+		Expect([]int{list[0].ID, list[1].ID}).Should(Equal([]int{3, 1}))
+
+		list, err = repo.List(ctx, reviews.ListRequestDto{CakeID: 1, Status: reviews.StatusApproved})
+		Expect(err).Should(Succeed())
+		Expect(list).Should(HaveLen(1))
+		Expect(list[0].ID).Should(Equal(3))
+
+		list, err = repo.List(ctx, reviews.ListRequestDto{Status: reviews.StatusPending, Limit: 1, Offset: 1})
+		Expect(err).Should(Succeed())
+		Expect(list).Should(HaveLen(1))
+		Expect(list[0].ID).Should(Equal(1))
+	})
+
+	It("moderates and deletes reviews only from the status they are in", func() {
+		post(1, 5)
+
+		approved, err := repo.Transition(ctx, 1, reviews.StatusPending, reviews.StatusApproved)
+		Expect(err).Should(Succeed())
+		Expect(approved.Status).Should(Equal(reviews.StatusApproved))
+		Expect(approved.UpdatedAt).ShouldNot(BeNil())
+
+		_, err = repo.Transition(ctx, 1, reviews.StatusPending, reviews.StatusRejected)
+		Expect(err).Should(MatchError(reviews.ErrConflict))
+		_, err = repo.Transition(ctx, 9, reviews.StatusPending, reviews.StatusApproved)
+		Expect(err).Should(MatchError(reviews.ErrNotFound))
+
+		Expect(repo.Delete(ctx, 1, reviews.StatusPending)).Should(MatchError(reviews.ErrConflict))
+		Expect(repo.Delete(ctx, 1, reviews.StatusApproved)).Should(Succeed())
+		Expect(repo.Delete(ctx, 1, reviews.StatusApproved)).Should(MatchError(reviews.ErrNotFound))
+	})
+
+	It("deletes the reviews of a cake", func() {
+		post(1, 5)
+		post(2, 4)
+		Expect(repo.DeleteForCake(ctx, 1)).Should(Succeed())
+
+		list, err := repo.List(ctx, reviews.ListRequestDto{})
+		Expect(err).Should(Succeed())
+		Expect(list).Should(HaveLen(1))
+		Expect(list[0].CakeID).Should(Equal(2))
+	})
+})
+
+var _ = Describe("Review Moderation", func() {
+	review := reviews.Review{Stars: 4, Status: reviews.StatusPending}
+
+	It("only lets moderated reviews swap", func() {
+		Expect(reviews.CanTransition(review, reviews.StatusApproved)).Should(Succeed())
+		Expect(reviews.CanTransition(review, reviews.StatusPending)).Should(MatchError(reviews.ErrConflict))
+		approved := reviews.Review{Status: reviews.StatusApproved}
+		Expect(reviews.CanTransition(approved, reviews.StatusApproved)).Should(MatchError(reviews.ErrConflict))
+		Expect(reviews.CanTransition(approved, reviews.StatusRejected)).Should(Succeed())
+	})
+
+	It("counts only approved reviews towards the rating", func() {
+		stars, count := reviews.RatingChange(review, reviews.StatusPending, reviews.StatusApproved)
+		Expect([]int{stars, count}).Should(Equal([]int{4, 1}))
+		stars, count = reviews.RatingChange(review, reviews.StatusApproved, reviews.StatusRejected)
+		Expect([]int{stars, count}).Should(Equal([]int{-4, -1}))
+		stars, count = reviews.RatingChange(review, reviews.StatusApproved, "")
+		Expect([]int{stars, count}).Should(Equal([]int{-4, -1}))
+		stars, count = reviews.RatingChange(review, reviews.StatusPending, reviews.StatusRejected)
+		Expect([]int{stars, count}).Should(Equal([]int{0, 0}))
+	})
+})
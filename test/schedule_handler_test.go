@@ -0,0 +1,122 @@
+package test
+
+import (
+	"cake-store/internal/cakes"
+	"cake-store/internal/middlewares"
+	"cake-store/internal/scheduling"
+	mock_repository "cake-store/mocks/repository"
+	mock_scheduling "cake-store/mocks/scheduling"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test Scheduling Service", func() {
+	var (
+		e                *echo.Echo
+		mockCtrl         *gomock.Controller
+		serviceInterface scheduling.SvcInterface
+		repo             *mock_scheduling.MockRepoInterface
+		cakesRepo        *mock_repository.MockRepoInterface
+		day              string
+		hours            scheduling.HoursRequestDto
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		repo = mock_scheduling.NewMockRepoInterface(mockCtrl)
+		cakesRepo = mock_repository.NewMockRepoInterface(mockCtrl)
+		serviceInterface = scheduling.NewHandler(repo, scheduling.NewScheduler(repo), cakesRepo)
+		e = echo.New()
+		middlewares.UseCustomValidatorHandler(e)
+		day, hours = scheduleDay(3, "09:00", "11:00", 2)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	send := func(method, target, body string, handler echo.HandlerFunc) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		return rec, handler(c)
+	}
+
+	// open expects the scheduler to work out the slots of day.
+	open := func(cakeIDs []int, booked map[string]int) {
+		repo.EXPECT().GetHoliday(gomock.Any(), day).Return(nil, scheduling.ErrHolidayNotFound)
+		repo.EXPECT().Hours(gomock.Any()).Return(hours.Hours, nil)
+		repo.EXPECT().LeadTimes(gomock.Any(), cakeIDs).Return(map[int]int{}, nil)
+		repo.EXPECT().Booked(gomock.Any(), day).Return(booked, nil)
+	}
+
+	It("list the slots of a day for a cake", func() {
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1}, nil)
+		open([]int{1}, map[string]int{"10:00": 2})
+		rec, err := send(http.MethodGet, "/slots?cake_id=1&date="+day, "", serviceInterface.Slots)
+		Expect(err).Should(Succeed())
+		Expect(rec.Code).Should(Equal(http.StatusOK))
+		Expect(rec.Body.String()).Should(ContainSubstring(`"start":"09:00","end":"10:00","capacity":2,"booked":0,"available":2`))
+		Expect(rec.Body.String()).Should(ContainSubstring(`"start":"10:00","end":"11:00","capacity":2,"booked":2,"available":0`))
+	})
+
+	It("return not found for the slots of an unknown cake", func() {
+		cakesRepo.EXPECT().Get(gomock.Any(), 9).Return(nil, cakes.ErrNotFound)
+		_, err := send(http.MethodGet, "/slots?cake_id=9&date="+day, "", serviceInterface.Slots)
+		Expect(err).Should(MatchError(cakes.ErrNotFound))
+	})
+
+	It("return error on slots without a date", func() {
+		_, err := send(http.MethodGet, "/slots?cake_id=1", "", serviceInterface.Slots)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("book a slot", func() {
+		open(nil, map[string]int{})
+		repo.EXPECT().Book(gomock.Any(), scheduling.Slot{Day: day, Start: "09:00", End: "10:00", Capacity: 2, Available: 2}).
+			Return(&scheduling.Booking{ID: 3, Day: day, Start: "09:00", Status: scheduling.StatusBooked}, nil)
+		rec, err := send(http.MethodPost, "/", fmt.Sprintf(`{"day": "%s", "start": "09:00"}`, day), serviceInterface.Book)
+		Expect(err).Should(Succeed())
+		Expect(rec.Code).Should(Equal(http.StatusCreated))
+		Expect(rec.Header().Get(echo.HeaderLocation)).Should(Equal("/bookings/3"))
+	})
+
+	It("return conflict when the slot is taken", func() {
+		open(nil, map[string]int{"09:00": 1})
+		repo.EXPECT().Book(gomock.Any(), gomock.Any()).Return(nil, scheduling.ErrFull)
+		_, err := send(http.MethodPost, "/", fmt.Sprintf(`{"day": "%s", "start": "09:00"}`, day), serviceInterface.Book)
+		Expect(err).Should(MatchError(scheduling.ErrConflict))
+	})
+
+	It("return error on a time the store is closed", func() {
+		open(nil, map[string]int{})
+		_, err := send(http.MethodPost, "/", fmt.Sprintf(`{"day": "%s", "start": "19:00"}`, day), serviceInterface.Book)
+		Expect(err).Should(MatchError(scheduling.ErrValidation))
+	})
+
+	It("return error on invalid opening hours", func() {
+		_, err := send(http.MethodPut, "/", `{"hours": [{"weekday": 7, "opens": "09:00", "closes": "18:00", "capacity": 4}]}`, serviceInterface.SetHours)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("set the lead time of a cake", func() {
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1}, nil)
+		repo.EXPECT().SetLeadTime(gomock.Any(), scheduling.LeadTimeRequestDto{CakeID: 1, Hours: 48}).Return(nil)
+		req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"hours": 48}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		Expect(serviceInterface.SetLeadTime(c)).Should(Succeed())
+		Expect(rec.Body.String()).Should(ContainSubstring(`"hours":48`))
+	})
+})
@@ -0,0 +1,235 @@
+package test
+
+import (
+	"cake-store/internal/scheduling"
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// scheduleDay is a day far enough ahead for its slots to be bookable
+// whatever the time of day, and the hours the store opens on it.
+func scheduleDay(days int, opens, closes string, capacity int) (string, scheduling.HoursRequestDto) {
+	date := time.Now().UTC().AddDate(0, 0, days)
+	return date.Format(scheduling.DayLayout), scheduling.HoursRequestDto{Hours: []scheduling.Hours{
+		{Weekday: int(date.Weekday()), Opens: opens, Closes: closes, Capacity: capacity},
+	}}
+}
+
+var _ = describeConformance("Schedule Repository", scheduling.NewMemoryRepository, scheduling.NewRepository, func(subject *repoUnderTest[scheduling.RepoInterface]) {
+	var (
+		repo scheduling.RepoInterface
+		ctx  = context.TODO()
+	)
+
+	BeforeEach(func() {
+		repo = subject.Repo
+	})
+
+	It("replaces the opening hours of the week", func() {
+		_, err := repo.SetHours(ctx, scheduling.HoursRequestDto{Hours: []scheduling.Hours{
+			{Weekday: 6, Opens: "10:00", Closes: "14:00", Capacity: 2},
+			{Weekday: 1, Opens: "09:00", Closes: "18:00", Capacity: 4},
+		}})
+		Expect(err).Should(Succeed())
+		hours, err := repo.Hours(ctx)
+		Expect(err).Should(Succeed())
+		Expect([]int{hours[0].Weekday, hours[1].Weekday}).Should(Equal([]int{1, 6}))
+
+		_, err = repo.SetHours(ctx, scheduling.HoursRequestDto{Hours: []scheduling.Hours{{Weekday: 2, Opens: "09:00", Closes: "09:30", Capacity: 1}}})
+		Expect(err).Should(MatchError(scheduling.ErrValidation))
+		_, err = repo.SetHours(ctx, scheduling.HoursRequestDto{Hours: []scheduling.Hours{
+			{Weekday: 2, Opens: "09:00", Closes: "12:00", Capacity: 1},
+			{Weekday: 2, Opens: "13:00", Closes: "16:00", Capacity: 1},
+		}})
+		Expect(err).Should(MatchError(scheduling.ErrValidation))
+
+		_, err = repo.SetHours(ctx, scheduling.HoursRequestDto{})
+		Expect(err).Should(Succeed())
+		hours, err = repo.Hours(ctx)
+		Expect(err).Should(Succeed())
+		Expect(hours).Should(BeEmpty())
+	})
+
+	It("stores holidays and lead times", func() {
+		_, err := repo.SetHoliday(ctx, scheduling.HolidayRequestDto{Day: "2026-12-25", Name: "Christmas"})
+		Expect(err).Should(Succeed())
+		_, err = repo.SetHoliday(ctx, scheduling.HolidayRequestDto{Day: "2026-12-25", Name: "Christmas Day"})
+		Expect(err).Should(Succeed())
+		holidays, err := repo.Holidays(ctx)
+		Expect(err).Should(Succeed())
+		Expect(holidays).Should(Equal([]scheduling.Holiday{{Day: "2026-12-25", Name: "Christmas Day"}}))
+		Expect(repo.DeleteHoliday(ctx, "2026-12-25")).Should(Succeed())
+		Expect(repo.DeleteHoliday(ctx, "2026-12-25")).Should(MatchError(scheduling.ErrHolidayNotFound))
+
+		Expect(repo.SetLeadTime(ctx, scheduling.LeadTimeRequestDto{CakeID: 1, Hours: 48})).Should(Succeed())
+		Expect(repo.SetLeadTime(ctx, scheduling.LeadTimeRequestDto{CakeID: 2, Hours: 24})).Should(Succeed())
+		Expect(repo.SetLeadTime(ctx, scheduling.LeadTimeRequestDto{CakeID: 2, Hours: 0})).Should(Succeed())
+		leadTimes, err := repo.LeadTimes(ctx, []int{1, 2, 3})
+		Expect(err).Should(Succeed())
+		Expect(leadTimes).Should(Equal(map[int]int{1: 48}))
+		Expect(repo.DeleteForCake(ctx, 1)).Should(Succeed())
+		leadTimes, err = repo.LeadTimes(ctx, []int{1})
+		Expect(err).Should(Succeed())
+		Expect(leadTimes).Should(BeEmpty())
+	})
+
+	It("books and releases places in a slot", func() {
+		slot := scheduling.Slot{Day: "2026-10-20", Start: "09:00", Capacity: 1}
+		booking, err := repo.Book(ctx, slot)
+		Expect(err).Should(Succeed())
+		Expect(booking.Status).Should(Equal(scheduling.StatusBooked))
+		_, err = repo.Book(ctx, slot)
+		Expect(err).Should(MatchError(scheduling.ErrFull))
+
+		booked, err := repo.Booked(ctx, "2026-10-20")
+		Expect(err).Should(Succeed())
+		Expect(booked).Should(Equal(map[string]int{"09:00": 1}))
+
+		released, err := repo.Release(ctx, booking.ID)
+		Expect(err).Should(Succeed())
+		Expect(released.Status).Should(Equal(scheduling.StatusReleased))
+		Expect(released.UpdatedAt).ShouldNot(BeNil())
+		_, err = repo.Release(ctx, booking.ID)
+		Expect(err).Should(MatchError(scheduling.ErrConflict))
+		_, err = repo.Release(ctx, 9)
+		Expect(err).Should(MatchError(scheduling.ErrNotFound))
+
+		_, err = repo.Book(ctx, slot)
+		Expect(err).Should(Succeed())
+	})
+
+	It("never books more places than a slot has under concurrent requests", func() {
+		slot := scheduling.Slot{Day: "2026-10-20", Start: "09:00", Capacity: 3}
+
+		var (
+			wg     sync.WaitGroup
+			mu     sync.Mutex
+			booked int
+		)
+		for n := 0; n < 20; n++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				if _, err := repo.Book(ctx, slot); err == nil {
+					mu.Lock()
+					booked++
+					mu.Unlock()
+				} else {
+					Expect(err).Should(MatchError(scheduling.ErrFull))
+				}
+			}()
+		}
+		wg.Wait()
+
+		Expect(booked).Should(Equal(3))
+		counts, err := repo.Booked(ctx, "2026-10-20")
+		Expect(err).Should(Succeed())
+		Expect(counts["09:00"]).Should(Equal(3))
+	})
+})
+
+var _ = Describe("Scheduler", func() {
+	var (
+		repo      scheduling.RepoInterface
+		scheduler scheduling.Scheduler
+		ctx       = context.TODO()
+	)
+
+	BeforeEach(func() {
+		repo = scheduling.NewMemoryRepository()
+		scheduler = scheduling.NewScheduler(repo)
+	})
+
+	It("lists the hourly slots of an open day with the places left", func() {
+		day, hours := scheduleDay(3, "09:00", "12:30", 2)
+		_, err := repo.SetHours(ctx, hours)
+		Expect(err).Should(Succeed())
+		_, err = scheduler.Book(ctx, scheduling.BookRequestDto{Day: day, Start: "10:00"})
+		Expect(err).Should(Succeed())
+
+		slots, err := scheduler.Slots(ctx, day, nil)
+		Expect(err).Should(Succeed())
+		Expect(slots).Should(Equal([]scheduling.Slot{
+			{Day: day, Start: "09:00", End: "10:00", Capacity: 2, Booked: 0, Available: 2},
+			{Day: day, Start: "10:00", End: "11:00", Capacity: 2, Booked: 1, Available: 1},
+			{Day: day, Start: "11:00", End: "12:00", Capacity: 2, Booked: 0, Available: 2},
+		}))
+	})
+
+	It("has no slots on closed days and holidays", func() {
+		day, hours := scheduleDay(3, "09:00", "12:00", 2)
+		_, err := repo.SetHours(ctx, hours)
+		Expect(err).Should(Succeed())
+		closed, _ := scheduleDay(4, "09:00", "12:00", 2)
+		slots, err := scheduler.Slots(ctx, closed, nil)
+		Expect(err).Should(Succeed())
+		Expect(slots).Should(BeEmpty())
+
+		_, err = repo.SetHoliday(ctx, scheduling.HolidayRequestDto{Day: day})
+		Expect(err).Should(Succeed())
+		slots, err = scheduler.Slots(ctx, day, nil)
+		Expect(err).Should(Succeed())
+		Expect(slots).Should(BeEmpty())
+		_, err = scheduler.Book(ctx, scheduling.BookRequestDto{Day: day, Start: "09:00"})
+		Expect(err).Should(MatchError(scheduling.ErrValidation))
+	})
+
+	It("leaves out the slots within the longest lead time of the cakes", func() {
+		day, hours := scheduleDay(2, "00:00", "23:00", 1)
+		_, err := repo.SetHours(ctx, hours)
+		Expect(err).Should(Succeed())
+		Expect(repo.SetLeadTime(ctx, scheduling.LeadTimeRequestDto{CakeID: 1, Hours: 12})).Should(Succeed())
+		Expect(repo.SetLeadTime(ctx, scheduling.LeadTimeRequestDto{CakeID: 2, Hours: 72})).Should(Succeed())
+
+		slots, err := scheduler.Slots(ctx, day, []int{1})
+		Expect(err).Should(Succeed())
+		Expect(slots).Should(HaveLen(23))
+		slots, err = scheduler.Slots(ctx, day, []int{1, 2})
+		Expect(err).Should(Succeed())
+		Expect(slots).Should(BeEmpty())
+		_, err = scheduler.Book(ctx, scheduling.BookRequestDto{Day: day, Start: "12:00", CakeIDs: []int{2}})
+		Expect(err).Should(MatchError(scheduling.ErrValidation))
+	})
+
+	It("works out the slots on the clock of the shop", func() {
+		// Fourteen hours ahead of UTC, so the slots in UTC would be far off.
+		location, err := time.LoadLocation("Pacific/Kiritimati")
+		Expect(err).Should(Succeed())
+		scheduler = scheduling.NewScheduler(repo, scheduling.WithLocation(location))
+		earliest := time.Now().In(location).Add(24 * time.Hour)
+		day := earliest.Format(scheduling.DayLayout)
+		_, err = repo.SetHours(ctx, scheduling.HoursRequestDto{Hours: []scheduling.Hours{
+			{Weekday: int(earliest.Weekday()), Opens: "00:00", Closes: "23:00", Capacity: 1},
+		}})
+		Expect(err).Should(Succeed())
+		Expect(repo.SetLeadTime(ctx, scheduling.LeadTimeRequestDto{CakeID: 1, Hours: 24})).Should(Succeed())
+
+		slots, err := scheduler.Slots(ctx, day, []int{1})
+		Expect(err).Should(Succeed())
+		first := earliest.Hour()
+		if earliest.Minute() > 0 || earliest.Second() > 0 || earliest.Nanosecond() > 0 {
+			first++
+		}
+		if first > 22 {
+			Expect(slots).Should(BeEmpty())
+			return
+		}
+		Expect(slots).Should(HaveLen(23 - first))
+		Expect(slots[0].Start).Should(Equal(time.Date(2000, 1, 1, first, 0, 0, 0, time.UTC).Format(scheduling.TimeLayout)))
+	})
+
+	It("refuses a booking for a full slot", func() {
+		day, hours := scheduleDay(3, "09:00", "10:00", 1)
+		_, err := repo.SetHours(ctx, hours)
+		Expect(err).Should(Succeed())
+		_, err = scheduler.Book(ctx, scheduling.BookRequestDto{Day: day, Start: "09:00"})
+		Expect(err).Should(Succeed())
+		_, err = scheduler.Book(ctx, scheduling.BookRequestDto{Day: day, Start: "09:00"})
+		Expect(err).Should(MatchError(scheduling.ErrFull))
+	})
+})
@@ -0,0 +1,228 @@
+package test
+
+import (
+	"cake-store/internal/cakes"
+	"cake-store/internal/middlewares"
+	"cake-store/internal/money"
+	"cake-store/internal/query"
+	"cake-store/internal/stores"
+	"cake-store/internal/variants"
+	mock_repository "cake-store/mocks/repository"
+	mock_stores "cake-store/mocks/stores"
+	mock_variants "cake-store/mocks/variants"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test Store Service", func() {
+	var (
+		e                *echo.Echo
+		mockCtrl         *gomock.Controller
+		serviceInterface stores.SvcInterface
+		repo             *mock_stores.MockRepoInterface
+		cakesRepo        *mock_repository.MockRepoInterface
+		variantsRepo     *mock_variants.MockRepoInterface
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		repo = mock_stores.NewMockRepoInterface(mockCtrl)
+		cakesRepo = mock_repository.NewMockRepoInterface(mockCtrl)
+		variantsRepo = mock_variants.NewMockRepoInterface(mockCtrl)
+		serviceInterface = stores.NewHandler(repo, stores.NewMenu(repo, variantsRepo), cakesRepo)
+		e = echo.New()
+		middlewares.UseCustomValidatorHandler(e)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	send := func(method, body string, handler echo.HandlerFunc) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(method, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id", "cake_id")
+		c.SetParamValues("2", "1")
+		return rec, handler(c)
+	}
+
+	It("create store", func() {
+		repo.EXPECT().Create(gomock.Any(), stores.RequestDto{Name: "Riverside"}).Return(&stores.Store{ID: 2, Name: "Riverside", Slug: "riverside"}, nil)
+		rec, err := send(http.MethodPost, `{"name": "Riverside"}`, serviceInterface.Create)
+		Expect(err).Should(Succeed())
+		Expect(rec.Code).Should(Equal(http.StatusCreated))
+		Expect(rec.Header().Get(echo.HeaderLocation)).Should(Equal("/stores/2"))
+	})
+
+	It("return error on a store without a name", func() {
+		_, err := send(http.MethodPost, `{"address": "1 River Rd"}`, serviceInterface.Create)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("return error deleting the default store", func() {
+		repo.EXPECT().Delete(gomock.Any(), 2).Return(stores.ErrConflict)
+		_, err := send(http.MethodDelete, "", serviceInterface.Delete)
+		Expect(err).Should(MatchError(stores.ErrConflict))
+	})
+
+	It("get a cake at the prices of a store", func() {
+		active := true
+		repo.EXPECT().Get(gomock.Any(), 2).Return(&stores.Store{ID: 2}, nil)
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1}, nil)
+		repo.EXPECT().GetOverride(gomock.Any(), 2, 1).Return(&stores.Override{StoreID: 2, CakeID: 1, Available: true, Prices: []stores.Price{{VariantID: 3, PriceMinor: 1800}}}, nil)
+		variantsRepo.EXPECT().List(gomock.Any(), variants.ListRequestDto{CakeID: 1, Active: &active}).Return([]variants.Variant{
+			{ID: 3, CakeID: 1, Price: money.New(3000, "USD"), Active: true},
+		}, nil)
+		rec, err := send(http.MethodGet, "", serviceInterface.GetCake)
+		Expect(err).Should(Succeed())
+		Expect(rec.Code).Should(Equal(http.StatusOK))
+		Expect(rec.Body.String()).Should(ContainSubstring(`"price":{"amount":1800,"currency":"USD","decimal":"18.00"}`))
+	})
+
+	It("override a cake in a store", func() {
+		repo.EXPECT().Get(gomock.Any(), 2).Return(&stores.Store{ID: 2}, nil)
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1}, nil)
+		variantsRepo.EXPECT().Get(gomock.Any(), 1, 3).Return(&variants.Variant{ID: 3, CakeID: 1}, nil)
+		repo.EXPECT().SetOverride(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, dto stores.OverrideRequestDto) (*stores.Override, error) {
+			Expect(dto.StoreID).Should(Equal(2))
+			Expect(dto.CakeID).Should(Equal(1))
+			Expect(*dto.Available).Should(BeFalse())
+			return &stores.Override{StoreID: 2, CakeID: 1, Prices: []stores.Price{{VariantID: 3, PriceMinor: 1800}}}, nil
+		})
+		rec, err := send(http.MethodPut, `{"available": false, "prices": [{"variant_id": 3, "price_minor": 1800}]}`, serviceInterface.SetCake)
+		Expect(err).Should(Succeed())
+		Expect(rec.Code).Should(Equal(http.StatusOK))
+	})
+
+	It("return error pricing a variant of another cake", func() {
+		repo.EXPECT().Get(gomock.Any(), 2).Return(&stores.Store{ID: 2}, nil)
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1}, nil)
+		variantsRepo.EXPECT().Get(gomock.Any(), 1, 9).Return(nil, variants.ErrNotFound)
+		_, err := send(http.MethodPut, `{"prices": [{"variant_id": 9, "price_minor": 1800}]}`, serviceInterface.SetCake)
+		Expect(err).Should(MatchError(stores.ErrValidation))
+	})
+
+	It("return error overriding a cake in a missing store", func() {
+		repo.EXPECT().Get(gomock.Any(), 2).Return(nil, stores.ErrNotFound)
+		_, err := send(http.MethodDelete, "", serviceInterface.DeleteCake)
+		Expect(err).Should(MatchError(stores.ErrNotFound))
+	})
+})
+
+var _ = Describe("Test Cake Service With Stores", func() {
+	var (
+		e                *echo.Echo
+		mockCtrl         *gomock.Controller
+		serviceInterface cakes.SvcInterface
+		repo             *mock_repository.MockRepoInterface
+		storesRepo       *mock_stores.MockRepoInterface
+		variantsRepo     *mock_variants.MockRepoInterface
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		repo = mock_repository.NewMockRepoInterface(mockCtrl)
+		storesRepo = mock_stores.NewMockRepoInterface(mockCtrl)
+		variantsRepo = mock_variants.NewMockRepoInterface(mockCtrl)
+		menu := stores.NewMenu(storesRepo, variantsRepo)
+		serviceInterface = cakes.NewHandler(repo, cakes.WithVariants(variantsRepo), cakes.WithStores(menu))
+		e = echo.New()
+		middlewares.UseCustomValidatorHandler(e)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	get := func(target string, handler echo.HandlerFunc) error {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		return handler(c)
+	}
+
+	It("scope cakes to the menu of a store", func() {
+		storesRepo.EXPECT().Get(gomock.Any(), 2).Return(&stores.Store{ID: 2}, nil)
+		storesRepo.EXPECT().OffMenu(gomock.Any(), 2).Return(query.IDs{List: []int{4}}, nil)
+		repo.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, dto cakes.ListRequestDto) ([]cakes.Cake, int64, error) {
+			Expect(dto.Without).Should(Equal([]query.IDs{{List: []int{4}}}))
+			return []cakes.Cake{}, int64(0), nil
+		})
+		Expect(get("/cakes?store_id=2", serviceInterface.List)).Should(Succeed())
+	})
+
+	It("filter cakes by the prices of a store", func() {
+		min := int64(2500)
+		storesRepo.EXPECT().CakesInPriceRange(gomock.Any(), 2, money.Range{Min: &min, Currency: "USD"}).Return(query.IDs{List: []int{1, 2}}, nil)
+		storesRepo.EXPECT().Get(gomock.Any(), 2).Return(&stores.Store{ID: 2}, nil)
+		storesRepo.EXPECT().OffMenu(gomock.Any(), 2).Return(query.IDs{List: []int{}}, nil)
+		repo.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, dto cakes.ListRequestDto) ([]cakes.Cake, int64, error) {
+			Expect(dto.Within).Should(Equal([]query.IDs{{List: []int{1, 2}}}))
+			Expect(dto.Without).Should(Equal([]query.IDs{{List: []int{}}}))
+			return []cakes.Cake{}, int64(0), nil
+		})
+		Expect(get("/cakes?store_id=2&price_min=25", serviceInterface.List)).Should(Succeed())
+	})
+
+	It("return error for a cake off the menu of a store", func() {
+		repo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1}, nil)
+		storesRepo.EXPECT().Get(gomock.Any(), 2).Return(&stores.Store{ID: 2}, nil)
+		storesRepo.EXPECT().GetOverride(gomock.Any(), 2, 1).Return(&stores.Override{StoreID: 2, CakeID: 1}, nil)
+		Expect(get("/cakes/1?store_id=2", serviceInterface.Get)).Should(MatchError(cakes.ErrNotFound))
+	})
+
+	It("price a cake at the menu of a store", func() {
+		repo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1, Title: "Lemon cake"}, nil)
+		storesRepo.EXPECT().Get(gomock.Any(), 2).Return(&stores.Store{ID: 2}, nil)
+		storesRepo.EXPECT().GetOverride(gomock.Any(), 2, 1).Return(&stores.Override{StoreID: 2, CakeID: 1, Available: true, Prices: []stores.Price{{VariantID: 6, PriceMinor: 2900}}}, nil).Times(2)
+		variantsRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return([]variants.Variant{
+			{ID: 5, CakeID: 1, Size: "slice", Price: money.New(450, "USD"), Active: true},
+			{ID: 6, CakeID: 1, Size: "8 inch", Price: money.New(3250, "USD"), Active: true},
+		}, nil)
+		req := httptest.NewRequest(http.MethodGet, "/cakes/1?store_id=2", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		Expect(serviceInterface.Get(c)).Should(Succeed())
+		var cake cakes.Cake
+		Expect(json.Unmarshal(rec.Body.Bytes(), &cake)).Should(Succeed())
+		Expect(cake.Prices).Should(Equal([]cakes.StorePrice{
+			{VariantID: 5, Size: "slice", Price: money.New(450, "USD")},
+			{VariantID: 6, Size: "8 inch", Price: money.New(2900, "USD")},
+		}))
+	})
+
+	It("return error for an invalid store", func() {
+		repo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1}, nil)
+		err := get("/cakes/1?store_id=main", serviceInterface.Get)
+		Expect(err).Should(HaveOccurred())
+		Expect(err.(*echo.HTTPError).Code).Should(Equal(http.StatusUnprocessableEntity))
+
+		storesRepo.EXPECT().Get(gomock.Any(), 9).Return(nil, stores.ErrNotFound)
+		Expect(get("/cakes?store_id=9", serviceInterface.List)).Should(MatchError(stores.ErrNotFound))
+	})
+
+	It("delete the store overrides of a deleted cake", func() {
+		repo.EXPECT().Delete(gomock.Any(), 1, 0).Return(nil)
+		variantsRepo.EXPECT().DeleteForCake(gomock.Any(), 1).Return(nil)
+		storesRepo.EXPECT().DeleteForCake(gomock.Any(), 1).Return(nil)
+		req := httptest.NewRequest(http.MethodDelete, "/", nil)
+		req.Header.Set(cakes.HeaderIfMatch, "*")
+		c := e.NewContext(req, httptest.NewRecorder())
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		Expect(serviceInterface.Delete(c)).Should(Succeed())
+	})
+})
@@ -0,0 +1,249 @@
+package test
+
+import (
+	"cake-store/internal/money"
+	"cake-store/internal/stores"
+	"cake-store/internal/variants"
+	"context"
+	"database/sql"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// newMemoryStoreRepository returns a memory stores repository pricing from
+// memory variants.
+func newMemoryStoreRepository() stores.RepoInterface {
+	return stores.NewMemoryRepository(variants.NewMemoryRepository())
+}
+
+var _ = describeConformance("Store Repository", newMemoryStoreRepository, stores.NewRepository, func(subject *repoUnderTest[stores.RepoInterface]) {
+	var (
+		repo stores.RepoInterface
+		ctx  = context.TODO()
+	)
+
+	BeforeEach(func() {
+		repo = subject.Repo
+	})
+
+	It("starts with the default store", func() {
+		list, err := repo.List(ctx)
+		Expect(err).Should(Succeed())
+		Expect(list).Should(HaveLen(1))
+		Expect(list[0].ID).Should(Equal(stores.DefaultID))
+		Expect(list[0].Slug).Should(Equal("main"))
+		Expect(list[0].Default).Should(BeTrue())
+
+		err = repo.Delete(ctx, stores.DefaultID)
+		Expect(err).Should(MatchError(stores.ErrConflict))
+		_, err = repo.Get(ctx, stores.DefaultID)
+		Expect(err).Should(Succeed())
+	})
+
+	It("creates, updates and lists stores after the default one", func() {
+		riverside, err := repo.Create(ctx, stores.RequestDto{Name: "Riverside Bakery", Address: "1 River Rd"})
+		Expect(err).Should(Succeed())
+		Expect(riverside.Slug).Should(Equal("riverside-bakery"))
+		Expect(riverside.Default).Should(BeFalse())
+
+		_, err = repo.Create(ctx, stores.RequestDto{Name: "Another", Slug: "Riverside Bakery"})
+		Expect(err).Should(MatchError(stores.ErrConflict))
+		_, err = repo.Create(ctx, stores.RequestDto{Name: "!!!"})
+		Expect(err).Should(MatchError(stores.ErrValidation))
+
+		airport, err := repo.Create(ctx, stores.RequestDto{Name: "Airport"})
+		Expect(err).Should(Succeed())
+		_, err = repo.Update(ctx, stores.UpdateRequestDto{ID: airport.ID, Slug: "main"})
+		Expect(err).Should(MatchError(stores.ErrConflict))
+
+		updated, err := repo.Update(ctx, stores.UpdateRequestDto{ID: airport.ID, Address: "Terminal 2"})
+		Expect(err).Should(Succeed())
+		Expect(updated.Name).Should(Equal("Airport"))
+		Expect(updated.Address).Should(Equal("Terminal 2"))
+		Expect(updated.UpdatedAt).ShouldNot(BeNil())
+
+		list, err := repo.List(ctx)
+		Expect(err).Should(Succeed())
+		Expect(list).Should(HaveLen(3))
+		Expect([]string{list[0].Slug, list[1].Slug, list[2].Slug}).Should(Equal([]string{"main", "airport", "riverside-bakery"}))
+	})
+
+	It("overrides cakes per store", func() {
+		store, err := repo.Create(ctx, stores.RequestDto{Name: "Riverside"})
+		Expect(err).Should(Succeed())
+
+		override, err := repo.GetOverride(ctx, store.ID, 1)
+		Expect(err).Should(Succeed())
+		Expect(override.Available).Should(BeTrue())
+		Expect(override.Prices).Should(BeEmpty())
+
+		off := false
+		_, err = repo.SetOverride(ctx, stores.OverrideRequestDto{StoreID: store.ID, CakeID: 1, Available: &off})
+		Expect(err).Should(Succeed())
+		override, err = repo.SetOverride(ctx, stores.OverrideRequestDto{StoreID: store.ID, CakeID: 2, Prices: []stores.PriceDto{
+			{VariantID: 5, PriceMinor: 1500}, {VariantID: 4, PriceMinor: 900},
+		}})
+		Expect(err).Should(Succeed())
+		Expect(override.Available).Should(BeTrue())
+		Expect(override.Prices).Should(Equal([]stores.Price{{VariantID: 4, PriceMinor: 900}, {VariantID: 5, PriceMinor: 1500}}))
+
+		_, err = repo.SetOverride(ctx, stores.OverrideRequestDto{StoreID: store.ID, CakeID: 3, Prices: []stores.PriceDto{
+			{VariantID: 6, PriceMinor: 100}, {VariantID: 6, PriceMinor: 200},
+		}})
+		Expect(err).Should(MatchError(stores.ErrValidation))
+
+		offMenu, err := repo.OffMenu(ctx, store.ID)
+		Expect(err).Should(Succeed())
+		Expect(subject.selected(offMenu)).Should(Equal([]int{1}))
+		offMenu, err = repo.OffMenu(ctx, stores.DefaultID)
+		Expect(err).Should(Succeed())
+		Expect(subject.selected(offMenu)).Should(BeEmpty())
+
+		prices, err := repo.Prices(ctx, store.ID)
+		Expect(err).Should(Succeed())
+		Expect(prices).Should(HaveLen(1))
+		Expect(prices[2]).Should(HaveLen(2))
+
+		Expect(repo.DeleteOverride(ctx, store.ID, 1)).Should(Succeed())
+		offMenu, err = repo.OffMenu(ctx, store.ID)
+		Expect(err).Should(Succeed())
+		Expect(subject.selected(offMenu)).Should(BeEmpty())
+
+		Expect(repo.DeleteForCake(ctx, 2)).Should(Succeed())
+		prices, err = repo.Prices(ctx, store.ID)
+		Expect(err).Should(Succeed())
+		Expect(prices).Should(BeEmpty())
+	})
+
+	It("deletes a store with its overrides", func() {
+		store, err := repo.Create(ctx, stores.RequestDto{Name: "Riverside"})
+		Expect(err).Should(Succeed())
+		off := false
+		_, err = repo.SetOverride(ctx, stores.OverrideRequestDto{StoreID: store.ID, CakeID: 1, Available: &off})
+		Expect(err).Should(Succeed())
+
+		Expect(repo.Delete(ctx, store.ID)).Should(Succeed())
+		_, err = repo.Get(ctx, store.ID)
+		Expect(err).Should(MatchError(stores.ErrNotFound))
+		Expect(repo.Delete(ctx, store.ID)).Should(MatchError(stores.ErrNotFound))
+
+		offMenu, err := repo.OffMenu(ctx, store.ID)
+		Expect(err).Should(Succeed())
+		Expect(subject.selected(offMenu)).Should(BeEmpty())
+	})
+})
+
+// storeMenu is a Menu with the repositories it reads.
+type storeMenu struct {
+	menu     stores.Menu
+	stores   stores.RepoInterface
+	variants variants.RepoInterface
+}
+
+func newStoreMenu(storesRepo stores.RepoInterface, variantsRepo variants.RepoInterface) storeMenu {
+	return storeMenu{stores.NewMenu(storesRepo, variantsRepo), storesRepo, variantsRepo}
+}
+
+var _ = describeConformance("Store Menu", func() storeMenu {
+	variantsRepo := variants.NewMemoryRepository()
+	return newStoreMenu(stores.NewMemoryRepository(variantsRepo), variantsRepo)
+}, func(db *sql.DB) storeMenu {
+	return newStoreMenu(stores.NewRepository(db), variants.NewRepository(db))
+}, func(subject *repoUnderTest[storeMenu]) {
+	var (
+		menu         stores.Menu
+		storesRepo   stores.RepoInterface
+		variantsRepo variants.RepoInterface
+		store        *stores.Store
+		ctx          = context.TODO()
+	)
+
+	// addVariant creates an active variant of a cake priced in USD.
+	addVariant := func(cakeID int, sku string, price int64) *variants.Variant {
+		active := true
+		variant, err := variantsRepo.Create(ctx, variants.RequestDto{CakeID: cakeID, Size: "8 inch", SKU: sku, PriceMinor: price, Currency: "USD", Active: &active})
+		Expect(err).Should(Succeed())
+		return variant
+	}
+
+	BeforeEach(func() {
+		var err error
+		menu, storesRepo, variantsRepo = subject.Repo.menu, subject.Repo.stores, subject.Repo.variants
+		store, err = storesRepo.Create(ctx, stores.RequestDto{Name: "Riverside"})
+		Expect(err).Should(Succeed())
+	})
+
+	It("returns a cake at the prices of a store", func() {
+		small := addVariant(1, "LEMON-6", 2000)
+		large := addVariant(1, "LEMON-8", 3000)
+		_, err := storesRepo.SetOverride(ctx, stores.OverrideRequestDto{StoreID: store.ID, CakeID: 1, Prices: []stores.PriceDto{
+			{VariantID: large.ID, PriceMinor: 1800},
+		}})
+		Expect(err).Should(Succeed())
+
+		item, err := menu.Item(ctx, store.ID, 1)
+		Expect(err).Should(Succeed())
+		Expect(item.Available).Should(BeTrue())
+		Expect(item.Variants).Should(HaveLen(2))
+		Expect(item.Variants[0].ID).Should(Equal(large.ID))
+		Expect(item.Variants[0].Price).Should(Equal(money.New(1800, "USD")))
+		Expect(item.Variants[1].Price).Should(Equal(small.Price))
+
+		item, err = menu.Item(ctx, stores.DefaultID, 1)
+		Expect(err).Should(Succeed())
+		Expect(item.Variants[0].Price).Should(Equal(money.New(2000, "USD")))
+	})
+
+	It("filters prices at the prices of a store", func() {
+		addVariant(1, "LEMON-8", 3000)
+		chocolate := addVariant(2, "CHOC-8", 1500)
+		_, err := storesRepo.SetOverride(ctx, stores.OverrideRequestDto{StoreID: store.ID, CakeID: 2, Prices: []stores.PriceDto{
+			{VariantID: chocolate.ID, PriceMinor: 2800},
+		}})
+		Expect(err).Should(Succeed())
+
+		min, max := int64(2500), int64(2900)
+		r := money.Range{Min: &min, Currency: "USD"}
+		ids, err := menu.CakesInPriceRange(ctx, store.ID, r)
+		Expect(err).Should(Succeed())
+		Expect(subject.selected(ids)).Should(Equal([]int{1, 2}))
+
+		ids, err = menu.CakesInPriceRange(ctx, stores.DefaultID, r)
+		Expect(err).Should(Succeed())
+		Expect(subject.selected(ids)).Should(Equal([]int{1}))
+
+		ids, err = menu.CakesInPriceRange(ctx, store.ID, money.Range{Min: &min, Max: &max, Currency: "USD"})
+		Expect(err).Should(Succeed())
+		Expect(subject.selected(ids)).Should(Equal([]int{2}))
+	})
+
+	It("returns the cakes off the menu of an existing store", func() {
+		off := false
+		_, err := storesRepo.SetOverride(ctx, stores.OverrideRequestDto{StoreID: store.ID, CakeID: 3, Available: &off})
+		Expect(err).Should(Succeed())
+
+		ids, err := menu.OffMenu(ctx, store.ID)
+		Expect(err).Should(Succeed())
+		Expect(subject.selected(ids)).Should(Equal([]int{3}))
+
+		_, err = menu.OffMenu(ctx, 99)
+		Expect(err).Should(MatchError(stores.ErrNotFound))
+	})
+
+	It("tells whether a store sells a cake", func() {
+		off := false
+		_, err := storesRepo.SetOverride(ctx, stores.OverrideRequestDto{StoreID: store.ID, CakeID: 3, Available: &off})
+		Expect(err).Should(Succeed())
+
+		sells, err := menu.Sells(ctx, store.ID, 3)
+		Expect(err).Should(Succeed())
+		Expect(sells).Should(BeFalse())
+		sells, err = menu.Sells(ctx, store.ID, 4)
+		Expect(err).Should(Succeed())
+		Expect(sells).Should(BeTrue())
+
+		_, err = menu.Sells(ctx, 99, 3)
+		Expect(err).Should(MatchError(stores.ErrNotFound))
+	})
+})
@@ -2,9 +2,12 @@ package test
 
 import (
 	"cake-store/internal/cakes"
+	"cake-store/internal/helpers"
 	"cake-store/internal/middlewares"
 	mock_repository "cake-store/mocks/repository"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/golang/mock/gomock"
 	"github.com/labstack/echo/v4"
 	"net/http"
@@ -50,6 +53,7 @@ var _ = Describe("Test Cake Service", func() {
 			Image:       &image,
 			CreatedAt:   time.Now(),
 			UpdatedAt:   nil,
+			Version:     3,
 		}
 		mockDataList = []cakes.Cake{
 			{
@@ -83,10 +87,10 @@ var _ = Describe("Test Cake Service", func() {
 	})
 
 	Describe("Create Cake", func() {
-		request := `{"title": "Cinnamon Cheesecake", "description": "A cheesecake with a hint of cinnamon", "rating": 7, "image": "https://www.elmundoeats.com/wp-content/uploads/2020/10/FP-Cinnamon-Roll-Cheesecake.jpg"}`
+		request := `{"title": "Cinnamon Cheesecake", "description": "A cheesecake with a hint of cinnamon", "image": "https://www.elmundoeats.com/wp-content/uploads/2020/10/FP-Cinnamon-Roll-Cheesecake.jpg"}`
 
 		It("return succeed", func() {
-			repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&mockData, nil)
 			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(request))
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 			rec := httptest.NewRecorder()
@@ -94,6 +98,8 @@ var _ = Describe("Test Cake Service", func() {
 			err := serviceInterface.Create(c)
 			Expect(err).Should(Succeed())
 			Expect(rec.Code).Should(Equal(http.StatusCreated))
+			Expect(rec.Header().Get(echo.HeaderLocation)).Should(Equal("/cakes/1"))
+			Expect(rec.Body.String()).Should(ContainSubstring(`"id":1`))
 		})
 
 		It("return error", func() {
@@ -101,7 +107,7 @@ var _ = Describe("Test Cake Service", func() {
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
-			repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errSomething)
+			repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errSomething)
 			err := serviceInterface.Create(c)
 			Expect(err).Should(HaveOccurred())
 		})
@@ -140,6 +146,90 @@ var _ = Describe("Test Cake Service", func() {
 			Expect(rec.Code).Should(Equal(http.StatusOK))
 		})
 
+		It("return next cursor on a full page", func() {
+			repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(mockDataList, int64(-1), nil)
+			req := httptest.NewRequest(http.MethodGet, "/cakes?limit=3&skip_count=true", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			err := serviceInterface.List(c)
+			Expect(err).Should(Succeed())
+			Expect(rec.Header().Get("Pagination-Rows")).Should(BeEmpty())
+			keys, _ := cakes.ParseSort("")
+			Expect(rec.Header().Get("Pagination-Next-Cursor")).Should(Equal(cakes.EncodeCursor(keys, mockDataList[2])))
+		})
+
+		It("pass the decoded cursor to the repository", func() {
+			keys, _ := cakes.ParseSort("-created_at")
+			cursor := cakes.EncodeCursor(keys, mockDataList[0])
+			repo.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, dto cakes.ListRequestDto) ([]cakes.Cake, int64, error) {
+				Expect(dto.SortKeys).Should(Equal([]cakes.SortKey{{Field: "created_at", Desc: true}, {Field: "id"}}))
+				Expect(dto.After.ID).Should(Equal(1))
+				Expect(dto.After.Title).Should(Equal("Lemon cheesecake"))
+				return mockDataList[1:], int64(3), nil
+			})
+			req := httptest.NewRequest(http.MethodGet, "/cakes?sort=-created_at&cursor="+cursor, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			err := serviceInterface.List(c)
+			Expect(err).Should(Succeed())
+			Expect(rec.Header().Get("Pagination-Next-Cursor")).Should(BeEmpty())
+		})
+
+		It("return error on invalid cursor", func() {
+			req := httptest.NewRequest(http.MethodGet, "/cakes?cursor=!!", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			err := serviceInterface.List(c)
+			Expect(err).Should(MatchError(cakes.ErrValidation))
+		})
+
+		It("return error on cursor with offset", func() {
+			req := httptest.NewRequest(http.MethodGet, "/cakes?offset=10&cursor=abc", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			err := serviceInterface.List(c)
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("return error on unknown sort field", func() {
+			req := httptest.NewRequest(http.MethodGet, "/cakes?sort=-rating,price", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			err := serviceInterface.List(c)
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("return error on malformed ids", func() {
+			req := httptest.NewRequest(http.MethodGet, "/cakes?ids=1,two", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			err := serviceInterface.List(c)
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("return facet counts in headers", func() {
+			repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(mockDataList, int64(len(mockDataList)), nil)
+			repo.EXPECT().Facets(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, dto cakes.ListRequestDto) (cakes.Facets, error) {
+				Expect(dto.Facets).Should(Equal("has_image"))
+				return cakes.Facets{"has_image": {{Value: "true", Count: 1}, {Value: "false", Count: 2}}}, nil
+			})
+			req := httptest.NewRequest(http.MethodGet, "/cakes?facets=has_image", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			err := serviceInterface.List(c)
+			Expect(err).Should(Succeed())
+			Expect(rec.Header().Get("Facets-Has-Image")).Should(Equal("true=1, false=2"))
+			Expect(rec.Header().Get("Facets-Rating")).Should(BeEmpty())
+		})
+
+		It("return error on unknown facet", func() {
+			req := httptest.NewRequest(http.MethodGet, "/cakes?facets=rating,flavour", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			err := serviceInterface.List(c)
+			Expect(err).Should(HaveOccurred())
+		})
+
 		It("return error on validate param", func() {
 			req := httptest.NewRequest(http.MethodGet, "/cakes?limit=-1", nil)
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -161,6 +251,36 @@ var _ = Describe("Test Cake Service", func() {
 		})
 	})
 
+	Describe("Suggest Cakes", func() {
+		It("return succeed with default limit", func() {
+			repo.EXPECT().Suggest(gomock.Any(), "lem", 5).Return([]cakes.Suggestion{{ID: 1, Title: "Lemon cheesecake"}}, nil)
+			req := httptest.NewRequest(http.MethodGet, "/cakes/suggest?prefix=lem", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			err := serviceInterface.Suggest(c)
+			Expect(err).Should(Succeed())
+			Expect(rec.Code).Should(Equal(http.StatusOK))
+			Expect(rec.Body.String()).Should(MatchJSON(`[{"id":1,"title":"Lemon cheesecake"}]`))
+		})
+
+		It("return error without prefix", func() {
+			req := httptest.NewRequest(http.MethodGet, "/cakes/suggest?limit=5", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			err := serviceInterface.Suggest(c)
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("return error", func() {
+			repo.EXPECT().Suggest(gomock.Any(), "lem", 3).Return(nil, errSomething)
+			req := httptest.NewRequest(http.MethodGet, "/cakes/suggest?prefix=lem&limit=3", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			err := serviceInterface.Suggest(c)
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
 	Describe("Get Cake", func() {
 		It("return succeed", func() {
 			repo.EXPECT().Get(gomock.Any(), 1).Return(&mockData, nil)
@@ -174,6 +294,27 @@ var _ = Describe("Test Cake Service", func() {
 			err := serviceInterface.Get(c)
 			Expect(err).Should(Succeed())
 			Expect(rec.Code).Should(Equal(http.StatusOK))
+			Expect(rec.Header().Get(cakes.HeaderETag)).Should(Equal(cakes.ETag(3, rec.Body.Bytes())))
+		})
+
+		It("return not modified for a matching If-None-Match", func() {
+			repo.EXPECT().Get(gomock.Any(), 1).Return(&mockData, nil).Times(2)
+			get := func(etag string) *httptest.ResponseRecorder {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set(cakes.HeaderIfNoneMatch, etag)
+				rec := httptest.NewRecorder()
+				c := e.NewContext(req, rec)
+				c.SetPath("/cakes/:id")
+				c.SetParamNames("id")
+				c.SetParamValues("1")
+				Expect(serviceInterface.Get(c)).Should(Succeed())
+				return rec
+			}
+			etag := get(`"2-0123456789abcdef"`).Header().Get(cakes.HeaderETag)
+			rec := get(`"2-0123456789abcdef", W/` + etag)
+			Expect(rec.Code).Should(Equal(http.StatusNotModified))
+			Expect(rec.Body.Len()).Should(BeZero())
+			Expect(rec.Header().Get(cakes.HeaderETag)).Should(Equal(etag))
 		})
 
 		It("return error on invalid id", func() {
@@ -189,7 +330,7 @@ var _ = Describe("Test Cake Service", func() {
 		})
 
 		It("return data not found", func() {
-			repo.EXPECT().Get(gomock.Any(), 1).Return(nil, nil)
+			repo.EXPECT().Get(gomock.Any(), 1).Return(nil, cakes.ErrNotFound)
 			req := httptest.NewRequest(http.MethodGet, "/", nil)
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 			rec := httptest.NewRecorder()
@@ -198,11 +339,11 @@ var _ = Describe("Test Cake Service", func() {
 			c.SetParamNames("id")
 			c.SetParamValues("1")
 			err := serviceInterface.Get(c)
-			Expect(err).Should(HaveOccurred())
+			Expect(err).Should(MatchError(cakes.ErrNotFound))
 		})
 
 		It("return error", func() {
-			repo.EXPECT().Get(gomock.Any(), 1).Return(&mockData, errSomething)
+			repo.EXPECT().Get(gomock.Any(), 1).Return(nil, errSomething)
 			req := httptest.NewRequest(http.MethodGet, "/", nil)
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 			rec := httptest.NewRecorder()
@@ -219,14 +360,13 @@ var _ = Describe("Test Cake Service", func() {
 		request := `{
 			"title": "Cinnamon Cheesecake",
 			"description": "A cheesecake with a hint of cinnamon",
-			"rating": 7,
 			"image": "https://www.elmundoeats.com/wp-content/uploads/2020/10/FP-Cinnamon-Roll-Cheesecake.jpg"
 		}`
 		It("return succeed", func() {
-			repo.EXPECT().Get(gomock.Any(), 1).Return(&mockData, nil)
-			repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&mockData, nil)
 			req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(request))
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(cakes.HeaderIfMatch, "*")
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetPath("/cakes/:id")
@@ -235,13 +375,14 @@ var _ = Describe("Test Cake Service", func() {
 			err := serviceInterface.Update(c)
 			Expect(err).Should(Succeed())
 			Expect(rec.Code).Should(Equal(http.StatusOK))
+			Expect(rec.Body.String()).Should(ContainSubstring(`"title":"Lemon cheesecake"`))
 		})
 
 		It("return error", func() {
-			repo.EXPECT().Get(gomock.Any(), 1).Return(&mockData, nil)
-			repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errSomething)
+			repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, errSomething)
 			req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(request))
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(cakes.HeaderIfMatch, "*")
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetPath("/cakes/:id")
@@ -252,35 +393,81 @@ var _ = Describe("Test Cake Service", func() {
 		})
 
 		It("return error on not found", func() {
-			repo.EXPECT().Get(gomock.Any(), 1).Return(nil, nil)
+			repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, cakes.ErrNotFound)
 			req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(request))
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(cakes.HeaderIfMatch, "*")
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetPath("/cakes/:id")
 			c.SetParamNames("id")
 			c.SetParamValues("1")
 			err := serviceInterface.Update(c)
-			Expect(err).Should(HaveOccurred())
+			Expect(err).Should(MatchError(cakes.ErrNotFound))
 		})
 
-		It("return error on getting data", func() {
-			repo.EXPECT().Get(gomock.Any(), 1).Return(&mockData, errSomething)
-			req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(request))
+		It("apply only to the version in If-Match", func() {
+			repo.EXPECT().Update(gomock.Any(), cakes.UpdateRequestDto{ID: 1, Title: "Cinnamon Cheesecake", Version: 3}).Return(nil, cakes.ErrStale)
+			req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"title": "Cinnamon Cheesecake"}`))
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(cakes.HeaderIfMatch, `"3-0123456789abcdef"`)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetPath("/cakes/:id")
 			c.SetParamNames("id")
 			c.SetParamValues("1")
 			err := serviceInterface.Update(c)
-			Expect(err).Should(HaveOccurred())
+			Expect(err).Should(MatchError(cakes.ErrStale))
+		})
+
+		It("never match weak tags in If-Match", func() {
+			req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"title": "Cinnamon Cheesecake"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(cakes.HeaderIfMatch, `W/"3-0123456789abcdef"`)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/cakes/:id")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			e.HTTPErrorHandler(serviceInterface.Update(c), c)
+			Expect(rec.Code).Should(Equal(http.StatusPreconditionFailed))
+		})
+
+		It("pick the current version of several in If-Match", func() {
+			repo.EXPECT().Get(gomock.Any(), 1).Return(&mockData, nil).Times(2)
+			repo.EXPECT().Update(gomock.Any(), cakes.UpdateRequestDto{ID: 1, Title: "Cinnamon Cheesecake", Version: 3}).Return(&mockData, nil)
+			update := func(etags string) error {
+				req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"title": "Cinnamon Cheesecake"}`))
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				req.Header.Set(cakes.HeaderIfMatch, etags)
+				c := e.NewContext(req, httptest.NewRecorder())
+				c.SetPath("/cakes/:id")
+				c.SetParamNames("id")
+				c.SetParamValues("1")
+				return serviceInterface.Update(c)
+			}
+			Expect(update(`"2-0123456789abcdef", "3-0123456789abcdef"`)).Should(Succeed())
+			Expect(update(`"1-0123456789abcdef", "2-0123456789abcdef"`)).Should(MatchError(cakes.ErrStale))
+			Expect(update(`"unknown"`)).Should(MatchError(cakes.ErrStale))
+		})
+
+		It("return error without If-Match", func() {
+			req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"title": "Cinnamon Cheesecake"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/cakes/:id")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			err := serviceInterface.Update(c)
+			Expect(err).Should(MatchError(cakes.ErrIfMatchRequired))
 		})
 
 		It("return error on binding body", func() {
 			request = `{`
 			req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(request))
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(cakes.HeaderIfMatch, "*")
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetPath("/cakes/:id")
@@ -294,6 +481,7 @@ var _ = Describe("Test Cake Service", func() {
 			request = `{"image":"plain"}`
 			req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(request))
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(cakes.HeaderIfMatch, "*")
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetPath("/cakes/:id")
@@ -306,10 +494,10 @@ var _ = Describe("Test Cake Service", func() {
 
 	Describe("Delete Cake", func() {
 		It("return succeed", func() {
-			repo.EXPECT().Get(gomock.Any(), 1).Return(&mockData, nil)
-			repo.EXPECT().Delete(gomock.Any(), 1).Return(nil)
+			repo.EXPECT().Delete(gomock.Any(), 1, 3).Return(nil)
 			req := httptest.NewRequest(http.MethodDelete, "/", nil)
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(cakes.HeaderIfMatch, `"3-0123456789abcdef"`)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetPath("/cakes/:id")
@@ -323,6 +511,7 @@ var _ = Describe("Test Cake Service", func() {
 		It("return error on invalid id", func() {
 			req := httptest.NewRequest(http.MethodDelete, "/", nil)
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(cakes.HeaderIfMatch, "*")
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetPath("/cakes/:id")
@@ -333,22 +522,24 @@ var _ = Describe("Test Cake Service", func() {
 		})
 
 		It("return error on not found", func() {
-			repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, errSomething)
+			repo.EXPECT().Delete(gomock.Any(), 18, 0).Return(cakes.ErrNotFound)
 			req := httptest.NewRequest(http.MethodDelete, "/", nil)
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(cakes.HeaderIfMatch, "*")
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetPath("/cakes/:id")
 			c.SetParamNames("id")
 			c.SetParamValues("18")
 			err := serviceInterface.Delete(c)
-			Expect(err).Should(HaveOccurred())
+			Expect(err).Should(MatchError(cakes.ErrNotFound))
 		})
 
-		It("return internal server error on get data", func() {
-			repo.EXPECT().Get(gomock.Any(), 1).Return(&mockData, errSomething)
+		It("return error", func() {
 			req := httptest.NewRequest(http.MethodDelete, "/", nil)
+			repo.EXPECT().Delete(gomock.Any(), 1, 0).Return(errSomething)
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(cakes.HeaderIfMatch, "*")
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetPath("/cakes/:id")
@@ -357,19 +548,70 @@ var _ = Describe("Test Cake Service", func() {
 			err := serviceInterface.Delete(c)
 			Expect(err).Should(HaveOccurred())
 		})
+	})
 
-		It("return error", func() {
-			repo.EXPECT().Get(gomock.Any(), 1).Return(&mockData, nil)
-			req := httptest.NewRequest(http.MethodDelete, "/", nil)
-			repo.EXPECT().Delete(gomock.Any(), 1).Return(errSomething)
-			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	Describe("Error Handler", func() {
+		handle := func(err error) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodGet, "/cakes/1", nil)
+			req.Header.Set(echo.HeaderXRequestID, "req-1")
 			rec := httptest.NewRecorder()
-			c := e.NewContext(req, rec)
-			c.SetPath("/cakes/:id")
-			c.SetParamNames("id")
-			c.SetParamValues("1")
-			err := serviceInterface.Delete(c)
+			e.HTTPErrorHandler(err, e.NewContext(req, rec))
+			return rec
+		}
+
+		decode := func(rec *httptest.ResponseRecorder) helpers.Problem {
+			var problem helpers.Problem
+			Expect(json.Unmarshal(rec.Body.Bytes(), &problem)).Should(Succeed())
+			return problem
+		}
+
+		It("maps not found to a 404 problem", func() {
+			rec := handle(cakes.ErrNotFound)
+			Expect(rec.Code).Should(Equal(http.StatusNotFound))
+			Expect(rec.Header().Get(echo.HeaderContentType)).Should(Equal(helpers.MIMEApplicationProblemJSON))
+			Expect(decode(rec)).Should(Equal(helpers.Problem{
+				Type:      "about:blank",
+				Title:     "Not Found",
+				Status:    http.StatusNotFound,
+				Detail:    "cake not found",
+				Instance:  "/cakes/1",
+				RequestID: "req-1",
+			}))
+		})
+
+		It("maps conflict to 409", func() {
+			Expect(handle(cakes.ErrConflict).Code).Should(Equal(http.StatusConflict))
+		})
+
+		It("maps failed and missing preconditions to 412 and 428", func() {
+			Expect(handle(cakes.ErrStale).Code).Should(Equal(http.StatusPreconditionFailed))
+			Expect(handle(cakes.ErrIfMatchRequired).Code).Should(Equal(http.StatusPreconditionRequired))
+		})
+
+		It("maps wrapped validation errors to 422", func() {
+			Expect(handle(fmt.Errorf("%w: bad filter", cakes.ErrValidation)).Code).Should(Equal(http.StatusUnprocessableEntity))
+		})
+
+		It("lists invalid params for validator failures", func() {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"image":"plain"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, httptest.NewRecorder())
+			err := serviceInterface.Create(c)
 			Expect(err).Should(HaveOccurred())
+
+			rec := handle(err)
+			Expect(rec.Code).Should(Equal(http.StatusUnprocessableEntity))
+			Expect(decode(rec).InvalidParams).Should(ConsistOf(
+				helpers.InvalidParam{Name: "title", Reason: "title is required"},
+				helpers.InvalidParam{Name: "image", Reason: "image is not valid url"},
+			))
+		})
+
+		It("hides unknown errors behind a generic 500", func() {
+			rec := handle(errSomething)
+			Expect(rec.Code).Should(Equal(http.StatusInternalServerError))
+			Expect(rec.Body.String()).ShouldNot(ContainSubstring(errSomething.Error()))
+			Expect(decode(rec).Detail).Should(Equal("An unexpected error occurred."))
 		})
 	})
 })
@@ -0,0 +1,186 @@
+package test
+
+import (
+	"cake-store/internal/auth"
+	"cake-store/internal/users"
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = describeConformance("User Repository", users.NewMemoryRepository, users.NewRepository, func(subject *repoUnderTest[users.RepoInterface]) {
+	var (
+		repo users.RepoInterface
+		ctx  = context.TODO()
+	)
+
+	BeforeEach(func() {
+		repo = subject.Repo
+	})
+
+	It("creates users with unique emails", func() {
+		created, err := repo.Create(ctx, users.User{Email: " Sam@Example.com", Name: "Sam", Role: auth.RoleCustomer, PasswordHash: "hash"})
+		Expect(err).Should(Succeed())
+		Expect(created.Email).Should(Equal("sam@example.com"))
+		Expect(created.PasswordHash).Should(Equal("hash"))
+
+		_, err = repo.Create(ctx, users.User{Email: "SAM@example.com", Role: auth.RoleCustomer, PasswordHash: "hash"})
+		Expect(err).Should(MatchError(users.ErrConflict))
+
+		found, err := repo.GetByEmail(ctx, "sam@EXAMPLE.com")
+		Expect(err).Should(Succeed())
+		Expect(found.ID).Should(Equal(created.ID))
+		_, err = repo.GetByEmail(ctx, "alex@example.com")
+		Expect(err).Should(MatchError(users.ErrNotFound))
+	})
+
+	It("sets the role of a user", func() {
+		created, err := repo.Create(ctx, users.User{Email: "sam@example.com", Role: auth.RoleCustomer, PasswordHash: "hash"})
+		Expect(err).Should(Succeed())
+
+		updated, err := repo.SetRole(ctx, created.ID, auth.RoleStaff)
+		Expect(err).Should(Succeed())
+		Expect(updated.Role).Should(Equal(auth.RoleStaff))
+		Expect(updated.UpdatedAt).ShouldNot(BeNil())
+
+		_, err = repo.SetRole(ctx, created.ID+1, auth.RoleStaff)
+		Expect(err).Should(MatchError(users.ErrNotFound))
+	})
+
+	It("uses refresh tokens once", func() {
+		user, err := repo.Create(ctx, users.User{Email: "sam@example.com", Role: auth.RoleCustomer, PasswordHash: "hash"})
+		Expect(err).Should(Succeed())
+		now := time.Now()
+		Expect(repo.SaveRefreshToken(ctx, users.RefreshToken{Hash: "a", UserID: user.ID, ExpiresAt: now.Add(time.Hour)})).Should(Succeed())
+		Expect(repo.SaveRefreshToken(ctx, users.RefreshToken{Hash: "b", UserID: user.ID, ExpiresAt: now.Add(-time.Hour)})).Should(Succeed())
+
+		token, err := repo.UseRefreshToken(ctx, "a", now)
+		Expect(err).Should(Succeed())
+		Expect(token.UserID).Should(Equal(user.ID))
+		_, err = repo.UseRefreshToken(ctx, "a", now)
+		Expect(err).Should(MatchError(users.ErrRefreshToken))
+		_, err = repo.UseRefreshToken(ctx, "b", now)
+		Expect(err).Should(MatchError(users.ErrRefreshToken))
+	})
+
+	It("lets one of concurrent refreshes use a token", func() {
+		user, err := repo.Create(ctx, users.User{Email: "sam@example.com", Role: auth.RoleCustomer, PasswordHash: "hash"})
+		Expect(err).Should(Succeed())
+		Expect(repo.SaveRefreshToken(ctx, users.RefreshToken{Hash: "a", UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)})).Should(Succeed())
+
+		var (
+			wg        sync.WaitGroup
+			mu        sync.Mutex
+			succeeded int
+		)
+		for n := 0; n < 10; n++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				_, err := repo.UseRefreshToken(ctx, "a", time.Now())
+				if err == nil {
+					mu.Lock()
+					succeeded++
+					mu.Unlock()
+					return
+				}
+				Expect(err).Should(MatchError(users.ErrRefreshToken))
+			}()
+		}
+		wg.Wait()
+		Expect(succeeded).Should(Equal(1))
+	})
+
+	It("revokes the refresh tokens of a user", func() {
+		user, err := repo.Create(ctx, users.User{Email: "sam@example.com", Role: auth.RoleCustomer, PasswordHash: "hash"})
+		Expect(err).Should(Succeed())
+		Expect(repo.SaveRefreshToken(ctx, users.RefreshToken{Hash: "a", UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)})).Should(Succeed())
+
+		Expect(repo.RevokeRefreshTokens(ctx, user.ID)).Should(Succeed())
+		_, err = repo.UseRefreshToken(ctx, "a", time.Now())
+		Expect(err).Should(MatchError(users.ErrRefreshToken))
+	})
+})
+
+var _ = Describe("Authenticator", func() {
+	var (
+		repo          users.RepoInterface
+		tokens        auth.Tokens
+		authenticator users.Authenticator
+		ctx           = context.TODO()
+	)
+
+	BeforeEach(func() {
+		repo = users.NewMemoryRepository()
+		tokens = hs256Tokens()
+		authenticator = users.NewAuthenticator(repo, tokens)
+		_, err := authenticator.Register(ctx, users.RegisterRequestDto{Email: "sam@example.com", Password: "correct horse"})
+		Expect(err).Should(Succeed())
+	})
+
+	It("logs in with the right password only", func() {
+		session, err := authenticator.Login(ctx, users.LoginRequestDto{Email: "SAM@example.com", Password: "correct horse"})
+		Expect(err).Should(Succeed())
+		Expect(session.TokenType).Should(Equal("Bearer"))
+		Expect(session.ExpiresIn).Should(Equal(60))
+		Expect(session.RefreshToken).ShouldNot(BeEmpty())
+
+		principal, err := tokens.Parse(session.AccessToken)
+		Expect(err).Should(Succeed())
+		Expect(principal.Role).Should(Equal(auth.RoleCustomer))
+
+		_, err = authenticator.Login(ctx, users.LoginRequestDto{Email: "sam@example.com", Password: "wrong horse"})
+		Expect(err).Should(MatchError(users.ErrCredentials))
+		_, err = authenticator.Login(ctx, users.LoginRequestDto{Email: "alex@example.com", Password: "correct horse"})
+		Expect(err).Should(MatchError(users.ErrCredentials))
+	})
+
+	It("rotates refresh tokens with the current role", func() {
+		session, err := authenticator.Login(ctx, users.LoginRequestDto{Email: "sam@example.com", Password: "correct horse"})
+		Expect(err).Should(Succeed())
+		_, err = repo.SetRole(ctx, session.User.ID, auth.RoleStaff)
+		Expect(err).Should(Succeed())
+
+		refreshed, err := authenticator.Refresh(ctx, users.RefreshRequestDto{RefreshToken: session.RefreshToken})
+		Expect(err).Should(Succeed())
+		Expect(refreshed.RefreshToken).ShouldNot(Equal(session.RefreshToken))
+		principal, err := tokens.Parse(refreshed.AccessToken)
+		Expect(err).Should(Succeed())
+		Expect(principal.Role).Should(Equal(auth.RoleStaff))
+
+		_, err = authenticator.Refresh(ctx, users.RefreshRequestDto{RefreshToken: session.RefreshToken})
+		Expect(err).Should(MatchError(users.ErrRefreshToken))
+
+		Expect(authenticator.Logout(ctx, users.RefreshRequestDto{RefreshToken: refreshed.RefreshToken})).Should(Succeed())
+		Expect(authenticator.Logout(ctx, users.RefreshRequestDto{RefreshToken: refreshed.RefreshToken})).Should(Succeed())
+		_, err = authenticator.Refresh(ctx, users.RefreshRequestDto{RefreshToken: refreshed.RefreshToken})
+		Expect(err).Should(MatchError(users.ErrRefreshToken))
+	})
+
+	It("bootstraps an admin", func() {
+		Expect(authenticator.Bootstrap(ctx, "", "")).Should(Succeed())
+		Expect(authenticator.Bootstrap(ctx, "admin@example.com", "short")).Should(MatchError(users.ErrValidation))
+		Expect(authenticator.Bootstrap(ctx, "admin@example.com", "")).Should(MatchError(users.ErrValidation))
+		Expect(authenticator.Bootstrap(ctx, "admin@example.com", "change-me-admin")).Should(MatchError(users.ErrValidation))
+
+		Expect(authenticator.Bootstrap(ctx, "admin@example.com", "admin-password")).Should(Succeed())
+		session, err := authenticator.Login(ctx, users.LoginRequestDto{Email: "admin@example.com", Password: "admin-password"})
+		Expect(err).Should(Succeed())
+		Expect(session.User.Role).Should(Equal(auth.RoleAdmin))
+
+		Expect(authenticator.Bootstrap(ctx, "admin@example.com", "other-password")).Should(Succeed())
+		_, err = authenticator.Login(ctx, users.LoginRequestDto{Email: "admin@example.com", Password: "admin-password"})
+		Expect(err).Should(Succeed())
+	})
+
+	It("never promotes a registered user to admin", func() {
+		Expect(authenticator.Bootstrap(ctx, "sam@example.com", "admin-password")).Should(MatchError(users.ErrConflict))
+		sam, err := repo.GetByEmail(ctx, "sam@example.com")
+		Expect(err).Should(Succeed())
+		Expect(sam.Role).ShouldNot(Equal(auth.RoleAdmin))
+	})
+})
@@ -0,0 +1,148 @@
+package test
+
+import (
+	"cake-store/internal/cakes"
+	"cake-store/internal/middlewares"
+	"cake-store/internal/money"
+	"cake-store/internal/query"
+	"cake-store/internal/variants"
+	mock_repository "cake-store/mocks/repository"
+	mock_variants "cake-store/mocks/variants"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test Variant Service", func() {
+	var (
+		e                *echo.Echo
+		mockCtrl         *gomock.Controller
+		serviceInterface variants.SvcInterface
+		repo             *mock_variants.MockRepoInterface
+		cakesRepo        *mock_repository.MockRepoInterface
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		repo = mock_variants.NewMockRepoInterface(mockCtrl)
+		cakesRepo = mock_repository.NewMockRepoInterface(mockCtrl)
+		serviceInterface = variants.NewHandler(repo, cakesRepo)
+		e = echo.New()
+		middlewares.UseCustomValidatorHandler(e)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("create a variant", func() {
+		cakesRepo.EXPECT().Get(gomock.Any(), 1).Return(&cakes.Cake{ID: 1}, nil)
+		created := variants.Variant{ID: 3, CakeID: 1, Size: "8 inch", SKU: "LEMON-8", Price: money.New(3250, "USD"), Active: true, CreatedAt: time.Now()}
+		repo.EXPECT().Create(gomock.Any(), variants.RequestDto{CakeID: 1, Size: "8 inch", SKU: "lemon-8", PriceMinor: 3250, Currency: "USD"}).Return(&created, nil)
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"size": "8 inch", "sku": "lemon-8", "price_minor": 3250, "currency": "USD"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		err := serviceInterface.Create(c)
+		Expect(err).Should(Succeed())
+		Expect(rec.Code).Should(Equal(http.StatusCreated))
+		Expect(rec.Header().Get(echo.HeaderLocation)).Should(Equal("/cakes/1/variants/3"))
+		Expect(rec.Body.String()).Should(ContainSubstring(`"price":{"amount":3250,"currency":"USD","decimal":"32.50"}`))
+	})
+
+	It("return not found for variants of an unknown cake", func() {
+		cakesRepo.EXPECT().Get(gomock.Any(), 9).Return(nil, cakes.ErrNotFound)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("9")
+		err := serviceInterface.List(c)
+		Expect(err).Should(MatchError(cakes.ErrNotFound))
+	})
+
+	It("return error on invalid currency", func() {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"size": "8 inch", "sku": "lemon-8", "price_minor": 3250, "currency": "XYZ"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		err := serviceInterface.Create(c)
+		Expect(err).Should(HaveOccurred())
+	})
+})
+
+var _ = Describe("Test Cake Service With Variants", func() {
+	var (
+		e                *echo.Echo
+		mockCtrl         *gomock.Controller
+		serviceInterface cakes.SvcInterface
+		repo             *mock_repository.MockRepoInterface
+		variantsRepo     *mock_variants.MockRepoInterface
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		repo = mock_repository.NewMockRepoInterface(mockCtrl)
+		variantsRepo = mock_variants.NewMockRepoInterface(mockCtrl)
+		serviceInterface = cakes.NewHandler(repo, cakes.WithVariants(variantsRepo))
+		e = echo.New()
+		middlewares.UseCustomValidatorHandler(e)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("filter cakes by variant price", func() {
+		min, max := int64(1250), int64(3000)
+		variantsRepo.EXPECT().CakesInPriceRange(gomock.Any(), money.Range{Min: &min, Max: &max, Currency: "USD"}).Return(query.IDs{List: []int{2, 3}}, nil)
+		repo.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, dto cakes.ListRequestDto) ([]cakes.Cake, int64, error) {
+			Expect(dto.Within).Should(Equal([]query.IDs{{List: []int{2, 3}}}))
+			return []cakes.Cake{}, int64(0), nil
+		})
+		req := httptest.NewRequest(http.MethodGet, "/cakes?price_min=12.50&price_max=30", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err := serviceInterface.List(c)
+		Expect(err).Should(Succeed())
+	})
+
+	It("return error on prices with too many decimals", func() {
+		req := httptest.NewRequest(http.MethodGet, "/cakes?price_min=12.345", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err := serviceInterface.List(c)
+		Expect(err).Should(MatchError(money.ErrInvalid))
+	})
+
+	It("return error when price_min exceeds price_max", func() {
+		req := httptest.NewRequest(http.MethodGet, "/cakes?price_min=30&price_max=10", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err := serviceInterface.List(c)
+		Expect(err).Should(MatchError(cakes.ErrValidation))
+	})
+
+	It("delete the variants of a deleted cake", func() {
+		repo.EXPECT().Delete(gomock.Any(), 1, 0).Return(nil)
+		variantsRepo.EXPECT().DeleteForCake(gomock.Any(), 1).Return(nil)
+		req := httptest.NewRequest(http.MethodDelete, "/", nil)
+		req.Header.Set(cakes.HeaderIfMatch, "*")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		err := serviceInterface.Delete(c)
+		Expect(err).Should(Succeed())
+	})
+})
@@ -0,0 +1,120 @@
+package test
+
+import (
+	"cake-store/internal/money"
+	"cake-store/internal/variants"
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = describeConformance("Variant Repository", variants.NewMemoryRepository, variants.NewRepository, func(subject *repoUnderTest[variants.RepoInterface]) {
+	var (
+		repo variants.RepoInterface
+		ctx  = context.TODO()
+	)
+
+	BeforeEach(func() {
+		repo = subject.Repo
+	})
+
+	create := func(cakeID int, sku string, price int64, currency string, active bool) *variants.Variant {
+		variant, err := repo.Create(ctx, variants.RequestDto{CakeID: cakeID, Size: "8 inch", SKU: sku, PriceMinor: price, Currency: currency, Active: &active})
+		Expect(err).Should(Succeed())
+		return variant
+	}
+
+	It("creates, lists and updates variants of a cake", func() {
+		created := create(1, " lemon-8 ", 3250, "USD", true)
+		Expect(created.ID).Should(Equal(1))
+		Expect(created.SKU).Should(Equal("LEMON-8"))
+		Expect(created.Price).Should(Equal(money.Money{Amount: 3250, Currency: "USD", Decimal: "32.50"}))
+		create(1, "LEMON-SLICE", 450, "USD", false)
+		create(2, "PLAIN-8", 2000, "USD", true)
+
+		list, err := repo.List(ctx, variants.ListRequestDto{CakeID: 1})
+		Expect(err).Should(Succeed())
+		Expect([]string{list[0].SKU, list[1].SKU}).Should(Equal([]string{"LEMON-SLICE", "LEMON-8"}))
+		active := true
+		list, err = repo.List(ctx, variants.ListRequestDto{CakeID: 1, Active: &active})
+		Expect(err).Should(Succeed())
+		Expect(list).Should(HaveLen(1))
+
+		_, err = repo.Create(ctx, variants.RequestDto{CakeID: 2, Size: "slice", SKU: "Lemon-8", Currency: "USD"})
+		Expect(err).Should(MatchError(variants.ErrConflict))
+		_, err = repo.Update(ctx, variants.UpdateRequestDto{CakeID: 1, ID: 2, SKU: "plain-8"})
+		Expect(err).Should(MatchError(variants.ErrConflict))
+
+		price := int64(3500)
+		updated, err := repo.Update(ctx, variants.UpdateRequestDto{CakeID: 1, ID: 1, PriceMinor: &price})
+		Expect(err).Should(Succeed())
+		Expect(updated.Price.Decimal).Should(Equal("35.00"))
+		Expect(updated.SKU).Should(Equal("LEMON-8"))
+		Expect(updated.UpdatedAt).ShouldNot(BeNil())
+
+		_, err = repo.Get(ctx, 2, 1)
+		Expect(err).Should(MatchError(variants.ErrNotFound))
+		_, err = repo.Update(ctx, variants.UpdateRequestDto{CakeID: 2, ID: 1, Size: "slice"})
+		Expect(err).Should(MatchError(variants.ErrNotFound))
+		Expect(repo.Delete(ctx, 2, 1)).Should(MatchError(variants.ErrNotFound))
+		Expect(repo.Delete(ctx, 1, 1)).Should(Succeed())
+	})
+
+	It("finds cakes by the price of their active variants", func() {
+		create(1, "A-8", 3250, "USD", true)
+		create(1, "A-SLICE", 450, "USD", false)
+		create(2, "B-8", 2000, "USD", true)
+		create(3, "C-8", 2000, "EUR", true)
+
+		min, max := int64(400), int64(2500)
+		ids, err := repo.CakesInPriceRange(ctx, money.Range{Min: &min, Max: &max, Currency: "USD"})
+		Expect(err).Should(Succeed())
+		Expect(subject.selected(ids)).Should(ConsistOf(2))
+		ids, err = repo.CakesInPriceRange(ctx, money.Range{Min: &min, Currency: "USD"})
+		Expect(err).Should(Succeed())
+		Expect(subject.selected(ids)).Should(ConsistOf(1, 2))
+
+		list, err := repo.ListActive(ctx, []int{3, 1})
+		Expect(err).Should(Succeed())
+		Expect([]string{list[0].SKU, list[1].SKU}).Should(Equal([]string{"A-8", "C-8"}))
+
+		Expect(repo.DeleteForCake(ctx, 1)).Should(Succeed())
+		list, err = repo.List(ctx, variants.ListRequestDto{CakeID: 1})
+		Expect(err).Should(Succeed())
+		Expect(list).Should(BeEmpty())
+	})
+})
+
+var _ = Describe("Money", func() {
+	It("formats minor units with the currency exponent", func() {
+		Expect(money.Format(1250, "USD")).Should(Equal("12.50"))
+		Expect(money.Format(5, "USD")).Should(Equal("0.05"))
+		Expect(money.Format(1500, "JPY")).Should(Equal("1500"))
+		Expect(money.Format(1234, "BHD")).Should(Equal("1.234"))
+	})
+
+	It("parses decimal amounts without rounding", func() {
+		amount, err := money.Parse("12.5", "USD")
+		Expect(err).Should(Succeed())
+		Expect(amount).Should(Equal(int64(1250)))
+		amount, err = money.Parse("1500", "JPY")
+		Expect(err).Should(Succeed())
+		Expect(amount).Should(Equal(int64(1500)))
+
+		for _, s := range []string{"12.345", "-1", "1e3", "", "1.2.3"} {
+			_, err = money.Parse(s, "USD")
+			Expect(err).Should(MatchError(money.ErrInvalid), s)
+		}
+		_, err = money.Parse("15.5", "JPY")
+		Expect(err).Should(MatchError(money.ErrInvalid))
+	})
+
+	It("checks amounts against a range in one currency", func() {
+		min, max := int64(100), int64(200)
+		r := money.Range{Min: &min, Max: &max, Currency: "USD"}
+		Expect(r.Contains(money.New(150, "USD"))).Should(BeTrue())
+		Expect(r.Contains(money.New(250, "USD"))).Should(BeFalse())
+		Expect(r.Contains(money.New(150, "EUR"))).Should(BeFalse())
+	})
+})